@@ -47,6 +47,11 @@ export COMMANDS=(
 	socialapi/workers/cmd/collaboration
 	socialapi/workers/cmd/email/emailsender
 	socialapi/workers/cmd/team
+	socialapi/workers/cmd/webhook
+	socialapi/workers/cmd/metering
+	socialapi/workers/cmd/slack
+	socialapi/workers/cmd/accountdata
+	socialapi/workers/cmd/analytics
 	vendor/github.com/koding/kite/kitectl
 	vendor/github.com/canthefason/go-watcher
 	vendor/github.com/mattes/migrate
@@ -35,6 +35,21 @@ type ComputeStack struct {
 	Config   bson.M `bson:"config,omitempty"`
 	Meta     bson.M `bson:"meta,omitempty"`
 	Title    string `bson:"title,omitempty"`
+
+	// TemplateRevision is a number of jStackTemplateRevisions
+	// document the stack was last built from.
+	TemplateRevision int `bson:"templateRevision,omitempty"`
+
+	// Outputs holds Terraform outputs of the stack, read
+	// after each successful apply.
+	Outputs map[string]*StackOutput `bson:"outputs,omitempty"`
+}
+
+// StackOutput represents a single Terraform output of a stack.
+type StackOutput struct {
+	Type      string      `bson:"type" json:"type"`
+	Sensitive bool        `bson:"sensitive,omitempty" json:"sensitive,omitempty"`
+	Value     interface{} `bson:"value" json:"value,omitempty"`
 }
 
 func (c *ComputeStack) State() stackstate.State {
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// StackTemplateRevision is a document from jStackTemplateRevisions
+// collection. It is an immutable snapshot of jStackTemplate.template
+// field, created each time the template content changes.
+type StackTemplateRevision struct {
+	Id         bson.ObjectId `bson:"_id" json:"-"`
+	TemplateID bson.ObjectId `bson:"templateId" json:"templateId"`
+
+	// Revision is a sequential number of the revision,
+	// starting from 1 for each template.
+	Revision int `bson:"revision" json:"revision"`
+
+	Content    string `bson:"content" json:"content"`
+	RawContent string `bson:"rawContent" json:"rawContent"`
+	Sum        string `bson:"sum" json:"sum"`
+
+	// Author is a username of the account which made the change.
+	Author    string    `bson:"author" json:"author,omitempty"`
+	Message   string    `bson:"message,omitempty" json:"message,omitempty"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
@@ -76,6 +76,11 @@ type ForeignAuth struct {
 
 type Slack struct {
 	Token string `bson:"token" json:"-"`
+
+	// UserID and TeamID hold the slack identity of the user, they are
+	// set when the user authorizes koding
+	UserID string `bson:"userId,omitempty" json:"-"`
+	TeamID string `bson:"teamId,omitempty" json:"-"`
 }
 
 type Github struct {
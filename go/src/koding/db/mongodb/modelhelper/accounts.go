@@ -87,6 +87,46 @@ func UpdateAccount(selector, options Selector) error {
 	return Mongo.Run(AccountsColl, query)
 }
 
+// AnonymizeAccount replaces the nickname of the account with the given
+// username and removes its profile, it is the go counterpart of the account
+// update of JUser.unregister
+func AnonymizeAccount(id bson.ObjectId, newUsername string) error {
+	update := Selector{
+		"$set": bson.M{
+			"type":             "deleted",
+			"profile.nickname": newUsername,
+		},
+		"$unset": bson.M{
+			"shareLocation":            1,
+			"skillTags":                1,
+			"locationTags":             1,
+			"systemInfo":               1,
+			"counts":                   1,
+			"environmentIsCreated":     1,
+			"profile.about":            1,
+			"profile.hash":             1,
+			"profile.ircNickname":      1,
+			"profile.firstName":        1,
+			"profile.lastName":         1,
+			"profile.description":      1,
+			"profile.avatar":           1,
+			"profile.status":           1,
+			"profile.experience":       1,
+			"profile.experiencePoints": 1,
+			"profile.lastStatusUpdate": 1,
+			"referrerUsername":         1,
+			"referralUsed":             1,
+			"preferredKDProxyDomain":   1,
+			"isExempt":                 1,
+			"globalFlags":              1,
+			"onlineStatus":             1,
+			"lastLoginTimezoneOffset":  1,
+		},
+	}
+
+	return UpdateAccount(Selector{"_id": id}, update)
+}
+
 // RemoveAccount removes given account
 func RemoveAccount(id bson.ObjectId) error {
 	return RemoveDocument(AccountsColl, id)
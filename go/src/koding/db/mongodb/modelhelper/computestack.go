@@ -49,6 +49,55 @@ func GetComputeStackByGroup(slug string, accountID bson.ObjectId) (*models.Compu
 	return &stack, nil
 }
 
+// GetComputeStacksByGroup fetches the stacks of the account in the group
+func GetComputeStacksByGroup(slug string, accountID bson.ObjectId) ([]*models.ComputeStack, error) {
+	var stacks []*models.ComputeStack
+
+	query := func(c *mgo.Collection) error {
+		f := bson.M{
+			"group":    slug,
+			"originId": accountID,
+		}
+		return c.Find(f).All(&stacks)
+	}
+
+	if err := Mongo.Run(ComputeStackColl, query); err != nil {
+		return nil, err
+	}
+
+	return stacks, nil
+}
+
+// GetComputeStacksByOriginID fetches the stacks of the account in all groups
+func GetComputeStacksByOriginID(accountID bson.ObjectId) ([]*models.ComputeStack, error) {
+	var stacks []*models.ComputeStack
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"originId": accountID}).All(&stacks)
+	}
+
+	if err := Mongo.Run(ComputeStackColl, query); err != nil {
+		return nil, err
+	}
+
+	return stacks, nil
+}
+
+// GetComputeStackByMachineID fetches the stack the machine belongs to
+func GetComputeStackByMachineID(machineID bson.ObjectId) (*models.ComputeStack, error) {
+	var stack models.ComputeStack
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"machines": machineID}).One(&stack)
+	}
+
+	if err := Mongo.Run(ComputeStackColl, query); err != nil {
+		return nil, err
+	}
+
+	return &stack, nil
+}
+
 func GetComputeStackByUserGroup(userID, groupID bson.ObjectId) (*models.ComputeStack, error) {
 	user, err := GetUserById(userID.Hex())
 	if err != nil {
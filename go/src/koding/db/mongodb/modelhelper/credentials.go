@@ -100,3 +100,30 @@ func SetCredentialVerified(identifier string, verified bool) error {
 		"$set": bson.M{"verified": verified},
 	})
 }
+
+// GetCredentialsByOriginID fetches the credentials created by the account
+func GetCredentialsByOriginID(originID bson.ObjectId) ([]*models.Credential, error) {
+	var creds []*models.Credential
+
+	return creds, Mongo.Run(CredentialsColl, func(c *mgo.Collection) error {
+		return c.Find(bson.M{"originId": originID}).All(&creds)
+	})
+}
+
+// RemoveCredentials removes the credentials along with their data
+func RemoveCredentials(identifier ...string) error {
+	selector := bson.M{"identifier": bson.M{"$in": identifier}}
+
+	err := Mongo.Run(CredentialDatasColl, func(c *mgo.Collection) error {
+		_, err := c.RemoveAll(selector)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return Mongo.Run(CredentialsColl, func(c *mgo.Collection) error {
+		_, err := c.RemoveAll(selector)
+		return err
+	})
+}
@@ -125,6 +125,13 @@ func GetRunningVms(provider string) ([]*models.Machine, error) {
 	return findMachine(query)
 }
 
+// GetRunningMachines gives the running machines of all providers, only their
+// ids, groups and statuses are fetched
+func GetRunningMachines() ([]*models.Machine, error) {
+	query := bson.M{"status.state": MachineStateRunning}
+	return findMachineFields(query, []string{"_id", "groups", "status"})
+}
+
 func GetMachinesByUsernameAndProvider(username, provider string) ([]*models.Machine, error) {
 	user, err := GetUser(username)
 	if err != nil {
@@ -48,6 +48,23 @@ func GetSessionsByUsername(username string) ([]*models.Session, error) {
 	return sessions, nil
 }
 
+// RemoveSessionsByUsername removes all sessions of the user, it returns the
+// number of the removed sessions
+func RemoveSessionsByUsername(username string) (int, error) {
+	var removed int
+
+	query := func(c *mgo.Collection) error {
+		info, err := c.RemoveAll(bson.M{"username": username})
+		if info != nil {
+			removed = info.Removed
+		}
+
+		return err
+	}
+
+	return removed, Mongo.Run(SessionColl, query)
+}
+
 // Sessions is a helper type for a slice of sessions,
 // that allows for filtering, sorting etc.
 type Sessions []*models.Session
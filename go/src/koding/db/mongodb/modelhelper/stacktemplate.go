@@ -91,3 +91,29 @@ func CreateStackTemplate(tmpl *models.StackTemplate) error {
 	query := insertQuery(tmpl)
 	return Mongo.Run(StackTemplateColl, query)
 }
+
+// GetStackTemplatesByOriginID gives all stack templates created by
+// the given account.
+func GetStackTemplatesByOriginID(originID bson.ObjectId) ([]*models.StackTemplate, error) {
+	var tmpls []*models.StackTemplate
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"originId": originID}).All(&tmpls)
+	}
+
+	if err := Mongo.Run(StackTemplateColl, query); err != nil {
+		return nil, err
+	}
+
+	return tmpls, nil
+}
+
+// DeleteStackTemplate removes the stack template, its revisions
+// are left intact.
+func DeleteStackTemplate(id string) error {
+	query := func(c *mgo.Collection) error {
+		return c.RemoveId(bson.ObjectIdHex(id))
+	}
+
+	return Mongo.Run(StackTemplateColl, query)
+}
@@ -0,0 +1,207 @@
+package modelhelper
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"koding/db/models"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const StackTemplateRevisionColl = "jStackTemplateRevisions"
+
+// revisionIndex ensures revision numbers are unique per template,
+// so concurrent snapshots do not end up with the same number.
+var revisionIndex = mgo.Index{
+	Key:        []string{"templateId", "-revision"},
+	Unique:     true,
+	Background: true,
+}
+
+// GetStackTemplateRevisions gives all revisions of the given
+// template, starting from the newest one.
+func GetStackTemplateRevisions(templateID string) ([]*models.StackTemplateRevision, error) {
+	if !bson.IsObjectIdHex(templateID) {
+		return nil, fmt.Errorf("Not valid ObjectIdHex: '%s'", templateID)
+	}
+
+	var revs []*models.StackTemplateRevision
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"templateId": bson.ObjectIdHex(templateID)}).Sort("-revision").All(&revs)
+	}
+
+	if err := Mongo.Run(StackTemplateRevisionColl, query); err != nil {
+		return nil, err
+	}
+
+	return revs, nil
+}
+
+// GetStackTemplateRevision gives a single revision of the given template.
+func GetStackTemplateRevision(templateID string, revision int) (*models.StackTemplateRevision, error) {
+	if !bson.IsObjectIdHex(templateID) {
+		return nil, fmt.Errorf("Not valid ObjectIdHex: '%s'", templateID)
+	}
+
+	rev := new(models.StackTemplateRevision)
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{
+			"templateId": bson.ObjectIdHex(templateID),
+			"revision":   revision,
+		}).One(rev)
+	}
+
+	if err := Mongo.Run(StackTemplateRevisionColl, query); err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// GetLatestStackTemplateRevision gives the newest revision of the given
+// template. If the template has no revisions, mgo.ErrNotFound is returned.
+func GetLatestStackTemplateRevision(templateID string) (*models.StackTemplateRevision, error) {
+	if !bson.IsObjectIdHex(templateID) {
+		return nil, fmt.Errorf("Not valid ObjectIdHex: '%s'", templateID)
+	}
+
+	rev := new(models.StackTemplateRevision)
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"templateId": bson.ObjectIdHex(templateID)}).Sort("-revision").One(rev)
+	}
+
+	if err := Mongo.Run(StackTemplateRevisionColl, query); err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// SnapshotStackTemplate stores current content of the given template
+// as a new revision, unless it is the same as the latest revision,
+// in which case the latest revision is returned instead.
+//
+// If author is empty, the last updater of the template is used, or
+// its owner when the last updater is unknown.
+func SnapshotStackTemplate(tmpl *models.StackTemplate, author, message string) (*models.StackTemplateRevision, error) {
+	if author == "" {
+		author = stackTemplateAuthor(tmpl)
+	}
+
+	// Retry when revision number was taken by a concurrent snapshot.
+	for i := 0; i < 3; i++ {
+		latest, err := GetLatestStackTemplateRevision(tmpl.Id.Hex())
+
+		n := 1
+
+		switch {
+		case err == mgo.ErrNotFound:
+		case err != nil:
+			return nil, err
+		case latest.Sum == tmpl.Template.Sum:
+			return latest, nil
+		default:
+			n = latest.Revision + 1
+		}
+
+		rev := &models.StackTemplateRevision{
+			Id:         bson.NewObjectId(),
+			TemplateID: tmpl.Id,
+			Revision:   n,
+			Content:    tmpl.Template.Content,
+			RawContent: tmpl.Template.RawContent,
+			Sum:        tmpl.Template.Sum,
+			Author:     author,
+			Message:    message,
+			CreatedAt:  time.Now().UTC(),
+		}
+
+		query := func(c *mgo.Collection) error {
+			if err := c.EnsureIndex(revisionIndex); err != nil {
+				return err
+			}
+
+			return c.Insert(rev)
+		}
+
+		switch err := Mongo.Run(StackTemplateRevisionColl, query); {
+		case mgo.IsDup(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		return rev, nil
+	}
+
+	return nil, errors.New("unable to create template revision due to concurrent updates")
+}
+
+func stackTemplateAuthor(tmpl *models.StackTemplate) string {
+	id := tmpl.OriginID
+
+	if updater, ok := tmpl.Template.Details["lastUpdaterId"].(bson.ObjectId); ok {
+		id = updater
+	}
+
+	if !id.Valid() {
+		return ""
+	}
+
+	account, err := GetAccountById(id.Hex())
+	if err != nil {
+		return ""
+	}
+
+	return account.Profile.Nickname
+}
+
+// GetStackTemplateRevisionsByAuthor gives all revisions made by the
+// given user, starting from the oldest one.
+func GetStackTemplateRevisionsByAuthor(author string) ([]*models.StackTemplateRevision, error) {
+	var revs []*models.StackTemplateRevision
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{"author": author}).Sort("createdAt").All(&revs)
+	}
+
+	if err := Mongo.Run(StackTemplateRevisionColl, query); err != nil {
+		return nil, err
+	}
+
+	return revs, nil
+}
+
+// DeleteStackTemplateRevisions removes all revisions of the given template.
+func DeleteStackTemplateRevisions(templateID string) error {
+	if !bson.IsObjectIdHex(templateID) {
+		return fmt.Errorf("Not valid ObjectIdHex: '%s'", templateID)
+	}
+
+	query := func(c *mgo.Collection) error {
+		_, err := c.RemoveAll(bson.M{"templateId": bson.ObjectIdHex(templateID)})
+		return err
+	}
+
+	return Mongo.Run(StackTemplateRevisionColl, query)
+}
+
+// SetStackTemplateRevisionsAuthor replaces the author of all revisions
+// made by the given user.
+func SetStackTemplateRevisionsAuthor(author, newAuthor string) error {
+	query := func(c *mgo.Collection) error {
+		_, err := c.UpdateAll(
+			bson.M{"author": author},
+			bson.M{"$set": bson.M{"author": newAuthor}},
+		)
+		return err
+	}
+
+	return Mongo.Run(StackTemplateRevisionColl, query)
+}
@@ -109,6 +109,24 @@ func GetAnySlackTokenWithGroup(groupName string) ([]models.User, error) {
 	return users, nil
 }
 
+// GetUserBySlackUserID fetches the user, who linked the given slack user to
+// their account in the group
+func GetUserBySlackUserID(groupName, slackUserID string) (*models.User, error) {
+	user := new(models.User)
+
+	key := fmt.Sprintf("foreignAuth.slack.%s.userId", groupName)
+
+	query := func(c *mgo.Collection) error {
+		return c.Find(bson.M{key: slackUserID}).One(&user)
+	}
+
+	if err := Mongo.Run(UserColl, query); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 func GetAccountByUserId(id bson.ObjectId) (*models.Account, error) {
 	user := new(models.User)
 	err := Mongo.One(UserColl, id.Hex(), user)
@@ -343,3 +361,42 @@ func UserLogin(username, groupName string) (*models.Session, error) {
 
 	return FetchOrCreateSession(username, groupName)
 }
+
+// AnonymizeUser replaces the username and the email of the user with the
+// given username and removes its secrets, it is the go counterpart of
+// JUser.unregister
+func AnonymizeUser(username, newUsername string) error {
+	email := newUsername + "@koding.com"
+
+	update := bson.M{
+		"$set": bson.M{
+			"username":       newUsername,
+			"email":          email,
+			"sanitizedEmail": email,
+			"status":         models.UserDeleted,
+		},
+		"$unset": bson.M{
+			"sshKeys":        1,
+			"password":       1,
+			"salt":           1,
+			"twofactorkey":   1,
+			"onlineStatus":   1,
+			"registeredAt":   1,
+			"lastLoginDate":  1,
+			"passwordStatus": 1,
+			"emailFrequency": 1,
+			"oldUsername":    1,
+			"blockedUntil":   1,
+			"blockedReason":  1,
+			"registeredFrom": 1,
+			"inactive":       1,
+			"foreignAuth":    1,
+		},
+	}
+
+	query := func(c *mgo.Collection) error {
+		return c.Update(bson.M{"username": username}, update)
+	}
+
+	return Mongo.Run(UserColl, query)
+}
@@ -0,0 +1,314 @@
+// Package dockerapi implements a minimal client for the Docker Engine API,
+// which is used by kloud to manage lifecycle of containers created
+// by the docker provider.
+//
+// The full API is described here:
+//
+//   https://docs.docker.com/engine/api/
+//
+package dockerapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"koding/kites/kloud/machinestate"
+)
+
+// DefaultHost is a default address of the Docker daemon.
+const DefaultHost = "unix:///var/run/docker.sock"
+
+const defaultTimeout = 30 * time.Second
+
+// ErrNotFound is returned when the requested container does not exist.
+var ErrNotFound = errors.New("no such container")
+
+// Options configures Client.
+type Options struct {
+	// Host is an address of the Docker daemon, e.g.
+	// "tcp://127.0.0.1:2376" or "unix:///var/run/docker.sock".
+	//
+	// If empty, DefaultHost is used.
+	Host string
+
+	// PEM-encoded TLS material used to authenticate with the
+	// daemon. Either all of them must be set, or none.
+	CACert string
+	Cert   string
+	Key    string
+
+	// Timeout is a timeout for a single API request.
+	//
+	// If zero, 30s is used.
+	Timeout time.Duration
+}
+
+// Client is a Docker Engine API client.
+type Client struct {
+	// BaseURL is a base URL of the API.
+	BaseURL *url.URL
+
+	// Client is used for making requests to the API.
+	Client *http.Client
+}
+
+// State describes status of a container.
+type State struct {
+	Status     string `json:"Status"` // created, restarting, running, paused, exited or dead
+	Running    bool   `json:"Running"`
+	Paused     bool   `json:"Paused"`
+	Restarting bool   `json:"Restarting"`
+	Dead       bool   `json:"Dead"`
+	ExitCode   int    `json:"ExitCode"`
+	Error      string `json:"Error"`
+}
+
+// MachineState maps the container state to a machinestate.State value.
+func (s *State) MachineState() machinestate.State {
+	switch {
+	case s.Dead:
+		return machinestate.Terminated
+	case s.Restarting:
+		return machinestate.Starting
+	case s.Running, s.Paused:
+		return machinestate.Running
+	}
+
+	switch s.Status {
+	case "created", "exited":
+		return machinestate.Stopped
+	case "removing":
+		return machinestate.Terminating
+	default:
+		return machinestate.Unknown
+	}
+}
+
+// Container represents a container, as returned by the
+// inspect endpoint.
+type Container struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	Image string `json:"Image"`
+	State *State `json:"State"`
+}
+
+// Version represents a version of the Docker daemon.
+type Version struct {
+	Version    string `json:"Version"`
+	APIVersion string `json:"ApiVersion"`
+	Os         string `json:"Os"`
+	Arch       string `json:"Arch"`
+}
+
+// Error represents an error response of the Docker daemon.
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+}
+
+// Error implements the built-in error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("docker: %s (status code %d)", e.Message, e.StatusCode)
+}
+
+// New gives new client for the given options.
+func New(opts *Options) (*Client, error) {
+	host := opts.Host
+	if host == "" {
+		host = DefaultHost
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %s", host, err)
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout: timeout,
+	}
+
+	baseURL := &url.URL{
+		Scheme: "http",
+		Host:   u.Host,
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+
+		transport.Proxy = nil
+		transport.Dial = func(string, string) (net.Conn, error) {
+			return net.DialTimeout("unix", socket, timeout)
+		}
+
+		baseURL.Host = "docker"
+	case "tcp", "http", "https":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid docker host %q: missing address", host)
+		}
+	default:
+		return nil, fmt.Errorf("invalid docker host %q: unsupported scheme %q", host, u.Scheme)
+	}
+
+	tlsCfg, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsCfg != nil || u.Scheme == "https" {
+		if u.Scheme == "unix" {
+			return nil, errors.New("TLS is not supported for unix sockets")
+		}
+
+		baseURL.Scheme = "https"
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	return &Client{
+		BaseURL: baseURL,
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+func newTLSConfig(opts *Options) (*tls.Config, error) {
+	if opts.CACert == "" && opts.Cert == "" && opts.Key == "" {
+		return nil, nil
+	}
+
+	if opts.CACert == "" || opts.Cert == "" || opts.Key == "" {
+		return nil, errors.New("either all or none of TLS CA, certificate and key must be set")
+	}
+
+	cert, err := tls.X509KeyPair([]byte(opts.Cert), []byte(opts.Key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS certificate: %s", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(opts.CACert)) {
+		return nil, errors.New("invalid TLS CA certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Ping checks whether the daemon is reachable.
+func (c *Client) Ping() error {
+	return c.do("GET", "/_ping", nil, nil)
+}
+
+// Version gives version of the daemon.
+func (c *Client) Version() (*Version, error) {
+	var v Version
+
+	if err := c.do("GET", "/version", nil, &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// InspectContainer gives details of the container with the given ID or name.
+//
+// If the container does not exist, ErrNotFound is returned.
+func (c *Client) InspectContainer(id string) (*Container, error) {
+	var container Container
+
+	if err := c.do("GET", "/containers/"+url.QueryEscape(id)+"/json", nil, &container); err != nil {
+		return nil, err
+	}
+
+	if container.State == nil {
+		container.State = &State{}
+	}
+
+	return &container, nil
+}
+
+// StartContainer starts the container with the given ID or name.
+//
+// Starting already running container is not an error.
+func (c *Client) StartContainer(id string) error {
+	return c.do("POST", "/containers/"+url.QueryEscape(id)+"/start", nil, nil)
+}
+
+// StopContainer stops the container with the given ID or name. If the container
+// does not stop after timeout, it is killed.
+//
+// Stopping already stopped container is not an error.
+func (c *Client) StopContainer(id string, timeout time.Duration) error {
+	q := make(url.Values)
+	q.Set("t", strconv.Itoa(int(timeout/time.Second)))
+
+	return c.do("POST", "/containers/"+url.QueryEscape(id)+"/stop", q, nil)
+}
+
+func (c *Client) do(method, path string, query url.Values, out interface{}) error {
+	u := *c.BaseURL
+	u.Path = path
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		// Container already started or stopped.
+		return nil
+	case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, "/containers/"):
+		return ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		e := &Error{StatusCode: resp.StatusCode}
+
+		p, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+		if json.Unmarshal(p, e) != nil || e.Message == "" {
+			e.Message = strings.TrimSpace(string(p))
+		}
+
+		return e
+	}
+
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+
+	return nil
+}
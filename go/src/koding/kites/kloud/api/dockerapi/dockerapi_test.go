@@ -0,0 +1,205 @@
+package dockerapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"koding/kites/kloud/api/dockerapi"
+	"koding/kites/kloud/machinestate"
+)
+
+// fakeDaemon implements a subset of Docker Engine API
+// required by the client.
+type fakeDaemon struct {
+	mu         sync.Mutex
+	containers map[string]*dockerapi.Container
+}
+
+func newFakeDaemon(ids ...string) *fakeDaemon {
+	d := &fakeDaemon{
+		containers: make(map[string]*dockerapi.Container),
+	}
+
+	for _, id := range ids {
+		d.containers[id] = &dockerapi.Container{
+			ID:    id,
+			Name:  "/" + id,
+			Image: "ubuntu:16.04",
+			State: &dockerapi.State{Status: "created"},
+		}
+	}
+
+	return d
+}
+
+func (d *fakeDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if r.URL.Path == "/_ping" {
+		w.Write([]byte("OK"))
+		return
+	}
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(path) != 3 || path[0] != "containers" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "page not found"})
+		return
+	}
+
+	c, ok := d.containers[path[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "No such container: " + path[1]})
+		return
+	}
+
+	switch path[2] {
+	case "json":
+		json.NewEncoder(w).Encode(c)
+	case "start":
+		if c.State.Running {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		c.State = &dockerapi.State{Status: "running", Running: true}
+		w.WriteHeader(http.StatusNoContent)
+	case "stop":
+		if r.URL.Query().Get("t") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !c.State.Running {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		c.State = &dockerapi.State{Status: "exited"}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(newFakeDaemon("abc"))
+	defer srv.Close()
+
+	c, err := dockerapi.New(&dockerapi.Options{
+		Host: strings.Replace(srv.URL, "http://", "tcp://", 1),
+	})
+	if err != nil {
+		t.Fatalf("New()=%s", err)
+	}
+
+	if err := c.Ping(); err != nil {
+		t.Fatalf("Ping()=%s", err)
+	}
+
+	assertState := func(want machinestate.State) {
+		container, err := c.InspectContainer("abc")
+		if err != nil {
+			t.Fatalf("InspectContainer()=%s", err)
+		}
+
+		if got := container.State.MachineState(); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+
+	assertState(machinestate.Stopped)
+
+	for i := 0; i < 2; i++ {
+		if err := c.StartContainer("abc"); err != nil {
+			t.Fatalf("StartContainer()=%s", err)
+		}
+	}
+
+	assertState(machinestate.Running)
+
+	for i := 0; i < 2; i++ {
+		if err := c.StopContainer("abc", 10*time.Second); err != nil {
+			t.Fatalf("StopContainer()=%s", err)
+		}
+	}
+
+	assertState(machinestate.Stopped)
+
+	if _, err := c.InspectContainer("nonexisting"); err != dockerapi.ErrNotFound {
+		t.Fatalf("got %v, want %v", err, dockerapi.ErrNotFound)
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		opts *dockerapi.Options
+		ok   bool
+	}{
+		"default host": {
+			&dockerapi.Options{},
+			true,
+		},
+		"tcp host": {
+			&dockerapi.Options{Host: "tcp://127.0.0.1:2376"},
+			true,
+		},
+		"unsupported scheme": {
+			&dockerapi.Options{Host: "ftp://127.0.0.1"},
+			false,
+		},
+		"missing address": {
+			&dockerapi.Options{Host: "tcp://"},
+			false,
+		},
+		"partial TLS": {
+			&dockerapi.Options{Host: "tcp://127.0.0.1:2376", CACert: "ca"},
+			false,
+		},
+		"invalid TLS": {
+			&dockerapi.Options{Host: "tcp://127.0.0.1:2376", CACert: "ca", Cert: "cert", Key: "key"},
+			false,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := dockerapi.New(cas.opts)
+			if cas.ok && err != nil {
+				t.Fatalf("New()=%s", err)
+			}
+
+			if !cas.ok && err == nil {
+				t.Fatal("expected New() to fail")
+			}
+		})
+	}
+}
+
+func TestMachineState(t *testing.T) {
+	cases := map[string]struct {
+		state *dockerapi.State
+		want  machinestate.State
+	}{
+		"created":    {&dockerapi.State{Status: "created"}, machinestate.Stopped},
+		"running":    {&dockerapi.State{Status: "running", Running: true}, machinestate.Running},
+		"paused":     {&dockerapi.State{Status: "paused", Running: true, Paused: true}, machinestate.Running},
+		"restarting": {&dockerapi.State{Status: "restarting", Restarting: true}, machinestate.Starting},
+		"exited":     {&dockerapi.State{Status: "exited"}, machinestate.Stopped},
+		"removing":   {&dockerapi.State{Status: "removing"}, machinestate.Terminating},
+		"dead":       {&dockerapi.State{Status: "dead", Dead: true}, machinestate.Terminated},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := cas.state.MachineState(); got != cas.want {
+				t.Fatalf("got %s, want %s", got, cas.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,12 @@ type key int
 
 const sessionKey key = 0
 
+// Notifier publishes team events, which are delivered to the webhooks
+// registered by the team.
+type Notifier interface {
+	Notify(groupName, event string, data interface{}) error
+}
+
 // TerraformerOptions are used to connect to a terraformer kite.
 type TerraformerOptions struct {
 	Endpoint  string
@@ -38,6 +44,10 @@ type Session struct {
 	// single connection instead of connecting for each
 	// request.
 	Terraformer *TerraformerOptions
+
+	// Notifier is used to publish team events, if nil
+	// no events are published.
+	Notifier Notifier
 }
 
 func FromContext(ctx context.Context) (*Session, bool) {
@@ -234,6 +234,11 @@ func (k *Klient) Kill(req *os.KillRequest) (*os.KillResponse, error) {
 	return &resp, nil
 }
 
+// SetEnv calls the os.setenv method of remote klient.
+func (k *Klient) SetEnv(req *os.SetEnvRequest) error {
+	return k.call("os.setenv", req, nil)
+}
+
 func (k *Klient) call(method string, req, resp interface{}) error {
 	type validator interface {
 		Valid() error
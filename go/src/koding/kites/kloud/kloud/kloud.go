@@ -28,6 +28,7 @@ import (
 	"koding/kites/kloud/keycreator"
 	"koding/kites/kloud/machine"
 	"koding/kites/kloud/metrics"
+	"koding/kites/kloud/pkg/dnsclient"
 	"koding/kites/kloud/queue"
 	"koding/kites/kloud/stack"
 	"koding/kites/kloud/stack/provider"
@@ -37,6 +38,7 @@ import (
 	"koding/remoteapi"
 	"koding/tools/util"
 	"socialapi/workers/presence/client"
+	webhookclient "socialapi/workers/webhook/client"
 
 	"github.com/DataDog/datadog-go/statsd"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -155,6 +157,29 @@ type Config struct {
 
 	KodingURL *config.URL // Koding base URL
 	NoSneaker bool        // use Mongo for reading credentials, instead of /social/credential endpoint
+
+	// --- DNS CONFIGURATION ---
+	// DNSBackend selects the backend used for managing domain records,
+	// one of: route53, rfc2136, powerdns or zonefile. If empty,
+	// the domain records are not managed.
+	DNSBackend string
+
+	// rfc2136 backend: address of the primary name server and the
+	// TSIG key used for signing updates.
+	DNSServer        string
+	DNSTSIGKey       string
+	DNSTSIGSecret    string
+	DNSTSIGAlgorithm string
+
+	// powerdns backend: PowerDNS HTTP API endpoint and its key.
+	DNSPowerDNSURL      string
+	DNSPowerDNSAPIKey   string
+	DNSPowerDNSServerID string
+
+	// zonefile backend: path to the zone file served e.g. by CoreDNS
+	// and the primary name server of the zone.
+	DNSZoneFile   string
+	DNSNameServer string
 }
 
 // New gives new, registered kloud kite.
@@ -278,10 +303,16 @@ func New(conf *Config) (*Kloud, error) {
 	presenceClient.HTTPClient = restClient
 	kloud.presenceCollector = NewPresenceCollector(presenceClient)
 
+	webhookClient := webhookclient.NewInternal(e.Social().Private.String())
+	webhookClient.HTTPClient = restClient
+	sess.Notifier = webhookClient
+	kloud.Queue.Notifier = webhookClient
+
 	kloud.Stack.Environment = conf.Environment
 	kloud.Stack.Endpoints = e
 	kloud.Stack.Userdata = sess.Userdata
 	kloud.Stack.DescribeFunc = provider.Desc
+	kloud.Stack.ValidateFunc = provider.Validate
 	kloud.Stack.CredClient = credential.NewClient(storeOpts)
 	kloud.Stack.MachineClient = machine.NewClient(machine.NewMongoDatabase())
 	kloud.Stack.TeamClient = team.NewClient(team.NewMongoDatabase())
@@ -352,6 +383,16 @@ func New(conf *Config) (*Kloud, error) {
 	kloud.HandleFunc("authenticate", kloud.Stack.Authenticate)
 	kloud.HandleFunc("bootstrap", kloud.Stack.Bootstrap)
 	kloud.HandleFunc("import", kloud.Stack.Import)
+	kloud.HandleFunc("stack.validate", kloud.Stack.StackValidate)
+	kloud.HandleFunc("stack.outputs", kloud.Stack.StackOutputs)
+	kloud.HandleFunc("stack.state.versions", kloud.Stack.StateVersions)
+	kloud.HandleFunc("stack.state.rollback", kloud.Stack.StateRollback)
+
+	// Stack template handling.
+	kloud.HandleFunc("template.history", kloud.Stack.TemplateHistory)
+	kloud.HandleFunc("template.diff", kloud.Stack.TemplateDiff)
+	kloud.HandleFunc("template.update", kloud.Stack.TemplateUpdate)
+	kloud.HandleFunc("template.rollback", kloud.Stack.TemplateRollback)
 
 	// Credential handling.
 	kloud.HandleFunc("credential.describe", kloud.Stack.CredentialDescribe)
@@ -495,9 +536,44 @@ func newSession(conf *Config, k *kite.Kite) (*session.Session, error) {
 
 	sess.DNSStorage = dnsstorage.NewMongodbStorage(sess.DB)
 
+	if conf.DNSBackend != "" {
+		dns, err := dnsclient.New(newDNSConfig(conf, c, sess.Log.New("dns")))
+		if err != nil {
+			return nil, err
+		}
+
+		sess.DNSClient = dns
+	}
+
 	return sess, nil
 }
 
+func newDNSConfig(conf *Config, c *credentials.Credentials, log logging.Logger) *dnsclient.Config {
+	cfg := &dnsclient.Config{
+		Backend:    conf.DNSBackend,
+		HostedZone: conf.HostedZone,
+		Creds:      c,
+		Server:     conf.DNSServer,
+		URL:        conf.DNSPowerDNSURL,
+		APIKey:     conf.DNSPowerDNSAPIKey,
+		ServerID:   conf.DNSPowerDNSServerID,
+		ZoneFile:   conf.DNSZoneFile,
+		NameServer: conf.DNSNameServer,
+		Log:        log,
+		Debug:      conf.DebugMode,
+	}
+
+	if conf.DNSTSIGKey != "" {
+		cfg.TSIG = &dnsclient.TSIG{
+			Name:      conf.DNSTSIGKey,
+			Secret:    conf.DNSTSIGSecret,
+			Algorithm: conf.DNSTSIGAlgorithm,
+		}
+	}
+
+	return cfg
+}
+
 func newEndpoints(cfg *Config) *config.Endpoints {
 	e := config.NewKonfig(&config.Environments{Env: cfg.Environment}).Endpoints
 
@@ -79,6 +79,14 @@ func New(cfg *Config) (CloudInit, error) {
 	return ci, nil
 }
 
+// Encode gives JSON-encoded klient metadata for the given configuration.
+//
+// It is used by providers that do not support cloud-init,
+// which pass the metadata to klient with the -metadata flag instead.
+func Encode(cfg *Config) ([]byte, error) {
+	return newMetadata(cfg)
+}
+
 // TODO(rjeczalik): refactor to a separate function in kites/config
 // and use in marathon as well.
 func newMetadata(cfg *Config) ([]byte, error) {
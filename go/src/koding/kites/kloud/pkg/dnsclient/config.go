@@ -0,0 +1,95 @@
+package dnsclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/koding/logging"
+)
+
+// Supported DNS backends.
+const (
+	BackendRoute53  = "route53"
+	BackendRFC2136  = "rfc2136"
+	BackendPowerDNS = "powerdns"
+	BackendZoneFile = "zonefile"
+)
+
+// Config describes which backend should be used for
+// managing DNS records and how to configure it.
+type Config struct {
+	// Backend is one of the BackendRoute53, BackendRFC2136,
+	// BackendPowerDNS or BackendZoneFile.
+	Backend string
+
+	HostedZone string
+
+	// Route53 configuration.
+	Creds *credentials.Credentials
+
+	// RFC2136 configuration.
+	Server string
+	TSIG   *TSIG
+
+	// PowerDNS configuration.
+	URL      string
+	APIKey   string
+	ServerID string
+
+	// ZoneFile configuration.
+	ZoneFile   string
+	NameServer string
+
+	SyncTimeout time.Duration
+	Log         logging.Logger
+	Debug       bool
+}
+
+// New gives new Client for the backend described by the cfg.
+func New(cfg *Config) (Client, error) {
+	var c Client
+	var err error
+
+	switch strings.ToLower(cfg.Backend) {
+	case BackendRoute53, "":
+		c, err = NewRoute53Client(&Options{
+			Creds:       cfg.Creds,
+			HostedZone:  cfg.HostedZone,
+			Log:         cfg.Log,
+			SyncTimeout: cfg.SyncTimeout,
+			Debug:       cfg.Debug,
+		})
+	case BackendRFC2136:
+		c, err = NewRFC2136Client(&RFC2136Options{
+			Server:     cfg.Server,
+			HostedZone: cfg.HostedZone,
+			TSIG:       cfg.TSIG,
+			Log:        cfg.Log,
+		})
+	case BackendPowerDNS:
+		c, err = NewPowerDNSClient(&PowerDNSOptions{
+			URL:        cfg.URL,
+			APIKey:     cfg.APIKey,
+			ServerID:   cfg.ServerID,
+			HostedZone: cfg.HostedZone,
+			Log:        cfg.Log,
+		})
+	case BackendZoneFile:
+		c, err = NewZoneFileClient(&ZoneFileOptions{
+			Path:       cfg.ZoneFile,
+			HostedZone: cfg.HostedZone,
+			NameServer: cfg.NameServer,
+			Log:        cfg.Log,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported DNS backend: %q", cfg.Backend)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
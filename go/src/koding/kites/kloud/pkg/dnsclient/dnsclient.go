@@ -12,7 +12,6 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/cenkalti/backoff"
-	"github.com/dchest/validator"
 	"github.com/koding/logging"
 )
 
@@ -50,6 +49,7 @@ func (opts *Options) log() logging.Logger {
 // NewRoute53Client initializes a new DNSClient interface instance based on AWS Route53
 func NewRoute53Client(opts *Options) (*Route53, error) {
 	optsCopy := *opts
+	optsCopy.Log = opts.log()
 
 	awsOpts := &amazon.ClientOptions{
 		Credentials: opts.Creds,
@@ -334,33 +334,9 @@ func (r *Route53) HostedZone() string {
 }
 
 func (r *Route53) Validate(domain, username string) error {
-	hostedZone := r.HostedZone()
-
-	if domain == "" {
-		return r.errorf("Domain name argument is empty")
-	}
-
-	if domain == hostedZone {
-		return r.errorf("Domain %q can't be the same as top-level domain %q", domain, hostedZone)
-	}
-
-	if !strings.Contains(domain, hostedZone) {
-		return r.errorf("Domain %q doesn't contain hostedzone %q", domain, hostedZone)
-	}
-
-	rest := strings.TrimSuffix(domain, "."+hostedZone)
-	if rest == domain {
-		return r.errorf("Domain %q is invalid (1)", domain)
-	}
-
-	if split := strings.Split(rest, "."); split[len(split)-1] != username {
-		return r.errorf("Domain %q doesn't contain %q username (hostedZone=%q)", domain, username, hostedZone)
+	if err := validateDomain(r.HostedZone(), domain, username); err != nil {
+		return r.errorf("%s", err)
 	}
-
-	if !validator.IsValidDomain(domain) {
-		return r.errorf("Domain %q is invalid (2)", domain)
-	}
-
 	return nil
 }
 
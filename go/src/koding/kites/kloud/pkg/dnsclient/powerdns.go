@@ -0,0 +1,373 @@
+package dnsclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/koding/logging"
+)
+
+// PowerDNSOptions configures PowerDNS client.
+type PowerDNSOptions struct {
+	// URL is a base URL of the PowerDNS HTTP API,
+	// e.g. "http://127.0.0.1:8081".
+	URL string
+
+	// APIKey is a value of the X-API-Key header, as
+	// configured by the api-key PowerDNS setting.
+	APIKey string
+
+	// ServerID is a name of the server the hosted zone
+	// belongs to. If empty, "localhost" is used.
+	ServerID string
+
+	HostedZone string
+
+	// Client is used for making requests to the API.
+	//
+	// If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	Log logging.Logger
+}
+
+func (opts *PowerDNSOptions) log() logging.Logger {
+	if opts.Log != nil {
+		return opts.Log
+	}
+	return defaultLog
+}
+
+func (opts *PowerDNSOptions) client() *http.Client {
+	if opts.Client != nil {
+		return opts.Client
+	}
+	return http.DefaultClient
+}
+
+// PowerDNS is a Client implementation that manages records
+// using the PowerDNS Authoritative Server HTTP API.
+type PowerDNS struct {
+	opts *PowerDNSOptions
+	zone string // zone endpoint
+}
+
+var _ Client = (*PowerDNS)(nil)
+
+type pdnsRecord struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+type pdnsRRset struct {
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	TTL        int          `json:"ttl,omitempty"`
+	ChangeType string       `json:"changetype,omitempty"`
+	Records    []pdnsRecord `json:"records"`
+}
+
+type pdnsZone struct {
+	Name   string      `json:"name,omitempty"`
+	RRsets []pdnsRRset `json:"rrsets"`
+}
+
+type pdnsError struct {
+	Err string `json:"error"`
+}
+
+// NewPowerDNSClient gives new PowerDNS client for the given options.
+//
+// It fails if the hosted zone does not exist on the server.
+func NewPowerDNSClient(opts *PowerDNSOptions) (*PowerDNS, error) {
+	optsCopy := *opts
+
+	if optsCopy.HostedZone == "" {
+		return nil, fmt.Errorf("hosted zone is empty")
+	}
+
+	if optsCopy.ServerID == "" {
+		optsCopy.ServerID = "localhost"
+	}
+
+	u, err := url.Parse(optsCopy.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PowerDNS API URL: %s", err)
+	}
+
+	optsCopy.Log = optsCopy.log()
+
+	p := &PowerDNS{
+		opts: &optsCopy,
+		zone: strings.TrimRight(u.String(), "/") + "/api/v1/servers/" + url.QueryEscape(optsCopy.ServerID) +
+			"/zones/" + url.QueryEscape(fqdn(optsCopy.HostedZone)),
+	}
+
+	if _, err := p.rrsets(); err != nil {
+		return nil, fmt.Errorf("Hosted zone with the name %q doesn't exist: %s", optsCopy.HostedZone, err)
+	}
+
+	return p, nil
+}
+
+// Upsert creates or updates the domain record with the given ip address. If
+// the record already exists, the record is updated with the new IP.
+func (p *PowerDNS) Upsert(domain, newIP string) error {
+	rec := &Record{
+		Name: domain,
+		Type: "A",
+		IP:   newIP,
+		TTL:  30,
+	}
+	return p.UpsertRecord(rec)
+}
+
+// UpsertRecord creates or updates a DNS record.
+func (p *PowerDNS) UpsertRecord(rec *Record) error {
+	return p.UpsertRecords(rec)
+}
+
+// UpsertRecords creates or updates the given records in a single
+// API request.
+func (p *PowerDNS) UpsertRecords(recs ...*Record) error {
+	rrsets := make([]pdnsRRset, len(recs))
+
+	for i, rec := range recs {
+		p.opts.Log.Debug("upserting record: %# v", rec)
+
+		rrsets[i] = p.replace(rec)
+	}
+
+	if err := p.patch(rrsets...); err != nil {
+		return p.errorf("upserting records failed: %s", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the record for the given domain name.
+func (p *PowerDNS) Get(domain string) (*Record, error) {
+	p.opts.Log.Debug("fetching domain record for domain: %s", domain)
+
+	recs, err := p.GetAll(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range recs {
+		if strings.EqualFold(rec.Name, fqdn(domain)) {
+			return rec, nil
+		}
+	}
+
+	return nil, p.error(ErrNoRecord)
+}
+
+// GetAll retrieves all the records from the hosted zone
+// which name matches the given one. If name is empty, all
+// the records are returned.
+func (p *PowerDNS) GetAll(name string) ([]*Record, error) {
+	rrsets, err := p.rrsets()
+	if err != nil {
+		return nil, p.error(err)
+	}
+
+	var recs Records
+
+	for _, rrset := range rrsets {
+		for _, r := range rrset.Records {
+			if r.Disabled {
+				continue
+			}
+
+			recs = append(recs, &Record{
+				Name: rrset.Name,
+				Type: rrset.Type,
+				IP:   p.value(rrset.Type, r.Content),
+				TTL:  rrset.TTL,
+			})
+		}
+	}
+
+	if name != "" {
+		recs = recs.ByName(fqdn(name))
+	}
+
+	if len(recs) == 0 {
+		return nil, p.error(ErrNoRecord)
+	}
+
+	return recs, nil
+}
+
+// Rename changes the domain from oldDomain to newDomain in a single
+// API request.
+func (p *PowerDNS) Rename(oldDomain, newDomain string) error {
+	rec, err := p.Get(oldDomain)
+	if err != nil {
+		return err
+	}
+
+	p.opts.Log.Debug("updating domain name of IP %s from %q to %q", rec.IP, oldDomain, newDomain)
+
+	renamed := *rec
+	renamed.Name = newDomain
+
+	if err := p.patch(p.delete(rec), p.replace(&renamed)); err != nil {
+		return p.errorf("could not rename domain %q to %q: %s", oldDomain, newDomain, err)
+	}
+
+	return nil
+}
+
+// Delete deletes a domain record for the given domain.
+func (p *PowerDNS) Delete(domain string) error {
+	rec, err := p.Get(domain)
+	if err == ErrNoRecord {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return p.DeleteRecord(rec)
+}
+
+// DeleteRecord deletes the given record.
+func (p *PowerDNS) DeleteRecord(rec *Record) error {
+	p.opts.Log.Debug("deleting record: %v", rec)
+
+	if err := p.patch(p.delete(rec)); err != nil {
+		return p.errorf("could not delete record %v: %s", rec, err)
+	}
+
+	return nil
+}
+
+// HostedZone gives the zone the client is managing.
+func (p *PowerDNS) HostedZone() string {
+	return p.opts.HostedZone
+}
+
+// Validate validates if the given domain name is valid.
+func (p *PowerDNS) Validate(domain, username string) error {
+	if err := validateDomain(p.HostedZone(), domain, username); err != nil {
+		return p.errorf("%s", err)
+	}
+	return nil
+}
+
+func (p *PowerDNS) replace(rec *Record) pdnsRRset {
+	content := rec.IP
+	if strings.EqualFold(rec.Type, "CNAME") {
+		content = fqdn(content)
+	}
+
+	return pdnsRRset{
+		Name:       fqdn(rec.Name),
+		Type:       strings.ToUpper(rec.Type),
+		TTL:        rec.TTL,
+		ChangeType: "REPLACE",
+		Records:    []pdnsRecord{{Content: content}},
+	}
+}
+
+func (p *PowerDNS) delete(rec *Record) pdnsRRset {
+	return pdnsRRset{
+		Name:       fqdn(rec.Name),
+		Type:       strings.ToUpper(rec.Type),
+		ChangeType: "DELETE",
+		Records:    []pdnsRecord{},
+	}
+}
+
+func (*PowerDNS) value(typ, content string) string {
+	if strings.EqualFold(typ, "CNAME") {
+		return unfqdn(content)
+	}
+	return content
+}
+
+func (p *PowerDNS) rrsets() ([]pdnsRRset, error) {
+	var zone pdnsZone
+
+	if err := p.do("GET", nil, &zone); err != nil {
+		return nil, err
+	}
+
+	return zone.RRsets, nil
+}
+
+func (p *PowerDNS) patch(rrsets ...pdnsRRset) error {
+	return p.do("PATCH", &pdnsZone{RRsets: rrsets}, nil)
+}
+
+func (p *PowerDNS) do(method string, in, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		var buf bytes.Buffer
+
+		if err := json.NewEncoder(&buf).Encode(in); err != nil {
+			return err
+		}
+
+		body = &buf
+	}
+
+	req, err := http.NewRequest(method, p.zone, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-API-Key", p.opts.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.opts.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e pdnsError
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		if json.Unmarshal(body, &e) == nil && e.Err != "" {
+			return fmt.Errorf("%s %s: %s", method, resp.Request.URL.Path, e.Err)
+		}
+
+		return fmt.Errorf("%s %s: %s", method, resp.Request.URL.Path, resp.Status)
+	}
+
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+
+	return nil
+}
+
+func (p *PowerDNS) errorf(format string, v ...interface{}) error {
+	err := fmt.Errorf(format, v...)
+	p.opts.Log.Error(err.Error())
+	return err
+}
+
+func (p *PowerDNS) error(err error) error {
+	// Ignore ErrNoRecord errors, as they're expected
+	// and handled by the caller.
+	if err != ErrNoRecord {
+		p.opts.Log.Error("%q", err)
+	}
+	return err
+}
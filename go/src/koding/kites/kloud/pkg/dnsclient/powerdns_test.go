@@ -0,0 +1,165 @@
+package dnsclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fakePowerDNS implements the zone endpoint of
+// the PowerDNS HTTP API.
+type fakePowerDNS struct {
+	mu     sync.Mutex
+	rrsets map[string]pdnsRRset // maps "name type" to rrset
+}
+
+func (f *fakePowerDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(&pdnsError{Err: "Unauthorized"})
+		return
+	}
+
+	if r.URL.Path != "/api/v1/servers/localhost/zones/dev.koding.io." {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(&pdnsError{Err: "Could not find domain"})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case "GET":
+		zone := &pdnsZone{Name: "dev.koding.io."}
+
+		for _, rrset := range f.rrsets {
+			zone.RRsets = append(zone.RRsets, rrset)
+		}
+
+		json.NewEncoder(w).Encode(zone)
+	case "PATCH":
+		var zone pdnsZone
+
+		if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(&pdnsError{Err: err.Error()})
+			return
+		}
+
+		for _, rrset := range zone.RRsets {
+			key := rrset.Name + " " + rrset.Type
+
+			switch rrset.ChangeType {
+			case "REPLACE":
+				rrset.ChangeType = ""
+				f.rrsets[key] = rrset
+			case "DELETE":
+				delete(f.rrsets, key)
+			default:
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(&pdnsError{Err: "invalid changetype"})
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestPowerDNS(t *testing.T) {
+	fake := &fakePowerDNS{
+		rrsets: make(map[string]pdnsRRset),
+	}
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	opts := &PowerDNSOptions{
+		URL:        srv.URL,
+		APIKey:     "secret",
+		HostedZone: "dev.koding.io",
+	}
+
+	c, err := NewPowerDNSClient(opts)
+	if err != nil {
+		t.Fatalf("NewPowerDNSClient()=%s", err)
+	}
+
+	if err := c.Upsert("vm-0.user.dev.koding.io", "10.0.0.1"); err != nil {
+		t.Fatalf("Upsert()=%s", err)
+	}
+
+	cname := &Record{Name: "www.user.dev.koding.io", Type: "CNAME", IP: "vm-0.user.dev.koding.io", TTL: 60}
+
+	if err := c.UpsertRecord(cname); err != nil {
+		t.Fatalf("UpsertRecord()=%s", err)
+	}
+
+	if got := fake.rrsets["www.user.dev.koding.io. CNAME"].Records[0].Content; got != "vm-0.user.dev.koding.io." {
+		t.Fatalf("got %q, want CNAME content to be fully qualified", got)
+	}
+
+	rec, err := c.Get("www.user.dev.koding.io")
+	if err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	want := &Record{Name: "www.user.dev.koding.io.", Type: "CNAME", IP: "vm-0.user.dev.koding.io", TTL: 60}
+
+	if *rec != *want {
+		t.Fatalf("got %+v, want %+v", rec, want)
+	}
+
+	if err := c.Rename("vm-0.user.dev.koding.io", "vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Rename()=%s", err)
+	}
+
+	if _, err := c.Get("vm-0.user.dev.koding.io"); err != ErrNoRecord {
+		t.Fatalf("got %v, want %v", err, ErrNoRecord)
+	}
+
+	if rec, err = c.Get("vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	if rec.IP != "10.0.0.1" {
+		t.Fatalf("got %q, want %q", rec.IP, "10.0.0.1")
+	}
+
+	recs, err := c.GetAll("")
+	if err != nil {
+		t.Fatalf("GetAll()=%s", err)
+	}
+
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2", len(recs))
+	}
+
+	for _, name := range []string{"vm-1.user.dev.koding.io", "www.user.dev.koding.io", "nonexisting.user.dev.koding.io"} {
+		if err := c.Delete(name); err != nil {
+			t.Fatalf("Delete(%q)=%s", name, err)
+		}
+	}
+
+	if len(fake.rrsets) != 0 {
+		t.Fatalf("want all rrsets to be deleted, got %+v", fake.rrsets)
+	}
+
+	opts.APIKey = "invalid"
+
+	if _, err := NewPowerDNSClient(opts); err == nil {
+		t.Fatal("expected NewPowerDNSClient() to fail with invalid API key")
+	}
+
+	opts.APIKey = "secret"
+	opts.HostedZone = "nonexisting.koding.io"
+
+	if _, err := NewPowerDNSClient(opts); err == nil {
+		t.Fatal("expected NewPowerDNSClient() to fail with nonexisting zone")
+	}
+}
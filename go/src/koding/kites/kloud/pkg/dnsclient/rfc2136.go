@@ -0,0 +1,384 @@
+package dnsclient
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/koding/logging"
+)
+
+// RFC2136Options configures RFC2136 client.
+type RFC2136Options struct {
+	// Server is an address of the primary name server for the
+	// hosted zone, e.g. "ns1.example.com:53". If the port is
+	// missing, 53 is used.
+	Server string
+
+	HostedZone string
+
+	// TSIG is a key used for signing update messages. If nil,
+	// updates are sent unsigned.
+	TSIG *TSIG
+
+	// Timeout is a timeout for a single DNS exchange.
+	//
+	// If zero, 10s is used.
+	Timeout time.Duration
+
+	Log logging.Logger
+}
+
+func (opts *RFC2136Options) log() logging.Logger {
+	if opts.Log != nil {
+		return opts.Log
+	}
+	return defaultLog
+}
+
+func (opts *RFC2136Options) timeout() time.Duration {
+	if opts.Timeout != 0 {
+		return opts.Timeout
+	}
+	return 10 * time.Second
+}
+
+// RFC2136 is a Client implementation that manages records using
+// DNS dynamic updates, as described in RFC 2136. It works with
+// any name server that supports them, e.g. BIND or Knot.
+type RFC2136 struct {
+	opts *RFC2136Options
+}
+
+var _ Client = (*RFC2136)(nil)
+
+// NewRFC2136Client gives new RFC2136 client for the given options.
+func NewRFC2136Client(opts *RFC2136Options) (*RFC2136, error) {
+	optsCopy := *opts
+
+	if optsCopy.HostedZone == "" {
+		return nil, fmt.Errorf("hosted zone is empty")
+	}
+
+	if optsCopy.Server == "" {
+		return nil, fmt.Errorf("DNS server address is empty")
+	}
+
+	if _, _, err := net.SplitHostPort(optsCopy.Server); err != nil {
+		optsCopy.Server = net.JoinHostPort(optsCopy.Server, "53")
+	}
+
+	if optsCopy.TSIG != nil {
+		if _, err := optsCopy.TSIG.mac(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	optsCopy.Log = optsCopy.log()
+
+	return &RFC2136{
+		opts: &optsCopy,
+	}, nil
+}
+
+// Upsert creates or updates the domain record with the given ip address. If
+// the record already exists, the record is updated with the new IP.
+func (r *RFC2136) Upsert(domain, newIP string) error {
+	rec := &Record{
+		Name: domain,
+		Type: "A",
+		IP:   newIP,
+		TTL:  30,
+	}
+	return r.UpsertRecord(rec)
+}
+
+// UpsertRecord creates or updates a DNS record.
+func (r *RFC2136) UpsertRecord(rec *Record) error {
+	return r.UpsertRecords(rec)
+}
+
+// UpsertRecords creates or updates the given records in a single
+// update message.
+func (r *RFC2136) UpsertRecords(recs ...*Record) error {
+	var update []dnsRR
+
+	for _, rec := range recs {
+		r.opts.Log.Debug("upserting record: %# v", rec)
+
+		rr, err := newRR(rec, classINET)
+		if err != nil {
+			return r.errorf("invalid record %v: %s", rec, err)
+		}
+
+		update = append(update, deleteRRset(rr), rr)
+	}
+
+	if err := r.update(update); err != nil {
+		return r.errorf("upserting records failed: %s", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the record for the given domain name.
+func (r *RFC2136) Get(domain string) (*Record, error) {
+	r.opts.Log.Debug("fetching domain record for domain: %s", domain)
+
+	for _, typ := range []uint16{typeA, typeAAAA, typeCNAME} {
+		rec, err := r.get(domain, typ)
+		if err == ErrNoRecord {
+			continue
+		}
+		if err != nil {
+			return nil, r.error(err)
+		}
+		return rec, nil
+	}
+
+	return nil, r.error(ErrNoRecord)
+}
+
+func (r *RFC2136) get(domain string, typ uint16) (*Record, error) {
+	query := &dnsMsg{
+		ID:     r.id(),
+		Opcode: opcodeQuery,
+		Question: []dnsQuestion{{
+			Name:  fqdn(domain),
+			Type:  typ,
+			Class: classINET,
+		}},
+	}
+
+	resp, err := r.exchange(query, false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.RCode {
+	case rcodeSuccess:
+	case rcodeNXDomain:
+		return nil, ErrNoRecord
+	default:
+		return nil, fmt.Errorf("query for %q failed: %s", domain, rcodeString(resp.RCode))
+	}
+
+	for _, rr := range resp.Answer {
+		if rr.Type == typ && strings.EqualFold(rr.Name, fqdn(domain)) {
+			return rr.toRecord()
+		}
+	}
+
+	return nil, ErrNoRecord
+}
+
+// Rename changes the domain from oldDomain to newDomain in a single
+// update message.
+func (r *RFC2136) Rename(oldDomain, newDomain string) error {
+	rec, err := r.Get(oldDomain)
+	if err != nil {
+		return err
+	}
+
+	r.opts.Log.Debug("updating domain name of IP %s from %q to %q", rec.IP, oldDomain, newDomain)
+
+	oldRR, err := newRR(rec, classINET)
+	if err != nil {
+		return r.errorf("invalid record %v: %s", rec, err)
+	}
+
+	renamedRR := oldRR
+	renamedRR.Name = fqdn(newDomain)
+
+	if err := r.update([]dnsRR{deleteRRset(oldRR), deleteRRset(renamedRR), renamedRR}); err != nil {
+		return r.errorf("could not rename domain %q to %q: %s", oldDomain, newDomain, err)
+	}
+
+	return nil
+}
+
+// Delete deletes a domain record for the given domain.
+func (r *RFC2136) Delete(domain string) error {
+	rec, err := r.Get(domain)
+	if err == ErrNoRecord {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return r.DeleteRecord(rec)
+}
+
+// DeleteRecord deletes the given record.
+func (r *RFC2136) DeleteRecord(rec *Record) error {
+	r.opts.Log.Debug("deleting record: %v", rec)
+
+	rr, err := newRR(rec, classNONE)
+	if err != nil {
+		return r.errorf("invalid record %v: %s", rec, err)
+	}
+	rr.TTL = 0
+
+	if err := r.update([]dnsRR{rr}); err != nil {
+		return r.errorf("could not delete record %v: %s", rec, err)
+	}
+
+	return nil
+}
+
+// HostedZone gives the zone the client is managing.
+func (r *RFC2136) HostedZone() string {
+	return r.opts.HostedZone
+}
+
+// Validate validates if the given domain name is valid.
+func (r *RFC2136) Validate(domain, username string) error {
+	if err := validateDomain(r.HostedZone(), domain, username); err != nil {
+		return r.errorf("%s", err)
+	}
+	return nil
+}
+
+func (r *RFC2136) update(rrs []dnsRR) error {
+	msg := &dnsMsg{
+		ID:     r.id(),
+		Opcode: opcodeUpdate,
+		Question: []dnsQuestion{{
+			Name:  fqdn(r.opts.HostedZone),
+			Type:  typeSOA,
+			Class: classINET,
+		}},
+		Ns: rrs,
+	}
+
+	resp, err := r.exchange(msg, true)
+	if err != nil {
+		return err
+	}
+
+	if resp.RCode != rcodeSuccess {
+		return fmt.Errorf("server responded with %s", rcodeString(resp.RCode))
+	}
+
+	return nil
+}
+
+// exchange sends the message to the server and reads the reply.
+//
+// The exchange is made over UDP, unless the reply was truncated,
+// in which case it is retried over TCP.
+func (r *RFC2136) exchange(msg *dnsMsg, sign bool) (*dnsMsg, error) {
+	p, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	if sign && r.opts.TSIG != nil {
+		if p, err = r.opts.TSIG.Sign(p, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := r.roundtrip("udp", p, msg.ID)
+	if err == nil && resp.Truncated {
+		resp, err = r.roundtrip("tcp", p, msg.ID)
+	}
+
+	return resp, err
+}
+
+func (r *RFC2136) roundtrip(network string, p []byte, id uint16) (*dnsMsg, error) {
+	conn, err := net.DialTimeout(network, r.opts.Server, r.opts.timeout())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(r.opts.timeout())); err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+
+	if network == "tcp" {
+		var n [2]byte
+		binary.BigEndian.PutUint16(n[:], uint16(len(p)))
+
+		if _, err := conn.Write(append(n[:], p...)); err != nil {
+			return nil, err
+		}
+
+		if _, err := io.ReadFull(conn, n[:]); err != nil {
+			return nil, err
+		}
+
+		buf = make([]byte, binary.BigEndian.Uint16(n[:]))
+
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(p); err != nil {
+			return nil, err
+		}
+
+		buf = make([]byte, 65535)
+
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+
+			// Ignore stray replies.
+			if n >= 2 && binary.BigEndian.Uint16(buf) == id {
+				buf = buf[:n]
+				break
+			}
+		}
+	}
+
+	var resp dnsMsg
+	if err := resp.Unpack(buf); err != nil {
+		return nil, err
+	}
+
+	if resp.ID != id {
+		return nil, fmt.Errorf("reply id mismatch: want %d, got %d", id, resp.ID)
+	}
+
+	return &resp, nil
+}
+
+func (r *RFC2136) id() uint16 {
+	return uint16(rand.Uint32())
+}
+
+func (r *RFC2136) errorf(format string, v ...interface{}) error {
+	err := fmt.Errorf(format, v...)
+	r.opts.Log.Error(err.Error())
+	return err
+}
+
+func (r *RFC2136) error(err error) error {
+	// Ignore ErrNoRecord errors, as they're expected
+	// and handled by the caller.
+	if err != ErrNoRecord {
+		r.opts.Log.Error("%q", err)
+	}
+	return err
+}
+
+// deleteRRset gives an update record that deletes all the
+// records with the given name and type (RFC 2136, 2.5.2).
+func deleteRRset(rr dnsRR) dnsRR {
+	return dnsRR{
+		Name:  rr.Name,
+		Type:  rr.Type,
+		Class: classANY,
+	}
+}
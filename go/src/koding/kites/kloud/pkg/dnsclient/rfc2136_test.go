@@ -0,0 +1,358 @@
+package dnsclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testTSIG = &TSIG{
+	Name:   "kloud.",
+	Secret: "c2VjcmV0LWtleS1mb3ItdGVzdGluZw==",
+}
+
+// testServer is an in-process authoritative name server that
+// supports queries and TSIG-signed dynamic updates.
+type testServer struct {
+	conn net.PacketConn
+	zone string
+	tsig *TSIG
+
+	mu      sync.Mutex
+	records map[string][]dnsRR // maps lowercased name to records
+}
+
+func newTestServer(t *testing.T, zone string, tsig *TSIG) *testServer {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket()=%s", err)
+	}
+
+	srv := &testServer{
+		conn:    conn,
+		zone:    fqdn(zone),
+		tsig:    tsig,
+		records: make(map[string][]dnsRR),
+	}
+
+	go srv.serve()
+
+	return srv
+}
+
+func (srv *testServer) Addr() string {
+	return srv.conn.LocalAddr().String()
+}
+
+func (srv *testServer) Close() error {
+	return srv.conn.Close()
+}
+
+func (srv *testServer) serve() {
+	buf := make([]byte, 65535)
+
+	for {
+		n, addr, err := srv.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var req dnsMsg
+		if err := req.Unpack(buf[:n]); err != nil {
+			continue
+		}
+
+		resp := &dnsMsg{
+			ID:       req.ID,
+			Response: true,
+			Opcode:   req.Opcode,
+			Question: req.Question,
+		}
+
+		switch req.Opcode {
+		case opcodeQuery:
+			srv.query(&req, resp)
+		case opcodeUpdate:
+			srv.update(&req, resp)
+		}
+
+		p, err := resp.Pack()
+		if err != nil {
+			continue
+		}
+
+		srv.conn.WriteTo(p, addr)
+	}
+}
+
+func (srv *testServer) query(req, resp *dnsMsg) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	q := req.Question[0]
+
+	rrs, ok := srv.records[strings.ToLower(q.Name)]
+	if !ok {
+		resp.RCode = rcodeNXDomain
+		return
+	}
+
+	for _, rr := range rrs {
+		if rr.Type == q.Type {
+			resp.Answer = append(resp.Answer, rr)
+		}
+	}
+}
+
+func (srv *testServer) update(req, resp *dnsMsg) {
+	if len(req.Question) != 1 || !strings.EqualFold(req.Question[0].Name, srv.zone) {
+		resp.RCode = rcodeNotAuth
+		return
+	}
+
+	if srv.tsig != nil && !srv.verify(req) {
+		resp.RCode = rcodeNotAuth
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	for _, rr := range req.Ns {
+		name := strings.ToLower(rr.Name)
+
+		switch rr.Class {
+		case classANY:
+			srv.remove(name, func(r dnsRR) bool { return r.Type == rr.Type })
+		case classNONE:
+			srv.remove(name, func(r dnsRR) bool { return r.Type == rr.Type && bytes.Equal(r.Data, rr.Data) })
+		case classINET:
+			srv.remove(name, func(r dnsRR) bool { return r.Type == rr.Type && bytes.Equal(r.Data, rr.Data) })
+			srv.records[name] = append(srv.records[name], rr)
+		}
+	}
+}
+
+func (srv *testServer) remove(name string, fn func(dnsRR) bool) {
+	var rrs []dnsRR
+	for _, rr := range srv.records[name] {
+		if !fn(rr) {
+			rrs = append(rrs, rr)
+		}
+	}
+
+	if len(rrs) == 0 {
+		delete(srv.records, name)
+	} else {
+		srv.records[name] = rrs
+	}
+}
+
+// verify checks TSIG of the request, as described in RFC 2845, 4.5.
+func (srv *testServer) verify(req *dnsMsg) bool {
+	if len(req.Extra) == 0 {
+		return false
+	}
+
+	rr := req.Extra[len(req.Extra)-1]
+	if rr.Type != typeTSIG || !strings.EqualFold(rr.Name, fqdn(srv.tsig.Name)) {
+		return false
+	}
+
+	alg, off, err := unpackName(rr.Data, 0)
+	if err != nil || alg != srv.tsig.algorithm() || off+10 > len(rr.Data) {
+		return false
+	}
+
+	var t [8]byte
+	copy(t[2:], rr.Data[off:off+6])
+	signed := time.Unix(int64(binary.BigEndian.Uint64(t[:])), 0)
+
+	if d := time.Since(signed); d > tsigFudge*time.Second || d < -tsigFudge*time.Second {
+		return false
+	}
+
+	n := int(binary.BigEndian.Uint16(rr.Data[off+8:]))
+	if off+10+n > len(rr.Data) {
+		return false
+	}
+	mac := rr.Data[off+10 : off+10+n]
+
+	unsigned := *req
+	unsigned.Extra = req.Extra[:len(req.Extra)-1]
+
+	p, err := unsigned.Pack()
+	if err != nil {
+		return false
+	}
+
+	vars, err := srv.tsig.variables(signed, 0)
+	if err != nil {
+		return false
+	}
+
+	want, err := srv.tsig.mac(append(p, vars...))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(mac, want)
+}
+
+func newTestRFC2136(t *testing.T, srv *testServer, tsig *TSIG) *RFC2136 {
+	c, err := NewRFC2136Client(&RFC2136Options{
+		Server:     srv.Addr(),
+		HostedZone: "dev.koding.io",
+		TSIG:       tsig,
+		Timeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewRFC2136Client()=%s", err)
+	}
+	return c
+}
+
+func TestRFC2136(t *testing.T) {
+	srv := newTestServer(t, "dev.koding.io", testTSIG)
+	defer srv.Close()
+
+	c := newTestRFC2136(t, srv, testTSIG)
+
+	if err := c.Upsert("vm-0.user.dev.koding.io", "10.0.0.1"); err != nil {
+		t.Fatalf("Upsert()=%s", err)
+	}
+
+	if err := c.Upsert("vm-0.user.dev.koding.io", "10.0.0.2"); err != nil {
+		t.Fatalf("Upsert()=%s", err)
+	}
+
+	rec, err := c.Get("vm-0.user.dev.koding.io")
+	if err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	want := &Record{Name: "vm-0.user.dev.koding.io.", Type: "A", IP: "10.0.0.2", TTL: 30}
+
+	if *rec != *want {
+		t.Fatalf("got %+v, want %+v", rec, want)
+	}
+
+	if err := c.Rename("vm-0.user.dev.koding.io", "vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Rename()=%s", err)
+	}
+
+	if _, err := c.Get("vm-0.user.dev.koding.io"); err != ErrNoRecord {
+		t.Fatalf("got %v, want %v", err, ErrNoRecord)
+	}
+
+	if rec, err = c.Get("vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	if rec.IP != "10.0.0.2" {
+		t.Fatalf("got %q, want %q", rec.IP, "10.0.0.2")
+	}
+
+	cname := &Record{Name: "www.user.dev.koding.io", Type: "CNAME", IP: "vm-1.user.dev.koding.io", TTL: 60}
+
+	if err := c.UpsertRecord(cname); err != nil {
+		t.Fatalf("UpsertRecord()=%s", err)
+	}
+
+	if rec, err = c.Get("www.user.dev.koding.io"); err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	if rec.Type != "CNAME" || rec.IP != cname.IP {
+		t.Fatalf("got %+v, want %+v", rec, cname)
+	}
+
+	for _, name := range []string{"vm-1.user.dev.koding.io", "www.user.dev.koding.io", "nonexisting.user.dev.koding.io"} {
+		if err := c.Delete(name); err != nil {
+			t.Fatalf("Delete(%q)=%s", name, err)
+		}
+
+		if _, err := c.Get(name); err != ErrNoRecord {
+			t.Fatalf("Get(%q): got %v, want %v", name, err, ErrNoRecord)
+		}
+	}
+}
+
+func TestRFC2136Unauthorized(t *testing.T) {
+	srv := newTestServer(t, "dev.koding.io", testTSIG)
+	defer srv.Close()
+
+	cases := map[string]*TSIG{
+		"unsigned": nil,
+		"invalid secret": {
+			Name:   testTSIG.Name,
+			Secret: "aW52YWxpZC1zZWNyZXQ=",
+		},
+		"invalid key name": {
+			Name:   "other.",
+			Secret: testTSIG.Secret,
+		},
+	}
+
+	for name, tsig := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestRFC2136(t, srv, tsig)
+
+			if err := c.Upsert("vm-0.user.dev.koding.io", "10.0.0.1"); err == nil {
+				t.Fatal("expected Upsert() to fail")
+			}
+
+			if _, err := c.Get("vm-0.user.dev.koding.io"); err != ErrNoRecord {
+				t.Fatalf("got %v, want %v", err, ErrNoRecord)
+			}
+		})
+	}
+}
+
+func TestWireRoundtrip(t *testing.T) {
+	recs := []*Record{
+		{Name: "a.dev.koding.io.", Type: "A", IP: "192.168.1.1", TTL: 30},
+		{Name: "b.dev.koding.io.", Type: "AAAA", IP: "2001:db8::1", TTL: 60},
+		{Name: "c.dev.koding.io.", Type: "CNAME", IP: "a.dev.koding.io", TTL: 300},
+		{Name: "d.dev.koding.io.", Type: "TXT", IP: strings.Repeat("x", 300), TTL: 30},
+	}
+
+	msg := &dnsMsg{ID: 42, Response: true}
+
+	for _, rec := range recs {
+		rr, err := newRR(rec, classINET)
+		if err != nil {
+			t.Fatalf("newRR(%+v)=%s", rec, err)
+		}
+		msg.Answer = append(msg.Answer, rr)
+	}
+
+	p, err := msg.Pack()
+	if err != nil {
+		t.Fatalf("Pack()=%s", err)
+	}
+
+	var got dnsMsg
+	if err := got.Unpack(p); err != nil {
+		t.Fatalf("Unpack()=%s", err)
+	}
+
+	if got.ID != msg.ID || !got.Response || len(got.Answer) != len(recs) {
+		t.Fatalf("got %+v, want %+v", got, msg)
+	}
+
+	for i, rr := range got.Answer {
+		rec, err := rr.toRecord()
+		if err != nil {
+			t.Fatalf("toRecord()=%s", err)
+		}
+
+		if *rec != *recs[i] {
+			t.Errorf("%d: got %+v, want %+v", i, rec, recs[i])
+		}
+	}
+}
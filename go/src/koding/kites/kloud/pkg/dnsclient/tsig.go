@@ -0,0 +1,136 @@
+package dnsclient
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// TSIG algorithm names as defined in RFC 2845 and RFC 4635.
+const (
+	HmacMD5    = "hmac-md5.sig-alg.reg.int."
+	HmacSHA1   = "hmac-sha1."
+	HmacSHA256 = "hmac-sha256."
+	HmacSHA512 = "hmac-sha512."
+)
+
+// tsigFudge is the permitted time skew, in seconds,
+// between the signer and the server.
+const tsigFudge = 300
+
+var tsigHashes = map[string]func() hash.Hash{
+	HmacMD5:    md5.New,
+	HmacSHA1:   sha1.New,
+	HmacSHA256: sha256.New,
+	HmacSHA512: sha512.New,
+}
+
+// TSIG describes a shared secret used for authenticating
+// DNS messages, as described in RFC 2845.
+type TSIG struct {
+	Name      string // key name, e.g. "kloud."
+	Secret    string // base64-encoded secret
+	Algorithm string // HmacSHA256 if empty
+}
+
+func (t *TSIG) algorithm() string {
+	if t.Algorithm == "" {
+		return HmacSHA256
+	}
+	return fqdn(strings.ToLower(t.Algorithm))
+}
+
+func (t *TSIG) mac(p []byte) ([]byte, error) {
+	newHash, ok := tsigHashes[t.algorithm()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %q", t.Algorithm)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(t.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TSIG secret: %s", err)
+	}
+
+	h := hmac.New(newHash, secret)
+	h.Write(p)
+	return h.Sum(nil), nil
+}
+
+// tsigVariables encodes TSIG variables that are digested
+// together with the message, as described in RFC 2845, 3.4.2.
+func (t *TSIG) variables(signed time.Time, rcode uint16) ([]byte, error) {
+	b, err := packName(nil, strings.ToLower(t.Name))
+	if err != nil {
+		return nil, err
+	}
+	b = packUint16(b, classANY)
+	b = packUint32(b, 0)
+	if b, err = packName(b, t.algorithm()); err != nil {
+		return nil, err
+	}
+	b = packTime(b, signed)
+	b = packUint16(b, tsigFudge)
+	b = packUint16(b, rcode)
+	return packUint16(b, 0), nil // other len
+}
+
+// Sign appends TSIG record to the given packed message.
+//
+// The msg is expected to not contain the TSIG record yet.
+func (t *TSIG) Sign(msg []byte, now time.Time) ([]byte, error) {
+	if len(msg) < 12 {
+		return nil, errShortMsg
+	}
+
+	vars, err := t.variables(now, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := t.mac(append(append([]byte(nil), msg...), vars...))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := packName(nil, t.algorithm())
+	if err != nil {
+		return nil, err
+	}
+	data = packTime(data, now)
+	data = packUint16(data, tsigFudge)
+	data = packUint16(data, uint16(len(mac)))
+	data = append(data, mac...)
+	data = append(data, msg[0], msg[1]) // original ID
+	data = packUint16(data, 0)          // error
+	data = packUint16(data, 0)          // other len
+
+	rr := &dnsRR{
+		Name:  fqdn(strings.ToLower(t.Name)),
+		Type:  typeTSIG,
+		Class: classANY,
+		Data:  data,
+	}
+
+	signed, err := rr.pack(append([]byte(nil), msg...))
+	if err != nil {
+		return nil, err
+	}
+
+	arcount := uint16(signed[10])<<8 | uint16(signed[11])
+	arcount++
+	signed[10], signed[11] = byte(arcount>>8), byte(arcount)
+
+	return signed, nil
+}
+
+func packTime(b []byte, t time.Time) []byte {
+	sec := uint64(t.Unix())
+	return append(b, byte(sec>>40), byte(sec>>32), byte(sec>>24), byte(sec>>16), byte(sec>>8), byte(sec))
+}
@@ -0,0 +1,41 @@
+package dnsclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dchest/validator"
+)
+
+// validateDomain checks whether the given domain belongs to the
+// hosted zone and is a subdomain of the user's domain.
+//
+// It is shared by all the Client implementations.
+func validateDomain(hostedZone, domain, username string) error {
+	if domain == "" {
+		return fmt.Errorf("Domain name argument is empty")
+	}
+
+	if domain == hostedZone {
+		return fmt.Errorf("Domain %q can't be the same as top-level domain %q", domain, hostedZone)
+	}
+
+	if !strings.Contains(domain, hostedZone) {
+		return fmt.Errorf("Domain %q doesn't contain hostedzone %q", domain, hostedZone)
+	}
+
+	rest := strings.TrimSuffix(domain, "."+hostedZone)
+	if rest == domain {
+		return fmt.Errorf("Domain %q is invalid (1)", domain)
+	}
+
+	if split := strings.Split(rest, "."); split[len(split)-1] != username {
+		return fmt.Errorf("Domain %q doesn't contain %q username (hostedZone=%q)", domain, username, hostedZone)
+	}
+
+	if !validator.IsValidDomain(domain) {
+		return fmt.Errorf("Domain %q is invalid (2)", domain)
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+package dnsclient
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// The following is a minimal implementation of the DNS wire format
+// (RFC 1035), good enough for sending queries and dynamic updates
+// (RFC 2136) signed with TSIG (RFC 2845).
+//
+// Packed names are never compressed, names read from the wire
+// are decompressed, so rdata of CNAME and NS records is always
+// self-contained.
+
+const (
+	typeA     = 1
+	typeNS    = 2
+	typeCNAME = 5
+	typeSOA   = 6
+	typeTXT   = 16
+	typeAAAA  = 28
+	typeTSIG  = 250
+	typeANY   = 255
+
+	classINET = 1
+	classNONE = 254
+	classANY  = 255
+
+	opcodeQuery  = 0
+	opcodeUpdate = 5
+
+	rcodeSuccess  = 0
+	rcodeNXDomain = 3
+	rcodeNotAuth  = 9
+)
+
+var errShortMsg = errors.New("dns message is too short")
+
+var typeNames = map[uint16]string{
+	typeA:     "A",
+	typeNS:    "NS",
+	typeCNAME: "CNAME",
+	typeSOA:   "SOA",
+	typeTXT:   "TXT",
+	typeAAAA:  "AAAA",
+	typeTSIG:  "TSIG",
+	typeANY:   "ANY",
+}
+
+var rcodeNames = map[int]string{
+	0:  "NOERROR",
+	1:  "FORMERR",
+	2:  "SERVFAIL",
+	3:  "NXDOMAIN",
+	4:  "NOTIMP",
+	5:  "REFUSED",
+	6:  "YXDOMAIN",
+	7:  "YXRRSET",
+	8:  "NXRRSET",
+	9:  "NOTAUTH",
+	10: "NOTZONE",
+	16: "BADSIG",
+	17: "BADKEY",
+	18: "BADTIME",
+}
+
+func typeString(typ uint16) string {
+	if s, ok := typeNames[typ]; ok {
+		return s
+	}
+	return fmt.Sprintf("TYPE%d", typ)
+}
+
+func typeValue(s string) (uint16, error) {
+	s = strings.ToUpper(s)
+	for typ, name := range typeNames {
+		if name == s {
+			return typ, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported record type %q", s)
+}
+
+func rcodeString(rcode int) string {
+	if s, ok := rcodeNames[rcode]; ok {
+		return s
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// dnsQuestion represents a single entry of question section,
+// which for update messages is the zone section.
+type dnsQuestion struct {
+	Name  string
+	Type  uint16
+	Class uint16
+}
+
+// dnsRR represents a single resource record. The Data holds
+// raw rdata.
+type dnsRR struct {
+	Name  string
+	Type  uint16
+	Class uint16
+	TTL   uint32
+	Data  []byte
+}
+
+// dnsMsg represents a DNS message. For update messages
+// the sections are: zone, prerequisite, update and
+// additional.
+type dnsMsg struct {
+	ID        uint16
+	Response  bool
+	Opcode    int
+	Truncated bool
+	RD        bool
+	RCode     int
+
+	Question []dnsQuestion
+	Answer   []dnsRR
+	Ns       []dnsRR
+	Extra    []dnsRR
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func unfqdn(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+func packName(b []byte, name string) ([]byte, error) {
+	name = fqdn(name)
+	if name == "." {
+		return append(b, 0), nil
+	}
+	for _, label := range strings.Split(unfqdn(name), ".") {
+		if label == "" || len(label) > 63 {
+			return nil, fmt.Errorf("invalid domain name %q", name)
+		}
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0), nil
+}
+
+func unpackName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1 // offset after the name, once the first pointer is followed
+	for hops := 0; ; hops++ {
+		if off >= len(msg) || hops > 64 {
+			return "", 0, errShortMsg
+		}
+		c := int(msg[off])
+		switch c & 0xC0 {
+		case 0x00:
+			if c == 0 {
+				off++
+				if next == -1 {
+					next = off
+				}
+				return strings.Join(labels, ".") + ".", next, nil
+			}
+			if off+1+c > len(msg) {
+				return "", 0, errShortMsg
+			}
+			labels = append(labels, string(msg[off+1:off+1+c]))
+			off += 1 + c
+		case 0xC0:
+			if off+1 >= len(msg) {
+				return "", 0, errShortMsg
+			}
+			if next == -1 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3FFF)
+		default:
+			return "", 0, fmt.Errorf("invalid label at offset %d", off)
+		}
+	}
+}
+
+func packUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func packUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (rr *dnsRR) pack(b []byte) ([]byte, error) {
+	b, err := packName(b, rr.Name)
+	if err != nil {
+		return nil, err
+	}
+	b = packUint16(b, rr.Type)
+	b = packUint16(b, rr.Class)
+	b = packUint32(b, rr.TTL)
+	b = packUint16(b, uint16(len(rr.Data)))
+	return append(b, rr.Data...), nil
+}
+
+func (m *dnsMsg) flags() uint16 {
+	var bits uint16
+	if m.Response {
+		bits |= 1 << 15
+	}
+	bits |= uint16(m.Opcode&0xF) << 11
+	if m.Truncated {
+		bits |= 1 << 9
+	}
+	if m.RD {
+		bits |= 1 << 8
+	}
+	return bits | uint16(m.RCode&0xF)
+}
+
+// Pack encodes the message in the wire format.
+func (m *dnsMsg) Pack() ([]byte, error) {
+	b := make([]byte, 0, 512)
+	b = packUint16(b, m.ID)
+	b = packUint16(b, m.flags())
+	b = packUint16(b, uint16(len(m.Question)))
+	b = packUint16(b, uint16(len(m.Answer)))
+	b = packUint16(b, uint16(len(m.Ns)))
+	b = packUint16(b, uint16(len(m.Extra)))
+
+	var err error
+	for _, q := range m.Question {
+		if b, err = packName(b, q.Name); err != nil {
+			return nil, err
+		}
+		b = packUint16(b, q.Type)
+		b = packUint16(b, q.Class)
+	}
+
+	for _, section := range [][]dnsRR{m.Answer, m.Ns, m.Extra} {
+		for i := range section {
+			if b, err = section[i].pack(b); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// Unpack decodes the wire format message.
+func (m *dnsMsg) Unpack(msg []byte) error {
+	if len(msg) < 12 {
+		return errShortMsg
+	}
+
+	bits := binary.BigEndian.Uint16(msg[2:])
+
+	m.ID = binary.BigEndian.Uint16(msg)
+	m.Response = bits&(1<<15) != 0
+	m.Opcode = int(bits>>11) & 0xF
+	m.Truncated = bits&(1<<9) != 0
+	m.RD = bits&(1<<8) != 0
+	m.RCode = int(bits & 0xF)
+
+	counts := [4]int{
+		int(binary.BigEndian.Uint16(msg[4:])),
+		int(binary.BigEndian.Uint16(msg[6:])),
+		int(binary.BigEndian.Uint16(msg[8:])),
+		int(binary.BigEndian.Uint16(msg[10:])),
+	}
+
+	off := 12
+	m.Question = make([]dnsQuestion, 0, counts[0])
+	for i := 0; i < counts[0]; i++ {
+		name, n, err := unpackName(msg, off)
+		if err != nil {
+			return err
+		}
+		if n+4 > len(msg) {
+			return errShortMsg
+		}
+		m.Question = append(m.Question, dnsQuestion{
+			Name:  name,
+			Type:  binary.BigEndian.Uint16(msg[n:]),
+			Class: binary.BigEndian.Uint16(msg[n+2:]),
+		})
+		off = n + 4
+	}
+
+	sections := []*[]dnsRR{&m.Answer, &m.Ns, &m.Extra}
+	for i, section := range sections {
+		*section = make([]dnsRR, 0, counts[i+1])
+		for j := 0; j < counts[i+1]; j++ {
+			rr, n, err := unpackRR(msg, off)
+			if err != nil {
+				return err
+			}
+			*section = append(*section, rr)
+			off = n
+		}
+	}
+
+	return nil
+}
+
+func unpackRR(msg []byte, off int) (dnsRR, int, error) {
+	var rr dnsRR
+	name, off, err := unpackName(msg, off)
+	if err != nil {
+		return rr, 0, err
+	}
+	if off+10 > len(msg) {
+		return rr, 0, errShortMsg
+	}
+
+	rr.Name = name
+	rr.Type = binary.BigEndian.Uint16(msg[off:])
+	rr.Class = binary.BigEndian.Uint16(msg[off+2:])
+	rr.TTL = binary.BigEndian.Uint32(msg[off+4:])
+	n := int(binary.BigEndian.Uint16(msg[off+8:]))
+	off += 10
+
+	if off+n > len(msg) {
+		return rr, 0, errShortMsg
+	}
+
+	switch {
+	case n != 0 && (rr.Type == typeCNAME || rr.Type == typeNS):
+		// Decompress the target name, so the rdata can be
+		// used outside of the message.
+		target, _, err := unpackName(msg[:off+n], off)
+		if err != nil {
+			return rr, 0, err
+		}
+		if rr.Data, err = packName(nil, target); err != nil {
+			return rr, 0, err
+		}
+	default:
+		rr.Data = append([]byte(nil), msg[off:off+n]...)
+	}
+
+	return rr, off + n, nil
+}
+
+// packRData encodes the given presentation value
+// as rdata for the given record type.
+func packRData(typ uint16, value string) ([]byte, error) {
+	switch typ {
+	case typeA:
+		ip := net.ParseIP(value).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", value)
+		}
+		return []byte(ip), nil
+	case typeAAAA:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 address %q", value)
+		}
+		return []byte(ip.To16()), nil
+	case typeCNAME, typeNS:
+		return packName(nil, value)
+	case typeTXT:
+		var b []byte
+		for len(value) > 255 {
+			b = append(b, 255)
+			b = append(b, value[:255]...)
+			value = value[255:]
+		}
+		b = append(b, byte(len(value)))
+		return append(b, value...), nil
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", typeString(typ))
+	}
+}
+
+// unpackRData decodes the rdata into its presentation value.
+func unpackRData(typ uint16, data []byte) (string, error) {
+	switch typ {
+	case typeA:
+		if len(data) != net.IPv4len {
+			return "", errShortMsg
+		}
+		return net.IP(data).String(), nil
+	case typeAAAA:
+		if len(data) != net.IPv6len {
+			return "", errShortMsg
+		}
+		return net.IP(data).String(), nil
+	case typeCNAME, typeNS:
+		name, _, err := unpackName(data, 0)
+		if err != nil {
+			return "", err
+		}
+		return unfqdn(name), nil
+	case typeTXT:
+		var s []byte
+		for len(data) > 0 {
+			n := int(data[0])
+			if n+1 > len(data) {
+				return "", errShortMsg
+			}
+			s = append(s, data[1:n+1]...)
+			data = data[n+1:]
+		}
+		return string(s), nil
+	default:
+		return "", fmt.Errorf("unsupported record type %s", typeString(typ))
+	}
+}
+
+// newRR creates a resource record out of the given record.
+func newRR(rec *Record, class uint16) (dnsRR, error) {
+	typ, err := typeValue(rec.Type)
+	if err != nil {
+		return dnsRR{}, err
+	}
+
+	data, err := packRData(typ, rec.IP)
+	if err != nil {
+		return dnsRR{}, err
+	}
+
+	return dnsRR{
+		Name:  fqdn(rec.Name),
+		Type:  typ,
+		Class: class,
+		TTL:   uint32(rec.TTL),
+		Data:  data,
+	}, nil
+}
+
+// toRecord converts the resource record to a record. Similarly
+// to Route53, the record name is fully qualified.
+func (rr *dnsRR) toRecord() (*Record, error) {
+	value, err := unpackRData(rr.Type, rr.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{
+		Name: rr.Name,
+		Type: typeString(rr.Type),
+		IP:   value,
+		TTL:  int(rr.TTL),
+	}, nil
+}
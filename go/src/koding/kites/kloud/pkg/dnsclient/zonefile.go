@@ -0,0 +1,503 @@
+package dnsclient
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/koding/logging"
+)
+
+// ZoneFileOptions configures ZoneFile client.
+type ZoneFileOptions struct {
+	// Path is a path of the zone file, served e.g. by CoreDNS
+	// file plugin with reload enabled.
+	Path string
+
+	HostedZone string
+
+	// NameServer is a primary name server of the zone.
+	//
+	// If empty, "ns1.<hosted zone>" is used.
+	NameServer string
+
+	// Admin is a mailbox of the person responsible for the zone,
+	// in the domain name format.
+	//
+	// If empty, "hostmaster.<hosted zone>" is used.
+	Admin string
+
+	Log logging.Logger
+}
+
+func (opts *ZoneFileOptions) log() logging.Logger {
+	if opts.Log != nil {
+		return opts.Log
+	}
+	return defaultLog
+}
+
+// ZoneFile is a Client implementation that manages records by
+// rewriting a master zone file (RFC 1035, 5) of the hosted zone.
+//
+// On every change the serial number of the SOA record is increased,
+// which makes servers like CoreDNS reload the zone.
+//
+// The ZoneFile expects to own the zone file, it does not preserve
+// comments nor records it does not support.
+type ZoneFile struct {
+	opts *ZoneFileOptions
+	mu   sync.Mutex // protects the zone file
+}
+
+var _ Client = (*ZoneFile)(nil)
+
+// zone represents content of a zone file.
+type zone struct {
+	serial  uint32
+	records map[string]*Record // maps "name type" to a record
+}
+
+// NewZoneFileClient gives new ZoneFile client for the given options.
+//
+// If the zone file does not exist, it is created.
+func NewZoneFileClient(opts *ZoneFileOptions) (*ZoneFile, error) {
+	optsCopy := *opts
+
+	if optsCopy.HostedZone == "" {
+		return nil, fmt.Errorf("hosted zone is empty")
+	}
+
+	if optsCopy.Path == "" {
+		return nil, fmt.Errorf("zone file path is empty")
+	}
+
+	if optsCopy.NameServer == "" {
+		optsCopy.NameServer = "ns1." + optsCopy.HostedZone
+	}
+
+	if optsCopy.Admin == "" {
+		optsCopy.Admin = "hostmaster." + optsCopy.HostedZone
+	}
+
+	optsCopy.Log = optsCopy.log()
+
+	z := &ZoneFile{
+		opts: &optsCopy,
+	}
+
+	if _, err := os.Stat(z.opts.Path); os.IsNotExist(err) {
+		if err := z.update(func(*zone) error { return nil }); err != nil {
+			return nil, err
+		}
+	}
+
+	return z, nil
+}
+
+// Upsert creates or updates the domain record with the given ip address. If
+// the record already exists, the record is updated with the new IP.
+func (z *ZoneFile) Upsert(domain, newIP string) error {
+	rec := &Record{
+		Name: domain,
+		Type: "A",
+		IP:   newIP,
+		TTL:  30,
+	}
+	return z.UpsertRecord(rec)
+}
+
+// UpsertRecord creates or updates a DNS record.
+func (z *ZoneFile) UpsertRecord(rec *Record) error {
+	return z.UpsertRecords(rec)
+}
+
+// UpsertRecords creates or updates the given records.
+func (z *ZoneFile) UpsertRecords(recs ...*Record) error {
+	err := z.update(func(zn *zone) error {
+		for _, rec := range recs {
+			z.opts.Log.Debug("upserting record: %# v", rec)
+
+			if err := zn.add(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return z.errorf("upserting records failed: %s", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the record for the given domain name.
+func (z *ZoneFile) Get(domain string) (*Record, error) {
+	z.opts.Log.Debug("fetching domain record for domain: %s", domain)
+
+	recs, err := z.GetAll(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return recs[0], nil
+}
+
+// GetAll retrieves all the records with the given name. If name is
+// empty, all the records are returned.
+func (z *ZoneFile) GetAll(name string) ([]*Record, error) {
+	z.mu.Lock()
+	zn, err := z.read()
+	z.mu.Unlock()
+
+	if err != nil {
+		return nil, z.error(err)
+	}
+
+	recs := zn.sorted()
+
+	if name != "" {
+		recs = recs.ByName(fqdn(name))
+	}
+
+	if len(recs) == 0 {
+		return nil, z.error(ErrNoRecord)
+	}
+
+	return recs, nil
+}
+
+// Rename changes the domain from oldDomain to newDomain.
+func (z *ZoneFile) Rename(oldDomain, newDomain string) error {
+	err := z.update(func(zn *zone) error {
+		rec := zn.get(oldDomain)
+		if rec == nil {
+			return ErrNoRecord
+		}
+
+		z.opts.Log.Debug("updating domain name of IP %s from %q to %q", rec.IP, oldDomain, newDomain)
+
+		zn.delete(rec)
+
+		renamed := *rec
+		renamed.Name = newDomain
+
+		return zn.add(&renamed)
+	})
+
+	if err == ErrNoRecord {
+		return z.error(err)
+	}
+
+	if err != nil {
+		return z.errorf("could not rename domain %q to %q: %s", oldDomain, newDomain, err)
+	}
+
+	return nil
+}
+
+// Delete deletes a domain record for the given domain.
+func (z *ZoneFile) Delete(domain string) error {
+	err := z.update(func(zn *zone) error {
+		// domains can be removed via other business logics,
+		// so it's not an error if the record is missing
+		if rec := zn.get(domain); rec != nil {
+			zn.delete(rec)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return z.errorf("could not delete domain %q: %s", domain, err)
+	}
+
+	return nil
+}
+
+// DeleteRecord deletes the given record.
+func (z *ZoneFile) DeleteRecord(rec *Record) error {
+	z.opts.Log.Debug("deleting record: %v", rec)
+
+	err := z.update(func(zn *zone) error {
+		zn.delete(rec)
+		return nil
+	})
+
+	if err != nil {
+		return z.errorf("could not delete record %v: %s", rec, err)
+	}
+
+	return nil
+}
+
+// HostedZone gives the zone the client is managing.
+func (z *ZoneFile) HostedZone() string {
+	return z.opts.HostedZone
+}
+
+// Validate validates if the given domain name is valid.
+func (z *ZoneFile) Validate(domain, username string) error {
+	if err := validateDomain(z.HostedZone(), domain, username); err != nil {
+		return z.errorf("%s", err)
+	}
+	return nil
+}
+
+// update applies the given change to the zone file and writes
+// it back with increased serial number.
+func (z *ZoneFile) update(fn func(*zone) error) error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	zn, err := z.read()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(zn); err != nil {
+		return err
+	}
+
+	zn.serial = nextSerial(zn.serial)
+
+	return z.write(zn)
+}
+
+func (z *ZoneFile) read() (*zone, error) {
+	zn := &zone{
+		records: make(map[string]*Record),
+	}
+
+	f, err := os.Open(z.opts.Path)
+	if os.IsNotExist(err) {
+		return zn, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		if i := strings.IndexRune(line, ';'); i != -1 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "$") {
+			continue
+		}
+
+		// Each line has the following format:
+		//
+		//   <name> <ttl> IN <type> <rdata>...
+		//
+		if len(fields) < 5 || !strings.EqualFold(fields[2], "IN") {
+			return nil, fmt.Errorf("%s:%d: unsupported record format", z.opts.Path, n)
+		}
+
+		ttl, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid TTL: %s", z.opts.Path, n, err)
+		}
+
+		switch typ := strings.ToUpper(fields[3]); typ {
+		case "SOA":
+			if len(fields) < 7 {
+				return nil, fmt.Errorf("%s:%d: invalid SOA record", z.opts.Path, n)
+			}
+
+			serial, err := strconv.ParseUint(fields[6], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid SOA serial: %s", z.opts.Path, n, err)
+			}
+
+			zn.serial = uint32(serial)
+		case "NS":
+			// NS records are generated on write.
+		default:
+			rec := &Record{
+				Name: z.abs(fields[0]),
+				Type: typ,
+				IP:   strings.Join(fields[4:], " "),
+				TTL:  ttl,
+			}
+
+			if typ == "CNAME" {
+				rec.IP = unfqdn(z.abs(rec.IP))
+			}
+
+			if typ == "TXT" {
+				rec.IP = strings.Trim(rec.IP, `"`)
+			}
+
+			if err := zn.add(rec); err != nil {
+				return nil, fmt.Errorf("%s:%d: %s", z.opts.Path, n, err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return zn, nil
+}
+
+func (z *ZoneFile) write(zn *zone) error {
+	var buf bytes.Buffer
+
+	origin := fqdn(z.opts.HostedZone)
+
+	fmt.Fprintf(&buf, "; Managed by kloud, do not edit.\n")
+	fmt.Fprintf(&buf, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(&buf, "%s 30 IN SOA %s %s %d 7200 3600 1209600 30\n", origin,
+		fqdn(z.opts.NameServer), fqdn(z.opts.Admin), zn.serial)
+	fmt.Fprintf(&buf, "%s 30 IN NS %s\n", origin, fqdn(z.opts.NameServer))
+
+	for _, rec := range zn.sorted() {
+		value := rec.IP
+
+		switch strings.ToUpper(rec.Type) {
+		case "CNAME":
+			value = fqdn(value)
+		case "TXT":
+			value = strconv.Quote(value)
+		}
+
+		fmt.Fprintf(&buf, "%s %d IN %s %s\n", rec.Name, rec.TTL, strings.ToUpper(rec.Type), value)
+	}
+
+	// Write the file atomically, so the DNS server never
+	// reads a partial zone.
+	f, err := ioutil.TempFile(filepath.Dir(z.opts.Path), ".zone")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), z.opts.Path)
+}
+
+// abs makes the name absolute, relative names
+// are relative to the hosted zone.
+func (z *ZoneFile) abs(name string) string {
+	switch {
+	case name == "@":
+		return fqdn(z.opts.HostedZone)
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + fqdn(z.opts.HostedZone)
+	}
+}
+
+func (z *ZoneFile) errorf(format string, v ...interface{}) error {
+	err := fmt.Errorf(format, v...)
+	z.opts.Log.Error(err.Error())
+	return err
+}
+
+func (z *ZoneFile) error(err error) error {
+	// Ignore ErrNoRecord errors, as they're expected
+	// and handled by the caller.
+	if err != ErrNoRecord {
+		z.opts.Log.Error("%q", err)
+	}
+	return err
+}
+
+func (zn *zone) add(rec *Record) error {
+	typ, err := typeValue(rec.Type)
+	if err != nil {
+		return err
+	}
+
+	// Validate rdata.
+	if _, err := packRData(typ, rec.IP); err != nil {
+		return err
+	}
+
+	r := &Record{
+		Name: fqdn(strings.ToLower(rec.Name)),
+		Type: typeString(typ),
+		IP:   rec.IP,
+		TTL:  rec.TTL,
+	}
+
+	zn.records[r.Name+" "+r.Type] = r
+	return nil
+}
+
+// get gives the first matching record with the given name,
+// looking up A, AAAA and CNAME records in this order.
+func (zn *zone) get(name string) *Record {
+	name = fqdn(strings.ToLower(name))
+
+	for _, typ := range []string{"A", "AAAA", "CNAME"} {
+		if rec, ok := zn.records[name+" "+typ]; ok {
+			return rec
+		}
+	}
+
+	return nil
+}
+
+func (zn *zone) delete(rec *Record) {
+	delete(zn.records, fqdn(strings.ToLower(rec.Name))+" "+strings.ToUpper(rec.Type))
+}
+
+func (zn *zone) sorted() Records {
+	keys := make([]string, 0, len(zn.records))
+	for key := range zn.records {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	recs := make(Records, len(keys))
+	for i, key := range keys {
+		rec := *zn.records[key]
+		recs[i] = &rec
+	}
+
+	return recs
+}
+
+// nextSerial gives a serial number greater than the given one,
+// using the current time, so the serial increases also when
+// the zone file gets recreated.
+func nextSerial(serial uint32) uint32 {
+	if now := uint32(time.Now().Unix()); now > serial {
+		return now
+	}
+	return serial + 1
+}
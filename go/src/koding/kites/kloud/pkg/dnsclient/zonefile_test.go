@@ -0,0 +1,123 @@
+package dnsclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestZoneFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnsclient")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := &ZoneFileOptions{
+		Path:       filepath.Join(dir, "db.dev.koding.io"),
+		HostedZone: "dev.koding.io",
+	}
+
+	c, err := NewZoneFileClient(opts)
+	if err != nil {
+		t.Fatalf("NewZoneFileClient()=%s", err)
+	}
+
+	serial := readSerial(t, c)
+
+	if err := c.Upsert("vm-0.user.dev.koding.io", "10.0.0.1"); err != nil {
+		t.Fatalf("Upsert()=%s", err)
+	}
+
+	if s := readSerial(t, c); s <= serial {
+		t.Fatalf("want serial to be increased, was %d, got %d", serial, s)
+	} else {
+		serial = s
+	}
+
+	cname := &Record{Name: "www.user.dev.koding.io", Type: "CNAME", IP: "vm-0.user.dev.koding.io", TTL: 60}
+
+	if err := c.UpsertRecord(cname); err != nil {
+		t.Fatalf("UpsertRecord()=%s", err)
+	}
+
+	p, err := ioutil.ReadFile(opts.Path)
+	if err != nil {
+		t.Fatalf("ReadFile()=%s", err)
+	}
+
+	for _, line := range []string{
+		"$ORIGIN dev.koding.io.",
+		"dev.koding.io. 30 IN NS ns1.dev.koding.io.",
+		"vm-0.user.dev.koding.io. 30 IN A 10.0.0.1",
+		"www.user.dev.koding.io. 60 IN CNAME vm-0.user.dev.koding.io.",
+	} {
+		if !strings.Contains(string(p), line+"\n") {
+			t.Errorf("zone file is missing %q line:\n%s", line, p)
+		}
+	}
+
+	// Reopening the zone file must preserve the records.
+	if c, err = NewZoneFileClient(opts); err != nil {
+		t.Fatalf("NewZoneFileClient()=%s", err)
+	}
+
+	rec, err := c.Get("www.user.dev.koding.io")
+	if err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	want := &Record{Name: "www.user.dev.koding.io.", Type: "CNAME", IP: "vm-0.user.dev.koding.io", TTL: 60}
+
+	if *rec != *want {
+		t.Fatalf("got %+v, want %+v", rec, want)
+	}
+
+	if err := c.Rename("vm-0.user.dev.koding.io", "vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Rename()=%s", err)
+	}
+
+	if _, err := c.Get("vm-0.user.dev.koding.io"); err != ErrNoRecord {
+		t.Fatalf("got %v, want %v", err, ErrNoRecord)
+	}
+
+	if err := c.Rename("vm-0.user.dev.koding.io", "vm-2.user.dev.koding.io"); err != ErrNoRecord {
+		t.Fatalf("got %v, want %v", err, ErrNoRecord)
+	}
+
+	if rec, err = c.Get("vm-1.user.dev.koding.io"); err != nil {
+		t.Fatalf("Get()=%s", err)
+	}
+
+	if rec.IP != "10.0.0.1" {
+		t.Fatalf("got %q, want %q", rec.IP, "10.0.0.1")
+	}
+
+	for _, name := range []string{"vm-1.user.dev.koding.io", "www.user.dev.koding.io", "nonexisting.user.dev.koding.io"} {
+		if err := c.Delete(name); err != nil {
+			t.Fatalf("Delete(%q)=%s", name, err)
+		}
+	}
+
+	if _, err := c.GetAll(""); err != ErrNoRecord {
+		t.Fatalf("got %v, want %v", err, ErrNoRecord)
+	}
+
+	if s := readSerial(t, c); s <= serial {
+		t.Fatalf("want serial to be increased, was %d, got %d", serial, s)
+	}
+
+	if err := c.Upsert("vm-0.user.dev.koding.io", "invalid"); err == nil {
+		t.Fatal("expected Upsert() to fail for invalid IP")
+	}
+}
+
+func readSerial(t *testing.T, z *ZoneFile) uint32 {
+	zn, err := z.read()
+	if err != nil {
+		t.Fatalf("read()=%s", err)
+	}
+	return zn.serial
+}
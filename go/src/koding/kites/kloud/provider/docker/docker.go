@@ -0,0 +1,32 @@
+// Package docker implements Kloud provider for plain Docker hosts:
+//
+//   https://docs.docker.com/engine/
+//
+// Each docker_container resource in a stack template becomes a single
+// Koding machine. Since containers do not run cloud-init, klient is
+// installed by an entrypoint script, which kloud uploads into each
+// container before it is started.
+//
+// The containers are created with the Terraform docker provider:
+//
+//   https://www.terraform.io/docs/providers/docker/index.html
+//
+// Lifecycle of already built machines (start, stop, info) is handled
+// directly with the Docker Engine API.
+package docker
+
+import "koding/kites/kloud/stack/provider"
+
+var Provider = &provider.Provider{
+	Name:         "docker",
+	ResourceName: "container",
+	NoCloudInit:  true,
+	Userdata:     "command",
+	Machine:      newMachine,
+	Stack:        newStack,
+	Schema:       schema,
+}
+
+func init() {
+	provider.Register(Provider)
+}
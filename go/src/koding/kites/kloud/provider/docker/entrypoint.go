@@ -0,0 +1,49 @@
+package docker
+
+// EntrypointPath is a path, under which the Koding entrypoint
+// is uploaded into each container.
+const EntrypointPath = "/opt/koding/entrypoint.sh"
+
+// entrypoint is a script, which installs and starts klient before
+// executing the container's command.
+//
+// The script is uploaded by Terraform, which interpolates
+// any ${...} sequences, thus they must not be used here.
+const entrypoint = `#!/bin/sh
+
+# Koding Entrypoint.
+#
+# This script is a part of Koding. It wraps container's entrypoint
+# and injects a Klient service into it in order to connect
+# that container with Koding.
+
+set -eu
+
+export USER_LOG=/var/log/cloud-init-output.log
+
+echo "[entrypoint] connecting to Koding" | tee -a $USER_LOG >&2
+
+if command -v curl >/dev/null 2>&1; then
+	curl --silent --show-error --location --output /tmp/klient.gz "$KODING_KLIENT_URL"
+elif command -v wget >/dev/null 2>&1; then
+	wget --quiet --output-document /tmp/klient.gz "$KODING_KLIENT_URL"
+else
+	echo "[entrypoint] neither curl nor wget found, unable to download klient" | tee -a $USER_LOG >&2
+	exit 1
+fi
+
+gzip --decompress --force --stdout /tmp/klient.gz > /tmp/klient
+chmod +x /tmp/klient
+/tmp/klient -metadata "$KODING_METADATA" install
+
+echo _KD_DONE_ >> $USER_LOG
+
+if [ $# -gt 0 ]; then
+	/opt/kite/klient/klient start
+
+	echo "[entrypoint] executing: $@" | tee -a $USER_LOG >&2
+	exec "$@"
+fi
+
+exec /opt/kite/klient/klient
+`
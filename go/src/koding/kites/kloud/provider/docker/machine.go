@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"koding/kites/kloud/api/dockerapi"
+	"koding/kites/kloud/machinestate"
+	"koding/kites/kloud/stack/provider"
+
+	"golang.org/x/net/context"
+)
+
+// stopTimeout is a time the daemon waits for a container
+// to stop before killing it.
+const stopTimeout = 30 * time.Second
+
+var (
+	_ provider.Machine = (*Machine)(nil)
+
+	// ErrInvalidContainerID is returned when machine
+	// has no container ID set.
+	ErrInvalidContainerID = errors.New("container ID is invalid")
+)
+
+// Machine is responsible for handling a single container.
+type Machine struct {
+	*provider.BaseMachine
+
+	client *dockerapi.Client
+}
+
+func newMachine(bm *provider.BaseMachine) (provider.Machine, error) {
+	cred, ok := bm.Credential.(*Credential)
+	if !ok {
+		return nil, fmt.Errorf("credential is not of type docker.Credential: %T", bm.Credential)
+	}
+
+	client, err := dockerapi.New(cred.Options())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Machine{
+		BaseMachine: bm,
+		client:      client,
+	}, nil
+}
+
+// Start starts the container.
+func (m *Machine) Start(context.Context) (interface{}, error) {
+	id, err := m.ContainerID()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, m.client.StartContainer(id)
+}
+
+// Stop stops the container.
+func (m *Machine) Stop(context.Context) (interface{}, error) {
+	id, err := m.ContainerID()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, m.client.StopContainer(id, stopTimeout)
+}
+
+// Info gives the current state of the container.
+func (m *Machine) Info(context.Context) (machinestate.State, interface{}, error) {
+	id, err := m.ContainerID()
+	if err != nil {
+		return machinestate.Unknown, nil, err
+	}
+
+	container, err := m.client.InspectContainer(id)
+	if err == dockerapi.ErrNotFound {
+		return machinestate.NotInitialized, nil, nil
+	}
+	if err != nil {
+		return machinestate.Unknown, nil, err
+	}
+
+	return container.State.MachineState(), nil, nil
+}
+
+// ContainerID gives the ID of the container associated with the machine.
+func (m *Machine) ContainerID() (string, error) {
+	meta, ok := m.BaseMachine.Metadata.(*Metadata)
+	if !ok {
+		return "", fmt.Errorf("metadata is not of type docker.Metadata: %T", m.BaseMachine.Metadata)
+	}
+
+	if meta.ContainerID == "" {
+		return "", ErrInvalidContainerID
+	}
+
+	return meta.ContainerID, nil
+}
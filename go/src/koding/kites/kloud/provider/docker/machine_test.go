@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"koding/db/models"
+	"koding/kites/kloud/api/dockerapi"
+	"koding/kites/kloud/machinestate"
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/stack/provider"
+
+	"golang.org/x/net/context"
+)
+
+func newDockerBaseMachine(host, containerID string) *provider.BaseMachine {
+	return &provider.BaseMachine{
+		Machine:    &models.Machine{},
+		Credential: &Credential{Host: host},
+		Bootstrap:  Provider.Schema.NewBootstrap(),
+		Metadata: Provider.Schema.NewMetadata(&stack.Machine{
+			Attributes: map[string]string{"id": containerID},
+		}),
+		Provider: "docker",
+	}
+}
+
+func TestMachine(t *testing.T) {
+	state := &dockerapi.State{Status: "created"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/containers/abc/json":
+			json.NewEncoder(w).Encode(&dockerapi.Container{ID: "abc", State: state})
+		case "/containers/abc/start":
+			state = &dockerapi.State{Status: "running", Running: true}
+			w.WriteHeader(http.StatusNoContent)
+		case "/containers/abc/stop":
+			state = &dockerapi.State{Status: "exited"}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	host := strings.Replace(srv.URL, "http://", "tcp://", 1)
+
+	m, err := newMachine(newDockerBaseMachine(host, "abc"))
+	if err != nil {
+		t.Fatalf("newMachine()=%s", err)
+	}
+
+	ctx := context.Background()
+
+	assertState := func(want machinestate.State) {
+		got, _, err := m.Info(ctx)
+		if err != nil {
+			t.Fatalf("Info()=%s", err)
+		}
+
+		if got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+
+	assertState(machinestate.Stopped)
+
+	if _, err := m.Start(ctx); err != nil {
+		t.Fatalf("Start()=%s", err)
+	}
+
+	assertState(machinestate.Running)
+
+	if _, err := m.Stop(ctx); err != nil {
+		t.Fatalf("Stop()=%s", err)
+	}
+
+	assertState(machinestate.Stopped)
+
+	if m, err = newMachine(newDockerBaseMachine(host, "nonexisting")); err != nil {
+		t.Fatalf("newMachine()=%s", err)
+	}
+
+	assertState(machinestate.NotInitialized)
+
+	if m, err = newMachine(newDockerBaseMachine(host, "")); err != nil {
+		t.Fatalf("newMachine()=%s", err)
+	}
+
+	if _, err := m.Start(ctx); err != ErrInvalidContainerID {
+		t.Fatalf("got %v, want %v", err, ErrInvalidContainerID)
+	}
+}
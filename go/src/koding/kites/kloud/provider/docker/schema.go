@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"errors"
+
+	"koding/kites/kloud/api/dockerapi"
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/stack/provider"
+)
+
+var schema = &provider.Schema{
+	NewCredential: func() interface{} {
+		return &Credential{}
+	},
+	NewBootstrap: func() interface{} {
+		return &Bootstrap{}
+	},
+	NewMetadata: func(m *stack.Machine) interface{} {
+		if m == nil {
+			return &Metadata{}
+		}
+
+		return &Metadata{
+			ContainerID: m.Attributes["id"],
+			Name:        m.Attributes["name"],
+			Image:       m.Attributes["image"],
+		}
+	},
+}
+
+var (
+	_ stack.Validator = (*Credential)(nil)
+	_ stack.Validator = (*Bootstrap)(nil)
+	_ stack.Validator = (*Metadata)(nil)
+)
+
+// Credential represents credential information that are
+// required to create containers on a Docker host.
+type Credential struct {
+	// Host is an address of the Docker daemon,
+	// e.g. tcp://10.0.0.1:2376.
+	Host string `json:"host" bson:"host" hcl:"host"`
+
+	// PEM-encoded TLS material, used to authenticate with
+	// the daemon. Either all of them or none must be set.
+	CACert string `json:"ca_material" bson:"ca_material" hcl:"ca_material"`
+	Cert   string `json:"cert_material" bson:"cert_material" hcl:"cert_material"`
+	Key    string `json:"key_material" bson:"key_material" hcl:"key_material"`
+}
+
+// Valid implements the stack.Validator interface.
+func (c *Credential) Valid() error {
+	if c.Host == "" {
+		return errors.New("docker host is empty")
+	}
+
+	if c.CACert != "" || c.Cert != "" || c.Key != "" {
+		if c.CACert == "" || c.Cert == "" || c.Key == "" {
+			return errors.New("either all or none of ca_material, cert_material and key_material must be set")
+		}
+	}
+
+	return nil
+}
+
+// Options gives new configuration for Docker API client.
+func (c *Credential) Options() *dockerapi.Options {
+	return &dockerapi.Options{
+		Host:   c.Host,
+		CACert: c.CACert,
+		Cert:   c.Cert,
+		Key:    c.Key,
+	}
+}
+
+// Bootstrap represents resources that are created once per
+// credential, before any of the containers is built.
+type Bootstrap struct {
+	// Network to which all containers of the stack are attached.
+	NetworkID   string `json:"network_id" bson:"network_id" hcl:"network_id"`
+	NetworkName string `json:"network_name" bson:"network_name" hcl:"network_name"`
+}
+
+// Valid implements the stack.Validator interface.
+func (b *Bootstrap) Valid() error {
+	if b.NetworkID == "" {
+		return errors.New("network ID is empty")
+	}
+
+	if b.NetworkName == "" {
+		return errors.New("network name is empty")
+	}
+
+	return nil
+}
+
+// Metadata represents a single container metadata.
+type Metadata struct {
+	ContainerID string `json:"container_id" bson:"container_id" hcl:"container_id"`
+	Name        string `json:"name" bson:"name" hcl:"name"`
+	Image       string `json:"image" bson:"image" hcl:"image"`
+}
+
+// Valid implements the stack.Validator interface.
+func (m *Metadata) Valid() error {
+	if m.ContainerID == "" {
+		return errors.New("container ID is empty")
+	}
+
+	return nil
+}
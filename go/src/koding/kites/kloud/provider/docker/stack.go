@@ -0,0 +1,286 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"koding/kites/kloud/api/dockerapi"
+	"koding/kites/kloud/metadata"
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/stack/provider"
+)
+
+//go:generate $GOPATH/bin/go-bindata -mode 420 -modtime 1470666525 -pkg docker -o bootstrap.json.tmpl.go bootstrap.json.tmpl
+//go:generate gofmt -l -w -s bootstrap.json.tmpl.go
+
+var bootstrapTmpl = template.Must(
+	template.New("").Parse(string(MustAsset("bootstrap.json.tmpl"))),
+)
+
+// DefaultImage is used for containers, which do not specify
+// an image in the stack template.
+const DefaultImage = "ubuntu:16.04"
+
+var (
+	_ provider.Stack = (*Stack)(nil) // public API
+	_ stack.Stacker  = (*Stack)(nil) // internal API
+)
+
+// Stack is responsible for handling docker_container resources
+// of the terraform templates.
+type Stack struct {
+	*provider.BaseStack
+
+	// KlientURL is an URL of gzipped klient binary, which
+	// is downloaded by the entrypoint.
+	KlientURL string
+}
+
+func newStack(bs *provider.BaseStack) (provider.Stack, error) {
+	return &Stack{
+		BaseStack: bs,
+		KlientURL: stack.Konfig.KlientGzURL(),
+	}, nil
+}
+
+// VerifyCredential checks whether the Docker daemon described
+// by the given credential is reachable.
+func (s *Stack) VerifyCredential(c *stack.Credential) error {
+	cred, ok := c.Credential.(*Credential)
+	if !ok {
+		return fmt.Errorf("credential is not of type docker.Credential: %T", c.Credential)
+	}
+
+	if err := cred.Valid(); err != nil {
+		return err
+	}
+
+	client, err := dockerapi.New(cred.Options())
+	if err != nil {
+		return err
+	}
+
+	if err := client.Ping(); err != nil {
+		return &stack.Error{
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// BootstrapTemplates returns terraform templates that needs to be executed
+// before any container is created. The template creates a network,
+// to which all containers of the user are attached.
+func (s *Stack) BootstrapTemplates(c *stack.Credential) ([]*stack.Template, error) {
+	type tmplData struct {
+		NetworkName string
+	}
+
+	var buf bytes.Buffer
+	if err := bootstrapTmpl.Execute(&buf, &tmplData{
+		NetworkName: "koding-" + c.Identifier,
+	}); err != nil {
+		return nil, err
+	}
+
+	return []*stack.Template{
+		{Content: buf.String()},
+	}, nil
+}
+
+// ApplyTemplate enhances and updates the docker terraform template. It
+// configures the provider and injects the klient entrypoint and metadata
+// into each of the containers.
+func (s *Stack) ApplyTemplate(c *stack.Credential) (*stack.Template, error) {
+	cred, ok := c.Credential.(*Credential)
+	if !ok {
+		return nil, fmt.Errorf("credential is not of type docker.Credential: %T", c.Credential)
+	}
+
+	bootstrap, ok := c.Bootstrap.(*Bootstrap)
+	if !ok {
+		return nil, fmt.Errorf("bootstrap is not of type docker.Bootstrap: %T", c.Bootstrap)
+	}
+
+	t := s.Builder.Template
+
+	t.Provider["docker"] = map[string]interface{}{
+		"host":          cred.Host,
+		"ca_material":   cred.CACert,
+		"cert_material": cred.Cert,
+		"key_material":  cred.Key,
+	}
+
+	var resource struct {
+		Container map[string]map[string]interface{} `hcl:"docker_container"`
+	}
+
+	if err := t.DecodeResource(&resource); err != nil {
+		return nil, err
+	}
+
+	if len(resource.Container) == 0 {
+		return nil, errors.New("there are no containers available")
+	}
+
+	for name, container := range resource.Container {
+		if i, ok := container["image"].(string); !ok || i == "" {
+			container["image"] = DefaultImage
+		}
+
+		container["networks"] = appendSlice(container["networks"], bootstrap.NetworkName)
+
+		container["upload"] = appendSlice(container["upload"], map[string]interface{}{
+			"content": entrypoint,
+			"file":    EntrypointPath,
+		})
+
+		container["entrypoint"] = append([]interface{}{"/bin/sh", EntrypointPath}, getSlice(container["entrypoint"])...)
+
+		if err := s.injectMetadata(name, container); err != nil {
+			return nil, err
+		}
+
+		resource.Container[name] = container
+	}
+
+	t.Resource["docker_container"] = resource.Container
+
+	err := t.ShadowVariables("FORBIDDEN", "docker_ca_material", "docker_cert_material", "docker_key_material")
+	if err != nil {
+		return nil, errors.New("docker: error shadowing: " + err.Error())
+	}
+
+	if err := t.Flush(); err != nil {
+		return nil, errors.New("docker: error flushing template: " + err.Error())
+	}
+
+	content, err := t.JsonOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stack.Template{
+		Content: content,
+	}, nil
+}
+
+// injectMetadata creates klient metadata for each instance of the container
+// and passes it with KODING_METADATA environment variable.
+//
+// Since the metadata is unique for each instance, it is kept in a Terraform
+// lookup map, which is used in conjunction with the `count.index`.
+func (s *Stack) injectMetadata(name string, container map[string]interface{}) error {
+	count := 1
+	if n, ok := container["count"].(int); ok && n > 1 {
+		count = n
+	}
+
+	if v, ok := container["koding_klient_timeout"].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.New(`unable to read "koding_klient_timeout": ` + err.Error())
+		}
+
+		s.Planner.KlientTimeout = d
+
+		delete(container, "koding_klient_timeout")
+	}
+
+	cfg := &metadata.Config{
+		Konfig: stack.Konfig,
+	}
+
+	if b, ok := container["koding_debug"].(bool); ok {
+		cfg.Debug = b
+		s.Debug = b
+		delete(container, "koding_debug")
+	}
+
+	var meta map[string]interface{}
+
+	if b, ok := container["koding_always_on"].(bool); ok {
+		meta = map[string]interface{}{
+			"alwaysOn": b,
+		}
+		delete(container, "koding_always_on")
+	}
+
+	var labels []string
+	if count > 1 {
+		for i := 0; i < count; i++ {
+			labels = append(labels, fmt.Sprintf("%s.%d", name, i))
+		}
+	} else {
+		labels = append(labels, name)
+	}
+
+	metadataName := "koding_metadata_" + name
+	metadatas := make(map[int]string, count)
+
+	for i, label := range labels {
+		kiteKey, err := s.BuildKiteKey(label, s.Req.Username)
+		if err != nil {
+			return err
+		}
+
+		cfg.KiteKey = kiteKey
+
+		p, err := metadata.Encode(cfg)
+		if err != nil {
+			return err
+		}
+
+		metadatas[i] = base64.StdEncoding.EncodeToString(p)
+
+		if len(meta) != 0 {
+			s.Metas[label] = meta
+		}
+	}
+
+	s.Builder.Template.Variable[metadataName] = map[string]interface{}{
+		"default": metadatas,
+	}
+
+	container["env"] = appendSlice(container["env"],
+		"KODING_METADATA=${lookup(var."+metadataName+", count.index)}",
+		"KODING_KLIENT_URL="+s.KlientURL,
+	)
+
+	return nil
+}
+
+func getSlice(v interface{}) []interface{} {
+	var slice []interface{}
+
+	switch v := v.(type) {
+	case nil:
+	case []map[string]interface{}:
+		slice = make([]interface{}, 0, len(v))
+
+		for _, elem := range v {
+			slice = append(slice, elem)
+		}
+	case []string:
+		slice = make([]interface{}, 0, len(v))
+
+		for _, elem := range v {
+			slice = append(slice, elem)
+		}
+	case []interface{}:
+		slice = v
+	default:
+		slice = []interface{}{v}
+	}
+
+	return slice
+}
+
+func appendSlice(slice interface{}, elems ...interface{}) []interface{} {
+	return append(getSlice(slice), elems...)
+}
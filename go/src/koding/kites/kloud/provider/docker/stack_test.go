@@ -0,0 +1,215 @@
+package docker_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"koding/kites/config"
+	"koding/kites/kloud/contexthelper/session"
+	"koding/kites/kloud/keycreator"
+	"koding/kites/kloud/provider/docker"
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/stack/provider"
+	"koding/kites/kloud/stack/provider/providertest"
+	"koding/kites/kloud/userdata"
+
+	"github.com/koding/kite"
+	"github.com/koding/kite/testkeys"
+	"github.com/koding/logging"
+)
+
+func init() {
+	stack.Konfig = &config.Konfig{
+		Endpoints: &config.Endpoints{
+			Koding:       config.NewEndpoint(""),
+			Tunnel:       config.NewEndpoint(""),
+			KlientLatest: config.NewEndpoint(""),
+		},
+	}
+}
+
+var update = flag.Bool("update-golden", false, "Update golden files.")
+
+// stripNondeterministicResources sets the following fields to "***",
+// as they change between test runs:
+//
+//   - variable.koding_metadata_*
+//
+// The entrypoint script content is masked as well, to keep
+// the golden files readable.
+func stripNondeterministicResources(s string) string {
+	if strings.HasPrefix(s, "koding_metadata_") || s == "content" {
+		return "***"
+	}
+
+	return ""
+}
+
+func TestApplyTemplate(t *testing.T) {
+	flag.Parse()
+
+	log := logging.NewCustom("test", true)
+
+	cred := &stack.Credential{
+		Identifier: "ident",
+		Credential: &docker.Credential{
+			Host: "tcp://127.0.0.1:2376",
+		},
+		Bootstrap: &docker.Bootstrap{
+			NetworkID:   "d1f7a3b2",
+			NetworkName: "koding-ident",
+		},
+	}
+
+	cases := map[string]struct {
+		stack  string
+		want   string
+		labels []string
+		metas  map[string]map[string]interface{}
+	}{
+		"single container stack": {
+			"testdata/single-container.json",
+			"testdata/single-container.json.golden",
+			[]string{"app"},
+			map[string]map[string]interface{}{
+				"app": {"alwaysOn": true},
+			},
+		},
+		"multi container stack": {
+			"testdata/multi-container.json",
+			"testdata/multi-container.json.golden",
+			[]string{"app.0", "app.1"},
+			map[string]map[string]interface{}{},
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			pStack, err := ioutil.ReadFile(cas.stack)
+			if err != nil {
+				t.Fatalf("ReadFile()=%s", err)
+			}
+
+			template, err := provider.ParseTemplate(string(pStack), log)
+			if err != nil {
+				t.Fatalf("ParseTemplate()=%s", err)
+			}
+
+			s := &docker.Stack{
+				BaseStack: &provider.BaseStack{
+					Provider: docker.Provider,
+					Session: &session.Session{
+						Userdata: &userdata.Userdata{
+							Keycreator: &keycreator.Key{
+								KontrolURL:        "http://127.0.0.1/kontrol/kite",
+								KontrolPublicKey:  testkeys.Public,
+								KontrolPrivateKey: testkeys.Private,
+							},
+						},
+					},
+					Builder: &provider.Builder{
+						Template: template,
+					},
+					Req: &kite.Request{
+						Username: "user",
+					},
+					KlientIDs: make(stack.KiteMap),
+					Metas:     make(map[string]map[string]interface{}),
+					Planner:   &provider.Planner{},
+				},
+				KlientURL: "$KLIENT_URL",
+			}
+
+			stack, err := s.ApplyTemplate(cred)
+			if err != nil {
+				t.Fatalf("ApplyTemplate()=%s", err)
+			}
+
+			if *update {
+				if err := providertest.Write(cas.want, stack.Content, stripNondeterministicResources); err != nil {
+					t.Fatalf("Write()=%s", err)
+				}
+
+				return
+			}
+
+			pWant, err := ioutil.ReadFile(cas.want)
+			if err != nil {
+				t.Fatalf("ReadFile()=%s", err)
+			}
+
+			if err := providertest.Equal(stack.Content, string(pWant), stripNondeterministicResources); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, label := range cas.labels {
+				if _, ok := s.KlientIDs[label]; !ok {
+					t.Errorf("missing kite ID for %q", label)
+				}
+			}
+
+			if !reflect.DeepEqual(s.Metas, cas.metas) {
+				t.Fatalf("got %#v, want %#v", s.Metas, cas.metas)
+			}
+		})
+	}
+}
+
+func TestBootstrapTemplates(t *testing.T) {
+	s := &docker.Stack{
+		BaseStack: &provider.BaseStack{},
+	}
+
+	templates, err := s.BootstrapTemplates(&stack.Credential{Identifier: "ident"})
+	if err != nil {
+		t.Fatalf("BootstrapTemplates()=%s", err)
+	}
+
+	if len(templates) != 1 {
+		t.Fatalf("got %d templates, want 1", len(templates))
+	}
+
+	if !strings.Contains(templates[0].Content, `"default": "koding-ident"`) {
+		t.Fatalf("network name not found in bootstrap template:\n%s", templates[0].Content)
+	}
+}
+
+func TestCredentialValid(t *testing.T) {
+	cases := map[string]struct {
+		cred *docker.Credential
+		ok   bool
+	}{
+		"host only": {
+			&docker.Credential{Host: "unix:///var/run/docker.sock"},
+			true,
+		},
+		"host with TLS": {
+			&docker.Credential{Host: "tcp://127.0.0.1:2376", CACert: "ca", Cert: "cert", Key: "key"},
+			true,
+		},
+		"empty host": {
+			&docker.Credential{},
+			false,
+		},
+		"partial TLS": {
+			&docker.Credential{Host: "tcp://127.0.0.1:2376", Cert: "cert"},
+			false,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := cas.cred.Valid()
+			if cas.ok && err != nil {
+				t.Fatalf("Valid()=%s", err)
+			}
+
+			if !cas.ok && err == nil {
+				t.Fatal("expected Valid() to fail")
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"koding/db/mongodb"
 	"koding/db/mongodb/modelhelper"
 	"koding/kites/kloud/contexthelper/request"
+	"koding/kites/kloud/contexthelper/session"
 	"koding/kites/kloud/klient"
 	"koding/kites/kloud/machinestate"
 	"koding/kites/kloud/stack/provider"
@@ -32,6 +33,9 @@ type Queue struct {
 	MongoDB  *mongodb.MongoDB
 	Kite     *kite.Kite
 
+	// Notifier publishes the machine events of the stopped machines
+	Notifier session.Notifier
+
 	stackers map[string]*provider.Stacker
 }
 
@@ -146,6 +150,10 @@ func (q *Queue) Check(s *provider.Stacker) error {
 		return err
 	}
 
+	if bm.Notifier == nil {
+		bm.Notifier = q.Notifier
+	}
+
 	machine, err := s.BuildMachine(ctx, bm)
 	if err != nil {
 		return err
@@ -214,5 +222,11 @@ func (q *Queue) CheckUsage(providerName string, m provider.Machine, bm *provider
 	obj["status.state"] = machinestate.Stopped.String()
 	obj["status.reason"] = "Machine is stopped due to inactivity"
 
-	return modelhelper.UpdateMachine(bm.ObjectId, bson.M{"$set": obj})
+	if err := modelhelper.UpdateMachine(bm.ObjectId, bson.M{"$set": obj}); err != nil {
+		return err
+	}
+
+	bm.NotifyState(bm.State(), machinestate.Stopped)
+
+	return nil
 }
@@ -376,6 +376,32 @@ func (k *Kloud) CredentialAdd(r *kite.Request) (interface{}, error) {
 		}
 	}
 
+	kiteReq := &kite.Request{
+		Method:   "bootstrap",
+		Username: r.Username,
+	}
+
+	// Verify the credential before storing it, so invalid
+	// credentials are never persisted.
+	s, _, err := k.newStack(kiteReq, &TeamRequest{
+		Provider:  req.Provider,
+		GroupName: req.Team,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{
+		Provider:   c.Provider,
+		Title:      c.Title,
+		Credential: cred,
+		Bootstrap:  boot,
+	}
+
+	if err := s.VerifyCredential(credential); err != nil {
+		return nil, err
+	}
+
 	if err := k.CredClient.SetCred(r.Username, c); err != nil {
 		return nil, err
 	}
@@ -386,11 +412,6 @@ func (k *Kloud) CredentialAdd(r *kite.Request) (interface{}, error) {
 		Identifier: c.Ident,
 	}
 
-	kiteReq := &kite.Request{
-		Method:   "bootstrap",
-		Username: r.Username,
-	}
-
 	s, ctx, err := k.newStack(kiteReq, teamReq)
 	if err != nil {
 		return nil, err
@@ -404,18 +425,6 @@ func (k *Kloud) CredentialAdd(r *kite.Request) (interface{}, error) {
 
 	ctx = context.WithValue(ctx, BootstrapRequestKey, bootReq)
 
-	credential := &Credential{
-		Provider:   c.Provider,
-		Title:      c.Title,
-		Identifier: c.Ident,
-		Credential: cred,
-		Bootstrap:  boot,
-	}
-
-	if err := s.VerifyCredential(credential); err != nil {
-		return nil, err
-	}
-
 	if _, err := s.HandleBootstrap(ctx); err != nil {
 		return nil, err
 	}
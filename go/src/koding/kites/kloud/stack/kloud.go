@@ -91,6 +91,12 @@ type Kloud struct {
 	// import structure.
 	DescribeFunc func(providers ...string) map[string]*Description
 
+	// ValidateFunc is used to validate stack templates.
+	//
+	// It wraps provider.Validate function for the same
+	// reason the DescribeFunc does.
+	ValidateFunc func(*ValidateRequest) ValidationErrors
+
 	// NewStack is used to create new Stacker value out of the given
 	// kite and team requests.
 	//
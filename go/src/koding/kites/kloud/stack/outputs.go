@@ -0,0 +1,88 @@
+package stack
+
+import (
+	"errors"
+
+	"koding/db/models"
+	"koding/db/mongodb/modelhelper"
+
+	"github.com/koding/kite"
+)
+
+// OutputsRequest represents a request value for "stack.outputs"
+// kloud method.
+type OutputsRequest struct {
+	StackID string `json:"stackId"`
+
+	// Sensitive tells whether values of sensitive
+	// outputs should be returned as well.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *OutputsRequest) Valid() error {
+	if req.StackID == "" {
+		return errors.New("stackId is not passed")
+	}
+
+	return nil
+}
+
+// OutputsResponse represents a response value from "stack.outputs"
+// kloud method.
+type OutputsResponse struct {
+	StackID string                         `json:"stackId"`
+	Outputs map[string]*models.StackOutput `json:"outputs"`
+}
+
+// StackOutputs is a kite.Handler for "stack.outputs" kite method.
+//
+// It returns Terraform outputs read during last successful apply
+// of the stack. Only the owner of the stack is allowed to read
+// them.
+func (k *Kloud) StackOutputs(r *kite.Request) (interface{}, error) {
+	if r.Args == nil {
+		return nil, NewError(ErrNoArguments)
+	}
+
+	var req OutputsRequest
+
+	if err := r.Args.One().Unmarshal(&req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, err
+	}
+
+	computeStack, err := modelhelper.GetComputeStack(req.StackID)
+	if err != nil {
+		return nil, models.ResError(err, "jComputeStack")
+	}
+
+	account, err := modelhelper.GetAccount(r.Username)
+	if err != nil {
+		return nil, models.ResError(err, "jAccount")
+	}
+
+	if computeStack.OriginId != account.Id {
+		return nil, NewError(ErrNotAuthorized)
+	}
+
+	resp := &OutputsResponse{
+		StackID: req.StackID,
+		Outputs: make(map[string]*models.StackOutput, len(computeStack.Outputs)),
+	}
+
+	for name, out := range computeStack.Outputs {
+		outCopy := *out
+
+		if outCopy.Sensitive && !req.Sensitive {
+			outCopy.Value = nil
+		}
+
+		resp.Outputs[name] = &outCopy
+	}
+
+	return resp, nil
+}
@@ -21,6 +21,7 @@ import (
 	"koding/kites/kloud/stackstate"
 	"koding/kites/kloud/terraformer"
 	"koding/kites/kloud/utils/object"
+	webhookclient "socialapi/workers/webhook/client"
 
 	"golang.org/x/net/context"
 )
@@ -110,7 +111,18 @@ func (bs *BaseStack) apply(ctx context.Context, req *stack.ApplyRequest) {
 			}
 		}
 
+		built := map[string]interface{}{
+			"stackId":  req.StackID,
+			"username": bs.Req.Username,
+			"duration": int64(time.Since(start) / time.Second),
+			"status":   "succeeded",
+		}
+
 		if err != nil {
+			// the error is not sent, the event is delivered
+			// to the external webhook endpoints and slack
+			built["status"] = "failed"
+
 			modelhelper.SetStackState(req.StackID, "Stack building failed", stackstate.NotInitialized)
 			finalEvent.Status = machinestate.NotInitialized
 
@@ -125,11 +137,36 @@ func (bs *BaseStack) apply(ctx context.Context, req *stack.ApplyRequest) {
 		}
 
 		bs.Eventer.Push(finalEvent)
+		bs.notify(req.GroupName, webhookclient.EventStackBuilt, built)
 	}()
 
 	err = bs.applyAsync(ctx, req)
 }
 
+// notifyMachine publishes the machine event of the stack in the background.
+func (bs *BaseStack) notifyMachine(groupName, event string, m *models.Machine) {
+	bs.notify(groupName, event, map[string]interface{}{
+		"machineId": m.ObjectId.Hex(),
+		"label":     m.Label,
+		"slug":      m.Slug,
+		"provider":  m.Provider,
+		"username":  bs.Req.Username,
+	})
+}
+
+// notify publishes the team event in the background.
+func (bs *BaseStack) notify(groupName, event string, data interface{}) {
+	if bs.Session == nil || bs.Session.Notifier == nil {
+		return
+	}
+
+	go func() {
+		if err := bs.Session.Notifier.Notify(groupName, event, data); err != nil {
+			bs.Log.Warning("unable to publish %q event of %q: %s", event, groupName, err)
+		}
+	}()
+}
+
 func (bs *BaseStack) destroy(ctx context.Context, req *stack.ApplyRequest) error {
 	log := bs.Log.New(req.StackID)
 
@@ -157,6 +194,11 @@ func (bs *BaseStack) destroy(ctx context.Context, req *stack.ApplyRequest) error
 		return err
 	}
 
+	// machines are marked as terminated with the detach
+	for _, m := range bs.Builder.Machines {
+		bs.notifyMachine(req.GroupName, webhookclient.EventMachineStopped, m)
+	}
+
 	// This part is done asynchronously.
 	go func() {
 		finalEvent := &eventer.Event{
@@ -281,6 +323,10 @@ func (bs *BaseStack) applyAsync(ctx context.Context, req *stack.ApplyRequest) er
 		return err
 	}
 
+	if err := bs.Builder.BuildTemplateRevision(); err != nil {
+		bs.Log.Warning("unable to record template revision: %s", err)
+	}
+
 	if len(req.Variables) != 0 {
 		if err := bs.Builder.Template.InjectVariables("", req.Variables); err != nil {
 			return err
@@ -346,6 +392,12 @@ func (bs *BaseStack) applyAsync(ctx context.Context, req *stack.ApplyRequest) er
 		return err
 	}
 
+	bs.Builder.Stack.Outputs = ReadOutputs(state)
+
+	if env := OutputsEnv(bs.Builder.Stack.Outputs); len(env) != 0 {
+		bs.Planner.OnDial = bs.setEnvOnDial(bs.Planner.OnDial, env)
+	}
+
 	bs.Eventer.Push(&eventer.Event{
 		Message:    "Checking VM connections",
 		Percentage: 70,
@@ -408,6 +460,10 @@ func (bs *BaseStack) UpdateResources(state *terraform.State) error {
 			err = multierror.Append(err, fmt.Errorf("machine %q failed to update: %s", label, e))
 			continue
 		}
+
+		if machine.State == machinestate.Running && m.State() != machinestate.Running && bs.Builder.Stack.Stack != nil {
+			bs.notifyMachine(bs.Builder.Stack.Stack.Group, webhookclient.EventMachineStarted, m)
+		}
 	}
 
 	return err
@@ -110,6 +110,8 @@ type Builder struct {
 	Koding        *stack.Credential
 	Credentials   []*stack.Credential
 	Template      *Template
+
+	baseTemplate *models.StackTemplate // jStackTemplate the stack was generated from
 }
 
 // NewBuilder gives new *Builder value.
@@ -180,6 +182,7 @@ func (b *Builder) BuildStack(stackID string, credentials map[string][]string) er
 		}
 
 		b.Stack.Template = stackTemplate.Template.Content
+		b.baseTemplate = stackTemplate
 	} else {
 		overallErr = models.ResError(err, "jStackTemplate")
 	}
@@ -205,6 +208,26 @@ func (b *Builder) BuildStack(stackID string, credentials map[string][]string) er
 	return overallErr
 }
 
+// BuildTemplateRevision records content of the stack template the
+// stack is going to be built from as a template revision, and sets
+// b.Stack.TemplateRevision to its number.
+//
+// Prior calling to this method it is required to build the stack first.
+func (b *Builder) BuildTemplateRevision() error {
+	if b.baseTemplate == nil {
+		return errors.New("stack template was not built")
+	}
+
+	rev, err := modelhelper.SnapshotStackTemplate(b.baseTemplate, "", "")
+	if err != nil {
+		return models.ResError(err, modelhelper.StackTemplateRevisionColl)
+	}
+
+	b.Stack.TemplateRevision = rev.Revision
+
+	return nil
+}
+
 // BuildStackTemplate fetched stack template details from MongoDB.
 //
 // When nil error is returned, the b.StackTemplate field is guaranteed to be non-nil.
@@ -583,10 +606,20 @@ func (b *Builder) PutCredentials(username string, creds ...*stack.Credential) er
 
 // UpdateStack updates jComputeStack document using b.Stack field.
 func (b *Builder) UpdateStack() error {
+	change := bson.M{
+		"credentials": b.Stack.Credentials,
+	}
+
+	if b.Stack.Outputs != nil {
+		change["outputs"] = b.Stack.Outputs
+	}
+
+	if b.Stack.TemplateRevision != 0 {
+		change["templateRevision"] = b.Stack.TemplateRevision
+	}
+
 	return modelhelper.UpdateStack(b.Stack.ID, bson.M{
-		"$set": bson.M{
-			"credentials": b.Stack.Credentials,
-		},
+		"$set": change,
 	})
 }
 
@@ -0,0 +1,140 @@
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/terraform/builtin/providers/aws"
+	"github.com/hashicorp/terraform/builtin/providers/azure"
+	"github.com/hashicorp/terraform/builtin/providers/digitalocean"
+	"github.com/hashicorp/terraform/builtin/providers/docker"
+	"github.com/hashicorp/terraform/builtin/providers/google"
+	"github.com/hashicorp/terraform/builtin/providers/softlayer"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+var output = flag.String("o", "-", "")
+
+// providers are Terraform providers of the kloud providers,
+// which resource schemas are generated.
+var providers = []func() terraform.ResourceProvider{
+	aws.Provider,
+	azure.Provider,
+	digitalocean.Provider,
+	docker.Provider,
+	google.Provider,
+	softlayer.Provider,
+}
+
+var types = map[schema.ValueType]string{
+	schema.TypeBool:   "TypeBool",
+	schema.TypeInt:    "TypeInt",
+	schema.TypeFloat:  "TypeFloat",
+	schema.TypeString: "TypeString",
+	schema.TypeList:   "TypeList",
+	schema.TypeMap:    "TypeMap",
+	schema.TypeSet:    "TypeSet",
+}
+
+func main() {
+	flag.Parse()
+
+	resources := make(map[string]*schema.Resource)
+
+	for _, fn := range providers {
+		p, ok := fn().(*schema.Provider)
+		if !ok {
+			log.Fatalf("unexpected provider type: %T", p)
+		}
+
+		for typ, r := range p.ResourcesMap {
+			resources[typ] = r
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by genschema.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package provider")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var resourceSchemas = map[string]Attributes{")
+
+	for _, typ := range sortedKeys(resources) {
+		fmt.Fprintf(&buf, "%q: {\n", typ)
+		writeAttributes(&buf, resources[typ].Schema)
+		fmt.Fprintln(&buf, "},")
+	}
+
+	fmt.Fprintln(&buf, "}")
+
+	p, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "-" || *output == "" {
+		os.Stdout.Write(p)
+		return
+	}
+
+	if err := ioutil.WriteFile(*output, p, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeAttributes(buf *bytes.Buffer, attrs map[string]*schema.Schema) {
+	for _, name := range sortedKeys(attrs) {
+		s := attrs[name]
+
+		if s.Removed != "" {
+			continue
+		}
+
+		fmt.Fprintf(buf, "%q: {Type: %s", name, types[s.Type])
+
+		if s.Required {
+			fmt.Fprint(buf, ", Required: true")
+		}
+
+		if s.Computed && !s.Optional && !s.Required {
+			fmt.Fprint(buf, ", Computed: true")
+		}
+
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			fmt.Fprint(buf, ", Block: Attributes{\n")
+			writeAttributes(buf, r.Schema)
+			fmt.Fprint(buf, "}")
+		}
+
+		fmt.Fprintln(buf, "},")
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case map[string]*schema.Resource:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*schema.Schema:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
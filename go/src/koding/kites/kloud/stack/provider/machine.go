@@ -10,6 +10,7 @@ import (
 	"koding/kites/kloud/machinestate"
 	"koding/kites/kloud/stack"
 	"koding/kites/kloud/utils/object"
+	webhookclient "socialapi/workers/webhook/client"
 
 	"golang.org/x/net/context"
 	"gopkg.in/mgo.v2/bson"
@@ -118,6 +119,54 @@ func (bm *BaseMachine) HandleStop(ctx context.Context) error {
 	return nil
 }
 
+// NotifyState publishes the machine event of the persisted state transition,
+// the metering worker opens and closes the running intervals of the machines
+// with them. Events are idempotent, a stopped event is sent for each halted
+// state, since a machine can be terminated or stopped by the provider while
+// its persisted state is not running.
+func (bm *BaseMachine) NotifyState(from, to machinestate.State) {
+	halted := func(s machinestate.State) bool {
+		return s.In(machinestate.Stopped, machinestate.Terminated, machinestate.NotInitialized)
+	}
+
+	switch {
+	case to == machinestate.Running && from != machinestate.Running:
+		bm.notify(webhookclient.EventMachineStarted)
+	case halted(to) && !halted(from):
+		bm.notify(webhookclient.EventMachineStopped)
+	}
+}
+
+// notify publishes the team event of the machine in the background.
+func (bm *BaseMachine) notify(event string) {
+	if bm.Notifier == nil || len(bm.Groups) == 0 || !bm.Groups[0].Id.Valid() {
+		return
+	}
+
+	go func() {
+		group, err := modelhelper.GetGroupById(bm.Groups[0].Id.Hex())
+		if err != nil {
+			bm.Log.Warning("unable to publish %q event: %s", event, err)
+			return
+		}
+
+		data := map[string]interface{}{
+			"machineId": bm.ObjectId.Hex(),
+			"label":     bm.Label,
+			"slug":      bm.Slug,
+			"provider":  bm.Provider,
+		}
+
+		if bm.Req != nil {
+			data["username"] = bm.Req.Username
+		}
+
+		if err := bm.Notifier.Notify(group.Slug, event, data); err != nil {
+			bm.Log.Warning("unable to publish %q event of %q: %s", event, group.Slug, err)
+		}
+	}()
+}
+
 func (bm *BaseMachine) HandleInfo(ctx context.Context) (*stack.InfoResponse, error) {
 	var state *DialState
 
@@ -136,7 +185,10 @@ func (bm *BaseMachine) HandleInfo(ctx context.Context) (*stack.InfoResponse, err
 		if meta != nil || state != nil {
 			bm.updateMachine(state, meta, currentState)
 		} else if currentState != 0 {
-			modelhelper.ChangeMachineState(bm.ObjectId, "Machine is marked as "+currentState.String(), currentState)
+			err := modelhelper.ChangeMachineState(bm.ObjectId, "Machine is marked as "+currentState.String(), currentState)
+			if err == nil {
+				bm.NotifyState(origState, currentState)
+			}
 		}
 	}()
 
@@ -207,5 +259,14 @@ func (bm *BaseMachine) updateMachine(state *DialState, meta interface{}, dbState
 
 	bm.Log.Debug("update object for %q: %+v (%# v)", bm.Label, obj, state)
 
-	return modelhelper.UpdateMachine(bm.ObjectId, bson.M{"$set": obj})
+	if err := modelhelper.UpdateMachine(bm.ObjectId, bson.M{"$set": obj}); err != nil {
+		return err
+	}
+
+	if dbState != 0 {
+		bm.NotifyState(bm.State(), dbState)
+		bm.Status.State = dbState.String()
+	}
+
+	return nil
 }
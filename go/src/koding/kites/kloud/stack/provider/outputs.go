@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"koding/db/models"
+	"koding/kites/kloud/klient"
+	"koding/klient/os"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/koding/kite"
+)
+
+// OutputEnvPrefix is a prefix of environment variables,
+// which hold stack outputs on each machine.
+const OutputEnvPrefix = "KODING_OUTPUT_"
+
+// ReadOutputs reads outputs of the root module from the given state.
+//
+// If the state has no root module, the function returns empty
+// non-nil map.
+func ReadOutputs(state *terraform.State) map[string]*models.StackOutput {
+	outputs := make(map[string]*models.StackOutput)
+
+	if state == nil {
+		return outputs
+	}
+
+	root := state.ModuleByPath(terraform.RootModulePath)
+	if root == nil {
+		return outputs
+	}
+
+	for name, out := range root.Outputs {
+		outputs[name] = &models.StackOutput{
+			Type:      out.Type,
+			Sensitive: out.Sensitive,
+			Value:     out.Value,
+		}
+	}
+
+	return outputs
+}
+
+// OutputsEnv converts the given outputs to environment variables.
+//
+// Each variable is named after the output, uppercased and prefixed
+// with OutputEnvPrefix. Values of list and map outputs are
+// JSON-encoded. Sensitive outputs are not converted.
+func OutputsEnv(outputs map[string]*models.StackOutput) map[string]string {
+	env := make(map[string]string, len(outputs))
+
+	for name, out := range outputs {
+		if out.Sensitive {
+			continue
+		}
+
+		var value string
+
+		switch v := out.Value.(type) {
+		case string:
+			value = v
+		case nil:
+		default:
+			p, err := json.Marshal(v)
+			if err != nil {
+				value = fmt.Sprintf("%v", v)
+			} else {
+				value = string(p)
+			}
+		}
+
+		env[OutputEnvPrefix+envName(name)] = value
+	}
+
+	return env
+}
+
+func envName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return '_'
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
+
+// setEnvOnDial wraps the fn dial callback with setting
+// the given environment variables on each dialed klient.
+//
+// Failing to set the variables is not fatal, as older
+// klients do not support it.
+func (bs *BaseStack) setEnvOnDial(fn func(*kite.Client) error, env map[string]string) func(*kite.Client) error {
+	return func(c *kite.Client) error {
+		if fn != nil {
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+
+		k := &klient.Klient{Client: c}
+
+		if err := k.SetEnv(&os.SetEnvRequest{Env: env}); err != nil {
+			bs.Log.Warning("unable to set stack outputs on %q klient: %s", c.URL, err)
+		}
+
+		return nil
+	}
+}
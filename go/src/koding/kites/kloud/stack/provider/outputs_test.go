@@ -0,0 +1,71 @@
+package provider_test
+
+import (
+	"reflect"
+	"testing"
+
+	"koding/db/models"
+	"koding/kites/kloud/stack/provider"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestOutputs(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{{
+			Path: terraform.RootModulePath,
+			Outputs: map[string]*terraform.OutputState{
+				"lb_dns": {
+					Type:  "string",
+					Value: "lb-1234.example.com",
+				},
+				"db-password": {
+					Type:      "string",
+					Sensitive: true,
+					Value:     "secret",
+				},
+				"bucket.names": {
+					Type:  "list",
+					Value: []interface{}{"a", "b"},
+				},
+			},
+		}},
+	}
+
+	outputs := provider.ReadOutputs(state)
+
+	wantOutputs := map[string]*models.StackOutput{
+		"lb_dns": {
+			Type:  "string",
+			Value: "lb-1234.example.com",
+		},
+		"db-password": {
+			Type:      "string",
+			Sensitive: true,
+			Value:     "secret",
+		},
+		"bucket.names": {
+			Type:  "list",
+			Value: []interface{}{"a", "b"},
+		},
+	}
+
+	if !reflect.DeepEqual(outputs, wantOutputs) {
+		t.Fatalf("got %+v, want %+v", outputs, wantOutputs)
+	}
+
+	env := provider.OutputsEnv(outputs)
+
+	wantEnv := map[string]string{
+		"KODING_OUTPUT_LB_DNS":       "lb-1234.example.com",
+		"KODING_OUTPUT_BUCKET_NAMES": `["a","b"]`,
+	}
+
+	if !reflect.DeepEqual(env, wantEnv) {
+		t.Fatalf("got %+v, want %+v", env, wantEnv)
+	}
+
+	if outputs := provider.ReadOutputs(&terraform.State{}); len(outputs) != 0 {
+		t.Fatalf("want no outputs, got %+v", outputs)
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+)
+
+//go:generate go run genschema.go -o schema_gen.go
+
+// ValueType is a type of a resource attribute, it mirrors
+// schema.ValueType of Terraform providers.
+type ValueType int
+
+// The following are types of resource attributes.
+const (
+	TypeInvalid ValueType = iota
+	TypeBool
+	TypeInt
+	TypeFloat
+	TypeString
+	TypeList
+	TypeMap
+	TypeSet
+)
+
+var valueTypes = map[ValueType]string{
+	TypeBool:   "bool",
+	TypeInt:    "int",
+	TypeFloat:  "float",
+	TypeString: "string",
+	TypeList:   "list",
+	TypeMap:    "map",
+	TypeSet:    "set",
+}
+
+// String implements the fmt.Stringer interface.
+func (t ValueType) String() string {
+	if s, ok := valueTypes[t]; ok {
+		return s
+	}
+	return "invalid"
+}
+
+// Attribute describes a single attribute of a resource.
+type Attribute struct {
+	Type     ValueType
+	Required bool       // attribute must be set
+	Computed bool       // attribute is read-only and can't be set
+	Block    Attributes // attributes of nested blocks, if any
+}
+
+// Attributes describes attributes of a resource or a nested block.
+type Attributes map[string]*Attribute
+
+// metaAttributes are attributes accepted by every resource,
+// they are handled by Terraform itself.
+var metaAttributes = map[string]struct{}{
+	"connection":  {},
+	"count":       {},
+	"depends_on":  {},
+	"lifecycle":   {},
+	"provider":    {},
+	"provisioner": {},
+	"timeouts":    {},
+}
+
+// resourceSchema gives attributes of the given resource type.
+//
+// The schemas are generated from Terraform providers, false is
+// returned when the provider does not have schemas or it does
+// not support the resource type.
+func (p *Provider) resourceSchema(typ string) (Attributes, bool) {
+	attrs, ok := resourceSchemas[typ]
+	return attrs, ok
+}
+
+// hasResourceSchemas returns true when resource schemas of
+// the provider are known.
+func (p *Provider) hasResourceSchemas() bool {
+	prefix := p.Name + "_"
+
+	for typ := range resourceSchemas {
+		if strings.HasPrefix(typ, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkType gives non-empty message when the given value
+// can't be decoded by Terraform as a value of the attribute.
+//
+// Since values are weakly decoded, scalar values are
+// accepted for each scalar type as long as they can be
+// converted. Values with interpolations are not checked,
+// as they are known not until the stack is built.
+func (attr *Attribute) checkType(v interface{}) string {
+	if s, ok := v.(string); ok && strings.Contains(s, "${") {
+		return ""
+	}
+
+	switch attr.Type {
+	case TypeBool:
+		switch v := v.(type) {
+		case bool:
+			return ""
+		case string:
+			if _, err := strconv.ParseBool(v); err == nil {
+				return ""
+			}
+		}
+	case TypeInt, TypeFloat:
+		switch v := v.(type) {
+		case float64:
+			return ""
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return ""
+			}
+		}
+	case TypeString:
+		switch v.(type) {
+		case string, float64, bool:
+			return ""
+		}
+	case TypeList, TypeSet:
+		switch v.(type) {
+		case []interface{}:
+			return ""
+		case map[string]interface{}:
+			if attr.Block != nil {
+				// Single nested block.
+				return ""
+			}
+		}
+	case TypeMap:
+		switch v := v.(type) {
+		case map[string]interface{}:
+			return ""
+		case []interface{}:
+			// Maps decoded from HCL are lists of maps.
+			for _, v := range v {
+				if _, ok := v.(map[string]interface{}); !ok {
+					return "must be a " + attr.Type.String()
+				}
+			}
+			return ""
+		}
+	}
+
+	return "must be a " + attr.Type.String()
+}
@@ -147,6 +147,10 @@ func (s *Stacker) BuildBaseMachine(ctx context.Context, m *models.Machine) (*Bas
 		Debug:      s.Debug,
 	}
 
+	if sess, ok := session.FromContext(ctx); ok {
+		bm.Notifier = sess.Notifier
+	}
+
 	// NOTE(rjeczalik): "internal" method is used by (*Queue).CheckAWS
 	if req.Method != "internal" {
 		// get user model which contains user ssh keys or the list of users that
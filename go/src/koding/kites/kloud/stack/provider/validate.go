@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/utils/object"
+)
+
+var metaBuilder = &object.Builder{
+	Tag:       "hcl",
+	Sep:       "_",
+	Recursive: true,
+}
+
+// Validate checks the given stack template for errors that would
+// otherwise be reported not until the stack is built.
+//
+// The following is checked:
+//
+//   - whether the template is a valid JSON and has no interpolation
+//     syntax errors
+//   - whether each provider used in the template is supported
+//   - whether each variable used in the template is either declared
+//     or injected by kloud
+//   - whether each resource type is supported by its provider and
+//     whether its attributes are known, have valid types and all
+//     the required ones are set, for providers with known resource
+//     schemas (see schema_gen.go)
+//   - whether user data of each machine is a string, for providers
+//     supporting cloud-init
+//
+// Each error contains a JSON Pointer to the offending value.
+func Validate(req *stack.ValidateRequest) stack.ValidationErrors {
+	var v interface{}
+
+	if err := json.Unmarshal(req.Template, &v); err != nil {
+		return stack.ValidationErrors{{
+			Message: "invalid template: " + err.Error(),
+		}}
+	}
+
+	t, err := ParseTemplate(string(req.Template), defaultLog)
+	if err != nil {
+		return stack.ValidationErrors{{
+			Message: "invalid template: " + err.Error(),
+		}}
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	val := &validator{
+		t:    t,
+		req:  req,
+		vars: make(map[string]struct{}),
+	}
+
+	if _, err := t.DetectUserVariables(""); err != nil {
+		val.errorf("", "invalid interpolation: %s", err)
+	}
+
+	val.providers()
+	val.resources()
+	val.walk(v)
+
+	return val.errs
+}
+
+type validator struct {
+	t    *Template
+	req  *stack.ValidateRequest
+	vars map[string]struct{} // provider variables injected by kloud
+	errs stack.ValidationErrors
+}
+
+func (val *validator) errorf(pointer, format string, args ...interface{}) {
+	val.errs = append(val.errs, &stack.ValidationError{
+		Pointer: pointer,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (val *validator) providers() {
+	if len(val.t.Provider) == 0 {
+		val.errorf(stack.JSONPointer("provider"), "no provider is configured")
+		return
+	}
+
+	for name := range val.t.Provider {
+		p, ok := providers[name]
+		if !ok {
+			val.errorf(stack.JSONPointer("provider", name), "unsupported provider %q", name)
+			continue
+		}
+
+		for k := range metaBuilder.New(p.Name).Build(p.newCredential()) {
+			val.vars[k] = struct{}{}
+		}
+
+		if v := p.newBootstrap(); v != nil {
+			for k := range metaBuilder.New(p.Name).Build(v) {
+				val.vars[k] = struct{}{}
+			}
+		}
+	}
+}
+
+func (val *validator) resources() {
+	for typ, v := range val.t.Resource {
+		ptr := stack.JSONPointer("resource", typ)
+
+		i := strings.IndexRune(typ, '_')
+		if i == -1 {
+			val.errorf(ptr, "invalid resource type %q", typ)
+			continue
+		}
+
+		p, ok := providers[typ[:i]]
+		if !ok {
+			// Resources of providers not handled by kloud
+			// are validated by terraform itself.
+			continue
+		}
+
+		if _, ok := val.t.Provider[p.Name]; !ok {
+			val.errorf(ptr, "resource of %q provider, which is not configured", p.Name)
+			continue
+		}
+
+		resources, ok := v.(map[string]interface{})
+		if !ok {
+			val.errorf(ptr, "resources must be an object, got %T", v)
+			continue
+		}
+
+		machine := typ == p.Name+"_"+p.resourceName()
+
+		schema, ok := p.resourceSchema(typ)
+		if !ok && p.hasResourceSchemas() {
+			val.errorf(ptr, "unsupported resource type %q", typ)
+			continue
+		}
+
+		for name, v := range resources {
+			attrs, ok := v.(map[string]interface{})
+			if !ok {
+				val.errorf(stack.JSONPointer("resource", typ, name), "resource must be an object, got %T", v)
+				continue
+			}
+
+			if machine && !p.NoCloudInit {
+				if userdata, ok := attrs[p.userdata()]; ok {
+					if _, ok := userdata.(string); !ok {
+						val.errorf(stack.JSONPointer("resource", typ, name, p.userdata()),
+							"user data must be a string, got %T", userdata)
+					}
+
+					// User data is already checked.
+					attrs = without(attrs, p.userdata())
+				}
+			}
+
+			if schema != nil {
+				val.attributes(schema, attrs, machine, "resource", typ, name)
+			}
+		}
+	}
+}
+
+// attributes checks the given attributes of a resource or a nested
+// block against its schema.
+//
+// Attributes of machine resources are augmented by kloud when the
+// stack is built, thus their required attributes are not checked
+// and the kloud-specific koding_* attributes are accepted.
+func (val *validator) attributes(schema Attributes, attrs map[string]interface{}, machine bool, tokens ...string) {
+	for _, name := range sortedKeys(attrs) {
+		ptr := stack.JSONPointer(append(tokens, name)...)
+
+		attr, ok := schema[name]
+		if !ok {
+			if _, ok := metaAttributes[name]; ok && len(tokens) == 3 {
+				continue
+			}
+
+			if machine && strings.HasPrefix(name, "koding_") {
+				continue
+			}
+
+			val.errorf(ptr, "unknown attribute %q", name)
+			continue
+		}
+
+		if attr.Computed {
+			val.errorf(ptr, "attribute %q is computed and can't be set", name)
+			continue
+		}
+
+		v := attrs[name]
+
+		if msg := attr.checkType(v); msg != "" {
+			val.errorf(ptr, "attribute %q %s, got %s", name, msg, jsonType(v))
+			continue
+		}
+
+		if attr.Block == nil {
+			continue
+		}
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			val.attributes(attr.Block, v, false, append(tokens, name)...)
+		case []interface{}:
+			for i, v := range v {
+				block, ok := v.(map[string]interface{})
+				if !ok {
+					if s, ok := v.(string); !ok || !strings.Contains(s, "${") {
+						val.errorf(stack.JSONPointer(append(tokens, name, strconv.Itoa(i))...),
+							"block of %q must be an object, got %s", name, jsonType(v))
+					}
+					continue
+				}
+
+				val.attributes(attr.Block, block, false, append(tokens, name, strconv.Itoa(i))...)
+			}
+		}
+	}
+
+	if machine {
+		return
+	}
+
+	for _, name := range sortedKeys(schema) {
+		if _, ok := attrs[name]; !ok && schema[name].Required {
+			val.errorf(stack.JSONPointer(tokens...), "missing required attribute %q", name)
+		}
+	}
+}
+
+// walk checks variables used in all string values of the template.
+func (val *validator) walk(v interface{}, tokens ...string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			val.walk(v[k], append(tokens, k)...)
+		}
+	case []interface{}:
+		for i, v := range v {
+			val.walk(v, append(tokens, strconv.Itoa(i))...)
+		}
+	case string:
+		for _, variable := range ReadVariables(v) {
+			if msg := val.checkVariable(variable.Name); msg != "" {
+				val.errorf(stack.JSONPointer(tokens...), "%s %q", msg, variable.Name)
+			}
+		}
+	}
+}
+
+// checkVariable gives non-empty message when the given
+// variable is not going to be defined when building the stack.
+func (val *validator) checkVariable(name string) string {
+	if _, ok := val.t.Variable[name]; ok {
+		return ""
+	}
+
+	for _, prefix := range escapeVars {
+		if strings.HasPrefix(name, prefix) {
+			return ""
+		}
+	}
+
+	if _, ok := val.vars[name]; ok {
+		return ""
+	}
+
+	i := strings.IndexRune(name, '_')
+	if i == -1 {
+		return "undefined variable"
+	}
+
+	switch prefix := name[:i]; prefix {
+	case "koding":
+		if _, ok := metaBuilder.New("koding").Build(&KodingMeta{})[name]; !ok {
+			return "unknown Koding variable"
+		}
+	case "custom":
+		if val.req.Variables == nil {
+			return ""
+		}
+
+		if _, ok := val.req.Variables[name[i+1:]]; !ok {
+			return "undefined custom variable"
+		}
+	default:
+		if _, ok := val.t.Provider[prefix]; ok {
+			return "unknown credential field"
+		}
+
+		return "undefined variable"
+	}
+
+	return ""
+}
+
+// without gives a shallow copy of attrs without the given attribute.
+func without(attrs map[string]interface{}, name string) map[string]interface{} {
+	attrsCopy := make(map[string]interface{}, len(attrs))
+
+	for k, v := range attrs {
+		if k != name {
+			attrsCopy[k] = v
+		}
+	}
+
+	return attrsCopy
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case Attributes:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// jsonType gives a name of JSON type of the given value.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,208 @@
+package provider_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	_ "koding/kites/kloud/provider/aws"
+	_ "koding/kites/kloud/provider/docker"
+	"koding/kites/kloud/stack"
+	"koding/kites/kloud/stack/provider"
+)
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		template  string
+		variables map[string]string
+		errs      stack.ValidationErrors
+	}{
+		"valid template": {
+			testTemplate,
+			nil,
+			nil,
+		},
+		"valid template with injected variables": {
+			`{
+			    "provider": {
+			        "aws": {
+			            "access_key": "${var.aws_access_key}",
+			            "secret_key": "${var.aws_secret_key}",
+			            "region": "${var.aws_region}"
+			        }
+			    },
+			    "resource": {
+			        "aws_instance": {
+			            "example": {
+			                "ami": "${var.aws_ami}",
+			                "tags": {
+			                    "Name": "${var.koding_user_username}-${var.custom_name}"
+			                },
+			                "user_data": "echo ${var.userInput_foo}"
+			            }
+			        }
+			    }
+			}`,
+			map[string]string{"name": "vm"},
+			nil,
+		},
+		"invalid JSON": {
+			`{"provider": `,
+			nil,
+			stack.ValidationErrors{{
+				Message: "invalid template: unexpected end of JSON input",
+			}},
+		},
+		"unsupported provider": {
+			`{"provider": {"foo": {}}}`,
+			nil,
+			stack.ValidationErrors{{
+				Pointer: "/provider/foo",
+				Message: `unsupported provider "foo"`,
+			}},
+		},
+		"not configured provider": {
+			`{
+			    "provider": {
+			        "aws": {}
+			    },
+			    "resource": {
+			        "docker_container": {
+			            "example": {}
+			        }
+			    }
+			}`,
+			nil,
+			stack.ValidationErrors{{
+				Pointer: "/resource/docker_container",
+				Message: `resource of "docker" provider, which is not configured`,
+			}},
+		},
+		"undefined variables": {
+			`{
+			    "provider": {
+			        "aws": {
+			            "access_key": "${var.aws_acces_key}"
+			        }
+			    },
+			    "resource": {
+			        "aws_instance": {
+			            "example/1": {
+			                "tags": {
+			                    "Name": "${var.koding_user_name}-${var.custom_nam}"
+			                },
+			                "user_data": "echo ${var.foo}"
+			            }
+			        }
+			    }
+			}`,
+			map[string]string{"name": "vm"},
+			stack.ValidationErrors{{
+				Pointer: "/provider/aws/access_key",
+				Message: `unknown credential field "aws_acces_key"`,
+			}, {
+				Pointer: "/resource/aws_instance/example~11/tags/Name",
+				Message: `undefined custom variable "custom_nam"`,
+			}, {
+				Pointer: "/resource/aws_instance/example~11/tags/Name",
+				Message: `unknown Koding variable "koding_user_name"`,
+			}, {
+				Pointer: "/resource/aws_instance/example~11/user_data",
+				Message: `undefined variable "foo"`,
+			}},
+		},
+		"invalid user data": {
+			`{
+			    "provider": {
+			        "aws": {}
+			    },
+			    "resource": {
+			        "aws_instance": {
+			            "example": {
+			                "user_data": ["echo", "foo"]
+			            }
+			        }
+			    }
+			}`,
+			nil,
+			stack.ValidationErrors{{
+				Pointer: "/resource/aws_instance/example/user_data",
+				Message: "user data must be a string, got []interface {}",
+			}},
+		},
+		"invalid resources": {
+			`{
+			    "provider": {
+			        "aws": {}
+			    },
+			    "resource": {
+			        "aws_foo": {
+			            "example": {}
+			        },
+			        "aws_instance": {
+			            "example": {
+			                "instance_type": ["t2.micro"],
+			                "ebs_optimized": "yes",
+			                "private_dns": "example.internal",
+			                "koding_always_on": true,
+			                "count": 2,
+			                "ebs_block_device": [{
+			                    "volume_size": "${var.userInput_size}",
+			                    "size": 20
+			                }],
+			                "foo": "bar"
+			            }
+			        },
+			        "aws_key_pair": {
+			            "example": {
+			                "key_name": "example"
+			            }
+			        }
+			    }
+			}`,
+			nil,
+			stack.ValidationErrors{{
+				Pointer: "/resource/aws_foo",
+				Message: `unsupported resource type "aws_foo"`,
+			}, {
+				Pointer: "/resource/aws_instance/example/ebs_block_device/0",
+				Message: `missing required attribute "device_name"`,
+			}, {
+				Pointer: "/resource/aws_instance/example/ebs_block_device/0/size",
+				Message: `unknown attribute "size"`,
+			}, {
+				Pointer: "/resource/aws_instance/example/ebs_optimized",
+				Message: `attribute "ebs_optimized" must be a bool, got string`,
+			}, {
+				Pointer: "/resource/aws_instance/example/foo",
+				Message: `unknown attribute "foo"`,
+			}, {
+				Pointer: "/resource/aws_instance/example/instance_type",
+				Message: `attribute "instance_type" must be a string, got array`,
+			}, {
+				Pointer: "/resource/aws_instance/example/private_dns",
+				Message: `attribute "private_dns" is computed and can't be set`,
+			}, {
+				Pointer: "/resource/aws_key_pair/example",
+				Message: `missing required attribute "public_key"`,
+			}},
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := &stack.ValidateRequest{
+				Template:  []byte(cas.template),
+				Variables: cas.variables,
+			}
+
+			errs := provider.Validate(req)
+
+			sort.Sort(errs)
+
+			if !reflect.DeepEqual(errs, cas.errs) {
+				t.Fatalf("got %+v, want %+v", errs, cas.errs)
+			}
+		})
+	}
+}
@@ -52,6 +52,13 @@ type Stack struct {
 
 	// Stack is a jComputeStack value.
 	Stack *models.ComputeStack
+
+	// Outputs are Terraform outputs read after apply.
+	Outputs map[string]*models.StackOutput
+
+	// TemplateRevision is a number of the template revision
+	// the stack is built from.
+	TemplateRevision int
 }
 
 // Credential represents jCredential{Datas} value. Meta is of a provider-specific
@@ -0,0 +1,146 @@
+package stack
+
+import (
+	"errors"
+
+	"koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"koding/kites/kloud/contexthelper/request"
+	"koding/kites/kloud/contexthelper/session"
+	"koding/kites/kloud/terraformer"
+
+	"github.com/koding/kite"
+	"golang.org/x/net/context"
+)
+
+// StateRequest represents a request value for "stack.state.versions"
+// and "stack.state.rollback" kloud methods.
+type StateRequest struct {
+	StackID string `json:"stackId"`
+
+	// Version is an ID of the state version to roll back to,
+	// used by "stack.state.rollback" only.
+	Version string `json:"version,omitempty"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *StateRequest) Valid() error {
+	if req.StackID == "" {
+		return errors.New("stackId is not passed")
+	}
+
+	return nil
+}
+
+// StateVersionsResponse represents a response value from
+// "stack.state.versions" kloud method.
+type StateVersionsResponse struct {
+	StackID  string                      `json:"stackId"`
+	Versions []*terraformer.StateVersion `json:"versions"`
+}
+
+// StateVersions is a kite.Handler for "stack.state.versions" kite method.
+//
+// It lists previous versions of the Terraform state of the stack,
+// starting from the most recent one.
+func (k *Kloud) StateVersions(r *kite.Request) (interface{}, error) {
+	var req StateRequest
+
+	tf, tfReq, err := k.stateTerraformer(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	defer tf.Close()
+
+	versions, err := tf.StateVersions(tfReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateVersionsResponse{
+		StackID:  req.StackID,
+		Versions: versions,
+	}, nil
+}
+
+// StateRollback is a kite.Handler for "stack.state.rollback" kite method.
+//
+// It replaces the Terraform state of the stack with the given
+// version of it. The rollback itself creates a new version,
+// so it can be reverted as well.
+func (k *Kloud) StateRollback(r *kite.Request) (interface{}, error) {
+	var req StateRequest
+
+	tf, tfReq, err := k.stateTerraformer(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	defer tf.Close()
+
+	if req.Version == "" {
+		return nil, errors.New("version is not passed")
+	}
+
+	tfReq.Version = req.Version
+
+	if err := tf.StateRollback(tfReq); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// stateTerraformer reads the request and connects to terraformer,
+// ensuring the requester is the owner of the stack.
+func (k *Kloud) stateTerraformer(r *kite.Request, req *StateRequest) (*terraformer.Terraformer, *terraformer.StateRequest, error) {
+	if r.Args == nil {
+		return nil, nil, NewError(ErrNoArguments)
+	}
+
+	if err := r.Args.One().Unmarshal(req); err != nil {
+		return nil, nil, err
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, nil, err
+	}
+
+	computeStack, err := modelhelper.GetComputeStack(req.StackID)
+	if err != nil {
+		return nil, nil, models.ResError(err, "jComputeStack")
+	}
+
+	account, err := modelhelper.GetAccount(r.Username)
+	if err != nil {
+		return nil, nil, models.ResError(err, "jAccount")
+	}
+
+	if computeStack.OriginId != account.Id {
+		return nil, nil, NewError(ErrNotAuthorized)
+	}
+
+	ctx := request.NewContext(context.Background(), r)
+	if k.ContextCreator != nil {
+		ctx = k.ContextCreator(ctx)
+	}
+
+	sess, ok := session.FromContext(ctx)
+	if !ok || sess.Terraformer == nil {
+		return nil, nil, errors.New("internal server error (err: session context is not available)")
+	}
+
+	opts := sess.Terraformer
+
+	tf, err := terraformer.Connect(opts.Endpoint, opts.SecretKey, opts.Kite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tfReq := &terraformer.StateRequest{
+		// Keep in sync with the content ID used by the
+		// provider's apply, see provider/apply.go.
+		ContentID: computeStack.Group + "-" + req.StackID,
+	}
+
+	return tf, tfReq, nil
+}
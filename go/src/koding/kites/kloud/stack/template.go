@@ -0,0 +1,355 @@
+package stack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"koding/api"
+	"koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"koding/kites/kloud/utils/object"
+	"koding/remoteapi"
+	stacktemplate "koding/remoteapi/client/j_stack_template"
+
+	"github.com/koding/kite"
+)
+
+// TemplateRevision represents a single revision of a stack template.
+type TemplateRevision struct {
+	Revision  int       `json:"revision"`
+	Author    string    `json:"author,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Sum       string    `json:"sum"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current tells whether the template content
+	// is the same as the revision's one.
+	Current bool `json:"current,omitempty"`
+}
+
+// TemplateHistoryRequest represents a request value for
+// "template.history" kloud method.
+type TemplateHistoryRequest struct {
+	TemplateID string `json:"templateId"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *TemplateHistoryRequest) Valid() error {
+	if req.TemplateID == "" {
+		return errors.New("templateId is not passed")
+	}
+
+	return nil
+}
+
+// TemplateHistoryResponse represents a response value from
+// "template.history" kloud method.
+type TemplateHistoryResponse struct {
+	TemplateID string              `json:"templateId"`
+	Revisions  []*TemplateRevision `json:"revisions"` // newest first
+}
+
+// TemplateDiffRequest represents a request value for
+// "template.diff" kloud method.
+type TemplateDiffRequest struct {
+	TemplateID string `json:"templateId"`
+	From       int    `json:"from"`
+	To         int    `json:"to,omitempty"` // current content if 0
+}
+
+// Valid implements the stack.Validator interface.
+func (req *TemplateDiffRequest) Valid() error {
+	if req.TemplateID == "" {
+		return errors.New("templateId is not passed")
+	}
+
+	if req.From <= 0 || req.To < 0 {
+		return errors.New("invalid revision number")
+	}
+
+	return nil
+}
+
+// TemplateDiffResponse represents a response value from
+// "template.diff" kloud method.
+type TemplateDiffResponse struct {
+	TemplateID string           `json:"templateId"`
+	From       int              `json:"from"`
+	To         int              `json:"to"`
+	Changes    []*object.Change `json:"changes"`
+}
+
+// TemplateUpdateRequest represents a request value for
+// "template.update" kloud method.
+type TemplateUpdateRequest struct {
+	TemplateID string `json:"templateId"`
+	Template   []byte `json:"template"`             // JSON content of the template
+	RawContent string `json:"rawContent,omitempty"` // original content, e.g. YAML
+	Message    string `json:"message,omitempty"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *TemplateUpdateRequest) Valid() error {
+	if req.TemplateID == "" {
+		return errors.New("templateId is not passed")
+	}
+
+	if len(req.Template) == 0 {
+		return errors.New("empty template")
+	}
+
+	var raw json.RawMessage
+
+	if err := json.Unmarshal(req.Template, &raw); err != nil {
+		return fmt.Errorf("template is not a valid JSON: %s", err)
+	}
+
+	return nil
+}
+
+// TemplateRollbackRequest represents a request value for
+// "template.rollback" kloud method.
+type TemplateRollbackRequest struct {
+	TemplateID string `json:"templateId"`
+	Revision   int    `json:"revision"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *TemplateRollbackRequest) Valid() error {
+	if req.TemplateID == "" {
+		return errors.New("templateId is not passed")
+	}
+
+	if req.Revision <= 0 {
+		return errors.New("invalid revision number")
+	}
+
+	return nil
+}
+
+// TemplateRevisionResponse represents a response value from
+// "template.update" and "template.rollback" kloud methods.
+type TemplateRevisionResponse struct {
+	TemplateID string            `json:"templateId"`
+	Revision   *TemplateRevision `json:"revision"`
+}
+
+// TemplateHistory is a kite.Handler for "template.history" kite method.
+//
+// Revisions are recorded by jStackTemplate on each change
+// of the template content, the method does not write any.
+func (k *Kloud) TemplateHistory(r *kite.Request) (interface{}, error) {
+	var req TemplateHistoryRequest
+
+	if err := unmarshal(r, &req); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := getTemplate(req.TemplateID, r.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	revs, err := modelhelper.GetStackTemplateRevisions(req.TemplateID)
+	if err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateRevisionColl)
+	}
+
+	resp := &TemplateHistoryResponse{
+		TemplateID: req.TemplateID,
+		Revisions:  make([]*TemplateRevision, len(revs)),
+	}
+
+	for i, rev := range revs {
+		resp.Revisions[i] = toTemplateRevision(tmpl, rev)
+	}
+
+	return resp, nil
+}
+
+// TemplateDiff is a kite.Handler for "template.diff" kite method.
+//
+// It gives a structural difference between two revisions
+// of the template.
+func (k *Kloud) TemplateDiff(r *kite.Request) (interface{}, error) {
+	var req TemplateDiffRequest
+
+	if err := unmarshal(r, &req); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := getTemplate(req.TemplateID, r.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := modelhelper.GetStackTemplateRevision(req.TemplateID, req.From)
+	if err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateRevisionColl)
+	}
+
+	to := &models.StackTemplateRevision{
+		Revision: req.To,
+		Content:  tmpl.Template.Content,
+	}
+
+	if req.To != 0 {
+		if to, err = modelhelper.GetStackTemplateRevision(req.TemplateID, req.To); err != nil {
+			return nil, models.ResError(err, modelhelper.StackTemplateRevisionColl)
+		}
+	}
+
+	var v1, v2 interface{}
+
+	if err := json.Unmarshal([]byte(from.Content), &v1); err != nil {
+		return nil, fmt.Errorf("revision %d is not a valid JSON: %s", from.Revision, err)
+	}
+
+	if err := json.Unmarshal([]byte(to.Content), &v2); err != nil {
+		return nil, fmt.Errorf("revision %d is not a valid JSON: %s", to.Revision, err)
+	}
+
+	return &TemplateDiffResponse{
+		TemplateID: req.TemplateID,
+		From:       from.Revision,
+		To:         to.Revision,
+		Changes:    object.Diff(v1, v2),
+	}, nil
+}
+
+// TemplateUpdate is a kite.Handler for "template.update" kite method.
+//
+// It updates content of the template and records it
+// as a new revision.
+func (k *Kloud) TemplateUpdate(r *kite.Request) (interface{}, error) {
+	var req TemplateUpdateRequest
+
+	if err := unmarshal(r, &req); err != nil {
+		return nil, err
+	}
+
+	return k.updateTemplate(r, req.TemplateID, string(req.Template), req.RawContent, req.Message)
+}
+
+// TemplateRollback is a kite.Handler for "template.rollback" kite method.
+//
+// It restores content of the template from the given revision
+// and records it as a new revision.
+func (k *Kloud) TemplateRollback(r *kite.Request) (interface{}, error) {
+	var req TemplateRollbackRequest
+
+	if err := unmarshal(r, &req); err != nil {
+		return nil, err
+	}
+
+	if _, err := getTemplate(req.TemplateID, r.Username); err != nil {
+		return nil, err
+	}
+
+	rev, err := modelhelper.GetStackTemplateRevision(req.TemplateID, req.Revision)
+	if err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateRevisionColl)
+	}
+
+	if req.Message == "" {
+		req.Message = fmt.Sprintf("Rollback to revision %d", req.Revision)
+	}
+
+	return k.updateTemplate(r, req.TemplateID, rev.Content, rev.RawContent, req.Message)
+}
+
+func (k *Kloud) updateTemplate(r *kite.Request, id, content, rawContent, message string) (*TemplateRevisionResponse, error) {
+	tmpl, err := getTemplate(id, r.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawContent == "" {
+		rawContent = content
+	}
+
+	// Update is done with remote.api, which checks permissions,
+	// updates sum and config of the template and records
+	// the new content as a revision.
+	params := &stacktemplate.JStackTemplateUpdateParams{
+		ID: id,
+		Body: map[string]interface{}{
+			"template":   content,
+			"rawContent": rawContent,
+			"message":    message,
+		},
+	}
+
+	params.SetTimeout(k.RemoteClient.Timeout())
+
+	client := k.RemoteClient.New(&api.User{
+		Username: r.Username,
+		Team:     tmpl.Group,
+	})
+
+	resp, err := client.JStackTemplate.JStackTemplateUpdate(params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := remoteapi.Unmarshal(&resp.Payload.DefaultResponse, nil); err != nil {
+		return nil, err
+	}
+
+	if tmpl, err = modelhelper.GetStackTemplate(id); err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateColl)
+	}
+
+	// The revision is already recorded by jStackTemplate,
+	// unless it failed to do so - it is retried here then.
+	rev, err := modelhelper.SnapshotStackTemplate(tmpl, r.Username, message)
+	if err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateRevisionColl)
+	}
+
+	return &TemplateRevisionResponse{
+		TemplateID: id,
+		Revision:   toTemplateRevision(tmpl, rev),
+	}, nil
+}
+
+// getTemplate fetches the template and ensures the user
+// is allowed to access it.
+func getTemplate(id, username string) (*models.StackTemplate, error) {
+	tmpl, err := modelhelper.GetStackTemplate(id)
+	if err != nil {
+		return nil, models.ResError(err, modelhelper.StackTemplateColl)
+	}
+
+	if err := modelhelper.HasTemplateAccess(tmpl, username); err != nil {
+		return nil, NewError(ErrNotAuthorized)
+	}
+
+	return tmpl, nil
+}
+
+func toTemplateRevision(tmpl *models.StackTemplate, rev *models.StackTemplateRevision) *TemplateRevision {
+	return &TemplateRevision{
+		Revision:  rev.Revision,
+		Author:    rev.Author,
+		Message:   rev.Message,
+		Sum:       rev.Sum,
+		CreatedAt: rev.CreatedAt,
+		Current:   rev.Sum == tmpl.Template.Sum,
+	}
+}
+
+func unmarshal(r *kite.Request, v Validator) error {
+	if r.Args == nil {
+		return NewError(ErrNoArguments)
+	}
+
+	if err := r.Args.One().Unmarshal(v); err != nil {
+		return err
+	}
+
+	return v.Valid()
+}
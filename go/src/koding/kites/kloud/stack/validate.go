@@ -0,0 +1,183 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"koding/kites/kloud/credential"
+
+	"github.com/koding/kite"
+)
+
+// ValidateRequest represents a request value for "stack.validate"
+// kloud method.
+type ValidateRequest struct {
+	// Template is a JSON-encoded stack template.
+	Template []byte `json:"template"`
+
+	// Credentials maps provider names to credential identifiers
+	// that are going to be used with the template.
+	//
+	// If nil, credential references are not checked.
+	Credentials map[string][]string `json:"credentials,omitempty"`
+
+	// Variables holds custom variables of the template, keyed
+	// by their names without the "custom_" prefix.
+	//
+	// If nil, custom variables are not checked.
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// Team is a name of the team the template belongs to.
+	Team string `json:"team,omitempty"`
+}
+
+// Valid implements the stack.Validator interface.
+func (req *ValidateRequest) Valid() error {
+	if len(req.Template) == 0 {
+		return errors.New("empty template")
+	}
+
+	return nil
+}
+
+// ValidationError describes a single error found in a stack template.
+type ValidationError struct {
+	// Pointer is a JSON Pointer (RFC 6901) to the template
+	// value the error relates to.
+	Pointer string `json:"pointer"`
+
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+// Error implements the built-in error interface.
+func (e *ValidationError) Error() string {
+	if e.Pointer == "" {
+		return e.Message
+	}
+
+	return e.Pointer + ": " + e.Message
+}
+
+// ValidationErrors is a list of validation errors,
+// sorted by their pointers.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Len() int      { return len(e) }
+func (e ValidationErrors) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e ValidationErrors) Less(i, j int) bool {
+	if e[i].Pointer != e[j].Pointer {
+		return e[i].Pointer < e[j].Pointer
+	}
+
+	return e[i].Message < e[j].Message
+}
+
+// ValidateResponse represents a response value from "stack.validate"
+// kloud method.
+type ValidateResponse struct {
+	Valid  bool             `json:"valid"`
+	Errors ValidationErrors `json:"errors,omitempty"`
+}
+
+// JSONPointer builds a JSON Pointer (RFC 6901) out of the given
+// reference tokens.
+func JSONPointer(tokens ...string) string {
+	var buf []string
+
+	for _, tok := range tokens {
+		tok = strings.Replace(tok, "~", "~0", -1)
+		tok = strings.Replace(tok, "/", "~1", -1)
+
+		buf = append(buf, "/"+tok)
+	}
+
+	return strings.Join(buf, "")
+}
+
+// StackValidate is a kite.Handler for "stack.validate" kite method.
+//
+// It validates the given stack template, without building it.
+func (k *Kloud) StackValidate(r *kite.Request) (interface{}, error) {
+	var req ValidateRequest
+
+	if err := r.Args.One().Unmarshal(&req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, err
+	}
+
+	if k.ValidateFunc == nil {
+		return nil, errors.New("template validation is not supported")
+	}
+
+	errs := k.ValidateFunc(&req)
+
+	if req.Credentials != nil {
+		errs = append(errs, k.validateCredentials(r.Username, &req)...)
+	}
+
+	sort.Sort(errs)
+
+	return &ValidateResponse{
+		Valid:  len(errs) == 0,
+		Errors: errs,
+	}, nil
+}
+
+// validateCredentials checks whether all providers used by the template
+// have credentials and whether the credentials are accessible by the user.
+func (k *Kloud) validateCredentials(username string, req *ValidateRequest) (errs ValidationErrors) {
+	providers, err := ReadProviders(req.Template)
+	if err != nil {
+		// Invalid template is already reported by ValidateFunc.
+		return nil
+	}
+
+	for _, provider := range providers {
+		if len(req.Credentials[provider]) == 0 {
+			errs = append(errs, &ValidationError{
+				Pointer: JSONPointer("provider", provider),
+				Message: fmt.Sprintf("no credential for %q provider", provider),
+			})
+		}
+	}
+
+	if k.CredClient == nil {
+		return errs
+	}
+
+	for provider, idents := range req.Credentials {
+		for _, ident := range idents {
+			creds, err := k.CredClient.Creds(&credential.Filter{
+				Ident:    ident,
+				Username: username,
+				Teamname: req.Team,
+			})
+
+			var msg string
+
+			switch {
+			case err != nil:
+				msg = fmt.Sprintf("unable to read %q credential: %s", ident, err)
+			case len(creds) == 0:
+				msg = fmt.Sprintf("credential %q does not exist or is not accessible", ident)
+			case creds[0].Provider != provider:
+				msg = fmt.Sprintf("credential %q is of %q provider, not %q", ident, creds[0].Provider, provider)
+			default:
+				continue
+			}
+
+			errs = append(errs, &ValidationError{
+				Pointer: JSONPointer("provider", provider),
+				Message: msg,
+			})
+		}
+	}
+
+	return errs
+}
@@ -19,6 +19,27 @@ type TerraformRequest struct {
 	TraceID   string
 }
 
+// StateRequest is a helper struct for terraformer kite requests,
+// which operate on versions of the state.
+//
+// Copied from kites/terraformer/terraformer.go to avoid dependency
+// on the terraformer package.
+type StateRequest struct {
+	ContentID string
+	TraceID   string
+	Version   string
+}
+
+// StateVersion describes a single version of the state.
+//
+// Copied from kites/terraformer/storage/secure.go to avoid dependency
+// on the terraformer package.
+type StateVersion struct {
+	ID      string    `json:"id"`
+	Size    int       `json:"size"`
+	Created time.Time `json:"created"`
+}
+
 // Terraformer represents a remote terraformer instance.
 type Terraformer struct {
 	Client *kite.Client
@@ -93,6 +114,25 @@ func (t *Terraformer) Destroy(req *TerraformRequest) (*terraform.State, error) {
 	return state, nil
 }
 
+func (t *Terraformer) StateVersions(req *StateRequest) ([]*StateVersion, error) {
+	resp, err := t.Client.Tell("state.versions", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*StateVersion
+	if err := resp.Unmarshal(&versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (t *Terraformer) StateRollback(req *StateRequest) error {
+	_, err := t.Client.Tell("state.rollback", req)
+	return err
+}
+
 // Ping checks if the given terraformer response with "pong" to the "ping" we send.
 // A nil error means a successful pong result.
 func (t *Terraformer) Ping() error {
@@ -0,0 +1,110 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeType describes a kind of a change.
+type ChangeType string
+
+// Change types.
+const (
+	ChangeAdd    ChangeType = "add"    // value was added
+	ChangeRemove ChangeType = "remove" // value was removed
+	ChangeUpdate ChangeType = "update" // value was changed
+)
+
+// Change represents a single difference between two values.
+type Change struct {
+	Path string      `json:"path"`          // dot-separated path of the value
+	Type ChangeType  `json:"type"`          // kind of the change
+	Old  interface{} `json:"old,omitempty"` // previous value, unless added
+	New  interface{} `json:"new,omitempty"` // current value, unless removed
+}
+
+// String implements the fmt.Stringer interface.
+func (c *Change) String() string {
+	switch c.Type {
+	case ChangeAdd:
+		return fmt.Sprintf("+ %s: %v", c.Path, c.New)
+	case ChangeRemove:
+		return fmt.Sprintf("- %s: %v", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Old, c.New)
+	}
+}
+
+// Diff gives a structural difference between v1 and v2, which are
+// expected to be JSON-decoded values - maps, slices and scalars.
+//
+// Objects are compared key by key and arrays are compared element
+// by element. Changes are sorted by their paths.
+func Diff(v1, v2 interface{}) []*Change {
+	var changes []*Change
+
+	diff("", v1, v2, &changes)
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+func diff(path string, v1, v2 interface{}, changes *[]*Change) {
+	switch m1 := v1.(type) {
+	case map[string]interface{}:
+		m2, ok := v2.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		for k, v := range m1 {
+			if w, ok := m2[k]; ok {
+				diff(join(path, k), v, w, changes)
+			} else {
+				*changes = append(*changes, &Change{Path: join(path, k), Type: ChangeRemove, Old: v})
+			}
+		}
+
+		for k, w := range m2 {
+			if _, ok := m1[k]; !ok {
+				*changes = append(*changes, &Change{Path: join(path, k), Type: ChangeAdd, New: w})
+			}
+		}
+
+		return
+	case []interface{}:
+		s2, ok := v2.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(m1) || i < len(s2); i++ {
+			p := path + "[" + strconv.Itoa(i) + "]"
+
+			switch {
+			case i >= len(s2):
+				*changes = append(*changes, &Change{Path: p, Type: ChangeRemove, Old: m1[i]})
+			case i >= len(m1):
+				*changes = append(*changes, &Change{Path: p, Type: ChangeAdd, New: s2[i]})
+			default:
+				diff(p, m1[i], s2[i], changes)
+			}
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(v1, v2) {
+		*changes = append(*changes, &Change{Path: path, Type: ChangeUpdate, Old: v1, New: v2})
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
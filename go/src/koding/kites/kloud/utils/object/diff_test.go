@@ -0,0 +1,75 @@
+package object_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"koding/kites/kloud/utils/object"
+)
+
+func TestDiff(t *testing.T) {
+	cases := map[string]struct {
+		v1, v2 string
+		want   []*object.Change
+	}{
+		"equal": {
+			`{"resource": {"aws_instance": {"vm": {"ami": "ami-1"}}}}`,
+			`{"resource": {"aws_instance": {"vm": {"ami": "ami-1"}}}}`,
+			nil,
+		},
+		"nested update": {
+			`{"resource": {"aws_instance": {"vm": {"ami": "ami-1", "count": 1}}}}`,
+			`{"resource": {"aws_instance": {"vm": {"ami": "ami-2", "count": 1}}}}`,
+			[]*object.Change{
+				{Path: "resource.aws_instance.vm.ami", Type: object.ChangeUpdate, Old: "ami-1", New: "ami-2"},
+			},
+		},
+		"add and remove keys": {
+			`{"provider": {"aws": {}}, "variable": {"a": 1}}`,
+			`{"provider": {"aws": {"region": "us-east-1"}}, "output": {"ip": "x"}}`,
+			[]*object.Change{
+				{Path: "output", Type: object.ChangeAdd, New: map[string]interface{}{"ip": "x"}},
+				{Path: "provider.aws.region", Type: object.ChangeAdd, New: "us-east-1"},
+				{Path: "variable", Type: object.ChangeRemove, Old: map[string]interface{}{"a": float64(1)}},
+			},
+		},
+		"arrays": {
+			`{"tags": ["a", "b", "c"]}`,
+			`{"tags": ["a", "x"]}`,
+			[]*object.Change{
+				{Path: "tags[1]", Type: object.ChangeUpdate, Old: "b", New: "x"},
+				{Path: "tags[2]", Type: object.ChangeRemove, Old: "c"},
+			},
+		},
+		"type change": {
+			`{"user_data": "echo"}`,
+			`{"user_data": ["echo"]}`,
+			[]*object.Change{
+				{Path: "user_data", Type: object.ChangeUpdate, Old: "echo", New: []interface{}{"echo"}},
+			},
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			var v1, v2 interface{}
+
+			if err := json.Unmarshal([]byte(cas.v1), &v1); err != nil {
+				t.Fatalf("Unmarshal()=%s", err)
+			}
+
+			if err := json.Unmarshal([]byte(cas.v2), &v2); err != nil {
+				t.Fatalf("Unmarshal()=%s", err)
+			}
+
+			got := object.Diff(v1, v2)
+
+			if !reflect.DeepEqual(got, cas.want) {
+				t.Fatalf("got %v, want %v", got, cas.want)
+			}
+		})
+	}
+}
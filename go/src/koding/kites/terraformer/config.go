@@ -1,5 +1,11 @@
 package terraformer
 
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
 // Config defines the configuration.
 type Config struct {
 	// Port
@@ -18,6 +24,9 @@ type Config struct {
 	// AWS secret and key
 	AWS AWS
 
+	// Storage configures how plans and states are kept in remote store
+	Storage Storage
+
 	// LocalStorePath stores base path for local store
 	LocalStorePath string `required:"true"`
 
@@ -33,3 +42,35 @@ type AWS struct {
 	Secret string
 	Bucket string
 }
+
+// Storage holds config variables for remote store
+type Storage struct {
+	// EncryptionKey is a base64-encoded 32-byte key used for encrypting
+	// plans and states. If empty, they are stored unencrypted.
+	EncryptionKey string
+
+	// MigratePlaintext allows reading plans and states written before
+	// encryption was enabled, they are rewritten encrypted when read.
+	// Otherwise reading them fails.
+	MigratePlaintext bool
+
+	// Versions is a number of versions kept for each file, 10 by default.
+	// If negative, versioning is disabled.
+	Versions int
+
+	// LockTTL is a time after which an abandoned lock expires, 1h by default.
+	LockTTL time.Duration
+}
+
+func (s *Storage) key() ([]byte, error) {
+	if s.EncryptionKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(s.EncryptionKey)
+	if err != nil {
+		return nil, errors.New("invalid storage encryption key: " + err.Error())
+	}
+
+	return key, nil
+}
@@ -33,6 +33,8 @@ func NewKite(t *Terraformer, conf *Config) (*kite.Kite, error) {
 	k.HandleFunc(wrapHandler(t.Metrics, "apply", t.Apply))
 	k.HandleFunc(wrapHandler(t.Metrics, "destroy", t.Destroy))
 	k.HandleFunc(wrapHandler(t.Metrics, "plan", t.Plan))
+	k.HandleFunc(wrapHandler(t.Metrics, "state.versions", t.StateVersions))
+	k.HandleFunc(wrapHandler(t.Metrics, "state.rollback", t.StateRollback))
 
 	// artifact handling
 	k.HandleHTTPFunc("/healthCheck", artifact.HealthCheckHandler(Name))
@@ -20,7 +20,12 @@ func (c *KodingContext) Apply(content io.Reader, destroy bool) (*terraform.State
 	}
 	cmd.Destroy = destroy
 
-	paths, err := c.run(cmd, content, destroy, c.populateApplyArgs)
+	op := "apply"
+	if destroy {
+		op = "destroy"
+	}
+
+	paths, err := c.run(op, cmd, content, destroy, c.populateApplyArgs)
 	if err != nil {
 		return nil, err
 	}
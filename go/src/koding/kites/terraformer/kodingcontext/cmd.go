@@ -5,12 +5,26 @@ import (
 	"io"
 	"path"
 
+	"koding/kites/terraformer/storage"
+
 	"github.com/mitchellh/cli"
+	uuid "github.com/satori/go.uuid"
 )
 
 type ArgsFunc func(paths *paths, destroy bool) []string
 
-func (c *KodingContext) run(cmd cli.Command, content io.Reader, destroy bool, argsFunc ArgsFunc) (*paths, error) {
+func (c *KodingContext) run(op string, cmd cli.Command, content io.Reader, destroy bool, argsFunc ArgsFunc) (_ *paths, err error) {
+	unlock, err := c.lock(op)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if e := unlock(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
 	// copy all contents from remote to local for operating
 	if err := c.RemoteStorage.Clone(c.ContentID, c.LocalStorage); err != nil {
 		return nil, err
@@ -48,6 +62,34 @@ func (c *KodingContext) run(cmd cli.Command, content io.Reader, destroy bool, ar
 	return paths, nil
 }
 
+// lock ensures no other terraformer operates on the same content,
+// if remote storage supports locking. The returned func releases
+// the lock.
+func (c *KodingContext) lock(op string) (func() error, error) {
+	locker, ok := c.RemoteStorage.(storage.Locker)
+	if !ok {
+		return func() error { return nil }, nil
+	}
+
+	info := &storage.LockInfo{
+		ID:        uuid.NewV4().String(),
+		Operation: op,
+		TraceID:   c.TraceID,
+	}
+
+	if err := locker.Lock(c.ContentID, info); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		err := locker.Unlock(c.ContentID, info)
+		if err != nil {
+			c.log.Error("unable to unlock %q: %s", c.ContentID, err)
+		}
+		return err
+	}, nil
+}
+
 type paths struct {
 	contentPath      string
 	statePath        string
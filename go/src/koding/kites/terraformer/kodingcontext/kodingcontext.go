@@ -22,6 +22,7 @@ func (c *context) newKodingContext(sc <-chan struct{}, contentID, traceID string
 			log:           c.log,
 		},
 		ContentID:    contentID,
+		TraceID:      traceID,
 		Buffer:       errorBuf,
 		ui:           NewUI(errorBuf, traceID),
 		ShutdownChan: sc,
@@ -41,6 +42,7 @@ type KodingContext struct {
 	Variables    map[string]interface{}
 	ShutdownChan <-chan struct{}
 	ContentID    string
+	TraceID      string
 
 	debug bool
 }
@@ -18,7 +18,7 @@ func (c *KodingContext) Plan(content io.Reader, destroy bool) (*terraform.Plan,
 		},
 	}
 
-	paths, err := c.run(cmd, content, destroy, c.populatePlanArgs)
+	paths, err := c.run("plan", cmd, content, destroy, c.populatePlanArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,52 @@
+package kodingcontext
+
+import (
+	"errors"
+	"path"
+
+	"koding/kites/terraformer/storage"
+)
+
+// ErrNoVersions is returned when remote storage does not
+// keep versions of the state files.
+var ErrNoVersions = errors.New("remote storage does not keep state versions")
+
+// StateVersions gives versions of the content's state file,
+// starting from the most recent one.
+func (c *KodingContext) StateVersions() ([]*storage.Version, error) {
+	v, ok := c.RemoteStorage.(storage.Versioner)
+	if !ok {
+		return nil, ErrNoVersions
+	}
+
+	return v.Versions(c.remoteStatePath())
+}
+
+// RollbackState replaces the content's state file with the given
+// version of it.
+//
+// The content is locked during the rollback, so it does not
+// interfere with plan or apply operations.
+func (c *KodingContext) RollbackState(id string) (err error) {
+	v, ok := c.RemoteStorage.(storage.Versioner)
+	if !ok {
+		return ErrNoVersions
+	}
+
+	unlock, err := c.lock("rollback")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := unlock(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	return v.Rollback(c.remoteStatePath(), id)
+}
+
+func (c *KodingContext) remoteStatePath() string {
+	return path.Join(c.ContentID, stateFileName+terraformStateFileExt)
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// envelopeVersion is a version of the envelope format.
+const envelopeVersion = 1
+
+// KeySize is a required size of the encryption key.
+const KeySize = 32
+
+// ErrNotEncrypted is returned when reading unencrypted content
+// while encryption is enabled.
+var ErrNotEncrypted = errors.New("content is not encrypted")
+
+// envelope represents a content encrypted with envelope encryption.
+//
+// Each content is encrypted with a random data key, which is
+// then encrypted with a master key and stored alongside the
+// content. Both are encrypted with AES-256-GCM.
+type envelope struct {
+	Envelope int    `json:"kodingEnvelope"`
+	KeyID    string `json:"keyId"` // identifies the master key
+	Key      []byte `json:"key"`   // data key encrypted with master key
+	Data     []byte `json:"data"`  // content encrypted with data key
+}
+
+// keyID gives an identifier of the given master key, that is
+// used to detect decryption attempts with a wrong key.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encrypt encrypts the given content with a new data key,
+// and wraps the data key with the master key.
+func encrypt(key, content []byte) ([]byte, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	data, err := seal(dataKey, content)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(key, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&envelope{
+		Envelope: envelopeVersion,
+		KeyID:    keyID(key),
+		Key:      wrapped,
+		Data:     data,
+	})
+}
+
+// decrypt decrypts the given content, that was encrypted
+// by encrypt.
+//
+// If no key is given, unencrypted content is returned as is.
+// Otherwise ErrNotEncrypted is returned for it, as it may
+// be tampered.
+func decrypt(key, content []byte) ([]byte, error) {
+	var env envelope
+
+	if json.Unmarshal(content, &env) != nil || env.Envelope == 0 {
+		if len(key) != 0 {
+			return nil, ErrNotEncrypted
+		}
+
+		return content, nil
+	}
+
+	if env.Envelope != envelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version: %d", env.Envelope)
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("content is encrypted, but no encryption key is configured")
+	}
+
+	if id := keyID(key); env.KeyID != id {
+		return nil, fmt.Errorf("content is encrypted with %q key, configured key is %q", env.KeyID, id)
+	}
+
+	dataKey, err := open(key, env.Key)
+	if err != nil {
+		return nil, errors.New("unable to decrypt data key: " + err.Error())
+	}
+
+	content, err = open(dataKey, env.Data)
+	if err != nil {
+		return nil, errors.New("unable to decrypt content: " + err.Error())
+	}
+
+	return content, nil
+}
@@ -1,16 +1,27 @@
 package storage
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/koding/logging"
 )
 
-var _ Interface = (*File)(nil)
+var (
+	_ Interface         = (*File)(nil)
+	_ ConditionalWriter = (*File)(nil)
+)
+
+// condMu serializes conditional reads and writes of File storages,
+// so the tag of a file can't change between comparing and writing it.
+var condMu sync.Mutex
 
 // File provides file based storage
 type File struct {
@@ -117,6 +128,54 @@ func (f *File) Read(filePath string) (io.Reader, error) {
 	return r, nil
 }
 
+// ReadTag implements the ConditionalWriter interface.
+//
+// The tag is a SHA-1 checksum of the file content.
+func (f *File) ReadTag(filePath string) (io.Reader, string, error) {
+	condMu.Lock()
+	defer condMu.Unlock()
+
+	p, tag, err := f.readTag(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return bytes.NewReader(p), tag, nil
+}
+
+// WriteIf implements the ConditionalWriter interface.
+func (f *File) WriteIf(filePath string, file io.Reader, tag string) error {
+	condMu.Lock()
+	defer condMu.Unlock()
+
+	_, cur, err := f.readTag(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if cur != tag {
+		return ErrPrecondition
+	}
+
+	return f.Write(filePath, file)
+}
+
+func (f *File) readTag(filePath string) ([]byte, string, error) {
+	fullPath, err := f.fullPath(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := ioutil.ReadFile(fullPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sum := sha1.Sum(p)
+
+	return p, hex.EncodeToString(sum[:]), nil
+}
+
 // Clone clones underlying files to the target storage
 func (f *File) Clone(filePath string, target Interface) error {
 	f.log.Debug("cloning %q", filePath)
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Locker is implemented by storages, which support taking
+// exclusive locks on contents.
+type Locker interface {
+	// Lock takes a lock on the given contentID.
+	//
+	// If the content is already locked by other
+	// holder, *LockedError is returned.
+	Lock(contentID string, info *LockInfo) error
+
+	// Unlock releases a lock on the given contentID,
+	// that was previously taken with the same info.
+	Unlock(contentID string, info *LockInfo) error
+}
+
+// LockInfo describes a holder of a lock.
+type LockInfo struct {
+	ID        string    `json:"id"`                // unique ID of the lock holder
+	Operation string    `json:"operation"`         // operation, e.g. plan or apply
+	TraceID   string    `json:"traceId,omitempty"` // trace ID of the request, if any
+	Created   time.Time `json:"created"`
+	Expires   time.Time `json:"expires"`
+}
+
+// LockedError is returned when a content is already
+// locked by other holder.
+type LockedError struct {
+	ContentID string
+	Info      *LockInfo
+}
+
+// Error implements the built-in error interface.
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("content %q is locked by %s operation (id=%s, traceID=%s) since %s",
+		e.ContentID, e.Info.Operation, e.Info.ID, e.Info.TraceID, e.Info.Created.Format(time.RFC3339))
+}
+
+// IsLocked returns true when the err is *LockedError.
+func IsLocked(err error) bool {
+	_, ok := err.(*LockedError)
+	return ok
+}
@@ -4,15 +4,21 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/koding/logging"
 )
 
-var _ Interface = (*S3)(nil)
+var (
+	_ Interface         = (*S3)(nil)
+	_ ConditionalWriter = (*S3)(nil)
+)
 
 // S3 provides Storage functionality backed by S3.
 type S3 struct {
@@ -52,6 +58,47 @@ func (s *S3) BasePath() (string, error) {
 
 // Write writes to a s3 bucket.
 func (s *S3) Write(path string, file io.Reader) error {
+	params, err := s.putObjectInput(path, file)
+	if err != nil {
+		return err
+	}
+	_, err = s.s3.PutObject(params)
+	// TODO(rjeczalik): make the write blocking with s3.WaitUntilObjectExists?
+	return err
+}
+
+// WriteIf implements the ConditionalWriter interface.
+//
+// The object is put with If-None-Match header when the tag is empty,
+// and with If-Match header otherwise.
+func (s *S3) WriteIf(path string, file io.Reader, tag string) error {
+	params, err := s.putObjectInput(path, file)
+	if err != nil {
+		return err
+	}
+
+	req, _ := s.s3.PutObjectRequest(params)
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		if tag == "" {
+			r.HTTPRequest.Header.Set("If-None-Match", "*")
+		} else {
+			r.HTTPRequest.Header.Set("If-Match", tag)
+		}
+	})
+
+	err = req.Send()
+	if e, ok := err.(awserr.RequestFailure); ok {
+		switch e.StatusCode() {
+		case http.StatusPreconditionFailed, http.StatusConflict:
+			// Conflict is returned when other conditional
+			// write to the same object is in progress.
+			return ErrPrecondition
+		}
+	}
+	return err
+}
+
+func (s *S3) putObjectInput(path string, file io.Reader) (*s3.PutObjectInput, error) {
 	params := &s3.PutObjectInput{
 		ACL:         aws.String(s3.BucketCannedACLPrivate),
 		Bucket:      aws.String(s.bucketName),
@@ -64,14 +111,12 @@ func (s *S3) Write(path string, file io.Reader) error {
 		// by the AWS streaming api - read it in-memory instead.
 		content, err := ioutil.ReadAll(file)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		body = bytes.NewReader(content)
 	}
 	params.Body = body
-	_, err := s.s3.PutObject(params)
-	// TODO(rjeczalik): make the write blocking with s3.WaitUntilObjectExists?
-	return err
+	return params, nil
 }
 
 // Remove removes a file from a bucket.
@@ -99,6 +144,22 @@ func (s *S3) Read(path string) (io.Reader, error) {
 	return resp.Body, nil
 }
 
+// ReadTag implements the ConditionalWriter interface.
+//
+// The tag is an ETag of the object. Caller is responsible
+// for closing the request body.
+func (s *S3) ReadTag(path string) (io.Reader, string, error) {
+	params := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(path),
+	}
+	resp, err := s.s3.GetObject(params)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, aws.StringValue(resp.ETag), nil
+}
+
 // Clone clones the contents of a bucket to target storage
 func (s *S3) Clone(path string, target Interface) error {
 	params := &s3.ListObjectsInput{
@@ -0,0 +1,568 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/koding/logging"
+)
+
+const (
+	// DefaultVersions is a default number of versions kept for each state file.
+	DefaultVersions = 10
+
+	// DefaultLockTTL is a default time after which an abandoned
+	// lock expires.
+	DefaultLockTTL = 1 * time.Hour
+
+	versionsDir  = "_versions"
+	locksDir     = "_locks"
+	lockAttempts = 3
+)
+
+var (
+	_ Interface = (*Secure)(nil)
+	_ Locker    = (*Secure)(nil)
+	_ Versioner = (*Secure)(nil)
+)
+
+var (
+	// ErrNoVersion is returned when requested version does not exist.
+	ErrNoVersion = errors.New("version does not exist")
+
+	// ErrNoConditionalWrite is returned when locking is requested, but
+	// the underlying storage does not implement ConditionalWriter.
+	ErrNoConditionalWrite = errors.New("storage does not support conditional writes")
+)
+
+// Versioner is implemented by storages, which keep previous
+// versions of files.
+type Versioner interface {
+	// Versions gives all versions of the given file, starting
+	// from the most recent one.
+	Versions(filePath string) ([]*Version, error)
+
+	// ReadVersion reads the given version of a file.
+	ReadVersion(filePath, id string) (io.Reader, error)
+
+	// Rollback replaces the file with the given version of it.
+	Rollback(filePath, id string) error
+}
+
+// SecureOptions configures Secure storage.
+type SecureOptions struct {
+	// Key is a master key used for encrypting the content.
+	// It must be KeySize long.
+	//
+	// If nil, content is stored unencrypted.
+	Key []byte
+
+	// Migrate allows reading content that was written before
+	// encryption was enabled. Such content is rewritten
+	// encrypted when it is read.
+	//
+	// If false, reading unencrypted content fails with
+	// ErrNotEncrypted.
+	Migrate bool
+
+	// Versions is a number of versions kept for each state file.
+	//
+	// If 0, DefaultVersions is used. If negative,
+	// versioning is disabled.
+	Versions int
+
+	// LockTTL is a time after which a lock is considered
+	// abandoned and can be taken by other holder.
+	//
+	// If 0, DefaultLockTTL is used.
+	LockTTL time.Duration
+
+	Log logging.Logger
+}
+
+// Version describes a single version of a file.
+type Version struct {
+	ID      string    `json:"id"`
+	Size    int       `json:"size"`
+	Sum     string    `json:"sum,omitempty"` // sha1 of the decrypted content
+	Created time.Time `json:"created"`
+}
+
+// Secure is a storage decorator, which encrypts the content
+// written to the underlying storage, keeps previous
+// versions of each state file and supports locking contents.
+//
+// Versions and locks are stored in the underlying storage,
+// under _versions and _locks directories respectively.
+type Secure struct {
+	Interface
+
+	key      []byte
+	migrate  bool
+	versions int
+	lockTTL  time.Duration
+	log      logging.Logger
+
+	mu sync.Mutex // protects versions index and locks
+}
+
+// NewSecure creates new Secure storage on top of the given one.
+func NewSecure(s Interface, opts *SecureOptions) (*Secure, error) {
+	if len(opts.Key) != 0 && len(opts.Key) != KeySize {
+		return nil, fmt.Errorf("invalid encryption key size: want %d, got %d", KeySize, len(opts.Key))
+	}
+
+	sec := &Secure{
+		Interface: s,
+		key:       opts.Key,
+		migrate:   opts.Migrate,
+		versions:  opts.Versions,
+		lockTTL:   opts.LockTTL,
+		log:       opts.Log,
+	}
+
+	if sec.versions == 0 {
+		sec.versions = DefaultVersions
+	}
+
+	if sec.lockTTL == 0 {
+		sec.lockTTL = DefaultLockTTL
+	}
+
+	return sec, nil
+}
+
+// Write encrypts the file and writes it to the underlying storage.
+//
+// A new version is created for state files, unless the content
+// is the same as the content of the latest version.
+func (s *Secure) Write(filePath string, file io.Reader) error {
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.encrypt(content)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Interface.Write(filePath, bytes.NewReader(p)); err != nil {
+		return err
+	}
+
+	if s.versions > 0 && isStateFile(filePath) {
+		if err := s.addVersion(filePath, p, content); err != nil {
+			// Failing to store a version is not fatal for the
+			// write, the current version was already written.
+			s.log.Warning("unable to store version of %q: %s", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// Read reads the file from the underlying storage and decrypts it.
+func (s *Secure) Read(filePath string) (io.Reader, error) {
+	p, err := s.read(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(p), nil
+}
+
+// Clone clones the decrypted files to the target storage.
+func (s *Secure) Clone(filePath string, target Interface) error {
+	return s.Interface.Clone(filePath, &decryptTarget{
+		Interface: target,
+		s:         s,
+	})
+}
+
+// Versions gives all versions of the given file, starting
+// from the most recent one.
+func (s *Secure) Versions(filePath string) ([]*Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.readIndex(filePath)
+}
+
+// ReadVersion reads the given version of a file.
+func (s *Secure) ReadVersion(filePath, id string) (io.Reader, error) {
+	versions, err := s.Versions(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if findVersion(versions, id) == -1 {
+		return nil, ErrNoVersion
+	}
+
+	p, err := s.read(versionPath(filePath, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(p), nil
+}
+
+// Rollback replaces the file with the given version of it.
+//
+// The rollback itself creates a new version, so it can be reverted
+// as well.
+func (s *Secure) Rollback(filePath, id string) error {
+	r, err := s.ReadVersion(filePath, id)
+	if err != nil {
+		return err
+	}
+
+	return s.Write(filePath, r)
+}
+
+// Lock implements the Locker interface.
+//
+// The lock is taken with a conditional write, which fails when
+// other holder took or released the lock in the meantime.
+// Expired locks are taken over in the same way.
+func (s *Secure) Lock(contentID string, info *LockInfo) error {
+	cw, ok := s.Interface.(ConditionalWriter)
+	if !ok {
+		return ErrNoConditionalWrite
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < lockAttempts; i++ {
+		cur, tag, err := s.readLock(cw, contentID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		if cur.ID != "" && cur.ID != info.ID {
+			if now.Before(cur.Expires) {
+				return &LockedError{ContentID: contentID, Info: cur}
+			}
+
+			s.log.Warning("taking over expired lock of %q: %+v", contentID, cur)
+		}
+
+		info.Created = now
+		info.Expires = now.Add(s.lockTTL)
+
+		p, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+
+		switch err := cw.WriteIf(lockPath(contentID), bytes.NewReader(p), tag); err {
+		case nil:
+			return nil
+		case ErrPrecondition:
+			// The lock was modified concurrently, read
+			// it again to find out who holds it now.
+			continue
+		default:
+			return err
+		}
+	}
+
+	return fmt.Errorf("unable to lock %q: lock is modified concurrently", contentID)
+}
+
+// Unlock implements the Locker interface.
+//
+// The lock is released by conditionally overwriting it
+// with an empty one.
+func (s *Secure) Unlock(contentID string, info *LockInfo) error {
+	cw, ok := s.Interface.(ConditionalWriter)
+	if !ok {
+		return ErrNoConditionalWrite
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, tag, err := s.readLock(cw, contentID)
+	if err != nil {
+		return err
+	}
+
+	if cur.ID == "" {
+		return nil
+	}
+
+	if cur.ID != info.ID {
+		return &LockedError{ContentID: contentID, Info: cur}
+	}
+
+	p, err := json.Marshal(&LockInfo{})
+	if err != nil {
+		return err
+	}
+
+	err = cw.WriteIf(lockPath(contentID), bytes.NewReader(p), tag)
+	if err == ErrPrecondition {
+		return fmt.Errorf("unable to unlock %q: lock was taken over by other holder", contentID)
+	}
+
+	return err
+}
+
+// readLock reads the current lock of the given content together with
+// its tag. Empty lock is returned when the content is not locked.
+func (s *Secure) readLock(cw ConditionalWriter, contentID string) (*LockInfo, string, error) {
+	r, tag, err := cw.ReadTag(lockPath(contentID))
+	if isNotExist(err) {
+		return &LockInfo{}, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := ioutil.ReadAll(r)
+
+	if c, ok := r.(io.Closer); ok {
+		err = nonil(err, c.Close())
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	var info LockInfo
+	if err := json.Unmarshal(p, &info); err != nil {
+		return nil, "", fmt.Errorf("invalid lock of %q: %s", contentID, err)
+	}
+
+	return &info, tag, nil
+}
+
+func (s *Secure) addVersion(filePath string, p, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions, err := s.readIndex(filePath)
+	if err != nil {
+		return err
+	}
+
+	sum := sha1.Sum(content)
+
+	v := &Version{
+		ID:      strconv.FormatInt(time.Now().UnixNano(), 10),
+		Size:    len(content),
+		Sum:     hex.EncodeToString(sum[:]),
+		Created: time.Now().UTC(),
+	}
+
+	// Terraformer writes back all the files after each
+	// operation, unchanged ones must not evict the history.
+	if len(versions) != 0 && versions[0].Sum == v.Sum {
+		return nil
+	}
+
+	if err := s.Interface.Write(versionPath(filePath, v.ID), bytes.NewReader(p)); err != nil {
+		return err
+	}
+
+	versions = append([]*Version{v}, versions...)
+
+	for len(versions) > s.versions {
+		old := versions[len(versions)-1]
+		versions = versions[:len(versions)-1]
+
+		if err := s.Interface.Remove(versionPath(filePath, old.ID)); err != nil {
+			s.log.Warning("unable to remove version %q of %q: %s", old.ID, filePath, err)
+		}
+	}
+
+	index, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+
+	return s.Interface.Write(indexPath(filePath), bytes.NewReader(index))
+}
+
+// isStateFile tells whether the file is a terraform state,
+// versions are kept only for states.
+func isStateFile(filePath string) bool {
+	return path.Ext(filePath) == ".tfstate"
+}
+
+func (s *Secure) readIndex(filePath string) ([]*Version, error) {
+	p, err := readAll(s.Interface, indexPath(filePath))
+	if isNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*Version
+	if err := json.Unmarshal(p, &versions); err != nil {
+		return nil, fmt.Errorf("invalid versions index of %q: %s", filePath, err)
+	}
+
+	sort.Sort(byCreated(versions))
+
+	return versions, nil
+}
+
+func (s *Secure) read(filePath string) ([]byte, error) {
+	p, err := readAll(s.Interface, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decrypt(filePath, p)
+}
+
+// decrypt decrypts the content of the given file. Unencrypted
+// content is rewritten encrypted, if migration is enabled.
+func (s *Secure) decrypt(filePath string, p []byte) ([]byte, error) {
+	content, err := decrypt(s.key, p)
+	if err != ErrNotEncrypted || !s.migrate {
+		return content, err
+	}
+
+	if e := s.migrateFile(filePath, p); e != nil {
+		// The content is still readable, migration is
+		// going to be retried with next read.
+		s.log.Warning("unable to encrypt %q: %s", filePath, e)
+	}
+
+	return p, nil
+}
+
+func (s *Secure) migrateFile(filePath string, p []byte) error {
+	enc, err := encrypt(s.key, p)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Interface.Write(filePath, bytes.NewReader(enc)); err != nil {
+		return err
+	}
+
+	s.log.Info("encrypted unencrypted content of %q", filePath)
+
+	return nil
+}
+
+func (s *Secure) encrypt(p []byte) ([]byte, error) {
+	if len(s.key) == 0 {
+		return p, nil
+	}
+
+	return encrypt(s.key, p)
+}
+
+// decryptTarget is used as a target storage when cloning,
+// it decrypts each file before writing it to the actual
+// target.
+type decryptTarget struct {
+	Interface
+	s *Secure
+}
+
+func (dt *decryptTarget) Write(filePath string, file io.Reader) error {
+	// Cloning the whole storage must not copy versions nor locks.
+	if isReserved(filePath) {
+		return nil
+	}
+
+	p, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	if p, err = dt.s.decrypt(filePath, p); err != nil {
+		return fmt.Errorf("unable to decrypt %q: %s", filePath, err)
+	}
+
+	return dt.Interface.Write(filePath, bytes.NewReader(p))
+}
+
+type byCreated []*Version
+
+func (v byCreated) Len() int           { return len(v) }
+func (v byCreated) Less(i, j int) bool { return v[i].Created.After(v[j].Created) }
+func (v byCreated) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+func findVersion(versions []*Version, id string) int {
+	for i, v := range versions {
+		if v.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func versionPath(filePath, id string) string {
+	return path.Join(versionsDir, filePath, id)
+}
+
+func indexPath(filePath string) string {
+	return path.Join(versionsDir, filePath, "index.json")
+}
+
+func lockPath(contentID string) string {
+	return path.Join(locksDir, contentID+".json")
+}
+
+func isReserved(filePath string) bool {
+	filePath = strings.TrimPrefix(filePath, "/")
+
+	return strings.HasPrefix(filePath, versionsDir+"/") || strings.HasPrefix(filePath, locksDir+"/")
+}
+
+func readAll(s Interface, filePath string) ([]byte, error) {
+	r, err := s.Read(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := ioutil.ReadAll(r)
+
+	if c, ok := r.(io.Closer); ok {
+		err = nonil(err, c.Close())
+	}
+
+	return p, err
+}
+
+func isNotExist(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if os.IsNotExist(err) {
+		return true
+	}
+
+	if e, ok := err.(awserr.Error); ok {
+		return e.Code() == "NoSuchKey"
+	}
+
+	return false
+}
@@ -0,0 +1,318 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/koding/logging"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestSecure(t *testing.T, key []byte, versions int) (*Secure, *File, func()) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+
+	log := logging.NewCustom("storage", testing.Verbose())
+
+	f, err := NewFile(dir, log)
+	if err != nil {
+		t.Fatalf("NewFile()=%s", err)
+	}
+
+	s, err := NewSecure(f, &SecureOptions{
+		Key:      key,
+		Versions: versions,
+		Log:      log,
+	})
+	if err != nil {
+		t.Fatalf("NewSecure()=%s", err)
+	}
+
+	return s, f, func() { os.RemoveAll(dir) }
+}
+
+func read(t *testing.T, s Interface, path string) string {
+	p, err := readAll(s, path)
+	if err != nil {
+		t.Fatalf("readAll(%q)=%s", path, err)
+	}
+	return string(p)
+}
+
+func TestSecureEncryption(t *testing.T) {
+	s, f, cleanup := newTestSecure(t, testKey, -1)
+	defer cleanup()
+
+	const state = `{"version": 3, "secret": "password"}`
+
+	if err := s.Write("content/state.tfstate", strings.NewReader(state)); err != nil {
+		t.Fatalf("Write()=%s", err)
+	}
+
+	if raw := read(t, f, "content/state.tfstate"); strings.Contains(raw, "password") {
+		t.Fatalf("content stored unencrypted: %s", raw)
+	}
+
+	if got := read(t, s, "content/state.tfstate"); got != state {
+		t.Fatalf("got %q, want %q", got, state)
+	}
+
+	local, err := NewFile("", logging.NewCustom("local", false))
+	if err != nil {
+		t.Fatalf("NewFile()=%s", err)
+	}
+	defer os.RemoveAll(local.basePath)
+
+	if err := s.Clone("content", local); err != nil {
+		t.Fatalf("Clone()=%s", err)
+	}
+
+	if got := read(t, local, "content/state.tfstate"); got != state {
+		t.Fatalf("got %q, want %q", got, state)
+	}
+
+	// Content written before encryption was enabled is rejected,
+	// unless migration is enabled.
+	if err := f.Write("content/plain.tfstate", strings.NewReader(state)); err != nil {
+		t.Fatalf("Write()=%s", err)
+	}
+
+	if _, err := s.Read("content/plain.tfstate"); err != ErrNotEncrypted {
+		t.Fatalf("got %v, want %v", err, ErrNotEncrypted)
+	}
+
+	s.migrate = true
+
+	if got := read(t, s, "content/plain.tfstate"); got != state {
+		t.Fatalf("got %q, want %q", got, state)
+	}
+
+	if raw := read(t, f, "content/plain.tfstate"); strings.Contains(raw, "password") {
+		t.Fatalf("content was not migrated: %s", raw)
+	}
+
+	s.migrate = false
+
+	if got := read(t, s, "content/plain.tfstate"); got != state {
+		t.Fatalf("got %q, want %q", got, state)
+	}
+
+	other, err := NewSecure(f, &SecureOptions{
+		Key: bytes.Repeat([]byte("x"), KeySize),
+		Log: s.log,
+	})
+	if err != nil {
+		t.Fatalf("NewSecure()=%s", err)
+	}
+
+	if _, err := other.Read("content/state.tfstate"); err == nil {
+		t.Fatal("expected Read() to fail with invalid key")
+	}
+
+	if _, err := NewSecure(f, &SecureOptions{Key: []byte("short")}); err == nil {
+		t.Fatal("expected NewSecure() to fail with invalid key size")
+	}
+}
+
+func TestSecureVersions(t *testing.T) {
+	s, f, cleanup := newTestSecure(t, testKey, 3)
+	defer cleanup()
+
+	for _, state := range []string{"1", "2", "3", "4"} {
+		if err := s.Write("content/state.tfstate", strings.NewReader(state)); err != nil {
+			t.Fatalf("Write()=%s", err)
+		}
+	}
+
+	// Unchanged content and other files are not versioned.
+	if err := s.Write("content/state.tfstate", strings.NewReader("4")); err != nil {
+		t.Fatalf("Write()=%s", err)
+	}
+
+	if err := s.Write("content/main.tf.json", strings.NewReader("{}")); err != nil {
+		t.Fatalf("Write()=%s", err)
+	}
+
+	if versions, err := s.Versions("content/main.tf.json"); err != nil || len(versions) != 0 {
+		t.Fatalf("want no versions of non-state files, got %d (%v)", len(versions), err)
+	}
+
+	versions, err := s.Versions("content/state.tfstate")
+	if err != nil {
+		t.Fatalf("Versions()=%s", err)
+	}
+
+	if len(versions) != 3 {
+		t.Fatalf("got %d versions, want 3", len(versions))
+	}
+
+	for i, want := range []string{"4", "3", "2"} {
+		r, err := s.ReadVersion("content/state.tfstate", versions[i].ID)
+		if err != nil {
+			t.Fatalf("ReadVersion()=%s", err)
+		}
+
+		if got, _ := ioutil.ReadAll(r); string(got) != want {
+			t.Fatalf("%d: got %q, want %q", i, got, want)
+		}
+	}
+
+	dir := filepath.Join(f.basePath, versionsDir, "content", "state.tfstate")
+
+	if fis, err := ioutil.ReadDir(dir); err != nil || len(fis) != 4 {
+		t.Fatalf("want 3 versions and index to be kept, got %d (%v)", len(fis), err)
+	}
+
+	if err := s.Rollback("content/state.tfstate", versions[2].ID); err != nil {
+		t.Fatalf("Rollback()=%s", err)
+	}
+
+	if got := read(t, s, "content/state.tfstate"); got != "2" {
+		t.Fatalf("got %q, want %q", got, "2")
+	}
+
+	if _, err := s.ReadVersion("content/state.tfstate", "123"); err != ErrNoVersion {
+		t.Fatalf("got %v, want %v", err, ErrNoVersion)
+	}
+
+	local, err := NewFile("", logging.NewCustom("local", false))
+	if err != nil {
+		t.Fatalf("NewFile()=%s", err)
+	}
+	defer os.RemoveAll(local.basePath)
+
+	if err := s.Clone("content", local); err != nil {
+		t.Fatalf("Clone()=%s", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(local.basePath, versionsDir)); !os.IsNotExist(err) {
+		t.Fatalf("want versions to not be cloned, got %v", err)
+	}
+}
+
+func TestSecureLock(t *testing.T) {
+	s, _, cleanup := newTestSecure(t, nil, 0)
+	defer cleanup()
+
+	plan := &LockInfo{ID: "1", Operation: "plan"}
+	apply := &LockInfo{ID: "2", Operation: "apply"}
+
+	if err := s.Lock("content", plan); err != nil {
+		t.Fatalf("Lock()=%s", err)
+	}
+
+	err := s.Lock("content", apply)
+	if !IsLocked(err) {
+		t.Fatalf("want content to be locked, got %v", err)
+	}
+
+	if e := err.(*LockedError); e.Info.ID != plan.ID {
+		t.Fatalf("got %q, want %q", e.Info.ID, plan.ID)
+	}
+
+	if err := s.Lock("other-content", apply); err != nil {
+		t.Fatalf("Lock()=%s", err)
+	}
+
+	if err := s.Unlock("content", apply); !IsLocked(err) {
+		t.Fatalf("want Unlock() to fail for non-holder, got %v", err)
+	}
+
+	if err := s.Unlock("content", plan); err != nil {
+		t.Fatalf("Unlock()=%s", err)
+	}
+
+	if err := s.Lock("content", apply); err != nil {
+		t.Fatalf("Lock()=%s", err)
+	}
+
+	// Expired lock can be taken over.
+	s.lockTTL = -1
+
+	if err := s.Lock("expired", plan); err != nil {
+		t.Fatalf("Lock()=%s", err)
+	}
+
+	if err := s.Lock("expired", apply); err != nil {
+		t.Fatalf("Lock()=%s", err)
+	}
+}
+
+func TestSecureLockConcurrent(t *testing.T) {
+	_, f, cleanup := newTestSecure(t, nil, 0)
+	defer cleanup()
+
+	const n = 16
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		locked []string
+	)
+
+	for i := 0; i < n; i++ {
+		// Each holder uses its own Secure storage, so they
+		// are synchronized only by conditional writes.
+		s, err := NewSecure(f, &SecureOptions{Log: f.log})
+		if err != nil {
+			t.Fatalf("NewSecure()=%s", err)
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			err := s.Lock("content", &LockInfo{ID: id, Operation: "apply"})
+			if err == nil {
+				mu.Lock()
+				locked = append(locked, id)
+				mu.Unlock()
+			}
+		}(strconv.Itoa(i))
+	}
+
+	wg.Wait()
+
+	if len(locked) != 1 {
+		t.Fatalf("want exactly one holder of the lock, got %v", locked)
+	}
+}
+
+func TestFileWriteIf(t *testing.T) {
+	_, f, cleanup := newTestSecure(t, nil, 0)
+	defer cleanup()
+
+	if err := f.WriteIf("file", strings.NewReader("1"), ""); err != nil {
+		t.Fatalf("WriteIf()=%s", err)
+	}
+
+	if err := f.WriteIf("file", strings.NewReader("2"), ""); err != ErrPrecondition {
+		t.Fatalf("got %v, want %v", err, ErrPrecondition)
+	}
+
+	_, tag, err := f.ReadTag("file")
+	if err != nil {
+		t.Fatalf("ReadTag()=%s", err)
+	}
+
+	if err := f.WriteIf("file", strings.NewReader("3"), tag); err != nil {
+		t.Fatalf("WriteIf()=%s", err)
+	}
+
+	if err := f.WriteIf("file", strings.NewReader("4"), tag); err != ErrPrecondition {
+		t.Fatalf("got %v, want %v", err, ErrPrecondition)
+	}
+
+	if got := read(t, f, "file"); got != "3" {
+		t.Fatalf("got %q, want %q", got, "3")
+	}
+}
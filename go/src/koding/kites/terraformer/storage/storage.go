@@ -1,7 +1,14 @@
 // Package storage provides backend storage systems
 package storage
 
-import "io"
+import (
+	"errors"
+	"io"
+)
+
+// ErrPrecondition is returned by ConditionalWriter when the file
+// was modified by other writer since its tag was read.
+var ErrPrecondition = errors.New("file was modified concurrently")
 
 // nonil returns first non-nil error it encounters
 func nonil(err ...error) error {
@@ -21,3 +28,17 @@ type Interface interface {
 	Clone(string, Interface) error
 	BasePath() (string, error)
 }
+
+// ConditionalWriter is implemented by storages, which are able to write
+// a file only when it was not modified since it was read.
+type ConditionalWriter interface {
+	// ReadTag reads the file together with its tag, which identifies
+	// current content of the file.
+	ReadTag(string) (io.Reader, string, error)
+
+	// WriteIf writes the file only when its current tag is equal
+	// to the given one, otherwise ErrPrecondition is returned.
+	//
+	// Empty tag requires the file to not exist.
+	WriteIf(string, io.Reader, string) error
+}
@@ -62,6 +62,14 @@ type TerraformRequest struct {
 	TraceID   string
 }
 
+// StateRequest is a helper struct for terraformer kite requests,
+// which operate on versions of the state.
+type StateRequest struct {
+	ContentID string
+	TraceID   string
+	Version   string // used by rollback only
+}
+
 // New creates a new terraformer
 func New(conf *Config, log logging.Logger) (*Terraformer, error) {
 	ls, err := storage.NewFile(conf.LocalStorePath, log)
@@ -95,6 +103,22 @@ func New(conf *Config, log logging.Logger) (*Terraformer, error) {
 		rs = local
 	}
 
+	key, err := conf.Storage.key()
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err = storage.NewSecure(rs, &storage.SecureOptions{
+		Key:      key,
+		Migrate:  conf.Storage.MigratePlaintext,
+		Versions: conf.Storage.Versions,
+		LockTTL:  conf.Storage.LockTTL,
+		Log:      log.New("storage"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while creating secure store: %s", err)
+	}
+
 	c, err := kodingcontext.New(ls, rs, log, conf.Debug)
 	if err != nil {
 		return nil, err
@@ -218,6 +242,47 @@ func (t *Terraformer) apply(r *kite.Request, destroy bool) (*terraform.State, er
 	return c.Apply(content, destroy)
 }
 
+// StateVersions provides a kite call for listing versions of the state
+func (t *Terraformer) StateVersions(r *kite.Request) (interface{}, error) {
+	args := StateRequest{}
+	if err := r.Args.One().Unmarshal(&args); err != nil {
+		return nil, err
+	}
+
+	c, err := t.Context.Get(args.ContentID, args.TraceID)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	return c.StateVersions()
+}
+
+// StateRollback provides a kite call for rolling back the state
+// to one of its previous versions
+func (t *Terraformer) StateRollback(r *kite.Request) (interface{}, error) {
+	args := StateRequest{}
+	if err := r.Args.One().Unmarshal(&args); err != nil {
+		return nil, err
+	}
+
+	if args.Version == "" {
+		return nil, errors.New("version is not passed")
+	}
+
+	c, err := t.Context.Get(args.ContentID, args.TraceID)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := c.RollbackState(args.Version); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
 func (t *Terraformer) handleState(r *kite.Request) (interface{}, error) {
 	t.rwmu.RLock()
 	defer t.rwmu.RUnlock()
@@ -440,6 +440,7 @@ func (k *Klient) RegisterMethods() {
 	k.handleWithSub("os.currentUsername", kos.CurrentUsername)
 	k.handleWithSub("os.exec", kos.Exec)
 	k.handleWithSub("os.kill", kos.Kill)
+	k.handleFunc("os.setenv", kos.SetEnv)
 
 	// Klient Info method(s)
 	k.handleWithSub("klient.info", info.Info)
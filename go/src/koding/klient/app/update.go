@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path"
 	"strconv"
 	"sync"
 	"syscall"
@@ -16,6 +17,7 @@ import (
 
 	"koding/kites/config"
 	konfig "koding/klient/config"
+	"koding/klient/release"
 	kdconf "koding/klientctl/config"
 
 	version "github.com/hashicorp/go-version"
@@ -125,7 +127,12 @@ func (u *Updater) updateBinary(url string, latest *version.Version) error {
 
 	u.Log.Info("Going to update binary at: %s", self)
 
-	bin, err := u.fetch(url)
+	m, err := release.FetchFor(url, latest.Segments()[2])
+	if err != nil {
+		return err
+	}
+
+	bin, err := u.fetch(url, m)
 	if err != nil {
 		return err
 	}
@@ -135,7 +142,14 @@ func (u *Updater) updateBinary(url string, latest *version.Version) error {
 
 	u.Log.Info("Replacing new binary with the old one.")
 
-	if err = update.Apply(bytes.NewBuffer(bin), update.Options{}); err != nil {
+	// The previous binary is kept in order to be able to
+	// roll back the update, when the new one fails to start.
+	prev := self + ".prev"
+
+	if err = update.Apply(bytes.NewBuffer(bin), update.Options{OldSavePath: prev}); err != nil {
+		if e := update.RollbackError(err); e != nil {
+			u.Log.Error("Failed to roll back the update: %s", e)
+		}
 		return err
 	}
 
@@ -153,7 +167,13 @@ func (u *Updater) updateBinary(url string, latest *version.Version) error {
 
 	execErr := syscall.Exec(self, args, env)
 	if execErr != nil {
-		return err
+		u.Log.Error("Failed to start updated binary, rolling back: %s", execErr)
+
+		if err := os.Rename(prev, self); err != nil {
+			return fmt.Errorf("%s; rollback failed: %s", execErr, err)
+		}
+
+		return execErr
 	}
 
 	return nil
@@ -205,7 +225,9 @@ func (u *Updater) latestVersion(env string) (int, error) {
 	return strconv.Atoi(string(bytes.TrimSpace(latest)))
 }
 
-func (u *Updater) fetch(url string) ([]byte, error) {
+// fetch downloads the binary from the given url, verifies it
+// against the release manifest and decompresses it.
+func (u *Updater) fetch(url string, m *release.Manifest) ([]byte, error) {
 	u.Log.Info("Fetching binary %s", url)
 	resp, err := http.Get(url)
 	if err != nil {
@@ -217,8 +239,17 @@ func (u *Updater) fetch(url string) ([]byte, error) {
 		return nil, fmt.Errorf("bad http status from %s: %v", url, resp.Status)
 	}
 
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Verify(path.Base(url), raw); err != nil {
+		return nil, err
+	}
+
 	buf := new(bytes.Buffer)
-	gz, err := gzip.NewReader(resp.Body)
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ set -euo pipefail
 REPO_PATH=$(git rev-parse --show-toplevel)
 CHANNEL=${1:-}
 VERSION=${2:-}
+RELEASE_PUBLIC_KEY=${RELEASE_PUBLIC_KEY:-L98imPIZIms23n0ppppSBdA30rtHQaSs4Tx8tjDBFks=}
 
 export GOBIN="${REPO_PATH}"
 
@@ -25,7 +26,7 @@ fi
 PREFIX="klient-0.1.${VERSION}"
 
 klient_build() {
-	go install -v -ldflags "-X koding/klient/config.Version=0.1.${VERSION} -X koding/klient/config.Environment=${CHANNEL}" koding/klient
+	go install -v -ldflags "-X koding/klient/config.Version=0.1.${VERSION} -X koding/klient/config.Environment=${CHANNEL} -X koding/klient/release.PublicKey=${RELEASE_PUBLIC_KEY}" koding/klient
 }
 
 echo "# builing klient: version ${VERSION}, channel ${CHANNEL}, os $(uname)"
@@ -36,6 +36,17 @@ for file in "${DISTRIB[@]}"; do
 	[[ -f "$file" ]]
 done
 
+echo "# signing release manifest"
+
+pushd "${REPO_PATH}"
+go run go/src/koding/klient/release/cmd/release-sign/main.go -name klient -version "$VERSION" "${DISTRIB[@]}"
+popd
+
+DISTRIB+=(
+	"${REPO_PATH}/manifest-0.1.${VERSION}.json"
+	"${REPO_PATH}/manifest-0.1.${VERSION}.json.sig"
+)
+
 echo "# uploading files to s3://${BUCKET}/${CHANNEL}/${VERSION}/"
 
 for file in "${DISTRIB[@]}"; do
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"errors"
+	"path"
 	"regexp"
 	"runtime"
 	"strings"
@@ -95,6 +96,50 @@ func (rs *RegexSkip) Check(path string) error {
 	return nil
 }
 
+// GlobSkip filters all paths that match any of stored shell patterns.
+//
+// Patterns that do not contain a slash are matched against each path
+// element, thus "node_modules" skips the directory with all its content
+// and "*.log" skips log files in every directory. Other patterns are
+// matched against leading elements of the path.
+type GlobSkip []string
+
+// Check returns SkipPath error when provided path matches any of the patterns.
+func (gs GlobSkip) Check(p string) error {
+	elems := strings.Split(strings.Trim(p, "/"), "/")
+
+	for _, pattern := range gs {
+		pattern = strings.Trim(pattern, "/")
+
+		if !strings.Contains(pattern, "/") {
+			for _, elem := range elems {
+				if ok, _ := path.Match(pattern, elem); ok {
+					return SkipPath
+				}
+			}
+
+			continue
+		}
+
+		n := strings.Count(pattern, "/") + 1
+		if n > len(elems) {
+			continue
+		}
+
+		if ok, _ := path.Match(pattern, strings.Join(elems[:n], "/")); ok {
+			return SkipPath
+		}
+	}
+
+	return nil
+}
+
+// ValidGlob checks whether provided pattern can be used with GlobSkip filter.
+func ValidGlob(pattern string) error {
+	_, err := path.Match(pattern, "")
+	return err
+}
+
 // WithError implements Filter interface. It replaces returned non-nil wrapped
 // skipper error with provided one.
 type WithError struct {
@@ -62,6 +62,31 @@ func TestFilter(t *testing.T) {
 			F:      filter.NewRegexSkip(`\.git/index\.stash\.\d+\.lock$`),
 			IsSkip: true,
 		},
+		"glob directory": {
+			Path:   "app/node_modules/lib/index.js",
+			F:      filter.GlobSkip{"node_modules"},
+			IsSkip: true,
+		},
+		"glob file extension": {
+			Path:   "app/logs/server.log",
+			F:      filter.GlobSkip{"*.tmp", "*.log"},
+			IsSkip: true,
+		},
+		"glob leading path": {
+			Path:   "build/out/app",
+			F:      filter.GlobSkip{"build/out/"},
+			IsSkip: true,
+		},
+		"glob leading path not matching": {
+			Path:   "src/build/out/app",
+			F:      filter.GlobSkip{"build/out"},
+			IsSkip: false,
+		},
+		"glob no match": {
+			Path:   "app/main.go",
+			F:      filter.GlobSkip{"*.log", "vendor"},
+			IsSkip: false,
+		},
 	}
 
 	for name, test := range tests {
@@ -324,7 +324,7 @@ func TestUmount(t *testing.T) {
 	if umountRes.MountID != mountIDs[1] {
 		t.Errorf("want mount ID: %s; got %s", mountIDs[1], umountRes.MountID)
 	}
-	if umountRes.Mount != ms[1] || umountRes.MountID != mountIDs[1] {
+	if !reflect.DeepEqual(umountRes.Mount, ms[1]) || umountRes.MountID != mountIDs[1] {
 		t.Errorf("want mount %s; got %s", ms[1], umountRes.Mount)
 	}
 
@@ -343,7 +343,7 @@ func TestUmount(t *testing.T) {
 	if umountRes.MountID != mountIDs[2] {
 		t.Errorf("want mount ID: %s; got %s", mountIDs[2], umountRes.MountID)
 	}
-	if umountRes.Mount != ms[2] || umountRes.MountID != mountIDs[2] {
+	if !reflect.DeepEqual(umountRes.Mount, ms[2]) || umountRes.MountID != mountIDs[2] {
 		t.Errorf("want mount %s; got %s", ms[2], umountRes.Mount)
 	}
 
@@ -55,8 +55,9 @@ func (ids IDSlice) StringSlice() (res []string) {
 
 // Mount stores information about a single local to remote machine mount.
 type Mount struct {
-	Path       string `json:"path"`       // Mount point.
-	RemotePath string `json:"remotePath"` // Remote directory path.
+	Path       string   `json:"path"`             // Mount point.
+	RemotePath string   `json:"remotePath"`       // Remote directory path.
+	Ignore     []string `json:"ignore,omitempty"` // Shell patterns of paths that are not synced.
 }
 
 // String return a string form of stored mount.
@@ -157,6 +157,10 @@ func NewSync(mountID ID, m Mount, opts Options) (*Sync, error) {
 		s.opts.Filter = DefaultFilter
 	}
 
+	if len(m.Ignore) != 0 {
+		s.opts.Filter = filter.MultiFilter{s.opts.Filter, filter.GlobSkip(m.Ignore)}
+	}
+
 	if opts.Log != nil {
 		s.log = opts.Log.New("sync")
 	} else {
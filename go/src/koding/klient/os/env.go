@@ -0,0 +1,137 @@
+package os
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/koding/kite"
+)
+
+// DefaultProfilePath is a path of the shell profile script, which
+// exports variables set with "os.setenv" to login shells.
+var DefaultProfilePath = "/etc/profile.d/koding-env.sh"
+
+var envName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// SetEnvRequest represents a request value for the "os.setenv" kite method.
+type SetEnvRequest struct {
+	// Env holds variables that are set for each command started by
+	// klient. Variables set with previous request that are not
+	// present in Env are unset.
+	Env map[string]string `json:"env"`
+}
+
+// Valid implements the stack.Validator interface.
+func (r *SetEnvRequest) Valid() error {
+	for name := range r.Env {
+		if !envName.MatchString(name) {
+			return errors.New("invalid variable name: " + name)
+		}
+	}
+	return nil
+}
+
+// SetEnvResponse represents a response value for the "os.setenv" kite method.
+type SetEnvResponse struct{}
+
+// SetEnv is a kite handler for "os.setenv" method.
+//
+// The variables are used for commands started with "os.exec" and
+// written to a profile script, so they are available in login shells
+// as well, e.g. in terminal sessions.
+func (h *Handler) SetEnv(r *kite.Request) (interface{}, error) {
+	var req SetEnvRequest
+
+	if r.Args != nil {
+		if err := r.Args.One().Unmarshal(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, newError(err)
+	}
+
+	if err := h.setEnv(req.Env); err != nil {
+		return nil, err
+	}
+
+	return &SetEnvResponse{}, nil
+}
+
+func (h *Handler) setEnv(env Environ) error {
+	h.mu.Lock()
+	h.env = env
+	h.mu.Unlock()
+
+	return writeProfile(h.profilePath(), env)
+}
+
+// Env gives the variables set with "os.setenv" method.
+func (h *Handler) Env() Environ {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	env := make(Environ, len(h.env))
+
+	for k, v := range h.env {
+		env[k] = v
+	}
+
+	return env
+}
+
+func (h *Handler) profilePath() string {
+	if h.ProfilePath != "" {
+		return h.ProfilePath
+	}
+	return DefaultProfilePath
+}
+
+func writeProfile(path string, env Environ) error {
+	if len(env) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	buf.WriteString("# Generated by klient, do not edit.\n")
+
+	for _, name := range names {
+		buf.WriteString("export " + name + "=" + shellQuote(env[name]) + "\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func SetEnv(r *kite.Request) (interface{}, error) { return DefaultHandler.SetEnv(r) }
@@ -0,0 +1,89 @@
+package os_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	kos "koding/klient/os"
+
+	"github.com/koding/kite"
+)
+
+func TestSetEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "klient-os")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	h := kos.NewHandler()
+	h.ProfilePath = filepath.Join(dir, "profile.d", "koding-env.sh")
+
+	s, c, err := serve(map[string]kite.HandlerFunc{
+		"os.exec":   h.Exec,
+		"os.setenv": h.SetEnv,
+	})
+	defer s.Close()
+
+	if err != nil {
+		t.Fatalf("serve()=%s", err)
+	}
+
+	env := map[string]string{
+		"TESTHELPER_LB_DNS": "lb-1234.example.com",
+		"TESTHELPER_QUOTE":  "it's",
+	}
+
+	if err := call(c, "os.setenv", timeout, &kos.SetEnvRequest{Env: env}, nil); err != nil {
+		t.Fatalf("call()=%s", err)
+	}
+
+	const profile = "# Generated by klient, do not edit.\n" +
+		"export TESTHELPER_LB_DNS='lb-1234.example.com'\n" +
+		"export TESTHELPER_QUOTE='it'\\''s'\n"
+
+	p, err := ioutil.ReadFile(h.ProfilePath)
+	if err != nil {
+		t.Fatalf("ReadFile()=%s", err)
+	}
+
+	if string(p) != profile {
+		t.Fatalf("got %q, want %q", p, profile)
+	}
+
+	req := makereq(&kos.ExecRequest{
+		Cmd:  "env",
+		Envs: map[string]string{"TESTHELPER_FOO": "bar"},
+	})
+	rec := record(req)
+
+	if err := call(c, "os.exec", timeout, req, &kos.ExecResponse{}); err != nil {
+		t.Fatalf("call()=%s", err)
+	}
+
+	if err := rec.wait(timeout); err != nil {
+		t.Fatalf("wait()=%s", err)
+	}
+
+	want := kos.Environ(env).Encode(kos.Environ{"TESTHELPER_FOO": "bar"})
+
+	if stdout := rec.Stdout(); !reflect.DeepEqual(stdout, want) {
+		t.Fatalf("got %v, want %v", stdout, want)
+	}
+
+	if err := call(c, "os.setenv", timeout, &kos.SetEnvRequest{}, nil); err != nil {
+		t.Fatalf("call()=%s", err)
+	}
+
+	if _, err := os.Stat(h.ProfilePath); !os.IsNotExist(err) {
+		t.Fatalf("want profile to be removed, got %v", err)
+	}
+
+	err = call(c, "os.setenv", timeout, &kos.SetEnvRequest{Env: map[string]string{"A B": "c"}}, nil)
+	if err == nil {
+		t.Fatal("want invalid variable name to be rejected")
+	}
+}
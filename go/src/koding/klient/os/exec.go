@@ -62,8 +62,15 @@ type KillResponse struct{}
 
 // Handler implements kite handlers for "os.kill" and "os.exec" methods.
 type Handler struct {
+	// ProfilePath is a path of the shell profile script, which
+	// exports variables set with "os.setenv" method.
+	//
+	// If empty, DefaultProfilePath is used.
+	ProfilePath string
+
 	mu   sync.Mutex
 	cmds map[int]*exec.Cmd
+	env  Environ
 }
 
 // NewHandler gives
@@ -106,8 +113,12 @@ func (h *Handler) exec(r *ExecRequest) (*ExecResponse, error) {
 	cmd := exec.Command(rcmd, r.Args...)
 	cmd.Dir = r.WorkDir
 
-	if len(r.Envs) != 0 {
-		cmd.Env = environ.Encode(r.Envs)
+	if env := h.Env(); len(env) != 0 || len(r.Envs) != 0 {
+		for k, v := range r.Envs {
+			env[k] = v
+		}
+
+		cmd.Env = environ.Encode(env)
 	}
 
 	if len(r.Stdin) != 0 {
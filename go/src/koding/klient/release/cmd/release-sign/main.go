@@ -0,0 +1,114 @@
+// Command release-sign creates a signed release manifest
+// for the given files.
+//
+// Usage:
+//
+//   release-sign -name klient -version 123 klient-0.1.123.gz klient-0.1.123.darwin_amd64.gz
+//
+// The private key is read from a file given by -key flag, or from
+// the RELEASE_SIGNING_KEY environment variable, base64-encoded.
+//
+// The manifest and its signature are written to the current
+// working directory.
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"koding/klient/release"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	name    = flag.String("name", "", "Name of the released application.")
+	version = flag.Int("version", 0, "Version of the release.")
+	key     = flag.String("key", "", "File with base64-encoded Ed25519 private key.")
+	out     = flag.String("o", ".", "Directory to write the manifest to.")
+)
+
+func die(v ...interface{}) {
+	fmt.Fprintln(os.Stderr, v...)
+	os.Exit(1)
+}
+
+func privateKey() (ed25519.PrivateKey, error) {
+	s := os.Getenv("RELEASE_SIGNING_KEY")
+
+	if *key != "" {
+		p, err := ioutil.ReadFile(*key)
+		if err != nil {
+			return nil, err
+		}
+
+		s = string(p)
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf("no signing key provided")
+	}
+
+	p, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size: %d", len(p))
+	}
+
+	return ed25519.PrivateKey(p), nil
+}
+
+func main() {
+	flag.Parse()
+
+	if *name == "" || *version == 0 || flag.NArg() == 0 {
+		die("usage: release-sign -name NAME -version VERSION FILE...")
+	}
+
+	k, err := privateKey()
+	if err != nil {
+		die("error reading private key:", err)
+	}
+
+	m := &release.Manifest{
+		Name:    *name,
+		Version: *version,
+		Created: time.Now().UTC(),
+		Files:   make(map[string]string, flag.NArg()),
+	}
+
+	for _, file := range flag.Args() {
+		p, err := ioutil.ReadFile(file)
+		if err != nil {
+			die("error reading file:", err)
+		}
+
+		m.Files[filepath.Base(file)] = release.Sum(p)
+	}
+
+	manifest, sig, err := release.Sign(m, k)
+	if err != nil {
+		die("error signing manifest:", err)
+	}
+
+	file := filepath.Join(*out, release.ManifestName(*version))
+
+	if err := ioutil.WriteFile(file, manifest, 0644); err != nil {
+		die("error writing manifest:", err)
+	}
+
+	if err := ioutil.WriteFile(file+".sig", sig, 0644); err != nil {
+		die("error writing signature:", err)
+	}
+
+	fmt.Println(file)
+}
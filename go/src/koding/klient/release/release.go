@@ -0,0 +1,221 @@
+// Package release provides means for verifying authenticity
+// of kd and klient binaries before they are installed.
+//
+// Each release is described by a manifest, which lists SHA-256
+// digests of all the distributed files. The manifest is signed
+// with an Ed25519 key and the detached signature is distributed
+// alongside it, under the manifest name with the ".sig" suffix.
+//
+// Both the manifest and its signature are expected to reside in
+// the same directory as the released files.
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// PublicKey is a base64-encoded Ed25519 public key, which is used
+// to verify release manifests.
+//
+// It is overwritten during deploy via linker flag.
+var PublicKey = "L98imPIZIms23n0ppppSBdA30rtHQaSs4Tx8tjDBFks="
+
+// ErrInvalidSignature is returned when a manifest signature
+// does not match the public key.
+var ErrInvalidSignature = errors.New("release: invalid manifest signature")
+
+// Manifest describes a single release.
+type Manifest struct {
+	Name    string            `json:"name"`    // name of the released application, e.g. "kd" or "klient"
+	Version int               `json:"version"` // version of the release
+	Created time.Time         `json:"created"` // time the manifest was created
+	Files   map[string]string `json:"files"`   // maps file names to their hex-encoded SHA-256 digests
+}
+
+// Verify checks whether the given content is a file, that
+// was released under the given name.
+func (m *Manifest) Verify(name string, p []byte) error {
+	return m.VerifySum(name, Sum(p))
+}
+
+// VerifySum checks whether the given hex-encoded SHA-256 digest
+// is a digest of a file, that was released under the given name.
+func (m *Manifest) VerifySum(name, sum string) error {
+	want, ok := m.Files[name]
+	if !ok {
+		return fmt.Errorf("release: %q is not part of %s %d release", name, m.Name, m.Version)
+	}
+
+	if sum != want {
+		return fmt.Errorf("release: %q digest mismatch: want %s, got %s", name, want, sum)
+	}
+
+	return nil
+}
+
+// Sum gives a hex-encoded SHA-256 digest of p.
+func Sum(p []byte) string {
+	sum := sha256.Sum256(p)
+	return hex.EncodeToString(sum[:])
+}
+
+// ManifestName gives a name of the manifest file
+// for the given version.
+func ManifestName(version int) string {
+	return "manifest-0.1." + strconv.Itoa(version) + ".json"
+}
+
+// ManifestURL gives an URL of the manifest for the given
+// version, which describes a file given by fileURL.
+func ManifestURL(fileURL string, version int) (string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join(path.Dir(u.Path), ManifestName(version))
+
+	return u.String(), nil
+}
+
+// Sign encodes the manifest and signs it with the given private key.
+//
+// It returns the encoded manifest and its signature.
+func Sign(m *Manifest, key ed25519.PrivateKey) (manifest, sig []byte, err error) {
+	manifest, err = json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return manifest, ed25519.Sign(key, manifest), nil
+}
+
+// Client is used to fetch and verify release manifests.
+type Client struct {
+	// PublicKey is used to verify manifest signatures.
+	//
+	// If nil, the key decoded from PublicKey is used.
+	PublicKey ed25519.PublicKey
+
+	// Client is used to download manifests.
+	//
+	// If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// DefaultClient is a default client used by package-level functions.
+var DefaultClient = &Client{}
+
+// Parse decodes the manifest, after checking its signature.
+func (c *Client) Parse(manifest, sig []byte) (*Manifest, error) {
+	key, err := c.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed25519.Verify(key, manifest, sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	var m Manifest
+
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return nil, errors.New("release: invalid manifest: " + err.Error())
+	}
+
+	return &m, nil
+}
+
+// Fetch downloads the manifest and its signature from the given URL
+// and verifies it.
+func (c *Client) Fetch(manifestURL string) (*Manifest, error) {
+	manifest, err := c.get(manifestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := c.get(manifestURL + ".sig")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Parse(manifest, sig)
+}
+
+// FetchFor downloads and verifies the manifest for the given version,
+// which describes a file given by fileURL.
+func (c *Client) FetchFor(fileURL string, version int) (*Manifest, error) {
+	u, err := ManifestURL(fileURL, version)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := c.Fetch(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Version != version {
+		return nil, fmt.Errorf("release: manifest version mismatch: want %d, got %d", version, m.Version)
+	}
+
+	return m, nil
+}
+
+func (c *Client) get(u string) ([]byte, error) {
+	resp, err := c.client().Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("release: %s: %s", u, http.StatusText(resp.StatusCode))
+	}
+
+	// Manifests are small, guard against reading arbitrary large responses.
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+func (c *Client) publicKey() (ed25519.PublicKey, error) {
+	if c.PublicKey != nil {
+		return c.PublicKey, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(PublicKey)
+	if err != nil {
+		return nil, errors.New("release: invalid public key: " + err.Error())
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("release: invalid public key size: %d", len(key))
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+func (c *Client) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+// FetchFor downloads and verifies the manifest using DefaultClient.
+func FetchFor(fileURL string, version int) (*Manifest, error) {
+	return DefaultClient.FetchFor(fileURL, version)
+}
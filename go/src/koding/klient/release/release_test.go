@@ -0,0 +1,88 @@
+package release_test
+
+import (
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"koding/klient/release"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestFetchFor(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey()=%s", err)
+	}
+
+	bin := []byte("klient binary")
+
+	m := &release.Manifest{
+		Name:    "klient",
+		Version: 123,
+		Created: time.Now().UTC(),
+		Files: map[string]string{
+			"klient-0.1.123.gz": release.Sum(bin),
+		},
+	}
+
+	manifest, sig, err := release.Sign(m, priv)
+	if err != nil {
+		t.Fatalf("Sign()=%s", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/123/manifest-0.1.123.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(manifest)
+	})
+	mux.HandleFunc("/123/manifest-0.1.123.json.sig", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(sig)
+	})
+
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	fileURL := s.URL + "/123/klient-0.1.123.gz"
+
+	c := &release.Client{PublicKey: pub}
+
+	got, err := c.FetchFor(fileURL, 123)
+	if err != nil {
+		t.Fatalf("FetchFor()=%s", err)
+	}
+
+	if err := got.Verify("klient-0.1.123.gz", bin); err != nil {
+		t.Fatalf("Verify()=%s", err)
+	}
+
+	if err := got.Verify("klient-0.1.123.gz", []byte("tampered binary")); err == nil {
+		t.Fatal("expected Verify() to fail for tampered content")
+	}
+
+	if err := got.Verify("kd-0.1.123.gz", bin); err == nil {
+		t.Fatal("expected Verify() to fail for unknown file")
+	}
+
+	if _, err := c.FetchFor(fileURL, 124); err == nil {
+		t.Fatal("expected FetchFor() to fail for missing manifest")
+	}
+
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey()=%s", err)
+	}
+
+	if _, err := (&release.Client{PublicKey: other}).FetchFor(fileURL, 123); err != release.ErrInvalidSignature {
+		t.Fatalf("got %v, want %v", err, release.ErrInvalidSignature)
+	}
+
+	tampered := append([]byte{}, manifest...)
+	tampered[len(tampered)-2] = ' '
+
+	if _, err := c.Parse(tampered, sig); err != release.ErrInvalidSignature {
+		t.Fatalf("got %v, want %v", err, release.ErrInvalidSignature)
+	}
+}
@@ -0,0 +1,594 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"koding/kites/kloud/machinestate"
+	kstack "koding/kites/kloud/stack"
+	"koding/kites/kloud/stackstate"
+	"koding/kites/kloud/utils/object"
+	"koding/klientctl/endpoint/kloud"
+	"koding/klientctl/endpoint/machine"
+	"koding/klientctl/endpoint/remoteapi"
+	"koding/klientctl/endpoint/stack"
+	"koding/remoteapi/models"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Env brings a development environment to the state
+// described by its project file.
+//
+// Both Up and Down methods are idempotent - each step
+// that was already done is skipped.
+type Env struct {
+	Project *Project // project to manage
+
+	LockFile    string    // lock file; by default LockFile in the project directory
+	Builder     *Builder  // used to generate stack templates; new Builder if nil
+	UseDefaults bool      // forces default values for template variables; disables interactive mode
+	Out         io.Writer // progress output; os.Stdout if nil
+}
+
+// DownOptions are used when tearing down an environment.
+type DownOptions struct {
+	Destroy bool // destroys the stack instead of stopping its machines
+}
+
+// Up builds the project stack if it does not exist yet, starts
+// stopped machines, creates mounts and port forwards and runs
+// commands on machines that were built or started.
+func (e *Env) Up() error {
+	s, built, err := e.upStack()
+	if err != nil {
+		return err
+	}
+
+	machines, err := e.machines(s)
+	if err != nil {
+		return err
+	}
+
+	// Labels of machines that were built or started.
+	started := make(map[string]bool)
+
+	for label, m := range machines {
+		if built {
+			started[label] = true
+			continue
+		}
+
+		switch state := machineState(m); state {
+		case machinestate.Running:
+		case machinestate.Stopped:
+			fmt.Fprintf(e.out(), "Starting %q machine...\n\n", label)
+
+			event, err := machine.Start(&machine.StartOptions{
+				Identifier: m.ID,
+				AskList:    first,
+			})
+			if err != nil {
+				return fmt.Errorf("error starting %q machine: %s", label, err)
+			}
+
+			if err := e.wait(machine.Wait(event)); err != nil {
+				return fmt.Errorf("error starting %q machine: %s", label, err)
+			}
+
+			started[label] = true
+		default:
+			return fmt.Errorf("unable to start %q machine, which is %s", label, state)
+		}
+	}
+
+	// Register stack machines with KD Daemon.
+	if _, err := machine.List(&machine.ListOptions{}); err != nil {
+		return err
+	}
+
+	mounts, err := e.mounts()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range e.Project.Mounts {
+		id, err := e.machineID(machines, m.Machine)
+		if err != nil {
+			return err
+		}
+
+		path := e.Project.Path(m.Local)
+
+		if _, ok := mounts[path]; ok {
+			continue
+		}
+
+		opts := &machine.MountOptions{
+			Identifier: id,
+			Path:       path,
+			RemotePath: m.Remote,
+			Ignore:     m.Ignore,
+			AskList:    first,
+		}
+
+		if err := machine.Mount(opts); err != nil {
+			return fmt.Errorf("error mounting %s: %s", m.Local, err)
+		}
+	}
+
+	for _, f := range e.Project.Forwards {
+		id, err := e.machineID(machines, f.Machine)
+		if err != nil {
+			return err
+		}
+
+		opts := &machine.ForwardOptions{
+			Identifier: id,
+			LocalPort:  f.Local,
+			RemotePort: f.Remote,
+			AskList:    first,
+		}
+
+		if err := machine.Forward(opts); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(e.out(), "Forwarding localhost:%d to port %d.\n", f.Local, f.Remote)
+	}
+
+	for _, c := range e.Project.Commands {
+		id, err := e.machineID(machines, c.Machine)
+		if err != nil {
+			return err
+		}
+
+		if !started[machines.label(id)] {
+			continue
+		}
+
+		if err := e.exec(id, c.Run); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(e.out(), "\n%q stack is up.\n", str(s.Title))
+
+	return nil
+}
+
+// Down removes port forwards and mounts of the project and stops
+// all the stack machines. If opts.Destroy is true, the stack
+// is destroyed instead.
+func (e *Env) Down(opts *DownOptions) error {
+	if opts == nil {
+		opts = &DownOptions{}
+	}
+
+	l, err := ReadLock(e.lockFile())
+	if err != nil {
+		return err
+	}
+
+	if l == nil {
+		fmt.Fprintln(e.out(), "Project has no stack, nothing to do.")
+		return nil
+	}
+
+	s, err := getStack(l.Stack.ID)
+	if err == remoteapi.ErrNotFound {
+		fmt.Fprintf(e.out(), "%q stack no longer exists.\n", l.Stack.Title)
+		return os.Remove(e.lockFile())
+	}
+	if err != nil {
+		return err
+	}
+
+	machines, err := e.machines(s)
+	if err != nil {
+		return err
+	}
+
+	// Register stack machines with KD Daemon.
+	if _, err := machine.List(&machine.ListOptions{}); err != nil {
+		return err
+	}
+
+	for _, f := range e.Project.Forwards {
+		id, err := e.machineID(machines, f.Machine)
+		if err != nil {
+			return err
+		}
+
+		opts := &machine.ForwardOptions{
+			Identifier: id,
+			LocalPort:  f.Local,
+			RemotePort: f.Remote,
+			AskList:    first,
+		}
+
+		if err := machine.Unforward(opts); err != nil {
+			return err
+		}
+	}
+
+	mounts, err := e.mounts()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+
+	for _, m := range e.Project.Mounts {
+		if id, ok := mounts[e.Project.Path(m.Local)]; ok {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) != 0 {
+		if err := machine.Umount(&machine.UmountOptions{Identifiers: ids, Force: true}); err != nil {
+			return err
+		}
+	}
+
+	if opts.Destroy {
+		fmt.Fprintf(e.out(), "Destroying %q stack...\n\n", str(s.Title))
+
+		resp, err := stack.Apply(&stack.ApplyOptions{
+			StackID: s.ID,
+			Team:    e.Project.Stack.Team,
+			Destroy: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := e.wait(kloud.Wait(resp.EventId)); err != nil {
+			return fmt.Errorf("error destroying %q stack: %s", str(s.Title), err)
+		}
+
+		return os.Remove(e.lockFile())
+	}
+
+	for label, m := range machines {
+		if machineState(m) != machinestate.Running {
+			continue
+		}
+
+		fmt.Fprintf(e.out(), "Stopping %q machine...\n\n", label)
+
+		event, err := machine.Stop(&machine.StopOptions{
+			Identifier: m.ID,
+			AskList:    first,
+		})
+		if err != nil {
+			return fmt.Errorf("error stopping %q machine: %s", label, err)
+		}
+
+		if err := e.wait(machine.Wait(event)); err != nil {
+			return fmt.Errorf("error stopping %q machine: %s", label, err)
+		}
+	}
+
+	fmt.Fprintf(e.out(), "\n%q stack is down.\n", str(s.Title))
+
+	return nil
+}
+
+// upStack looks up the project stack and builds it if needed.
+//
+// It returns true if the stack was built.
+func (e *Env) upStack() (*models.JComputeStack, bool, error) {
+	l, err := ReadLock(e.lockFile())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if l != nil {
+		s, err := getStack(l.Stack.ID)
+		if err != nil && err != remoteapi.ErrNotFound {
+			return nil, false, err
+		}
+
+		if err == nil {
+			return e.buildStack(s)
+		}
+
+		fmt.Fprintf(e.out(), "%q stack no longer exists, creating a new one.\n\n", l.Stack.Title)
+	}
+
+	tmpl, err := e.template()
+	if err != nil {
+		return nil, false, err
+	}
+
+	fmt.Fprintf(e.out(), "Creating %q stack...\n\n", e.Project.Stack.Title)
+
+	resp, err := stack.Create(&stack.CreateOptions{
+		Team:        e.Project.Stack.Team,
+		Title:       e.Project.Stack.Title,
+		Credentials: e.Project.Stack.Credentials,
+		Template:    tmpl,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := e.wait(kloud.Wait(resp.EventID)); err != nil {
+		return nil, false, fmt.Errorf("building %q stack failed: %s", resp.Title, err)
+	}
+
+	if err := WriteLock(e.lockFile(), resp.StackID, resp.Title); err != nil {
+		return nil, false, err
+	}
+
+	s, err := getStack(resp.StackID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s, true, nil
+}
+
+// buildStack builds the existing stack if it is not yet built.
+func (e *Env) buildStack(s *models.JComputeStack) (*models.JComputeStack, bool, error) {
+	var state stackstate.State
+
+	if s.Status != nil {
+		state = stackstate.States[s.Status.State]
+	}
+
+	switch state {
+	case stackstate.Initialized:
+		return s, false, nil
+	case stackstate.Building:
+		fmt.Fprintf(e.out(), "Waiting for %q stack to finish building...\n\n", str(s.Title))
+
+		if err := e.wait(kloud.Wait(stack.EventID(s.ID))); err != nil {
+			return nil, false, fmt.Errorf("building %q stack failed: %s", str(s.Title), err)
+		}
+	case stackstate.NotInitialized:
+		fmt.Fprintf(e.out(), "Building %q stack...\n\n", str(s.Title))
+
+		resp, err := stack.Apply(&stack.ApplyOptions{
+			StackID: s.ID,
+			Team:    e.Project.Stack.Team,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		if err := e.wait(kloud.Wait(resp.EventId)); err != nil {
+			return nil, false, fmt.Errorf("building %q stack failed: %s", str(s.Title), err)
+		}
+	default:
+		return nil, false, fmt.Errorf("unable to build %q stack, which is %s", str(s.Title), state)
+	}
+
+	s, err := getStack(s.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s, true, nil
+}
+
+// template generates the project stack template.
+func (e *Env) template() ([]byte, error) {
+	var tmpl string
+
+	if e.Project.Stack.Template != "" {
+		p, err := ioutil.ReadFile(e.Project.Path(e.Project.Stack.Template))
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+
+		if err := yaml.Unmarshal(p, &v); err != nil {
+			return nil, fmt.Errorf("unable to read %s: %s", e.Project.Stack.Template, err)
+		}
+
+		p, err = json.Marshal(object.FixYAML(v))
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl = string(p)
+	}
+
+	m, err := e.Project.Mixin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mixin: %s", err)
+	}
+
+	b := e.builder()
+
+	err = b.BuildTemplate(&TemplateOptions{
+		UseDefaults: e.UseDefaults,
+		Provider:    e.Project.Stack.Provider,
+		Template:    tmpl,
+		Mixin:       m,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(b.Stack)
+}
+
+// exec runs the given command on a remote machine.
+func (e *Env) exec(id, command string) error {
+	done := make(chan int, 1)
+
+	fmt.Fprintf(e.out(), "Running %q...\n", command)
+
+	opts := &machine.ExecOptions{
+		MachineID: id,
+		Cmd:       "/bin/bash",
+		Args:      []string{"-c", command},
+		Stdout: func(line string) {
+			fmt.Fprintln(e.out(), line)
+		},
+		Stderr: func(line string) {
+			fmt.Fprintln(e.out(), line)
+		},
+		Exit: func(exit int) {
+			done <- exit
+			close(done)
+		},
+	}
+
+	if _, err := machine.Exec(opts); err != nil {
+		return fmt.Errorf("error running %q: %s", command, err)
+	}
+
+	if exit := <-done; exit != 0 {
+		return fmt.Errorf("command %q returned non zero exit code: %d", command, exit)
+	}
+
+	return nil
+}
+
+func (e *Env) wait(events <-chan *kstack.EventResponse) error {
+	for ev := range events {
+		if ev.Error != nil {
+			return ev.Error
+		}
+
+		fmt.Fprintf(e.out(), "[%d%%] %s\n", ev.Event.Percentage, ev.Event.Message)
+	}
+
+	fmt.Fprintln(e.out())
+
+	return nil
+}
+
+// machines gives the stack machines, keyed by their labels.
+func (e *Env) machines(s *models.JComputeStack) (machineMap, error) {
+	machines := make(machineMap)
+
+	for _, id := range remoteapi.StackMachineIDs(s) {
+		m, err := remoteapi.ListMachines(&remoteapi.Filter{ID: id})
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up %s machine: %s", id, err)
+		}
+
+		machines[m[0].Label] = m[0]
+	}
+
+	if len(machines) == 0 {
+		return nil, fmt.Errorf("%q stack has no machines", str(s.Title))
+	}
+
+	return machines, nil
+}
+
+// machineID gives an ID of the machine with the given label.
+//
+// The label can be empty, if the stack has only one machine.
+func (e *Env) machineID(machines machineMap, label string) (string, error) {
+	if label == "" {
+		if len(machines) != 1 {
+			return "", errors.New("machine label is required for stacks with multiple machines")
+		}
+
+		for _, m := range machines {
+			return m.ID, nil
+		}
+	}
+
+	m, ok := machines[label]
+	if !ok {
+		return "", fmt.Errorf("machine %q not found", label)
+	}
+
+	return m.ID, nil
+}
+
+// mounts gives IDs of existing mounts keyed by their local paths.
+func (e *Env) mounts() (map[string]string, error) {
+	all, err := machine.ListMount(&machine.ListMountOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make(map[string]string)
+
+	for _, infos := range all {
+		for _, info := range infos {
+			mounts[filepath.Clean(info.Mount.Path)] = string(info.ID)
+		}
+	}
+
+	return mounts, nil
+}
+
+func (e *Env) lockFile() string {
+	if e.LockFile != "" {
+		return e.LockFile
+	}
+	return filepath.Join(e.Project.Dir, LockFile)
+}
+
+func (e *Env) builder() *Builder {
+	if e.Builder != nil {
+		return e.Builder
+	}
+	return &Builder{}
+}
+
+func (e *Env) out() io.Writer {
+	if e.Out != nil {
+		return e.Out
+	}
+	return os.Stdout
+}
+
+type machineMap map[string]*models.JMachine
+
+func (mm machineMap) label(id string) string {
+	for label, m := range mm {
+		if m.ID == id {
+			return label
+		}
+	}
+	return ""
+}
+
+func getStack(id string) (*models.JComputeStack, error) {
+	stacks, err := remoteapi.ListStacks(&remoteapi.Filter{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return stacks[0], nil
+}
+
+func machineState(m *models.JMachine) machinestate.State {
+	if m.Status == nil {
+		return machinestate.Unknown
+	}
+	return machinestate.States[m.Status.State]
+}
+
+// first is used in place of interactive machine choice,
+// as machines are always looked up by their IDs.
+func first(ids, _ []string) (string, error) {
+	if len(ids) == 0 {
+		return "", errors.New("machine not found")
+	}
+	return ids[0], nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
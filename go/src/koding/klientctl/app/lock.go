@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// LockFile is a name of the file, which stores a stack
+// that was built for the project.
+const LockFile = ".kd.lock"
+
+// Lock describes a stack that was built for the project.
+type Lock struct {
+	Stack struct {
+		ID    string `yaml:"id"`
+		Title string `yaml:"title"`
+	} `yaml:"stack"`
+}
+
+// ReadLock reads the given lock file.
+//
+// If the file does not exist, the function returns
+// nil lock and nil error.
+func ReadLock(file string) (*Lock, error) {
+	p, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var l Lock
+
+	if err := yaml.Unmarshal(p, &l); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", file, err)
+	}
+
+	return &l, nil
+}
+
+// WriteLock writes lock file for the given stack.
+func WriteLock(file, id, title string) error {
+	var l Lock
+	l.Stack.ID = id
+	l.Stack.Title = title
+
+	p, err := yaml.Marshal(&l)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, p, 0644)
+}
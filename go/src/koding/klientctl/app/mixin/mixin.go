@@ -1,6 +1,8 @@
 package mixin
 
 import (
+	"errors"
+
 	"koding/kites/kloud/metadata"
 
 	yaml "gopkg.in/yaml.v2"
@@ -23,15 +25,29 @@ type Mixin struct {
 // If cloud-init is empty or p has unexpected content or format,
 // the function panics.
 func New(p []byte) *Mixin {
+	m, err := Parse(p)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Parse gives new mixin by unmarshaling yaml-encoded p
+// into a Mixin value.
+//
+// It returns non-nil error if cloud-init is empty or p has
+// unexpected content or format.
+func Parse(p []byte) (*Mixin, error) {
 	var m Mixin
 
 	if err := yaml.Unmarshal(p, &m); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if len(m.CloudInit) == 0 {
-		panic("empty cloud-init script")
+		return nil, errors.New("empty cloud-init script")
 	}
 
-	return &m
+	return &m, nil
 }
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"koding/klient/machine/index/filter"
+	"koding/klientctl/app/mixin"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ProjectFile is a default name of the project file.
+const ProjectFile = ".kd.yml"
+
+// Project describes a development environment, which is brought
+// up with "kd up" and torn down with "kd down".
+//
+// Example project file:
+//
+//   stack:
+//     title: My Project
+//     provider: aws
+//     mixin: app
+//     credentials:
+//     - 1d4b1c8e2bd4c91f8dd1b1e4c8d3a6a1
+//   mounts:
+//   - remote: /var/lib/koding/app
+//     local: ./app
+//     ignore:
+//     - node_modules
+//     - "*.log"
+//   forwards:
+//   - local: 8080
+//     remote: 8080
+//   commands:
+//   - run: /var/lib/koding/run
+//
+type Project struct {
+	Stack    ProjectStack      `json:"stack" yaml:"stack"`
+	Mounts   []*ProjectMount   `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+	Forwards []*ProjectForward `json:"forwards,omitempty" yaml:"forwards,omitempty"`
+	Commands []*ProjectCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+
+	// Dir is a directory of the project file. Relative paths
+	// of the project are resolved against it.
+	Dir string `json:"-" yaml:"-"`
+}
+
+// ProjectStack describes a stack of the project.
+//
+// The stack template is either read from a file given by Template,
+// or generated from a sample template of the Provider. Optionally
+// the template's user data is replaced with a Mixin, which is
+// either a name of a builtin mixin or a path to a mixin file.
+type ProjectStack struct {
+	Title       string   `json:"title" yaml:"title"`
+	Team        string   `json:"team,omitempty" yaml:"team,omitempty"`
+	Template    string   `json:"template,omitempty" yaml:"template,omitempty"`
+	Provider    string   `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Mixin       string   `json:"mixin,omitempty" yaml:"mixin,omitempty"`
+	Credentials []string `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+}
+
+// ProjectMount describes a mount of a remote directory.
+//
+// Machine is a label of the stack machine; it can be
+// omitted for stacks with a single machine.
+type ProjectMount struct {
+	Machine string   `json:"machine,omitempty" yaml:"machine,omitempty"`
+	Remote  string   `json:"remote" yaml:"remote"`
+	Local   string   `json:"local" yaml:"local"`
+	Ignore  []string `json:"ignore,omitempty" yaml:"ignore,omitempty"`
+}
+
+// ProjectForward describes forwarding of a local port
+// to a port on the remote machine.
+type ProjectForward struct {
+	Machine string `json:"machine,omitempty" yaml:"machine,omitempty"`
+	Local   int    `json:"local" yaml:"local"`
+	Remote  int    `json:"remote" yaml:"remote"`
+}
+
+// ProjectCommand describes a command, which is run on the
+// remote machine after it is built or started.
+type ProjectCommand struct {
+	Machine string `json:"machine,omitempty" yaml:"machine,omitempty"`
+	Run     string `json:"run" yaml:"run"`
+}
+
+// ReadProject reads and validates a project file.
+func ReadProject(file string) (*Project, error) {
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var proj Project
+
+	if err := yaml.Unmarshal(p, &proj); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", file, err)
+	}
+
+	if proj.Dir, err = filepath.Abs(filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+
+	if err := proj.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", file, err)
+	}
+
+	return &proj, nil
+}
+
+// Valid implements the stack.Validator interface.
+func (p *Project) Valid() error {
+	if p.Stack.Title == "" {
+		return errors.New("stack title is missing")
+	}
+
+	if p.Stack.Template == "" && p.Stack.Provider == "" {
+		return errors.New("either stack template or provider is required")
+	}
+
+	paths := make(map[string]struct{}, len(p.Mounts))
+
+	for i, m := range p.Mounts {
+		if m.Remote == "" {
+			return fmt.Errorf("mount %d: remote path is missing", i)
+		}
+
+		if m.Local == "" {
+			return fmt.Errorf("mount %d: local path is missing", i)
+		}
+
+		if _, ok := paths[p.Path(m.Local)]; ok {
+			return fmt.Errorf("mount %d: duplicated local path %q", i, m.Local)
+		}
+
+		paths[p.Path(m.Local)] = struct{}{}
+
+		for _, pattern := range m.Ignore {
+			if err := filter.ValidGlob(pattern); err != nil {
+				return fmt.Errorf("mount %d: invalid ignore pattern %q: %s", i, pattern, err)
+			}
+		}
+	}
+
+	ports := make(map[int]struct{}, len(p.Forwards))
+
+	for i, f := range p.Forwards {
+		if f.Local <= 0 || f.Local > 65535 {
+			return fmt.Errorf("forward %d: invalid local port %d", i, f.Local)
+		}
+
+		if f.Remote <= 0 || f.Remote > 65535 {
+			return fmt.Errorf("forward %d: invalid remote port %d", i, f.Remote)
+		}
+
+		if _, ok := ports[f.Local]; ok {
+			return fmt.Errorf("forward %d: duplicated local port %d", i, f.Local)
+		}
+
+		ports[f.Local] = struct{}{}
+	}
+
+	for i, c := range p.Commands {
+		if c.Run == "" {
+			return fmt.Errorf("command %d: nothing to run", i)
+		}
+	}
+
+	return nil
+}
+
+// Path resolves the given path relative to the project directory.
+func (p *Project) Path(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(p.Dir, path)
+}
+
+// Mixin gives a mixin of the project stack.
+//
+// If the stack has no mixin, the method returns nil.
+func (p *Project) Mixin() (*mixin.Mixin, error) {
+	switch p.Stack.Mixin {
+	case "":
+		return nil, nil
+	case "app":
+		return mixin.App, nil
+	}
+
+	b, err := ioutil.ReadFile(p.Path(p.Stack.Mixin))
+	if err != nil {
+		return nil, err
+	}
+
+	return mixin.Parse(b)
+}
@@ -0,0 +1,134 @@
+package app_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"koding/klientctl/app"
+)
+
+func TestReadProject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kd-project")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, app.ProjectFile)
+
+	content := []byte(`stack:
+  title: My Project
+  provider: aws
+mounts:
+- remote: /var/lib/koding/app
+  local: ./app
+  ignore:
+  - node_modules
+  - "*.log"
+forwards:
+- local: 8080
+  remote: 80
+commands:
+- run: make
+`)
+
+	if err := ioutil.WriteFile(file, content, 0644); err != nil {
+		t.Fatalf("WriteFile()=%s", err)
+	}
+
+	p, err := app.ReadProject(file)
+	if err != nil {
+		t.Fatalf("ReadProject()=%s", err)
+	}
+
+	want := &app.Project{
+		Stack: app.ProjectStack{
+			Title:    "My Project",
+			Provider: "aws",
+		},
+		Mounts: []*app.ProjectMount{{
+			Remote: "/var/lib/koding/app",
+			Local:  "./app",
+			Ignore: []string{"node_modules", "*.log"},
+		}},
+		Forwards: []*app.ProjectForward{{
+			Local:  8080,
+			Remote: 80,
+		}},
+		Commands: []*app.ProjectCommand{{
+			Run: "make",
+		}},
+		Dir: dir,
+	}
+
+	if !reflect.DeepEqual(p, want) {
+		t.Fatalf("got %+v, want %+v", p, want)
+	}
+
+	if got, want := p.Path("./app"), filepath.Join(dir, "app"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got, want := p.Path("/tmp/app/"), "/tmp/app"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestProjectValid(t *testing.T) {
+	stack := app.ProjectStack{
+		Title:    "My Project",
+		Provider: "aws",
+	}
+
+	cases := map[string]*app.Project{
+		"missing title": {
+			Stack: app.ProjectStack{Provider: "aws"},
+		},
+		"missing template": {
+			Stack: app.ProjectStack{Title: "My Project"},
+		},
+		"missing remote path": {
+			Stack:  stack,
+			Mounts: []*app.ProjectMount{{Local: "./app"}},
+		},
+		"duplicated local path": {
+			Stack: stack,
+			Mounts: []*app.ProjectMount{
+				{Remote: "/app", Local: "./app"},
+				{Remote: "/other", Local: "app/"},
+			},
+		},
+		"invalid ignore pattern": {
+			Stack:  stack,
+			Mounts: []*app.ProjectMount{{Remote: "/app", Local: "./app", Ignore: []string{"[a-"}}},
+		},
+		"invalid port": {
+			Stack:    stack,
+			Forwards: []*app.ProjectForward{{Local: 8080, Remote: 65536}},
+		},
+		"duplicated local port": {
+			Stack: stack,
+			Forwards: []*app.ProjectForward{
+				{Local: 8080, Remote: 80},
+				{Local: 8080, Remote: 8080},
+			},
+		},
+		"empty command": {
+			Stack:    stack,
+			Commands: []*app.ProjectCommand{{Machine: "example"}},
+		},
+	}
+
+	for name, p := range cases {
+		// capture range variable here
+		p := p
+		t.Run(name, func(t *testing.T) {
+			if err := p.Valid(); err == nil {
+				t.Fatal("expected Valid() to fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package bug
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"koding/klient/uploader"
+	"koding/klientctl/endpoint/machine"
+	"koding/klientctl/stream"
+)
+
+// BundleOptions are used to create a diagnostic bundle.
+type BundleOptions struct {
+	File string // path of the tar.gz archive to create
+}
+
+// Valid checks whether the options are complete.
+func (opts *BundleOptions) Valid() error {
+	if opts == nil {
+		return errors.New("bug: arguments are missing")
+	}
+
+	if opts.File == "" {
+		return errors.New("bug: bundle file is missing")
+	}
+
+	return nil
+}
+
+// Manifest describes content of a diagnostic bundle.
+type Manifest struct {
+	KiteID      string        `json:"kiteID"`
+	Version     int           `json:"version"`
+	Environment string        `json:"environment"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	Files       []*BundleFile `json:"files"`
+	Errors      []string      `json:"errors,omitempty"` // failures of best-effort collectors
+}
+
+// BundleFile describes a single file of a diagnostic bundle.
+type BundleFile struct {
+	Name       string `json:"name"`             // path within the archive
+	Source     string `json:"source,omitempty"` // local file the content was read from
+	Size       int64  `json:"size"`
+	Redactions int    `json:"redactions"` // number of removed secrets
+}
+
+// Bundle collects logs, configuration and state of kd and klient
+// into a local tar.gz archive, without uploading anything.
+//
+// Each collector is best-effort - a failure is recorded in the
+// manifest and does not prevent other files from being collected.
+// Tokens, kite keys and credential fields are redacted from all
+// the files.
+func Bundle(s stream.Streamer, opts *BundleOptions) (*Manifest, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	meta := metadata()
+
+	b := &bundle{
+		m: &Manifest{
+			KiteID:      meta.KiteID,
+			Version:     meta.Version,
+			Environment: meta.Environment,
+			CreatedAt:   meta.CreatedAt,
+		},
+	}
+
+	f, err := os.OpenFile(opts.File, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+	b.tw = tar.NewWriter(gz)
+
+	collectors := []struct {
+		name    string
+		collect func() error
+	}{
+		{"logs", b.logs},
+		{"konfig", func() error { return b.addJSON("konfig.json", meta.Konfig) }},
+		{"machines", b.machines},
+		{"mounts", b.mounts},
+		{"usage", b.usage},
+	}
+
+	for _, c := range collectors {
+		fmt.Fprintf(s.Err(), "Collecting %s...\n", c.name)
+
+		if err := c.collect(); err != nil {
+			if b.err != nil {
+				break
+			}
+
+			b.m.Errors = append(b.m.Errors, c.name+": "+err.Error())
+		}
+	}
+
+	if b.err == nil {
+		b.err = b.addJSON("manifest.json", b.m)
+	}
+
+	for _, c := range []io.Closer{b.tw, gz, f} {
+		if err := c.Close(); err != nil && b.err == nil {
+			b.err = err
+		}
+	}
+
+	if b.err != nil {
+		os.Remove(opts.File)
+		return nil, b.err
+	}
+
+	return b.m, nil
+}
+
+type bundle struct {
+	tw  *tar.Writer
+	m   *Manifest
+	err error // archive write failure
+}
+
+func (b *bundle) logs() error {
+	var errs []string
+
+	for _, log := range uploader.LogFiles {
+		for _, file := range rotated(log) {
+			if err := b.addLog(file); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+
+	if b.err != nil {
+		return b.err
+	}
+
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (b *bundle) addLog(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	name := file
+
+	if strings.HasSuffix(file, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+		defer gz.Close()
+
+		r = gz
+		name = strings.TrimSuffix(file, ".gz")
+	}
+
+	p, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%s: %s", file, err)
+	}
+
+	return b.add("logs/"+strings.TrimLeft(filepath.ToSlash(name), "/"), file, p)
+}
+
+func (b *bundle) machines() error {
+	infos, err := machine.List(&machine.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	return b.addJSON("machines.json", infos)
+}
+
+func (b *bundle) mounts() error {
+	mounts, err := machine.ListMount(&machine.ListMountOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err := b.addJSON("mounts.json", mounts); err != nil {
+		return err
+	}
+
+	var errs []string
+
+	for _, infos := range mounts {
+		for _, info := range infos {
+			opts := &machine.InspectMountOptions{
+				Identifier: string(info.ID),
+				Sync:       true,
+				Tree:       true,
+				Filesystem: true,
+			}
+
+			resp, err := machine.InspectMount(opts)
+			if err != nil {
+				errs = append(errs, string(info.ID)+": "+err.Error())
+				continue
+			}
+
+			dir := "mounts/" + string(info.ID) + "/"
+
+			if err := b.addJSON(dir+"sync.json", resp.Sync); err != nil {
+				return err
+			}
+
+			if err := b.addJSON(dir+"index.json", resp.Tree); err != nil {
+				return err
+			}
+
+			if err := b.addJSON(dir+"filesystem.json", resp.Filesystem); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (b *bundle) usage() error {
+	u, err := machine.Usage()
+	if err != nil {
+		return err
+	}
+
+	return b.addJSON("usage.json", u)
+}
+
+// addJSON adds v to the archive as a JSON file, redacting
+// values of secret fields.
+func (b *bundle) addJSON(name string, v interface{}) error {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var jv interface{}
+
+	if err := json.Unmarshal(p, &jv); err != nil {
+		return err
+	}
+
+	n := RedactValue(jv)
+
+	if p, err = json.MarshalIndent(jv, "", "\t"); err != nil {
+		return err
+	}
+
+	return b.addRedacted(name, "", append(p, '\n'), n)
+}
+
+// add adds p to the archive, redacting secrets found in the text.
+func (b *bundle) add(name, source string, p []byte) error {
+	return b.addRedacted(name, source, p, 0)
+}
+
+func (b *bundle) addRedacted(name, source string, p []byte, n int) error {
+	p, m := Redact(p)
+
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(p)),
+		ModTime: b.m.CreatedAt,
+	}
+
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		b.err = err
+		return err
+	}
+
+	if _, err := b.tw.Write(p); err != nil {
+		b.err = err
+		return err
+	}
+
+	if name != "manifest.json" {
+		b.m.Files = append(b.m.Files, &BundleFile{
+			Name:       name,
+			Source:     source,
+			Size:       hdr.Size,
+			Redactions: n + m,
+		})
+	}
+
+	return nil
+}
+
+// rotated gives the log file together with its rotated
+// copies, e.g. kd.log.1 or kd.log.2.gz, which exist.
+func rotated(log string) []string {
+	var files []string
+
+	if _, err := os.Stat(log); err == nil {
+		files = append(files, log)
+	}
+
+	for _, pattern := range []string{log + ".*", log + "-*"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+
+		sort.Strings(matches)
+
+		files = append(files, matches...)
+	}
+
+	return files
+}
@@ -0,0 +1,89 @@
+package bug
+
+import (
+	"regexp"
+)
+
+// Redacted replaces sensitive values removed from a bundle.
+const Redacted = "<redacted>"
+
+// secretKey matches names of fields, which hold tokens,
+// kite keys, passwords or credential data.
+var secretKey = regexp.MustCompile(`(?i)(token|secret|passw(or)?d|kite_?key|private_?key|access_?key|api_?key|session_?id|credential)`)
+
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{{
+	// JWT tokens, e.g. kite keys or kite tokens.
+	re:   regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	repl: Redacted,
+}, {
+	// AWS access key IDs.
+	re:   regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	repl: Redacted,
+}, {
+	// key=value, key: value and "key": "value" pairs
+	// with secret key names.
+	re:   regexp.MustCompile(`(?i)("?[A-Za-z_]*(token|secret|passw(or)?d|kite_?key|private_?key|access_?key|api_?key|session_?id)"?\s*[:=]\s*"?)([^\s"&,;<]+)`),
+	repl: "${1}" + Redacted,
+}}
+
+// Redact replaces tokens, kite keys and other secrets found
+// in the given text. It returns the redacted text and
+// a number of replaced values.
+func Redact(p []byte) ([]byte, int) {
+	var n int
+
+	for _, pat := range secretPatterns {
+		p = pat.re.ReplaceAllFunc(p, func(match []byte) []byte {
+			n++
+			return pat.re.ReplaceAll(match, []byte(pat.repl))
+		})
+	}
+
+	return p, n
+}
+
+// RedactValue replaces values of secret fields in the given
+// JSON value, as decoded by the encoding/json package. All string
+// values nested in a secret field, e.g. credential data, are
+// replaced as well. It returns a number of replaced values.
+func RedactValue(v interface{}) int {
+	return redactValue(v, false)
+}
+
+func redactValue(v interface{}, secret bool) (n int) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			isSecret := secret || secretKey.MatchString(key)
+
+			if s, ok := val.(string); ok {
+				if isSecret && s != "" && s != Redacted {
+					v[key] = Redacted
+					n++
+				}
+
+				continue
+			}
+
+			n += redactValue(val, isSecret)
+		}
+	case []interface{}:
+		for i, val := range v {
+			if s, ok := val.(string); ok {
+				if secret && s != "" && s != Redacted {
+					v[i] = Redacted
+					n++
+				}
+
+				continue
+			}
+
+			n += redactValue(val, secret)
+		}
+	}
+
+	return n
+}
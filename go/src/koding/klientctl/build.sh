@@ -6,6 +6,7 @@ REPO_PATH=$(git rev-parse --show-toplevel)
 CHANNEL=${1:-}
 VERSION=${2:-}
 KD_SEGMENTIO_KEY=${KD_SEGMENTIO_KEY}
+RELEASE_PUBLIC_KEY=${RELEASE_PUBLIC_KEY:-L98imPIZIms23n0ppppSBdA30rtHQaSs4Tx8tjDBFks=}
 
 export GOBIN="${REPO_PATH}"
 
@@ -34,7 +35,7 @@ if [[ -z "$VERSION" ]]; then
 fi
 
 kd_build() {
-	go install -v -ldflags "-X koding/klientctl/config.Version=$VERSION -X koding/klientctl/config.SegmentKey=$KD_SEGMENTIO_KEY -X koding/klientctl/config.Environment=$CHANNEL" koding/klientctl
+	go install -v -ldflags "-X koding/klientctl/config.Version=$VERSION -X koding/klientctl/config.SegmentKey=$KD_SEGMENTIO_KEY -X koding/klientctl/config.Environment=$CHANNEL -X koding/klient/release.PublicKey=$RELEASE_PUBLIC_KEY" koding/klientctl
 	mv "${REPO_PATH}/klientctl" "${REPO_PATH}/kd"
 }
 
@@ -13,11 +13,10 @@ import (
 )
 
 type loginOptions struct {
-	token      string
-	baseURL    string
-	team       string
-	jsonOutput bool
-	force      bool
+	token   string
+	baseURL string
+	team    string
+	force   bool
 }
 
 // NewLoginCommand creates a command that allows to log into Koding account.
@@ -35,12 +34,12 @@ func NewLoginCommand(c *cli.CLI) *cobra.Command {
 	flags.StringVar(&opts.token, "token", "", "temporary authorization token")
 	flags.StringVar(&opts.baseURL, "baseurl", config.Konfig.Endpoints.Koding.Public.String(), "service login endpoint")
 	flags.StringVar(&opts.team, "team", "kd.io", "team to login")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 	flags.BoolVarP(&opts.force, "force", "f", false, "force new session")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
-		cli.NoArgs, // No custom arguments are accepted.
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -76,9 +75,13 @@ func loginCommand(c *cli.CLI, opts *loginOptions) cli.CobraFuncE {
 			return fmt.Errorf("error logging into your Koding account: %v", err)
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), resp)
-			return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), resp, nil)
 		}
 
 		if resp.GroupName != "" {
@@ -1,18 +1,13 @@
 package auth
 
 import (
-	"fmt"
-	"text/tabwriter"
-
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/endpoint/auth"
 
 	"github.com/spf13/cobra"
 )
 
-type showOptions struct {
-	jsonOutput bool
-}
+type showOptions struct{}
 
 // NewShowCommand creates a command that displays current session details.
 func NewShowCommand(c *cli.CLI) *cobra.Command {
@@ -24,13 +19,10 @@ func NewShowCommand(c *cli.CLI) *cobra.Command {
 		RunE:  showCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
-		cli.NoArgs, // No custom arguments are accepted.
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -40,25 +32,18 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 	return func(cmd *cobra.Command, args []string) error {
 		info := auth.Used()
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), info)
-		} else {
-			printInfo(c, info)
-		}
-
-		return nil
+		return cli.Print(c, cmd, info, func(t *cli.Table) {
+			printInfo(t, info)
+		})
 	}
 }
 
-func printInfo(c *cli.CLI, info *auth.Info) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
+func printInfo(t *cli.Table, info *auth.Info) {
 	team := "-"
 	if info.Session != nil && info.Session.Team != "" {
 		team = info.Session.Team
 	}
 
-	fmt.Fprintln(w, "USERNAME\tTEAM\tBASEURL")
-	fmt.Fprintf(w, "%s\t%s\t%s\n", info.Username, team, info.BaseURL)
+	t.Header("USERNAME", "TEAM", "BASEURL")
+	t.Row(info.Username, team, info.BaseURL)
 }
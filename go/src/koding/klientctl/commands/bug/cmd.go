@@ -1,13 +1,17 @@
 package bug
 
 import (
+	"fmt"
+
 	"koding/klientctl/bug"
 	"koding/klientctl/commands/cli"
 
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+type options struct {
+	bundle string
+}
 
 // NewCommand creates a command that allows to report a bug.
 func NewCommand(c *cli.CLI) *cobra.Command {
@@ -16,20 +20,55 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bug",
 		Short: "Send a bug report",
-		RunE:  command(c, opts),
+		Long: "Send a bug report. Logs and diagnostic data are uploaded to Koding\n" +
+			"and a new GitHub issue is opened in the browser.\n\n" +
+			"With --bundle flag, nothing is uploaded - logs, redacted configuration,\n" +
+			"machine, mount and sync state are written to a local tar.gz archive instead.",
+		RunE: command(c, opts),
 	}
 
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVar(&opts.bundle, "bundle", "", "write diagnostic bundle to a local tar.gz file")
+
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
 }
 
-func command(c *cli.CLI, _ *options) cli.CobraFuncE {
+func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 	return func(cmd *cobra.Command, args []string) error {
-		return bug.Bug(c)
+		if opts.bundle == "" {
+			return bug.Bug(c)
+		}
+
+		m, err := bug.Bundle(c, &bug.BundleOptions{File: opts.bundle})
+		if err != nil {
+			return fmt.Errorf("error creating diagnostic bundle: %v", err)
+		}
+
+		for _, e := range m.Errors {
+			fmt.Fprintln(c.Err(), "Warning:", e)
+		}
+
+		err = cli.Print(c, cmd, m, func(t *cli.Table) {
+			t.Header("FILE", "SIZE", "REDACTIONS")
+
+			for _, f := range m.Files {
+				t.Row(f.Name, f.Size, f.Redactions)
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.Err(), "\nDiagnostic bundle was written to %s.\n", opts.bundle)
+
+		return nil
 	}
 }
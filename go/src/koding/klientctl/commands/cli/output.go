@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	FormatTable    = "table"       // human readable table, the default
+	FormatJSON     = "json"        // indented JSON
+	FormatYAML     = "yaml"        // YAML
+	FormatTemplate = "go-template" // Go template, given as go-template=...
+)
+
+// Output describes how a command prints its results.
+type Output struct {
+	Format    string             // one of Format* constants
+	Template  *template.Template // for FormatTemplate
+	NoHeaders bool               // whether to omit table headers
+}
+
+// IsMachineReadable tells whether output is meant to be parsed
+// by other programs. Nil output is a table one.
+func (o *Output) IsMachineReadable() bool {
+	return o != nil && o.Format != FormatTable
+}
+
+// ParseOutput parses value of the --output flag.
+func ParseOutput(s string) (*Output, error) {
+	switch {
+	case s == "", s == FormatTable:
+		return &Output{Format: FormatTable}, nil
+	case s == FormatJSON, s == FormatYAML:
+		return &Output{Format: s}, nil
+	case strings.HasPrefix(s, FormatTemplate+"="):
+		tmpl, err := template.New("output").Parse(s[len(FormatTemplate)+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid output template: %s", err)
+		}
+
+		return &Output{Format: FormatTemplate, Template: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q - supported formats are: %s, %s, %s and %s=...",
+			s, FormatTable, FormatJSON, FormatYAML, FormatTemplate)
+	}
+}
+
+// OutputOf gives output requested with flags of the given command.
+//
+// Commands which were not wrapped with WithOutput middleware
+// always use table output.
+func OutputOf(cmd *cobra.Command) (*Output, error) {
+	flags := cmd.Flags()
+
+	if js, err := flags.GetBool("json"); err == nil && js {
+		return &Output{Format: FormatJSON}, nil
+	}
+
+	s, err := flags.GetString("output")
+	if err != nil || flags.Lookup("output").Annotations[outputAnnotation] == nil {
+		return &Output{Format: FormatTable}, nil
+	}
+
+	out, err := ParseOutput(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out.NoHeaders, _ = flags.GetBool("no-headers")
+
+	return out, nil
+}
+
+const outputAnnotation = "cli-output"
+
+// WithOutput adds -o/--output and --no-headers flags to the command,
+// which control how results are printed with Print function. The
+// deprecated --json flag is registered as an alias for -o json.
+//
+// In machine readable formats errors returned by the command are
+// written to the error stream as structured objects.
+//
+// Commands, which define their own --output flag, are left intact.
+func WithOutput(cli *CLI, rootCmd *cobra.Command) {
+	tail := rootCmd.RunE
+	if tail == nil {
+		panic("cannot insert middleware into empty function")
+	}
+
+	flags := rootCmd.Flags()
+
+	if flags.Lookup("output") != nil {
+		return
+	}
+
+	cli.registerMiddleware("with_output", rootCmd)
+
+	flags.StringP("output", "o", FormatTable, "output format: table, json, yaml or go-template=...")
+	flags.Bool("no-headers", false, "do not print table headers")
+	flags.SetAnnotation("output", outputAnnotation, []string{"true"})
+
+	if flags.Lookup("json") == nil {
+		flags.Bool("json", false, "output in JSON format")
+		flags.MarkHidden("json")
+	}
+
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		out, err := OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err = tail(cmd, args); err != nil && out.IsMachineReadable() {
+			// Error is reported here, there is no need
+			// for cobra to print it again.
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			printError(cli.Err(), out, err)
+		}
+
+		return err
+	}
+}
+
+// TableFunc writes a value in table format.
+type TableFunc func(t *Table)
+
+// Print writes v to the output stream of c in the format requested
+// with flags of the given command.
+//
+// In machine readable formats field names of v are the ones defined
+// by its JSON encoding, in table format v is written with the table
+// function.
+func Print(c *CLI, cmd *cobra.Command, v interface{}, table TableFunc) error {
+	out, err := OutputOf(cmd)
+	if err != nil {
+		return err
+	}
+
+	return out.Print(c.Out(), v, table)
+}
+
+// Print writes v to w using o format.
+func (o *Output) Print(w io.Writer, v interface{}, table TableFunc) error {
+	switch o.Format {
+	case FormatJSON:
+		PrintJSON(w, v)
+		return nil
+	case FormatYAML:
+		return printYAML(w, v)
+	case FormatTemplate:
+		jv, err := toJSONValue(v)
+		if err != nil {
+			return err
+		}
+
+		if err := o.Template.Execute(w, jv); err != nil {
+			return fmt.Errorf("error executing output template: %s", err)
+		}
+
+		fmt.Fprintln(w)
+
+		return nil
+	default:
+		if table == nil {
+			return printYAML(w, v)
+		}
+
+		t := NewTable(w, o.NoHeaders)
+		table(t)
+
+		return t.Flush()
+	}
+}
+
+// PrintEvent writes v to w using o format as a single item of
+// a stream, e.g. progress events of a long running operation.
+//
+// Each JSON item is encoded in a single line, so the output can be
+// processed while the operation is in progress. Each YAML item is
+// a separate document.
+func (o *Output) PrintEvent(w io.Writer, v interface{}) error {
+	switch o.Format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(v)
+	case FormatYAML:
+		fmt.Fprintln(w, "---")
+	}
+
+	return o.Print(w, v, nil)
+}
+
+// Table writes tab-aligned columns.
+type Table struct {
+	w         *tabwriter.Writer
+	noHeaders bool
+}
+
+// NewTable creates new table which writes to w.
+func NewTable(w io.Writer, noHeaders bool) *Table {
+	return &Table{
+		w:         tabwriter.NewWriter(w, 2, 0, 2, ' ', 0),
+		noHeaders: noHeaders,
+	}
+}
+
+// Header writes a header row, unless headers are disabled.
+func (t *Table) Header(columns ...string) {
+	if !t.noHeaders {
+		fmt.Fprintln(t.w, strings.Join(columns, "\t"))
+	}
+}
+
+// Row writes a single row.
+func (t *Table) Row(values ...interface{}) {
+	for i, v := range values {
+		if i != 0 {
+			fmt.Fprint(t.w, "\t")
+		}
+
+		fmt.Fprint(t.w, v)
+	}
+
+	fmt.Fprintln(t.w)
+}
+
+// Rowf writes a single row using the given format.
+func (t *Table) Rowf(format string, args ...interface{}) {
+	fmt.Fprintf(t.w, format, args...)
+}
+
+// Flush writes buffered rows.
+func (t *Table) Flush() error {
+	return t.w.Flush()
+}
+
+// ErrorObject represents an error in machine readable formats.
+type ErrorObject struct {
+	Error struct {
+		Message  string `json:"message"`
+		ExitCode int    `json:"exitCode"`
+	} `json:"error"`
+}
+
+func printError(w io.Writer, o *Output, err error) {
+	var e ErrorObject
+
+	e.Error.Message = err.Error()
+	e.Error.ExitCode = ExitCodeFromError(err)
+
+	if o.Format == FormatYAML {
+		printYAML(w, &e)
+		return
+	}
+
+	PrintJSON(w, &e)
+}
+
+func printYAML(w io.Writer, v interface{}) error {
+	jv, err := toJSONValue(v)
+	if err != nil {
+		return err
+	}
+
+	p, err := yaml.Marshal(jv)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(p)
+	return err
+}
+
+// toJSONValue converts v to a generic value, which has
+// the same field names as JSON encoding of v.
+func toJSONValue(v interface{}) (interface{}, error) {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var jv interface{}
+
+	if err := json.Unmarshal(p, &jv); err != nil {
+		return nil, err
+	}
+
+	return jv, nil
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type testItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestWithOutput(t *testing.T) {
+	items := []*testItem{
+		{Name: "foo", Count: 1},
+		{Name: "bar", Count: 20},
+	}
+
+	cases := map[string]struct {
+		args []string
+		want string
+	}{
+		"table": {
+			nil,
+			"NAME  COUNT\nfoo   1\nbar   20\n",
+		},
+		"no headers": {
+			[]string{"--no-headers"},
+			"foo  1\nbar  20\n",
+		},
+		"json": {
+			[]string{"-o", "json"},
+			"[\n\t{\n\t\t\"name\": \"foo\",\n\t\t\"count\": 1\n\t},\n\t{\n\t\t\"name\": \"bar\",\n\t\t\"count\": 20\n\t}\n]\n",
+		},
+		"deprecated json": {
+			[]string{"--json"},
+			"[\n\t{\n\t\t\"name\": \"foo\",\n\t\t\"count\": 1\n\t},\n\t{\n\t\t\"name\": \"bar\",\n\t\t\"count\": 20\n\t}\n]\n",
+		},
+		"yaml": {
+			[]string{"--output", "yaml"},
+			"- count: 1\n  name: foo\n- count: 20\n  name: bar\n",
+		},
+		"go-template": {
+			[]string{"-o", "go-template={{range .}}{{.name}}={{.count}} {{end}}"},
+			"foo=1 bar=20 \n",
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			c := newTestCLI(&out, &bytes.Buffer{})
+
+			cmd := &cobra.Command{
+				Use: "test",
+				RunE: func(cmd *cobra.Command, _ []string) error {
+					return Print(c, cmd, items, func(t *Table) {
+						t.Header("NAME", "COUNT")
+
+						for _, item := range items {
+							t.Row(item.Name, item.Count)
+						}
+					})
+				},
+			}
+
+			WithOutput(c, cmd)
+
+			cmd.SetArgs(cas.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute()=%s", err)
+			}
+
+			if got := out.String(); got != cas.want {
+				t.Fatalf("got %q, want %q", got, cas.want)
+			}
+		})
+	}
+}
+
+func TestWithOutputError(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		want string
+	}{
+		"table": {
+			nil,
+			"",
+		},
+		"json": {
+			[]string{"-o", "json"},
+			"{\n\t\"error\": {\n\t\t\"message\": \"test failure\",\n\t\t\"exitCode\": 3\n\t}\n}\n",
+		},
+		"yaml": {
+			[]string{"-o", "yaml"},
+			"error:\n  exitCode: 3\n  message: test failure\n",
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			var errOut bytes.Buffer
+
+			c := newTestCLI(&bytes.Buffer{}, &errOut)
+
+			cmd := &cobra.Command{
+				Use: "test",
+				RunE: func(*cobra.Command, []string) error {
+					return NewError(3, errors.New("test failure"))
+				},
+				SilenceErrors: cas.args == nil,
+				SilenceUsage:  cas.args == nil,
+			}
+
+			WithOutput(c, cmd)
+
+			cmd.SetArgs(cas.args)
+
+			if err := cmd.Execute(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if got := errOut.String(); got != cas.want {
+				t.Fatalf("got %q, want %q", got, cas.want)
+			}
+		})
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	for _, s := range []string{"xml", "go-template", "go-template={{.foo"} {
+		if _, err := ParseOutput(s); err == nil {
+			t.Errorf("ParseOutput(%q): expected error, got nil", s)
+		}
+	}
+}
+
+func TestWithOutputOwnFlag(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:  "test",
+		RunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.Flags().StringP("output", "o", "file.json", "output filename")
+
+	WithOutput(newTestCLI(nil, nil), cmd)
+
+	if cmd.Flags().Lookup("no-headers") != nil {
+		t.Fatal("expected command with own output flag to be left intact")
+	}
+
+	out, err := OutputOf(cmd)
+	if err != nil {
+		t.Fatalf("OutputOf()=%s", err)
+	}
+
+	if out.IsMachineReadable() {
+		t.Fatalf("got %q format, want %q", out.Format, FormatTable)
+	}
+}
+
+func newTestCLI(out, err io.Writer) *CLI {
+	return &CLI{
+		out: out,
+		err: err,
+		mds: make(map[string][]func() string),
+	}
+}
@@ -1,9 +1,6 @@
 package config
 
 import (
-	"fmt"
-	"text/tabwriter"
-
 	konfig "koding/kites/config"
 	"koding/kites/config/configstore"
 	"koding/klientctl/commands/cli"
@@ -11,9 +8,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type listOptions struct {
-	jsonOutput bool
-}
+type listOptions struct{}
 
 // NewListCommand creates a command that shows all available configurations.
 func NewListCommand(c *cli.CLI) *cobra.Command {
@@ -26,13 +21,10 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 		RunE:    listCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
-		cli.NoArgs, // No custom arguments are accepted.
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -42,24 +34,16 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 	return func(cmd *cobra.Command, args []string) error {
 		konfigs := configstore.List()
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), konfigs)
-			return nil
-		}
-
-		printKonfigs(c, konfigs.Slice())
-
-		return nil
+		return cli.Print(c, cmd, konfigs, func(t *cli.Table) {
+			printKonfigs(t, konfigs.Slice())
+		})
 	}
 }
 
-func printKonfigs(c *cli.CLI, konfigs []*konfig.Konfig) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "ID\tKODING URL")
+func printKonfigs(t *cli.Table, konfigs []*konfig.Konfig) {
+	t.Header("ID", "KODING URL")
 
 	for _, konfig := range konfigs {
-		fmt.Fprintf(w, "%s\t%s\n", konfig.ID(), konfig.KodingPublic())
+		t.Row(konfig.ID(), konfig.KodingPublic())
 	}
 }
@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"text/tabwriter"
 
 	"koding/kites/kloud/utils/object"
 	"koding/klient/storage"
@@ -14,8 +13,7 @@ import (
 )
 
 type showOptions struct {
-	defaults   bool
-	jsonOutput bool
+	defaults bool
 }
 
 // NewShowCommand creates a command that displays configurations.
@@ -31,11 +29,11 @@ func NewShowCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.BoolVar(&opts.defaults, "defaults", false, "include default configuration")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
-		cli.NoArgs, // No custom arguments are accepted.
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -68,14 +66,9 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 			}
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), used)
-			return nil
-		}
-
-		printKeyVal(c, used, ignoredFields...)
-
-		return nil
+		return cli.Print(c, cmd, used, func(t *cli.Table) {
+			printKeyVal(t, used, ignoredFields...)
+		})
 	}
 }
 
@@ -86,11 +79,8 @@ var b = &object.Builder{
 	FlatStringers: true,
 }
 
-func printKeyVal(c *cli.CLI, v interface{}, ignoredFields ...string) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "KEY\tVALUE")
+func printKeyVal(t *cli.Table, v interface{}, ignoredFields ...string) {
+	t.Header("KEY", "VALUE")
 
 	obj := b.Build(v, ignoredFields...)
 
@@ -99,6 +89,6 @@ func printKeyVal(c *cli.CLI, v interface{}, ignoredFields ...string) {
 		if s := fmt.Sprintf("%v", value); value == nil || s == "" || s == "0" {
 			value = "-"
 		}
-		fmt.Fprintf(w, "%s\t%v\n", key, value)
+		t.Row(key, value)
 	}
 }
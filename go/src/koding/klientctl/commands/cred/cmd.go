@@ -18,10 +18,13 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 	// Subcommands.
 	cmd.AddCommand(
 		NewCreateCommand(c),
+		NewDeleteCommand(c),
 		NewDescribeCommand(c),
+		NewImportCommand(c),
 		NewInitCommand(c),
 		NewListCommand(c),
 		NewUseCommand(c),
+		NewValidateCommand(c),
 	)
 
 	// Middlewares.
@@ -17,11 +17,10 @@ import (
 )
 
 type createOptions struct {
-	provider   string
-	file       string
-	team       string
-	title      string
-	jsonOutput bool
+	provider string
+	file     string
+	team     string
+	title    string
 }
 
 // NewCreateCommand creates a command that can be used to create new stack
@@ -41,12 +40,12 @@ func NewCreateCommand(c *cli.CLI) *cobra.Command {
 	flags.StringVarP(&opts.file, "file", "f", "", "read from file")
 	flags.StringVar(&opts.team, "team", "", "owner of the credential")
 	flags.StringVar(&opts.title, "title", "", "credential title")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -60,7 +59,12 @@ func createCommand(c *cli.CLI, opts *createOptions) cli.CobraFuncE {
 			Title:    opts.title,
 		}
 
-		if err := Create(c, opts.file, createOpts, opts.jsonOutput); err != nil {
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := Create(c, opts.file, createOpts, out); err != nil {
 			return err
 		}
 
@@ -69,17 +73,22 @@ func createCommand(c *cli.CLI, opts *createOptions) cli.CobraFuncE {
 }
 
 // Create creates new credentials.
-func Create(c *cli.CLI, file string, opts *credential.CreateOptions, js bool) error {
+//
+// Credential data is read from the given file, or asked for
+// interactively when file is empty, unless opts.Data is already set.
+// The created credential is printed only in machine readable output.
+func Create(c *cli.CLI, file string, opts *credential.CreateOptions, out *cli.Output) error {
 	var p []byte
 	var err error
 
-	switch file {
-	case "":
+	switch {
+	case len(opts.Data) != 0:
+	case file == "":
 		opts, err = askCredentialCreate(c, opts)
 		if err != nil {
 			return fmt.Errorf("error building credential data: %v", err)
 		}
-	case "-":
+	case file == "-":
 		p, err = ioutil.ReadAll(c.In())
 	default:
 		p, err = ioutil.ReadFile(file)
@@ -100,9 +109,8 @@ func Create(c *cli.CLI, file string, opts *credential.CreateOptions, js bool) er
 		return fmt.Errorf("error creating credential: %v", err)
 	}
 
-	if js {
-		cli.PrintJSON(c.Out(), cred)
-		return nil
+	if out.IsMachineReadable() {
+		return out.Print(c.Out(), cred, nil)
 	}
 
 	fmt.Fprintf(c.Err(), "Created %q credential with %s identifier.\n", cred.Title, cred.Identifier)
@@ -0,0 +1,63 @@
+package cred
+
+import (
+	"errors"
+	"fmt"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/credential"
+	"koding/klientctl/helper"
+
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	force bool
+}
+
+// NewDeleteCommand creates a command that removes stack credential.
+func NewDeleteCommand(c *cli.CLI) *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete <credential-id>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a stack credential",
+		RunE:    deleteCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.force, "force", false, "do not ask for confirmation")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is accepted.
+	)(c, cmd)
+
+	return cmd
+}
+
+func deleteCommand(c *cli.CLI, opts *deleteOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		if !opts.force {
+			s, err := helper.Fask(c.In(), c.Out(), "Please type \"yes\" to confirm you want to delete %q credential []: ", args[0])
+			if err != nil {
+				return err
+			}
+
+			if s != "yes" {
+				return errors.New("confirmation failed, aborting")
+			}
+		}
+
+		if err := credential.Delete(args[0]); err != nil {
+			return errors.New("error deleting credential: " + err.Error())
+		}
+
+		fmt.Fprintf(c.Err(), "Deleted %s credential.\n", args[0])
+
+		return nil
+	}
+}
@@ -2,7 +2,6 @@ package cred
 
 import (
 	"fmt"
-	"text/tabwriter"
 
 	"koding/kites/kloud/stack"
 	"koding/klientctl/commands/cli"
@@ -12,8 +11,7 @@ import (
 )
 
 type describeOptions struct {
-	provider   string
-	jsonOutput bool
+	provider string
 }
 
 // NewDescribeCommand creates a command that describes credential documents.
@@ -29,12 +27,12 @@ func NewDescribeCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.provider, "provider", "p", "", "credential provider")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -56,26 +54,18 @@ func describeCommand(c *cli.CLI, opts *describeOptions) cli.CobraFuncE {
 			descs = stack.Descriptions{opts.provider: desc}
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), descs.Slice())
-			return nil
-		}
-
-		printDescs(c, descs.Slice())
-
-		return nil
+		return cli.Print(c, cmd, descs.Slice(), func(t *cli.Table) {
+			printDescs(t, descs.Slice())
+		})
 	}
 }
 
-func printDescs(c *cli.CLI, descs []*stack.Description) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "PROVIDER\tATTRIBUTE\tTYPE\tSECRET")
+func printDescs(t *cli.Table, descs []*stack.Description) {
+	t.Header("PROVIDER", "ATTRIBUTE", "TYPE", "SECRET")
 
 	for _, desc := range descs {
 		for _, field := range desc.Credential {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", desc.Provider, field.Name, field.Type, field.Secret)
+			t.Row(desc.Provider, field.Name, field.Type, field.Secret)
 		}
 	}
 }
@@ -0,0 +1,119 @@
+package cred
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/credential"
+
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	provider string
+	profile  string
+	file     string
+	team     string
+	title    string
+}
+
+// NewImportCommand creates a command that imports stack credential
+// from configuration of local cloud tools.
+func NewImportCommand(c *cli.CLI) *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a stack credential from local cloud tools",
+		Long: "Import a stack credential from configuration of local cloud tools.\n\n" +
+			"Supported sources are:\n\n" +
+			"  aws           shared credentials and config files (~/.aws)\n" +
+			"  azure         Azure CLI profile (~/.azure) and a publish settings file\n" +
+			"  digitalocean  doctl configuration (~/.config/doctl)\n" +
+			"  google        service account JSON key and gcloud configuration\n\n" +
+			"The credential is verified by the provider before it is stored.",
+		RunE: importCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.provider, "provider", "p", "", "credential provider: "+strings.Join(credential.ImportProviders(), ", "))
+	flags.StringVar(&opts.profile, "profile", "", "profile, configuration, subscription or context name")
+	flags.StringVarP(&opts.file, "file", "f", "", "source file; required for azure publish settings")
+	flags.StringVar(&opts.team, "team", "", "owner of the credential")
+	flags.StringVar(&opts.title, "title", "", "credential title")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func importCommand(c *cli.CLI, opts *importOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		if opts.provider == "" {
+			return fmt.Errorf("provider is required - supported providers are: %s", strings.Join(credential.ImportProviders(), ", "))
+		}
+
+		descs, err := credential.Describe()
+		if err != nil {
+			return fmt.Errorf("error requesting credential description: %v", err)
+		}
+
+		desc, ok := descs[opts.provider]
+		if !ok {
+			return fmt.Errorf("provider %q does not exist", opts.provider)
+		}
+
+		importOpts := &credential.ImportOptions{
+			Provider: opts.provider,
+			Profile:  opts.profile,
+			File:     opts.file,
+		}
+
+		imp, err := credential.Import(desc, importOpts)
+		if err != nil {
+			return fmt.Errorf("error importing credential: %v", err)
+		}
+
+		p, err := json.Marshal(imp.Data)
+		if err != nil {
+			return fmt.Errorf("error building credential data: %v", err)
+		}
+
+		createOpts := &credential.CreateOptions{
+			Provider: opts.provider,
+			Team:     opts.team,
+			Title:    opts.title,
+			Data:     p,
+		}
+
+		if createOpts.Title == "" {
+			createOpts.Title = fmt.Sprintf("%s (%s)", opts.provider, nonempty(opts.profile, "default"))
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.Err(), "Importing credential from %s...\n", imp.Source)
+
+		return Create(c, "", createOpts, out)
+	}
+}
+
+func nonempty(s ...string) string {
+	for _, s := range s {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
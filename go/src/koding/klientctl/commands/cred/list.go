@@ -2,7 +2,6 @@ package cred
 
 import (
 	"fmt"
-	"text/tabwriter"
 
 	"koding/kites/kloud/stack"
 	"koding/klientctl/commands/cli"
@@ -12,9 +11,8 @@ import (
 )
 
 type listOptions struct {
-	provider   string
-	team       string
-	jsonOutput bool
+	provider string
+	team     string
 }
 
 // NewListCommand creates a command that displays imported stack credentials.
@@ -32,12 +30,12 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.provider, "provider", "p", "", "credential provider")
 	flags.StringVar(&opts.team, "team", "", "owner of the credential")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -59,24 +57,16 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			return fmt.Errorf("you have no matching credentials attached to your Koding account")
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), creds)
-			return nil
-		}
-
-		printCreds(c, creds.ToSlice())
-
-		return nil
+		return cli.Print(c, cmd, creds, func(t *cli.Table) {
+			printCreds(t, creds.ToSlice())
+		})
 	}
 }
 
-func printCreds(c *cli.CLI, creds []stack.CredentialItem) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
+func printCreds(t *cli.Table, creds []stack.CredentialItem) {
 	used := credential.Used()
 
-	fmt.Fprintln(w, "ID\tTITLE\tTEAM\tPROVIDER\tUSED")
+	t.Header("ID", "TITLE", "TEAM", "PROVIDER", "USED")
 
 	for _, cred := range creds {
 		isUsed := "-"
@@ -85,6 +75,6 @@ func printCreds(c *cli.CLI, creds []stack.CredentialItem) {
 			isUsed = "default"
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", cred.Identifier, cred.Title, cred.Team, cred.Provider, isUsed)
+		t.Row(cred.Identifier, cred.Title, cred.Team, cred.Provider, isUsed)
 	}
 }
@@ -0,0 +1,96 @@
+package cred
+
+import (
+	"fmt"
+
+	"koding/kites/kloud/stack"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/credential"
+	"koding/klientctl/endpoint/team"
+
+	"github.com/spf13/cobra"
+)
+
+type validateOptions struct {
+	team string
+}
+
+// NewValidateCommand creates a command that verifies stack credentials
+// against their providers.
+func NewValidateCommand(c *cli.CLI) *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate <credential-id> [<credential-id>...]",
+		Short: "Verify stack credentials with their providers",
+		RunE:  validateCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVar(&opts.team, "team", "", "team of the credentials")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.MinArgs(1),     // At least one argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func validateCommand(c *cli.CLI, opts *validateOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		validateOpts := &credential.ValidateOptions{
+			Team:        nonempty(opts.team, team.Used().Name),
+			Identifiers: args,
+		}
+
+		resp, err := credential.Validate(validateOpts)
+		if err != nil {
+			return fmt.Errorf("error validating credentials: %v", err)
+		}
+
+		err = cli.Print(c, cmd, resp, func(t *cli.Table) {
+			printValidate(t, args, resp)
+		})
+		if err != nil {
+			return err
+		}
+
+		var failed int
+
+		for _, id := range args {
+			if res, ok := resp[id]; !ok || !res.Verified {
+				failed++
+			}
+		}
+
+		if failed != 0 {
+			return cli.NewError(1, fmt.Errorf("%d of %d credentials failed verification", failed, len(args)))
+		}
+
+		return nil
+	}
+}
+
+func printValidate(t *cli.Table, identifiers []string, resp stack.AuthenticateResponse) {
+	t.Header("ID", "VERIFIED", "MESSAGE")
+
+	for _, id := range identifiers {
+		verified, message := "no", "-"
+
+		if res, ok := resp[id]; ok {
+			if res.Verified {
+				verified = "yes"
+			}
+
+			if res.Message != "" {
+				message = res.Message
+			}
+		}
+
+		t.Row(id, verified, message)
+	}
+}
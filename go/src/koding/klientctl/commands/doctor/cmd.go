@@ -0,0 +1,111 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/config"
+	"koding/klientctl/doctor"
+
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	fix bool
+}
+
+// NewCommand creates a command that diagnoses KD installation.
+func NewCommand(c *cli.CLI) *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose kd problems",
+		Long: "Run a set of checks against KD Daemon, Koding endpoints, local system\n" +
+			"and mounts. Each failed check is reported together with a suggested fix.\n" +
+			"With --fix flag, kd tries to repair problems it knows how to fix.",
+		RunE: command(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.fix, "fix", false, "try to fix found problems")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func command(c *cli.CLI, opts *options) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		d := &doctor.Doctor{
+			Checks: doctor.Checks(config.Konfig),
+			Fix:    opts.fix,
+		}
+
+		results := d.Run()
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		err = out.Print(c.Out(), results, func(t *cli.Table) {
+			printResults(t, results)
+		})
+		if err != nil {
+			return err
+		}
+
+		if !out.IsMachineReadable() {
+			printSuggestions(c.Out(), results)
+		}
+
+		if n := doctor.Failed(results); n != 0 {
+			return cli.NewError(1, fmt.Errorf("%d of %d checks failed", n, len(results)))
+		}
+
+		return nil
+	}
+}
+
+func printResults(t *cli.Table, results []*doctor.Result) {
+	t.Header("CHECK", "STATUS", "DESCRIPTION")
+
+	for _, res := range results {
+		status := string(res.Status)
+
+		if res.Fixed {
+			status += " (fixed)"
+		}
+
+		t.Row(res.Name, status, res.Description)
+	}
+}
+
+func printSuggestions(w io.Writer, results []*doctor.Result) {
+	for _, res := range results {
+		if res.Status != doctor.StatusFail && res.Status != doctor.StatusWarn {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s: %s\n", res.Name, res.Message)
+
+		if res.FixError != "" {
+			fmt.Fprintf(w, "  Fix failed: %s\n", res.FixError)
+		}
+
+		if res.Suggestion != "" {
+			fmt.Fprintf(w, "  Suggestion: %s\n", res.Suggestion)
+		}
+
+		if res.Fixable && !res.Fixed && res.FixError == "" {
+			fmt.Fprintln(w, `  Run "kd doctor --fix" to fix it automatically.`)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package down
+
+import (
+	"errors"
+
+	"koding/klientctl/app"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/helper"
+
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	file    string
+	destroy bool
+	force   bool
+}
+
+// NewCommand creates a command that tears down a project environment.
+func NewCommand(c *cli.CLI) *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Tear down a project environment",
+		Long: "Remove port forwards and mounts of the project and stop its machines.\n" +
+			"With --destroy flag the project stack is destroyed instead.",
+		RunE: command(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.file, "file", "f", app.ProjectFile, "project file")
+	flags.BoolVar(&opts.destroy, "destroy", false, "destroy the project stack")
+	flags.BoolVar(&opts.force, "force", false, "do not ask for confirmation")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+	)(c, cmd)
+
+	return cmd
+}
+
+func command(c *cli.CLI, opts *options) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		p, err := app.ReadProject(opts.file)
+		if err != nil {
+			return err
+		}
+
+		if opts.destroy && !opts.force {
+			s, err := helper.Fask(c.In(), c.Out(), "Please type \"yes\" to confirm you want to destroy %q stack []: ", p.Stack.Title)
+			if err != nil {
+				return err
+			}
+
+			if s != "yes" {
+				return errors.New("confirmation failed, aborting")
+			}
+		}
+
+		env := &app.Env{
+			Project: p,
+			Out:     c.Out(),
+		}
+
+		return env.Down(&app.DownOptions{Destroy: opts.destroy})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	kstack "koding/kites/kloud/stack"
 	"koding/kites/kloud/utils/object"
+	"koding/klientctl/app"
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/commands/cred"
 	"koding/klientctl/commands/template"
@@ -93,7 +94,7 @@ func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 					Team:     team.Used().Name,
 				}
 
-				if err := cred.Create(c, "", opts, false); err != nil {
+				if err := cred.Create(c, "", opts, nil); err != nil {
 					return err
 				}
 
@@ -164,7 +165,7 @@ func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 			fmt.Fprintf(c.Out(), "[%d%%] %s\n", e.Event.Percentage, e.Event.Message)
 		}
 
-		if err := writelock(resp.StackID, resp.Title); err != nil {
+		if err := app.WriteLock(app.LockFile, resp.StackID, resp.Title); err != nil {
 			return err
 		}
 
@@ -172,44 +173,19 @@ func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 	}
 }
 
-type lock struct {
-	Stack struct {
-		ID    string `yaml:"id"`
-		Title string `yaml:"title"`
-	} `yaml:"stack"`
-}
-
 func isLocked() error {
-	p, err := ioutil.ReadFile(".kd.lock")
-	if os.IsNotExist(err) {
-		return nil
-	}
+	l, err := app.ReadLock(app.LockFile)
 	if err != nil {
 		return err
 	}
 
-	var l lock
-
-	if err := yaml.Unmarshal(p, &l); err != nil {
-		return err
+	if l == nil {
+		return nil
 	}
 
 	return fmt.Errorf("project already initialized with %q stack (%s)", l.Stack.Title, l.Stack.ID)
 }
 
-func writelock(id, title string) error {
-	var l lock
-	l.Stack.ID = id
-	l.Stack.Title = title
-
-	p, err := yaml.Marshal(&l)
-	if err != nil {
-		return err
-	}
-
-	return ioutil.WriteFile(".kd.lock", p, 0644)
-}
-
 func readTemplate(file string) ([]byte, error) {
 	p, err := ioutil.ReadFile(file)
 	if err != nil {
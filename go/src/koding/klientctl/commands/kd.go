@@ -8,6 +8,8 @@ import (
 	"koding/klientctl/commands/config"
 	"koding/klientctl/commands/cred"
 	"koding/klientctl/commands/daemon"
+	"koding/klientctl/commands/doctor"
+	"koding/klientctl/commands/down"
 	"koding/klientctl/commands/initial"
 	"koding/klientctl/commands/log"
 	"koding/klientctl/commands/machine"
@@ -19,6 +21,7 @@ import (
 	"koding/klientctl/commands/status"
 	"koding/klientctl/commands/team"
 	"koding/klientctl/commands/template"
+	"koding/klientctl/commands/up"
 	"koding/klientctl/commands/version"
 
 	"github.com/spf13/cobra"
@@ -47,6 +50,8 @@ func NewKdCommand(c *cli.CLI) *cobra.Command {
 		cli.Alias(daemon.NewStopCommand(c), "kd daemon"),
 		cli.Alias(daemon.NewUninstallCommand(c), "kd daemon"),
 		cli.Alias(daemon.NewUpdateCommand(c), "kd daemon"),
+		doctor.NewCommand(c),
+		down.NewCommand(c),
 		initial.NewCommand(c),
 		log.NewCommand(c),
 		machine.NewCommand(c),
@@ -63,6 +68,7 @@ func NewKdCommand(c *cli.CLI) *cobra.Command {
 		cli.Alias(sync.NewCommand(c), "kd machine mount"),
 		team.NewCommand(c),
 		template.NewCommand(c),
+		up.NewCommand(c),
 		version.NewCommand(c),
 	)
 
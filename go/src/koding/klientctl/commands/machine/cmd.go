@@ -25,6 +25,7 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 		NewIdentifiersCommand(c),
 		mount.NewCommand(c),
 		NewSSHCommand(c),
+		NewSSHConfigCommand(c),
 		NewStartCommand(c),
 		NewStopCommand(c),
 		NewUmountCommand(c),
@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"text/tabwriter"
 
 	"koding/kites/kloud/utils/object"
 	"koding/klientctl/commands/cli"
@@ -11,9 +10,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type showOptions struct {
-	jsonOutput bool
-}
+type showOptions struct{}
 
 // NewShowCommand creates a command that displays remote machine configuration.
 func NewShowCommand(c *cli.CLI) *cobra.Command {
@@ -25,14 +22,11 @@ func NewShowCommand(c *cli.CLI) *cobra.Command {
 		RunE:  showCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -48,13 +42,9 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), conf)
-		} else {
-			printKeyVal(c, conf)
-		}
-
-		return nil
+		return cli.Print(c, cmd, conf, func(t *cli.Table) {
+			printKeyVal(t, conf)
+		})
 	}
 }
 
@@ -78,11 +68,8 @@ var ignoredFields = []string{
 	"tunnelID",
 }
 
-func printKeyVal(c *cli.CLI, v interface{}, ignoredFields ...string) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "KEY\tVALUE")
+func printKeyVal(t *cli.Table, v interface{}, ignoredFields ...string) {
+	t.Header("KEY", "VALUE")
 
 	obj := b.Build(v, ignoredFields...)
 
@@ -91,6 +78,6 @@ func printKeyVal(c *cli.CLI, v interface{}, ignoredFields ...string) {
 		if s := fmt.Sprintf("%v", value); value == nil || s == "" || s == "0" {
 			value = "-"
 		}
-		fmt.Fprintf(w, "%s\t%v\n", key, value)
+		t.Row(key, value)
 	}
 }
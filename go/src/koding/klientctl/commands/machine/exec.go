@@ -1,20 +1,33 @@
 package machine
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/ctlcli"
 	"koding/klientctl/endpoint/machine"
+	"koding/klientctl/endpoint/remoteapi"
 
 	"github.com/spf13/cobra"
 )
 
-type execOptions struct{}
+type execOptions struct {
+	all      bool
+	stack    string
+	label    string
+	parallel int
+	failFast bool
+	group    bool
+}
 
 // NewExecCommand creates a command that can run arbitrary command on remote
 // machine.
@@ -22,7 +35,7 @@ func NewExecCommand(c *cli.CLI) *cobra.Command {
 	opts := &execOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "exec (<local-mount-path> | @<machine-id>) <command> [<args>...]",
+		Use:     "exec [flags] (<local-mount-path> | @<machine-id>) <command> [<args>...]",
 		Aliases: []string{"e"},
 		Short:   "Run a command on remote host",
 		Long: `Run <command> on a remote machine specified by either @<machine-id> or <local-mount-path>.
@@ -32,11 +45,31 @@ reading the remote source of the mount. The mount must be active and the remote
 end on-line.
 
 In order to run a <command> on a remote machine that has no local mounts, use
-@<machine-id> argument instead.`,
+@<machine-id> argument instead.
+
+In order to run a <command> on multiple machines, select them with --all,
+--stack or --label flags instead of @<machine-id>. The <command> is run
+concurrently, its output is prefixed with machine alias and exit codes
+are summarized when all commands finish. Flags must precede the <command>.`,
+		Example: `  kd machine exec --all uptime
+  kd machine exec --stack 5a1ef0c7d4d9a1c0a2b3c4d5 --fail-fast sudo service nginx restart
+  kd machine exec --label 'web-*' --group tail -n 10 /var/log/syslog`,
 		DisableFlagParsing: true,
 		RunE:               execCommand(c, opts),
 	}
 
+	// Flags.
+	//
+	// Parsing is disabled for the command, as flags of the remote command
+	// must be passed as they are; flags are parsed up to the first argument.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.all, "all", false, "run on all machines")
+	flags.StringVar(&opts.stack, "stack", "", "run on machines of the given stack")
+	flags.StringVar(&opts.label, "label", "", "run on machines with labels matching the given glob")
+	flags.IntVar(&opts.parallel, "parallel", machine.DefaultParallel, "maximum number of concurrent commands")
+	flags.BoolVar(&opts.failFast, "fail-fast", false, "stop after first failure, killing running commands")
+	flags.BoolVar(&opts.group, "group", false, "group output per machine instead of prefixing lines")
+
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
@@ -49,6 +82,23 @@ In order to run a <command> on a remote machine that has no local mounts, use
 
 func execCommand(c *cli.CLI, opts *execOptions) cli.CobraFuncE {
 	return func(cmd *cobra.Command, args []string) (err error) {
+		flags := cmd.Flags()
+		flags.SetInterspersed(false)
+
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+
+		args = flags.Args()
+
+		if opts.all || opts.stack != "" || opts.label != "" {
+			return execAll(c, opts, args)
+		}
+
+		if len(args) < 2 {
+			return errors.New("remote machine and command are required")
+		}
+
 		done := make(chan int, 1)
 
 		execOpts := &machine.ExecOptions{
@@ -107,6 +157,186 @@ func execCommand(c *cli.CLI, opts *execOptions) cli.CobraFuncE {
 	}
 }
 
+// execAll runs the command on multiple machines selected by opts.
+func execAll(c *cli.CLI, opts *execOptions, args []string) error {
+	if len(args) == 0 {
+		return errors.New("command is required")
+	}
+
+	if opts.all && (opts.stack != "" || opts.label != "") {
+		return errors.New("--all flag cannot be used with --stack or --label flags")
+	}
+
+	if opts.parallel <= 0 {
+		return fmt.Errorf("invalid --parallel value: %d", opts.parallel)
+	}
+
+	infos, err := selectMachines(opts)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu      sync.Mutex
+		names   = make(map[string]string, len(infos))
+		outputs = make(map[string]*bytes.Buffer, len(infos))
+		ids     = make([]string, len(infos))
+		width   int
+	)
+
+	for i, info := range infos {
+		name := info.Alias
+		if name == "" {
+			name = info.ID
+		}
+
+		if len(name) > width {
+			width = len(name)
+		}
+
+		ids[i] = info.ID
+		names[info.ID] = name
+		outputs[info.ID] = &bytes.Buffer{}
+	}
+
+	output := func(w io.Writer) func(id, line string) {
+		return func(id, line string) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if opts.group {
+				fmt.Fprintln(outputs[id], line)
+			} else {
+				fmt.Fprintf(w, "%-*s | %s\n", width, names[id], line)
+			}
+		}
+	}
+
+	execOpts := &machine.ExecAllOptions{
+		MachineIDs: ids,
+		Cmd:        args[0],
+		Args:       args[1:],
+		Parallel:   opts.parallel,
+		FailFast:   opts.failFast,
+		Stdout:     output(c.Out()),
+		Stderr:     output(c.Err()),
+	}
+
+	results, err := machine.ExecAll(execOpts)
+	if err != nil {
+		return err
+	}
+
+	if opts.group {
+		for _, id := range ids {
+			fmt.Fprintf(c.Out(), "==> %s <==\n", names[id])
+			outputs[id].WriteTo(c.Out())
+			fmt.Fprintln(c.Out())
+		}
+	} else {
+		fmt.Fprintln(c.Out())
+	}
+
+	failed := printExecResults(c, names, results)
+
+	if failed != 0 {
+		return cli.NewError(1, fmt.Errorf("command failed on %d of %d machines", failed, len(results)))
+	}
+
+	return nil
+}
+
+// selectMachines gives machines selected with --all, --stack and --label flags.
+func selectMachines(opts *execOptions) ([]*machine.Info, error) {
+	if opts.label != "" {
+		if _, err := path.Match(opts.label, ""); err != nil {
+			return nil, fmt.Errorf("invalid --label glob %q: %s", opts.label, err)
+		}
+	}
+
+	// Listing machines also registers them with KD Daemon,
+	// which is required to run commands on them.
+	infos, err := machine.List(&machine.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var stackIDs map[string]struct{}
+
+	if opts.stack != "" {
+		stacks, err := remoteapi.ListStacks(&remoteapi.Filter{ID: opts.stack})
+		if err == remoteapi.ErrNotFound {
+			return nil, fmt.Errorf("stack %q not found", opts.stack)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		stackIDs = make(map[string]struct{})
+
+		for _, id := range remoteapi.StackMachineIDs(stacks[0]) {
+			stackIDs[id] = struct{}{}
+		}
+	}
+
+	var selected []*machine.Info
+
+	for _, info := range infos {
+		if stackIDs != nil {
+			if _, ok := stackIDs[info.ID]; !ok {
+				continue
+			}
+		}
+
+		if opts.label != "" {
+			if ok, _ := path.Match(opts.label, info.Label); !ok {
+				continue
+			}
+		}
+
+		selected = append(selected, info)
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no machines matched")
+	}
+
+	return selected, nil
+}
+
+// printExecResults prints a summary table of the command results.
+//
+// It returns a number of failed commands.
+func printExecResults(c *cli.CLI, names map[string]string, results []*machine.ExecResult) (failed int) {
+	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "MACHINE\tID\tEXIT\tSTATUS")
+
+	for _, res := range results {
+		exit, status := fmt.Sprint(res.Exit), "ok"
+
+		switch {
+		case res.Err != nil:
+			exit, status = "-", "error: "+res.Err.Error()
+		case res.Skipped:
+			exit, status = "-", "skipped"
+		case res.Killed:
+			exit, status = "-", "killed"
+		case res.Exit != 0:
+			status = "failed"
+		}
+
+		if res.Failed() {
+			failed++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", names[res.MachineID], res.MachineID, exit, status)
+	}
+
+	return failed
+}
+
 func waitForMount(c *cli.CLI, path string) (err error) {
 	const timeout = 1 * time.Minute
 
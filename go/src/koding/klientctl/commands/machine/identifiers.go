@@ -11,10 +11,9 @@ import (
 )
 
 type identifiersOptions struct {
-	ids        bool
-	aliases    bool
-	ips        bool
-	jsonOutput bool
+	ids     bool
+	aliases bool
+	ips     bool
 }
 
 // NewIdentifiersCommand creates a command that displays identifiers of all
@@ -34,12 +33,12 @@ func NewIdentifiersCommand(c *cli.CLI) *cobra.Command {
 	flags.BoolVar(&opts.ids, "id", true, "machine IDs")
 	flags.BoolVar(&opts.aliases, "alias", true, "machine aliases")
 	flags.BoolVar(&opts.ips, "ip", true, "machine IP addresses")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -58,9 +57,13 @@ func identifiersCommand(c *cli.CLI, opts *identifiersOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), identifiers)
-			return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), identifiers, nil)
 		}
 
 		fmt.Fprintf(c.Out(), "%s\n", strings.Join(identifiers, " "))
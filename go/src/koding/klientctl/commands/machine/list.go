@@ -1,9 +1,6 @@
 package machine
 
 import (
-	"fmt"
-	"io"
-	"text/tabwriter"
 	"time"
 
 	"koding/klientctl/commands/cli"
@@ -13,9 +10,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type listOptions struct {
-	jsonOutput bool
-}
+type listOptions struct{}
 
 // NewListCommand creates a command that displays remote machines which belong
 // to the user or that can be accessed by their.
@@ -30,14 +25,11 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 		RunE:    listCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -61,24 +53,18 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			}
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), infos)
-			return nil
-		}
-
-		tabListFormatter(c.Out(), infos)
-		return nil
-
+		return cli.Print(c, cmd, infos, func(t *cli.Table) {
+			tabListFormatter(t, infos)
+		})
 	}
 }
 
-func tabListFormatter(w io.Writer, infos []*machine.Info) {
+func tabListFormatter(t *cli.Table, infos []*machine.Info) {
 	now := time.Now()
-	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
 
-	fmt.Fprintf(tw, "ID\tLABEL\tOWNER\tTEAM\tSTACK\tPROVIDER\tAGE\tIP\tSTATUS\n")
+	t.Header("ID", "LABEL", "OWNER", "TEAM", "STACK", "PROVIDER", "AGE", "IP", "STATUS")
 	for _, info := range infos {
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		t.Row(
 			info.ID,
 			info.Label,
 			info.Owner,
@@ -90,7 +76,6 @@ func tabListFormatter(w io.Writer, infos []*machine.Info) {
 			machine.PrettyStatus(info.Status, now),
 		)
 	}
-	tw.Flush()
 }
 
 func dashIfEmpty(val string) string {
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"koding/klient/machine/index/filter"
 	"koding/klientctl/commands/cli"
 	msync "koding/klientctl/commands/machine/mount/sync"
 	"koding/klientctl/endpoint/machine"
@@ -12,7 +13,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+type options struct {
+	ignore []string
+}
 
 // NewCommand creates a command that allows to create mounts and manage their
 // properties.
@@ -34,6 +37,10 @@ be created.`,
 		RunE: command(c, opts),
 	}
 
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.ignore, "ignore", nil, "shell patterns of paths that are not synced")
+
 	// Subcommands.
 	cmd.AddCommand(
 		NewInspectCommand(c),
@@ -58,14 +65,21 @@ func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 			return err
 		}
 
-		opts := &machine.MountOptions{
+		for _, pattern := range opts.ignore {
+			if err := filter.ValidGlob(pattern); err != nil {
+				return fmt.Errorf("invalid ignore pattern %q: %s", pattern, err)
+			}
+		}
+
+		mountOpts := &machine.MountOptions{
 			Identifier: ident,
 			Path:       path,
 			RemotePath: remotePath,
+			Ignore:     opts.ignore,
 			AskList:    cli.AskList(c, cmd),
 		}
 
-		if err := machine.Mount(opts); err != nil {
+		if err := machine.Mount(mountOpts); err != nil {
 			return err
 		}
 
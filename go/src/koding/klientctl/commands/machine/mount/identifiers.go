@@ -11,9 +11,8 @@ import (
 )
 
 type identifiersOptions struct {
-	mountIds   bool
-	basePaths  bool
-	jsonOutput bool
+	mountIds  bool
+	basePaths bool
 }
 
 // NewIdentifiersCommand creates a command that displays identifiers of all
@@ -32,12 +31,12 @@ func NewIdentifiersCommand(c *cli.CLI) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVar(&opts.mountIds, "mount-id", true, "mount IDs")
 	flags.BoolVar(&opts.basePaths, "base-path", true, "mount base paths")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -55,9 +54,13 @@ func identifiersCommand(c *cli.CLI, opts *identifiersOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), identifiers)
-			return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), identifiers, nil)
 		}
 
 		fmt.Fprintf(c.Out(), "%s\n", strings.Join(identifiers, " "))
@@ -34,6 +34,7 @@ func NewInspectCommand(c *cli.CLI) *cobra.Command {
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -59,7 +60,18 @@ func inspectCommand(c *cli.CLI, opts *inspectOptions) cli.CobraFuncE {
 			return err
 		}
 
-		cli.PrintJSON(c.Out(), records)
-		return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Inspect records have no table representation, they are
+		// printed as JSON by default.
+		if out.Format == cli.FormatTable {
+			cli.PrintJSON(c.Out(), records)
+			return nil
+		}
+
+		return out.Print(c.Out(), records, nil)
 	}
 }
@@ -2,9 +2,7 @@ package mount
 
 import (
 	"fmt"
-	"io"
 	"strconv"
-	"text/tabwriter"
 
 	"koding/klient/machine/mount"
 	"koding/klientctl/commands/cli"
@@ -15,8 +13,7 @@ import (
 )
 
 type listOptions struct {
-	filter     string
-	jsonOutput bool
+	filter string
 }
 
 // NewListCommand creates a command that displays available mounts.
@@ -33,12 +30,12 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.StringVar(&opts.filter, "filter", "", "limit to specific mount")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -55,26 +52,19 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), mounts)
-			return nil
-		}
-
-		tabListMountFormatter(c.Out(), mounts)
-		return nil
+		return cli.Print(c, cmd, mounts, func(t *cli.Table) {
+			tabListMountFormatter(t, mounts)
+		})
 	}
 }
 
-func tabListMountFormatter(w io.Writer, mounts map[string][]mount.Info) {
-	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
-	defer tw.Flush()
-
+func tabListMountFormatter(t *cli.Table, mounts map[string][]mount.Info) {
 	// TODO: keep the mounts list sorted.
-	fmt.Fprintf(tw, "ID\tMACHINE\tMOUNT\tFILES\tQUEUED\tSYNCING\tSIZE\n")
+	t.Header("ID", "MACHINE", "MOUNT", "FILES", "QUEUED", "SYNCING", "SIZE")
 	for alias, infos := range mounts {
 		for _, info := range infos {
 			sign := info.Syncing
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s/%s\t%s\t%s\t%s/%s\n",
+			t.Rowf("%s\t%s\t%s\t%s/%s\t%s\t%s\t%s/%s\n",
 				info.ID,
 				alias,
 				info.Mount,
@@ -9,6 +9,7 @@ import (
 
 type sshOptions struct {
 	username string
+	proxy    bool
 }
 
 // NewSSHCommand creates a command that allows to SSH into remote machine.
@@ -25,6 +26,7 @@ func NewSSHCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.username, "username", "u", "", "remote username")
+	flags.BoolVar(&opts.proxy, "proxy", false, "connect stdin and stdout to remote SSH server, for use as ProxyCommand")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
@@ -40,6 +42,7 @@ func sshCommand(c *cli.CLI, opts *sshOptions) cli.CobraFuncE {
 		sshOpts := &machine.SSHOptions{
 			Identifier: args[0],
 			Username:   opts.username,
+			Proxy:      opts.proxy,
 			AskList:    cli.AskList(c, cmd),
 		}
 
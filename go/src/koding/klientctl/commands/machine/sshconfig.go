@@ -0,0 +1,83 @@
+package machine
+
+import (
+	"fmt"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/machine"
+
+	"github.com/spf13/cobra"
+)
+
+type sshConfigOptions struct {
+	file   string
+	remove bool
+}
+
+// NewSSHConfigCommand creates a command that writes ssh config entries for
+// remote machines.
+func NewSSHConfigCommand(c *cli.CLI) *cobra.Command {
+	opts := &sshConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ssh-config",
+		Short: "Write ssh config entries for remote machines",
+		Long: `Write a managed block to ~/.ssh/config with a Host entry for each machine alias.
+
+The entries connect through "kd machine ssh --proxy", which looks up current
+machine address and installs local SSH key on the machine, so tools like ssh,
+scp or git can reach machines by their aliases. The block is kept up to date
+each time machines are listed. Content outside of the block is left intact.
+
+Host keys of machines are stored in ~/.ssh/kd_known_hosts. The key of a new
+machine is trusted on first use, a changed key is rejected. When a machine is
+rebuilt, remove its old key with "ssh-keygen -R <alias> -f ~/.ssh/kd_known_hosts".`,
+		RunE: sshConfigCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVar(&opts.file, "file", "", "ssh config file; ~/.ssh/config by default")
+	flags.BoolVar(&opts.remove, "remove", false, "remove managed block from the config file")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func sshConfigCommand(c *cli.CLI, opts *sshConfigOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		sshConfigOpts := &machine.SSHConfigOptions{
+			File:   opts.file,
+			Remove: opts.remove,
+		}
+
+		hosts, err := machine.SSHConfig(sshConfigOpts)
+		if err != nil {
+			return err
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if opts.remove && !out.IsMachineReadable() {
+			fmt.Fprintln(c.Out(), "Removed kd managed block from ssh config.")
+			return nil
+		}
+
+		return out.Print(c.Out(), hosts, func(t *cli.Table) {
+			t.Header("HOST", "USER")
+
+			for _, h := range hosts {
+				t.Row(h.Alias, h.User)
+			}
+		})
+	}
+}
@@ -9,9 +9,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type startOptions struct {
-	jsonOutput bool
-}
+type startOptions struct{}
 
 // NewStartCommand creates a command that can start a remote machine.
 func NewStartCommand(c *cli.CLI) *cobra.Command {
@@ -23,14 +21,11 @@ func NewStartCommand(c *cli.CLI) *cobra.Command {
 		RunE:  startCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -46,13 +41,18 @@ func startCommand(c *cli.CLI, opts *startOptions) cli.CobraFuncE {
 			return err
 		}
 
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
 		for e := range machine.Wait(event) {
 			if e.Error != nil {
 				err = e.Error
 			}
 
-			if opts.jsonOutput {
-				cli.PrintJSON(c.Out(), e)
+			if out.IsMachineReadable() {
+				out.PrintEvent(c.Out(), e)
 			} else {
 				fmt.Fprintf(c.Out(), "[%d%%] %s\n", e.Event.Percentage, e.Event.Message)
 			}
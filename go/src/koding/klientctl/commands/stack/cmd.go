@@ -17,7 +17,14 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 	// Subcommands.
 	cmd.AddCommand(
 		NewCreateCommand(c),
+		NewDestroyCommand(c),
+		NewEventsCommand(c),
 		NewListCommand(c),
+		NewMachinesCommand(c),
+		NewRebuildCommand(c),
+		NewRollbackCommand(c),
+		NewShowCommand(c),
+		NewVersionsCommand(c),
 	)
 
 	// Middlewares.
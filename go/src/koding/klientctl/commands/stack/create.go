@@ -12,11 +12,10 @@ import (
 )
 
 type createOptions struct {
-	team       string
-	title      string
-	file       string
-	creds      []string
-	jsonOutput bool
+	team  string
+	title string
+	file  string
+	creds []string
 }
 
 // NewCreateCommand creates a command that can create stacks.
@@ -35,12 +34,12 @@ func NewCreateCommand(c *cli.CLI) *cobra.Command {
 	flags.StringVar(&opts.title, "title", "", "stack title")
 	flags.StringVarP(&opts.file, "file", "f", "", "read stack template from a file")
 	flags.StringSliceVarP(&opts.creds, "credential", "c", nil, "stack credentials")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -78,9 +77,13 @@ func createCommand(c *cli.CLI, opts *createOptions) cli.CobraFuncE {
 			return errors.New("error creating stack: " + err.Error())
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), resp)
-			return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), resp, nil)
 		}
 
 		fmt.Fprintf(c.Err(), "\nCreatad %q stack with %s ID.\nWaiting for the stack to finish building...\n\n", resp.Title, resp.StackID)
@@ -0,0 +1,102 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+	"koding/klientctl/helper"
+	"koding/remoteapi/models"
+
+	"github.com/spf13/cobra"
+)
+
+type destroyOptions struct {
+	force bool
+}
+
+// NewDestroyCommand creates a command that destroys stacks.
+func NewDestroyCommand(c *cli.CLI) *cobra.Command {
+	opts := &destroyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "destroy <stack-id>",
+		Short: "Destroy a stack",
+		Long: "Destroy a stack and all its machines.\n\n" +
+			"The command waits until the stack is destroyed. It exits with code 2\n" +
+			"when destroying fails and with code 1 on any other error.",
+		RunE: destroyCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.force, "force", false, "confirm all questions")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func destroyCommand(c *cli.CLI, opts *destroyOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		s, err := getStack(args[0])
+		if err != nil {
+			return err
+		}
+
+		if !opts.force {
+			if err := confirm(c, fmt.Sprintf("destroy %q stack", str(s.Title))); err != nil {
+				return err
+			}
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		return destroyStack(c, s, out)
+	}
+}
+
+// destroyStack destroys the given stack and waits
+// until the operation is finished.
+func destroyStack(c *cli.CLI, s *models.JComputeStack, out *cli.Output) error {
+	fmt.Fprintf(c.Err(), "Destroying %q stack...\n\n", str(s.Title))
+
+	resp, err := stack.Apply(&stack.ApplyOptions{
+		StackID: s.ID,
+		Team:    stackTeam(s),
+		Destroy: true,
+	})
+	if err != nil {
+		return errors.New("error destroying stack: " + err.Error())
+	}
+
+	if err := wait(c, resp.EventId, out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Err(), "\nDestroyed %q stack.\n", str(s.Title))
+
+	return nil
+}
+
+func confirm(c *cli.CLI, action string) error {
+	s, err := helper.Fask(c.In(), c.Out(), "Please type \"yes\" to confirm you want to %s []: ", action)
+	if err != nil {
+		return err
+	}
+
+	if s != "yes" {
+		return errors.New("confirmation failed, aborting")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	kloudstack "koding/kites/kloud/stack"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/kloud"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+// exitOperationFailed is an exit code returned when a build
+// or destroy operation of a stack fails.
+const exitOperationFailed = 2
+
+type eventsOptions struct {
+	follow bool
+}
+
+// NewEventsCommand creates a command that shows progress of stack operations.
+func NewEventsCommand(c *cli.CLI) *cobra.Command {
+	opts := &eventsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "events <stack-id>",
+		Short: "Show progress of stack build or destroy",
+		RunE:  eventsCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.follow, "follow", false, "wait for the operation to finish")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func eventsCommand(c *cli.CLI, opts *eventsOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		events, err := stack.Events(args[0])
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			if e.Error != nil {
+				return fmt.Errorf("no events for %q stack: %s", args[0], e.Error)
+			}
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if opts.follow {
+			return wait(c, stack.EventID(args[0]), out)
+		}
+
+		for i := range events {
+			if err := printEvent(c, &events[i], out); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// wait streams events of the given operation until it finishes.
+//
+// If the operation fails, the returned error carries
+// exitOperationFailed exit code.
+func wait(c *cli.CLI, eventID string, out *cli.Output) error {
+	for e := range kloud.Wait(eventID) {
+		if err := printEvent(c, e, out); err != nil {
+			return err
+		}
+
+		if e.Error != nil {
+			return cli.NewError(exitOperationFailed, errors.New(e.Error.Message))
+		}
+	}
+
+	return nil
+}
+
+func printEvent(c *cli.CLI, e *kloudstack.EventResponse, out *cli.Output) error {
+	if out.IsMachineReadable() {
+		return out.PrintEvent(c.Out(), e)
+	}
+
+	if e.Event == nil {
+		return nil
+	}
+
+	if e.Event.Error != "" {
+		fmt.Fprintf(c.Out(), "[%d%%] %s: %s\n", e.Event.Percentage, e.Event.Message, e.Event.Error)
+		return nil
+	}
+
+	fmt.Fprintf(c.Out(), "[%d%%] %s\n", e.Event.Percentage, e.Event.Message)
+	return nil
+}
@@ -1,19 +1,16 @@
 package stack
 
 import (
-	"fmt"
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/endpoint/remoteapi"
 	"koding/klientctl/endpoint/team"
 	"koding/remoteapi/models"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 )
 
 type listOptions struct {
-	team       string
-	jsonOutput bool
+	team string
 }
 
 // NewListCommand creates a command that can list stacks.
@@ -30,12 +27,12 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.StringVar(&opts.team, "team", "", "limit to team's stacks")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.s
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -56,21 +53,14 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), stacks)
-			return nil
-		}
-
-		printStacks(c, stacks)
-		return nil
+		return cli.Print(c, cmd, stacks, func(t *cli.Table) {
+			printStacks(t, stacks)
+		})
 	}
 }
 
-func printStacks(c *cli.CLI, stacks []*models.JComputeStack) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "ID\tTITLE\tOWNER\tTEAM\tSTATE\tREVISION")
+func printStacks(t *cli.Table, stacks []*models.JComputeStack) {
+	t.Header("ID", "TITLE", "OWNER", "TEAM", "STATE", "REVISION")
 
 	for _, stack := range stacks {
 		owner := *stack.OriginID
@@ -80,7 +70,7 @@ func printStacks(c *cli.CLI, stacks []*models.JComputeStack) {
 			}
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", stack.ID, str(stack.Title), owner, str(stack.Group), state(stack.Status), stack.StackRevision)
+		t.Row(stack.ID, str(stack.Title), owner, str(stack.Group), state(stack.Status), stack.StackRevision)
 	}
 }
 
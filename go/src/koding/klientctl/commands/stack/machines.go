@@ -0,0 +1,112 @@
+package stack
+
+import (
+	"fmt"
+	"time"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/machine"
+	"koding/klientctl/endpoint/remoteapi"
+	"koding/remoteapi/models"
+
+	"github.com/spf13/cobra"
+)
+
+type machinesOptions struct{}
+
+// NewMachinesCommand creates a command that lists machines of a given stack.
+func NewMachinesCommand(c *cli.CLI) *cobra.Command {
+	opts := &machinesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "machines <stack-id>",
+		Short: "List machines of a stack",
+		RunE:  machinesCommand(c, opts),
+	}
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func machinesCommand(c *cli.CLI, opts *machinesOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		s, err := getStack(args[0])
+		if err != nil {
+			return err
+		}
+
+		infos, err := stackMachines(s)
+		if err != nil {
+			return err
+		}
+
+		return cli.Print(c, cmd, infos, func(t *cli.Table) {
+			printMachines(t, infos)
+		})
+	}
+}
+
+// getStack looks up a stack given by the id.
+func getStack(id string) (*models.JComputeStack, error) {
+	stacks, err := remoteapi.ListStacks(&remoteapi.Filter{ID: id})
+	if err == remoteapi.ErrNotFound {
+		return nil, fmt.Errorf("stack %q not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return stacks[0], nil
+}
+
+// stackMachines gives machines, which belong to the given stack.
+func stackMachines(s *models.JComputeStack) ([]*machine.Info, error) {
+	ids := make(map[string]struct{})
+
+	for _, id := range remoteapi.StackMachineIDs(s) {
+		ids[id] = struct{}{}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	infos, err := machine.List(&machine.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var machines []*machine.Info
+
+	for _, info := range infos {
+		if _, ok := ids[info.ID]; ok {
+			machines = append(machines, info)
+		}
+	}
+
+	return machines, nil
+}
+
+func stackTeam(s *models.JComputeStack) string {
+	if s.Group == nil {
+		return ""
+	}
+	return *s.Group
+}
+
+func printMachines(t *cli.Table, infos []*machine.Info) {
+	now := time.Now()
+
+	t.Header("ID", "ALIAS", "LABEL", "PROVIDER", "IP", "STATUS")
+
+	for _, info := range infos {
+		t.Row(info.ID, info.Alias, info.Label, info.Provider, info.IP,
+			machine.PrettyStatus(info.Status, now))
+	}
+}
@@ -0,0 +1,99 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/remoteapi"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+type rebuildOptions struct {
+	force bool
+}
+
+// NewRebuildCommand creates a command that rebuilds stacks.
+func NewRebuildCommand(c *cli.CLI) *cobra.Command {
+	opts := &rebuildOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rebuild <stack-id>",
+		Short: "Rebuild a stack",
+		Long: "Destroy a stack and build it again from its stack template.\n\n" +
+			"The rebuilt stack gets a new ID. The command waits until the stack\n" +
+			"is built. It exits with code 2 when destroying or building fails\n" +
+			"and with code 1 on any other error.",
+		RunE: rebuildCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.force, "force", false, "confirm all questions")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func rebuildCommand(c *cli.CLI, opts *rebuildOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		s, err := getStack(args[0])
+		if err != nil {
+			return err
+		}
+
+		if s.BaseStackID == "" {
+			return fmt.Errorf("unable to rebuild %q stack - no stack template found", str(s.Title))
+		}
+
+		if !opts.force {
+			if err := confirm(c, fmt.Sprintf("rebuild %q stack", str(s.Title))); err != nil {
+				return err
+			}
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := destroyStack(c, s, out); err != nil {
+			return err
+		}
+
+		newStack, err := remoteapi.GenerateStack(s.BaseStackID)
+		if err != nil {
+			return errors.New("error creating stack: " + err.Error())
+		}
+
+		fmt.Fprintf(c.Err(), "\nCreated %q stack with %s ID.\nWaiting for the stack to finish building...\n\n", str(newStack.Title), newStack.ID)
+
+		resp, err := stack.Apply(&stack.ApplyOptions{
+			StackID: newStack.ID,
+			Team:    stackTeam(s),
+		})
+		if err != nil {
+			return errors.New("error building stack: " + err.Error())
+		}
+
+		if err := wait(c, resp.EventId, out); err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.PrintEvent(c.Out(), newStack)
+		}
+
+		fmt.Fprintf(c.Err(), "\nRebuilt %q stack.\n", str(newStack.Title))
+
+		return nil
+	}
+}
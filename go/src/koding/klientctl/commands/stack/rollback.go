@@ -0,0 +1,67 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+	"koding/klientctl/helper"
+
+	"github.com/spf13/cobra"
+)
+
+type rollbackOptions struct {
+	force bool
+}
+
+// NewRollbackCommand creates a command that restores Terraform state
+// of the given stack from one of its versions.
+func NewRollbackCommand(c *cli.CLI) *cobra.Command {
+	opts := &rollbackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rollback <stack-id> <version>",
+		Short: "Restore stack state from a version",
+		Long: "Restore Terraform state of a stack from the given version.\n" +
+			"The restored state is recorded as a new version.",
+		RunE: rollbackCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.force, "force", false, "confirm all questions")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(2),   // Two arguments are required.
+	)(c, cmd)
+
+	return cmd
+}
+
+func rollbackCommand(c *cli.CLI, opts *rollbackOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		stackID, version := args[0], args[1]
+
+		if !opts.force {
+			s, err := helper.Fask(c.In(), c.Out(), "Please type \"yes\" to confirm you want to restore the stack state from version %s []: ", version)
+			if err != nil {
+				return err
+			}
+
+			if s != "yes" {
+				return errors.New("confirmation failed, aborting")
+			}
+		}
+
+		if err := stack.RollbackState(stackID, version); err != nil {
+			return errors.New("error rolling back stack state: " + err.Error())
+		}
+
+		fmt.Fprintf(c.Out(), "Stack state restored from version %s.\n", version)
+
+		return nil
+	}
+}
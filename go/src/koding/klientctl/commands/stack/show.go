@@ -0,0 +1,122 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"koding/db/models"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+	remotemodels "koding/remoteapi/models"
+
+	"github.com/spf13/cobra"
+)
+
+type showOptions struct {
+	outputs   bool
+	sensitive bool
+}
+
+// NewShowCommand creates a command that shows details of a given stack.
+func NewShowCommand(c *cli.CLI) *cobra.Command {
+	opts := &showOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show <stack-id>",
+		Short: "Show stack details",
+		RunE:  showCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.outputs, "outputs", false, "show stack outputs")
+	flags.BoolVar(&opts.sensitive, "sensitive", false, "show values of sensitive outputs")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		if opts.outputs {
+			outputs, err := stack.Outputs(args[0], opts.sensitive)
+			if err != nil {
+				return err
+			}
+
+			return cli.Print(c, cmd, outputs, func(t *cli.Table) {
+				printOutputs(t, outputs)
+			})
+		}
+
+		s, err := getStack(args[0])
+		if err != nil {
+			return err
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), s, nil)
+		}
+
+		infos, err := stackMachines(s)
+		if err != nil {
+			return err
+		}
+
+		return out.Print(c.Out(), s, func(t *cli.Table) {
+			printStacks(t, []*remotemodels.JComputeStack{s})
+
+			if len(infos) != 0 {
+				t.Row()
+				printMachines(t, infos)
+			}
+		})
+	}
+}
+
+func printOutputs(t *cli.Table, outputs map[string]*models.StackOutput) {
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	t.Header("NAME", "TYPE", "VALUE")
+
+	for _, name := range names {
+		out := outputs[name]
+
+		t.Row(name, out.Type, outputValue(out))
+	}
+}
+
+func outputValue(out *models.StackOutput) string {
+	switch v := out.Value.(type) {
+	case nil:
+		if out.Sensitive {
+			return "<sensitive>"
+		}
+		return "-"
+	case string:
+		return v
+	default:
+		p, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(p)
+	}
+}
@@ -0,0 +1,52 @@
+package stack
+
+import (
+	"strconv"
+	"time"
+
+	"koding/kites/kloud/terraformer"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+// NewVersionsCommand creates a command that lists previous versions
+// of the given stack's Terraform state.
+func NewVersionsCommand(c *cli.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions <stack-id>",
+		Short: "List stack state versions",
+		RunE:  versionsCommand(c),
+	}
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is required.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func versionsCommand(c *cli.CLI) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		versions, err := stack.StateVersions(args[0])
+		if err != nil {
+			return err
+		}
+
+		return cli.Print(c, cmd, versions, func(t *cli.Table) {
+			printVersions(t, versions)
+		})
+	}
+}
+
+func printVersions(t *cli.Table, versions []*terraformer.StateVersion) {
+	t.Header("VERSION", "SIZE", "CREATED")
+
+	for _, v := range versions {
+		t.Row(v.ID, strconv.Itoa(v.Size), v.Created.Local().Format(time.RFC822))
+	}
+}
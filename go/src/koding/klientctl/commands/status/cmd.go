@@ -11,6 +11,12 @@ import (
 
 type options struct{}
 
+// health describes the result of KD health check.
+type health struct {
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message"`
+}
+
 // NewCommand creates a command that can be used to check KD status.
 func NewCommand(c *cli.CLI) *cobra.Command {
 	opts := &options{}
@@ -26,6 +32,7 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -34,7 +41,19 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 	return func(cmd *cobra.Command, args []string) error {
 		res, ok := status.NewDefaultHealthChecker(c.Log()).CheckAllWithResponse()
-		fmt.Fprintln(c.Out(), res)
+
+		h := &health{
+			Healthy: ok,
+			Message: res,
+		}
+
+		err := cli.Print(c, cmd, h, func(t *cli.Table) {
+			t.Row(h.Message)
+		})
+		if err != nil {
+			return err
+		}
+
 		if !ok {
 			return fmt.Errorf("health check failed")
 		}
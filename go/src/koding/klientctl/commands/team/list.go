@@ -2,7 +2,6 @@ package team
 
 import (
 	"fmt"
-	"text/tabwriter"
 
 	"koding/kites/kloud/team"
 	"koding/klientctl/commands/cli"
@@ -12,8 +11,7 @@ import (
 )
 
 type listOptions struct {
-	slug       string
-	jsonOutput bool
+	slug string
 }
 
 // NewListCommand creates a command that lists user's teams.
@@ -30,12 +28,12 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 	// Flags.
 	flags := cmd.Flags()
 	flags.StringVar(&opts.slug, "slug", "", "limit to team with given slug")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -62,23 +60,16 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			return nil
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), teams)
-			return nil
-		}
-
-		printTeams(c, teams)
-		return nil
+		return cli.Print(c, cmd, teams, func(t *cli.Table) {
+			printTeams(t, teams)
+		})
 	}
 }
 
-func printTeams(c *cli.CLI, teams []*team.Team) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "NAME\tSLUG\tPRIVACY\tSUBSCRIPTION")
+func printTeams(t *cli.Table, teams []*team.Team) {
+	t.Header("NAME", "SLUG", "PRIVACY", "SUBSCRIPTION")
 
-	for _, t := range teams {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, t.Slug, t.Privacy, t.SubStatus)
+	for _, tm := range teams {
+		t.Row(tm.Name, tm.Slug, tm.Privacy, tm.SubStatus)
 	}
 }
@@ -9,9 +9,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type showOptions struct {
-	jsonOutput bool
-}
+type showOptions struct{}
 
 // NewShowCommand creates a command that displays currently used team.
 func NewShowCommand(c *cli.CLI) *cobra.Command {
@@ -23,14 +21,11 @@ func NewShowCommand(c *cli.CLI) *cobra.Command {
 		RunE:  showCommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -45,12 +40,17 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), t)
-		} else {
-			fmt.Fprintln(c.Err(), "You are currently logged in to the following team:", t.Name)
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), t, nil)
 		}
 
+		fmt.Fprintln(c.Err(), "You are currently logged in to the following team:", t.Name)
+
 		return nil
 	}
 }
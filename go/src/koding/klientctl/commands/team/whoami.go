@@ -1,9 +1,6 @@
 package team
 
 import (
-	"fmt"
-	"text/tabwriter"
-
 	"koding/kites/kloud/stack"
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/endpoint/kloud"
@@ -12,9 +9,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type whoAmIOptions struct {
-	jsonOutput bool
-}
+type whoAmIOptions struct{}
 
 // NewWhoAmICommand creates a command that displays authentication details.
 func NewWhoAmICommand(c *cli.CLI) *cobra.Command {
@@ -26,14 +21,11 @@ func NewWhoAmICommand(c *cli.CLI) *cobra.Command {
 		RunE:  whoAmICommand(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -46,23 +38,15 @@ func whoAmICommand(c *cli.CLI, opts *whoAmIOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), resp)
-			return nil
-		}
-
-		printWhoami(c, resp)
-
-		return nil
+		return cli.Print(c, cmd, resp, func(t *cli.Table) {
+			printWhoami(t, resp)
+		})
 	}
 }
 
-func printWhoami(c *cli.CLI, resp *stack.WhoamiResponse) {
-	t := resp.Team
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "USERNAME\tTEAM\tSLUG\tPRIVACY\tSUBSCRIPTION")
+func printWhoami(t *cli.Table, resp *stack.WhoamiResponse) {
+	tm := resp.Team
 
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", kloud.Username(), t.Name, t.Slug, t.Privacy, t.SubStatus)
+	t.Header("USERNAME", "TEAM", "SLUG", "PRIVACY", "SUBSCRIPTION")
+	t.Row(kloud.Username(), tm.Name, tm.Slug, tm.Privacy, tm.SubStatus)
 }
@@ -1,7 +1,11 @@
 package template
 
 import (
+	"errors"
+	"fmt"
+
 	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/remoteapi"
 
 	"github.com/spf13/cobra"
 )
@@ -17,9 +21,14 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 	// Subcommands.
 	cmd.AddCommand(
 		NewDeleteCommand(c),
+		NewDiffCommand(c),
+		NewHistoryCommand(c),
 		NewInitCommand(c),
 		NewListCommand(c),
+		NewRollbackCommand(c),
 		NewShowCommand(c),
+		NewUpdateCommand(c),
+		NewValidateCommand(c),
 	)
 
 	// Middlewares.
@@ -29,3 +38,26 @@ func NewCommand(c *cli.CLI) *cobra.Command {
 
 	return cmd
 }
+
+// templateID gives an ID of the template, which is looked up
+// by its slug if the ID is not given.
+func templateID(id, slug string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+
+	if slug == "" {
+		return "", errors.New("missing template id or slug name")
+	}
+
+	tmpls, err := remoteapi.ListTemplates(&remoteapi.Filter{Slug: slug})
+	if err != nil {
+		return "", err
+	}
+
+	if len(tmpls) != 1 {
+		return "", fmt.Errorf("got %d templates, expecting only one", len(tmpls))
+	}
+
+	return tmpls[0].ID, nil
+}
@@ -0,0 +1,92 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+type diffOptions struct {
+	template string
+	id       string
+}
+
+// NewDiffCommand creates a command that compares two revisions
+// of a stack template.
+func NewDiffCommand(c *cli.CLI) *cobra.Command {
+	opts := &diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <revision> [<revision>]",
+		Short: "Compare stack template revisions",
+		Long: "Show structural difference between two revisions of a stack template.\n" +
+			"If only one revision is given, it is compared with current template content.",
+		RunE: diffCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.template, "template", "t", "", "limit to template name")
+	flags.StringVar(&opts.id, "id", "", "limit to template id")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired,  // Deamon service is required.
+		cli.RangeArgs(1, 2), // One or two revisions are accepted.
+		cli.WithOutput,      // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func diffCommand(c *cli.CLI, opts *diffOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		revs := make([]int, 2)
+
+		for i, arg := range args {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid revision number: %q", arg)
+			}
+
+			revs[i] = n
+		}
+
+		id, err := templateID(opts.id, opts.template)
+		if err != nil {
+			return errors.New("error comparing template revisions - " + err.Error())
+		}
+
+		resp, err := stack.TemplateDiff(id, revs[0], revs[1])
+		if err != nil {
+			return errors.New("error comparing template revisions: " + err.Error())
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), resp.Changes, nil)
+		}
+
+		to := "current"
+		if resp.To != 0 {
+			to = strconv.Itoa(resp.To)
+		}
+
+		fmt.Fprintf(c.Out(), "--- revision %d\n+++ revision %s\n", resp.From, to)
+
+		for _, change := range resp.Changes {
+			fmt.Fprintln(c.Out(), change)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	kloudstack "koding/kites/kloud/stack"
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+type historyOptions struct {
+	template string
+	id       string
+}
+
+// NewHistoryCommand creates a command that displays revisions
+// of a stack template.
+func NewHistoryCommand(c *cli.CLI) *cobra.Command {
+	opts := &historyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show stack template revisions",
+		RunE:  historyCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.template, "template", "t", "", "limit to template name")
+	flags.StringVar(&opts.id, "id", "", "limit to template id")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func historyCommand(c *cli.CLI, opts *historyOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		id, err := templateID(opts.id, opts.template)
+		if err != nil {
+			return errors.New("error requesting template history - " + err.Error())
+		}
+
+		revs, err := stack.TemplateHistory(id)
+		if err != nil {
+			return errors.New("error requesting template history: " + err.Error())
+		}
+
+		return cli.Print(c, cmd, revs, func(t *cli.Table) {
+			printRevisions(t, revs)
+		})
+	}
+}
+
+func printRevisions(t *cli.Table, revs []*kloudstack.TemplateRevision) {
+	t.Header("REVISION", "AUTHOR", "CREATED", "MESSAGE")
+
+	for _, rev := range revs {
+		revision := fmt.Sprintf("%d", rev.Revision)
+		if rev.Current {
+			revision += " (current)"
+		}
+
+		t.Row(revision, nonempty(rev.Author), rev.CreatedAt.Local().Format(time.RFC822), nonempty(rev.Message))
+	}
+}
+
+func nonempty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
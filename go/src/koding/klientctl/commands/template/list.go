@@ -1,9 +1,6 @@
 package template
 
 import (
-	"fmt"
-	"text/tabwriter"
-
 	"koding/klientctl/commands/cli"
 	"koding/klientctl/endpoint/kloud"
 	"koding/klientctl/endpoint/remoteapi"
@@ -13,9 +10,8 @@ import (
 )
 
 type listOptions struct {
-	template   string
-	team       string
-	jsonOutput bool
+	template string
+	team     string
 }
 
 // NewListCommand creates a command that displays stack templates.
@@ -33,12 +29,12 @@ func NewListCommand(c *cli.CLI) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.template, "template", "t", "", "limit to template name")
 	flags.StringVar(&opts.team, "team", "", "limit to given team")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -60,22 +56,14 @@ func listCommand(c *cli.CLI, opts *listOptions) cli.CobraFuncE {
 			return err
 		}
 
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), tmpls)
-			return nil
-		}
-
-		printTemplates(c, tmpls)
-
-		return nil
+		return cli.Print(c, cmd, tmpls, func(t *cli.Table) {
+			printTemplates(t, tmpls)
+		})
 	}
 }
 
-func printTemplates(c *cli.CLI, templates []*models.JStackTemplate) {
-	w := tabwriter.NewWriter(c.Out(), 2, 0, 2, ' ', 0)
-	defer w.Flush()
-
-	fmt.Fprintln(w, "ID\tTITLE\tSLUG\tOWNER\tTEAM\tACCESS\tMACHINES")
+func printTemplates(t *cli.Table, templates []*models.JStackTemplate) {
+	t.Header("ID", "TITLE", "SLUG", "OWNER", "TEAM", "ACCESS", "MACHINES")
 
 	for _, tmpl := range templates {
 		owner := *tmpl.OriginID
@@ -85,7 +73,7 @@ func printTemplates(c *cli.CLI, templates []*models.JStackTemplate) {
 			}
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", tmpl.ID, str(tmpl.Title), str(tmpl.Slug), owner, str(tmpl.Group), tmpl.AccessLevel, len(tmpl.Machines))
+		t.Row(tmpl.ID, str(tmpl.Title), str(tmpl.Slug), owner, str(tmpl.Group), tmpl.AccessLevel, len(tmpl.Machines))
 	}
 }
 
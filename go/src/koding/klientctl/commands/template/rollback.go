@@ -0,0 +1,93 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+	"koding/klientctl/helper"
+
+	"github.com/spf13/cobra"
+)
+
+type rollbackOptions struct {
+	template string
+	id       string
+	message  string
+	force    bool
+}
+
+// NewRollbackCommand creates a command that restores a stack template
+// from one of its revisions.
+func NewRollbackCommand(c *cli.CLI) *cobra.Command {
+	opts := &rollbackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rollback <revision>",
+		Short: "Restore stack template from a revision",
+		Long: "Restore content of a stack template from the given revision.\n" +
+			"The restored content is recorded as a new revision.",
+		RunE: rollbackCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.template, "template", "t", "", "limit to template name")
+	flags.StringVar(&opts.id, "id", "", "limit to template id")
+	flags.StringVarP(&opts.message, "message", "m", "", "revision message")
+	flags.BoolVar(&opts.force, "force", false, "confirm all questions")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.ExactArgs(1),   // One argument is accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func rollbackCommand(c *cli.CLI, opts *rollbackOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid revision number: %q", args[0])
+		}
+
+		id, err := templateID(opts.id, opts.template)
+		if err != nil {
+			return errors.New("error rolling back template - " + err.Error())
+		}
+
+		if !opts.force {
+			s, err := helper.Fask(c.In(), c.Out(), "Please type \"yes\" to confirm you want to restore the template from revision %d []: ", n)
+			if err != nil {
+				return err
+			}
+
+			if s != "yes" {
+				return errors.New("confirmation failed, aborting")
+			}
+		}
+
+		rev, err := stack.RollbackTemplate(id, n, opts.message)
+		if err != nil {
+			return errors.New("error rolling back template: " + err.Error())
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), rev, nil)
+		}
+
+		fmt.Fprintf(c.Out(), "Stack template restored from revision %d as revision %d.\n", n, rev.Revision)
+
+		return nil
+	}
+}
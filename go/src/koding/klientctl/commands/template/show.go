@@ -11,13 +11,11 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v2"
 )
 
 type showOptions struct {
-	id         string
-	hclOutput  bool
-	jsonOutput bool
+	id        string
+	hclOutput bool
 }
 
 // NewShowCommand creates a command that shows details of a given stack template.
@@ -34,12 +32,12 @@ func NewShowCommand(c *cli.CLI) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opts.id, "id", "", "limit to template id")
 	flags.BoolVar(&opts.hclOutput, "hcl", false, "output in HCL format")
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
 
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
 		cli.DaemonRequired, // Deamon service is required.
 		cli.MaxArgs(1),     // No more than 1 arg.
+		cli.WithOutput,     // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -75,10 +73,12 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 			return errors.New("error reading template: " + err.Error())
 		}
 
-		switch {
-		case opts.jsonOutput:
-			cli.PrintJSON(c.Out(), v)
-		case opts.hclOutput:
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if opts.hclOutput && !out.IsMachineReadable() {
 			tree, err := hcl.Parse(tmpl.Template.Content)
 			if err != nil {
 				return errors.New("error reading template: " + err.Error())
@@ -86,15 +86,11 @@ func showCommand(c *cli.CLI, opts *showOptions) cli.CobraFuncE {
 
 			printer.Fprint(c.Out(), tree)
 			fmt.Fprintln(c.Out())
-		default:
-			p, err := yaml.Marshal(v)
-			if err != nil {
-				return errors.New("error reading template: " + err.Error())
-			}
 
-			fmt.Fprintf(c.Out(), "%s\n", p)
+			return nil
 		}
 
-		return nil
+		// Template content is printed in YAML format by default.
+		return out.Print(c.Out(), v, nil)
 	}
 }
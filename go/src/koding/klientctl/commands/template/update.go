@@ -0,0 +1,98 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+type updateOptions struct {
+	template string
+	id       string
+	file     string
+	message  string
+}
+
+// NewUpdateCommand creates a command that updates content
+// of a stack template.
+func NewUpdateCommand(c *cli.CLI) *cobra.Command {
+	opts := &updateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a stack template",
+		Long: "Update content of a stack template.\n" +
+			"The new content is recorded as a new revision.",
+		RunE: updateCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.template, "template", "t", "", "limit to template name")
+	flags.StringVar(&opts.id, "id", "", "limit to template id")
+	flags.StringVarP(&opts.file, "file", "f", "", "read stack template from a file")
+	flags.StringVarP(&opts.message, "message", "m", "", "revision message")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func updateCommand(c *cli.CLI, opts *updateOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		var p []byte
+		var err error
+
+		switch opts.file {
+		case "":
+			return errors.New("no template file was provided")
+		case "-":
+			p, err = ioutil.ReadAll(c.In())
+		default:
+			p, err = ioutil.ReadFile(opts.file)
+		}
+
+		if err != nil {
+			return errors.New("error reading template file: " + err.Error())
+		}
+
+		id, err := templateID(opts.id, opts.template)
+		if err != nil {
+			return errors.New("error updating template - " + err.Error())
+		}
+
+		updateOpts := &stack.UpdateTemplateOptions{
+			TemplateID: id,
+			Template:   p,
+			Message:    opts.message,
+		}
+
+		rev, err := stack.UpdateTemplate(updateOpts)
+		if err != nil {
+			return errors.New("error updating template: " + err.Error())
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), rev, nil)
+		}
+
+		fmt.Fprintf(c.Out(), "Stack template updated to revision %d.\n", rev.Revision)
+
+		return nil
+	}
+}
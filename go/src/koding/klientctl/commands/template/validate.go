@@ -0,0 +1,121 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"koding/klientctl/commands/cli"
+	"koding/klientctl/endpoint/stack"
+
+	"github.com/spf13/cobra"
+)
+
+type validateOptions struct {
+	team  string
+	file  string
+	creds []string
+	vars  []string
+}
+
+// NewValidateCommand creates a command that validates stack templates.
+func NewValidateCommand(c *cli.CLI) *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a stack template",
+		RunE:  validateCommand(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVar(&opts.team, "team", "", "owner of the template")
+	flags.StringVarP(&opts.file, "file", "f", "", "read stack template from a file")
+	flags.StringSliceVarP(&opts.creds, "credential", "c", nil, "stack credentials")
+	flags.StringSliceVar(&opts.vars, "var", nil, "custom variables in name=value format")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+		cli.WithOutput,     // Output format flags are supported.
+	)(c, cmd)
+
+	return cmd
+}
+
+func validateCommand(c *cli.CLI, opts *validateOptions) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		var p []byte
+		var err error
+
+		switch opts.file {
+		case "":
+			return errors.New("no template file was provided")
+		case "-":
+			p, err = ioutil.ReadAll(c.In())
+		default:
+			p, err = ioutil.ReadFile(opts.file)
+		}
+
+		if err != nil {
+			return errors.New("error reading template file: " + err.Error())
+		}
+
+		validateOpts := &stack.ValidateOptions{
+			Team:        opts.team,
+			Credentials: opts.creds,
+			Template:    p,
+		}
+
+		if len(opts.vars) != 0 {
+			validateOpts.Variables = make(map[string]string, len(opts.vars))
+
+			for _, v := range opts.vars {
+				i := strings.IndexRune(v, '=')
+				if i == -1 {
+					return fmt.Errorf("invalid variable %q, expected name=value format", v)
+				}
+
+				validateOpts.Variables[strings.TrimPrefix(v[:i], "custom_")] = v[i+1:]
+			}
+		}
+
+		resp, err := stack.Validate(validateOpts)
+		if err != nil {
+			return errors.New("error validating template: " + err.Error())
+		}
+
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if !out.IsMachineReadable() && resp.Valid {
+			fmt.Fprintln(c.Out(), "Template is valid.")
+		} else {
+			err = out.Print(c.Out(), resp, func(t *cli.Table) {
+				t.Header("POINTER", "MESSAGE")
+				for _, e := range resp.Errors {
+					pointer := e.Pointer
+					if pointer == "" {
+						pointer = "/"
+					}
+
+					t.Row(pointer, e.Message)
+				}
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !resp.Valid {
+			return fmt.Errorf("template is invalid: found %d error(s)", len(resp.Errors))
+		}
+
+		return nil
+	}
+}
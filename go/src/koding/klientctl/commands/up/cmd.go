@@ -0,0 +1,56 @@
+package up
+
+import (
+	"koding/klientctl/app"
+	"koding/klientctl/commands/cli"
+
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	file     string
+	defaults bool
+}
+
+// NewCommand creates a command that brings up a project environment.
+func NewCommand(c *cli.CLI) *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Bring up a project environment",
+		Long: "Build the project stack if needed, start its machines, create mounts\n" +
+			"and port forwards and run commands described by the project file.",
+		RunE: command(c, opts),
+	}
+
+	// Flags.
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.file, "file", "f", app.ProjectFile, "project file")
+	flags.BoolVar(&opts.defaults, "defaults", false, "use default values for stack variables")
+
+	// Middlewares.
+	cli.MultiCobraCmdMiddleware(
+		cli.DaemonRequired, // Deamon service is required.
+		cli.NoArgs,         // No custom arguments are accepted.
+	)(c, cmd)
+
+	return cmd
+}
+
+func command(c *cli.CLI, opts *options) cli.CobraFuncE {
+	return func(cmd *cobra.Command, args []string) error {
+		p, err := app.ReadProject(opts.file)
+		if err != nil {
+			return err
+		}
+
+		env := &app.Env{
+			Project:     p,
+			UseDefaults: opts.defaults,
+			Out:         c.Out(),
+		}
+
+		return env.Up()
+	}
+}
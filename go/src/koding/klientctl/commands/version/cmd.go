@@ -9,9 +9,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type options struct {
-	jsonOutput bool
-}
+type options struct{}
 
 // NewCommand creates a command that displays current version of this application.
 func NewCommand(c *cli.CLI) *cobra.Command {
@@ -25,13 +23,10 @@ with machine's kite query ID.`,
 		RunE: command(c, opts),
 	}
 
-	// Flags.
-	flags := cmd.Flags()
-	flags.BoolVar(&opts.jsonOutput, "json", false, "output in JSON format")
-
 	// Middlewares.
 	cli.MultiCobraCmdMiddleware(
-		cli.NoArgs, // No custom arguments are accepted.
+		cli.NoArgs,     // No custom arguments are accepted.
+		cli.WithOutput, // Output format flags are supported.
 	)(c, cmd)
 
 	return cmd
@@ -51,9 +46,13 @@ func command(c *cli.CLI, opts *options) cli.CobraFuncE {
 		}
 
 		v.Latest, _ = config.LatestKDVersionNum()
-		if opts.jsonOutput {
-			cli.PrintJSON(c.Out(), v)
-			return nil
+		out, err := cli.OutputOf(cmd)
+		if err != nil {
+			return err
+		}
+
+		if out.IsMachineReadable() {
+			return out.Print(c.Out(), v, nil)
 		}
 
 		fmt.Fprintf(c.Out(), "Installed Version: %s\n", getReadableVersion(v.Installed))
@@ -9,6 +9,7 @@ import (
 
 	"koding/kites/config"
 	"koding/kites/config/configstore"
+	"koding/klient/release"
 	conf "koding/klientctl/config"
 	"koding/klientctl/ctlcli"
 	"koding/klientctl/endpoint/auth"
@@ -35,11 +36,13 @@ type Client struct {
 	Log     logging.Logger      // logger to use; by default kloud.DefaultLog
 	Script  []InstallStep       // installation script to use; by default Script is used
 	Timeout time.Duration       // max time to wait for daemon to be ready; by default 20s
+	Release *release.Client     // used to verify downloaded binaries; by default release.DefaultClient
 
 	once      sync.Once
 	d         *Details
 	vagrant   *bool
 	uninstall bool
+	backups   []string // files backed up during update
 }
 
 // Starts starts KD daemon.
@@ -186,6 +189,13 @@ func (c *Client) store() *configstore.Client {
 	return configstore.DefaultClient
 }
 
+func (c *Client) release() *release.Client {
+	if c.Release != nil {
+		return c.Release
+	}
+	return release.DefaultClient
+}
+
 func (c *Client) kd(version int) string {
 	return conf.S3Klientctl(version, conf.Environments.KDEnv)
 }
@@ -3,6 +3,8 @@ package daemon
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +20,7 @@ import (
 	"github.com/mitchellh/ioprogress"
 
 	"koding/kites/config"
+	"koding/klient/release"
 	conf "koding/klientctl/config"
 )
 
@@ -184,6 +187,15 @@ func parseVersion(bin string) (int, error) {
 // wget is a helper function, that downloads any content from
 // the given url and writes it to the output file.
 func wget(url, output string, mode os.FileMode) error {
+	return wgetVerified(url, output, mode, nil)
+}
+
+// wgetVerified works like wget, but prior to writing the output
+// file it ensures the downloaded content matches its digest
+// from the given release manifest.
+//
+// If m is nil, no verification is performed.
+func wgetVerified(url, output string, mode os.FileMode, m *release.Manifest) error {
 	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
 		return err
 	}
@@ -195,17 +207,23 @@ func wget(url, output string, mode os.FileMode) error {
 
 	resp, err := http.Get(url)
 	if err != nil {
-		return nonil(err, f.Close())
+		return nonil(err, f.Close(), os.Remove(f.Name()))
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nonil(errors.New(url+":"+http.StatusText(resp.StatusCode)), f.Close())
+		return nonil(errors.New(url+":"+http.StatusText(resp.StatusCode)), f.Close(), os.Remove(f.Name()))
 	}
 
 	var buf bytes.Buffer // to restore beginning of a response body consumed by gzip.NewReader
 	var body io.Reader
+	var raw io.Reader = resp.Body
+
+	// The digest is computed out of the downloaded content,
+	// prior to decompressing it.
+	h := sha256.New()
+	raw = io.TeeReader(raw, h)
 
 	if resp.ContentLength > 0 {
 		file := path.Base(url)
@@ -215,19 +233,19 @@ func wget(url, output string, mode os.FileMode) error {
 		}
 
 		body = io.MultiReader(&buf, &ioprogress.Reader{
-			Reader:   resp.Body,
+			Reader:   raw,
 			Size:     resp.ContentLength,
 			DrawFunc: ioprogress.DrawTerminalf(os.Stdout, fn),
 		})
 		defer fmt.Println()
 	} else {
-		body = io.MultiReader(&buf, resp.Body)
+		body = io.MultiReader(&buf, raw)
 	}
 
 	// If body contains gzip header, it means the payload was compressed.
 	// Relying solely on Content-Type == "application/gzip" check
 	// was not reliable.
-	if _, err := gzip.NewReader(io.TeeReader(resp.Body, &buf)); err == nil {
+	if _, err := gzip.NewReader(io.TeeReader(raw, &buf)); err == nil {
 		if r, err := gzip.NewReader(body); err == nil {
 			body = r
 		}
@@ -235,7 +253,13 @@ func wget(url, output string, mode os.FileMode) error {
 
 	_, err = io.Copy(f, body)
 	if err = nonil(err, f.Chmod(mode), f.Close()); err != nil {
-		return err
+		return nonil(err, os.Remove(f.Name()))
+	}
+
+	if m != nil {
+		if err := m.VerifySum(path.Base(url), hex.EncodeToString(h.Sum(nil))); err != nil {
+			return nonil(err, os.Remove(f.Name()))
+		}
 	}
 
 	return os.Rename(f.Name(), output)
@@ -234,9 +234,35 @@ func (c *Client) Update(opts *Opts) error {
 		return errors.New(`KD is not yet installed. Please run "sudo kd install".`)
 	}
 
+	versions := make([]string, len(c.d.Installation))
+	for i, inst := range c.d.Installation {
+		versions[i] = inst.Version
+	}
+
+	c.backups = nil
+
+	err := c.update(opts)
+	if err == nil || len(c.backups) == 0 {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Update failed: %s\n\nRolling back to previous version...\n\n", err)
+
+	if e := c.rollback(); e != nil {
+		return fmt.Errorf("%s; rollback failed: %s", err, e)
+	}
+
+	for i, version := range versions {
+		c.d.Installation[i].Version = version
+	}
+
+	return fmt.Errorf("update failed and was rolled back: %s", err)
+}
+
+func (c *Client) update(opts *Opts) error {
 	var merr error
 	for i, step := range c.script() {
-		if !step.RunOnUpdate || c.Install == nil {
+		if !step.RunOnUpdate || step.Install == nil {
 			continue
 		}
 
@@ -267,6 +293,56 @@ func (c *Client) Update(opts *Opts) error {
 	return merr
 }
 
+// backup makes a copy of the given file, so it can be restored
+// by rollback when update fails. The copy is kept after
+// a successful update.
+//
+// If the file does not exist, backup is a nop.
+func (c *Client) backup(file string) error {
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := copyFile(file, file+".prev", 0); err != nil {
+		return err
+	}
+
+	c.backups = append(c.backups, file)
+
+	return nil
+}
+
+// rollback restores files backed up during update and restarts
+// the KD daemon.
+func (c *Client) rollback() error {
+	var merr error
+
+	for _, file := range c.backups {
+		if err := copyFile(file+".prev", file, 0); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	c.backups = nil
+
+	if merr != nil {
+		return merr
+	}
+
+	svc, err := c.d.service()
+	if err != nil {
+		return err
+	}
+
+	_ = svc.Stop()
+
+	if err := svc.Start(); err != nil {
+		return err
+	}
+
+	return c.Ping()
+}
+
 func (c *Client) needVagrant(opts *Opts) bool {
 	if c.vagrant != nil {
 		return *c.vagrant
@@ -687,6 +763,11 @@ var Script = []InstallStep{{
 			return strconv.Itoa(version), ErrSkipInstall
 		}
 
+		m, err := c.release().FetchFor(c.klient(newVersion), newVersion)
+		if err != nil {
+			return "", err
+		}
+
 		svc, err := c.d.service()
 		if err != nil {
 			return "", err
@@ -695,7 +776,11 @@ var Script = []InstallStep{{
 		// Best-effort attempt at stopping the running klient, if any.
 		_ = svc.Stop()
 
-		if err := wget(c.klient(newVersion), c.d.Files["klient"], 0755); err != nil {
+		if err := c.backup(c.d.Files["klient"]); err != nil {
+			return "", err
+		}
+
+		if err := wgetVerified(c.klient(newVersion), c.d.Files["klient"], 0755, m); err != nil {
 			return "", err
 		}
 
@@ -730,6 +815,8 @@ var Script = []InstallStep{{
 		_ = svc.Stop() // ignore failue, klient may be already stopped
 		_ = svc.Uninstall()
 
+		_ = os.Remove(c.d.Files["klient"] + ".prev") // backup may not exist
+
 		return nonil(os.Remove(c.d.Files["klient.sh"]), os.Remove(c.d.Files["klient"]))
 	},
 	RunOnUpdate: true,
@@ -761,7 +848,16 @@ var Script = []InstallStep{{
 			return strconv.Itoa(version), ErrSkipInstall
 		}
 
-		if err := wget(c.kd(newVersion), c.d.Files["kd"], 0755); err != nil {
+		m, err := c.release().FetchFor(c.kd(newVersion), newVersion)
+		if err != nil {
+			return "", err
+		}
+
+		if err := c.backup(c.d.Files["kd"]); err != nil {
+			return "", err
+		}
+
+		if err := wgetVerified(c.kd(newVersion), c.d.Files["kd"], 0755, m); err != nil {
 			return "", err
 		}
 
@@ -30,8 +30,16 @@ pushd "${REPO_PATH}"
 [[ -f "kd-0.1.${VERSION}.linux_amd64.gz" ]]
 [[ -f "kd-0.1.${VERSION}.darwin_amd64.gz" ]]
 
+echo "# signing release manifest"
+
+go run go/src/koding/klient/release/cmd/release-sign/main.go -name kd -version "$VERSION" \
+	"kd-0.1.${VERSION}.linux_amd64.gz" "kd-0.1.${VERSION}.darwin_amd64.gz"
+
 echo "# uploading files to s3://${BUCKET}/${CHANNEL}/"
 
+s3cp "manifest-0.1.${VERSION}.json" "s3://${BUCKET}/${CHANNEL}/"
+s3cp "manifest-0.1.${VERSION}.json.sig" "s3://${BUCKET}/${CHANNEL}/"
+
 s3cp "kd-0.1.${VERSION}.linux_amd64.gz" "s3://${BUCKET}/${CHANNEL}/"
 s3cp "kd-0.1.${VERSION}.darwin_amd64.gz" "s3://${BUCKET}/${CHANNEL}/"
 
@@ -0,0 +1,321 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"koding/httputil"
+	"koding/kites/config"
+	"koding/klient/machine/mount"
+	"koding/klientctl/daemon"
+	"koding/klientctl/endpoint/machine"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/koding/kite/kitekey"
+)
+
+// MaxClockSkew is a maximum difference between local and
+// Koding clocks, above which kite tokens may be rejected.
+var MaxClockSkew = 1 * time.Minute
+
+var kiteHTTPResponse = []byte("Welcome to SockJS!")
+
+var defaultClient = httputil.NewClient(&httputil.ClientConfig{
+	DialTimeout:           3 * time.Second,
+	RoundTripTimeout:      3 * time.Second,
+	TLSHandshakeTimeout:   3 * time.Second,
+	ResponseHeaderTimeout: 3 * time.Second,
+})
+
+// Checks gives default checks for the given configuration.
+func Checks(k *config.Konfig) []*Check {
+	return []*Check{{
+		Name:        "daemon-installed",
+		Description: "KD Daemon is installed",
+		Run: func() error {
+			if !daemon.Installed() {
+				return errors.New("KD Daemon is not installed")
+			}
+			return nil
+		},
+		Suggestion: `Install KD Daemon with "sudo kd daemon install".`,
+	}, {
+		Name:        "daemon",
+		Description: "KD Daemon is reachable",
+		Run: func() error {
+			return checkKite(k.Endpoints.Klient.Private.String())
+		},
+		Suggestion: `Restart KD Daemon with "sudo kd daemon restart".`,
+		Fix:        daemon.Restart,
+		Requires:   []string{"daemon-installed"},
+	}, {
+		Name:        "kontrol",
+		Description: "Kontrol endpoint is reachable",
+		Run: func() error {
+			return checkKite(k.Endpoints.Kontrol().Public.String())
+		},
+		Suggestion: "Ensure your internet connection is stable and Koding is reachable from your network.",
+	}, {
+		Name:        "kloud",
+		Description: "Kloud endpoint is reachable",
+		Run: func() error {
+			return checkKite(k.Endpoints.Kloud().Public.String())
+		},
+		Suggestion: "Ensure your internet connection is stable and Koding is reachable from your network.",
+	}, {
+		Name:        "clock",
+		Description: "Local clock is in sync with Koding",
+		Run: func() error {
+			return checkClock(k.Endpoints.Koding.Public.String())
+		},
+		Suggestion: "Synchronize your system clock, e.g. by enabling NTP.",
+		Requires:   []string{"kontrol"},
+	}, {
+		Name:        "kitekey",
+		Description: "Kite key is valid",
+		Run: func() error {
+			return checkKiteKey(k)
+		},
+		Suggestion: `Log in again with "kd auth login".`,
+	}, {
+		Name:        "fuse",
+		Description: "FUSE is available",
+		Run:         checkFuse,
+		Suggestion:  "Install FUSE (OSXFUSE on macOS) to use FUSE-based mounts.",
+	}, {
+		Name:        "rsync",
+		Description: "rsync is installed",
+		Run: func() error {
+			return checkBinary("rsync")
+		},
+		Suggestion: "Install rsync and make sure it is accessible from your system path.",
+	}, {
+		Name:        "ssh",
+		Description: "ssh is installed",
+		Run: func() error {
+			return checkBinary("ssh")
+		},
+		Suggestion: "Install OpenSSH client and make sure it is accessible from your system path.",
+	}, {
+		Name:        "mounts",
+		Description: "Mounted directories are consistent",
+		Run:         checkMounts,
+		Suggestion:  `Remount affected directories with "kd machine umount" and "kd machine mount".`,
+		Requires:    []string{"daemon"},
+	}, {
+		Name:        "cache",
+		Description: "There are no stale mount caches",
+		Run: func() error {
+			dirs, err := staleCaches()
+			if err != nil {
+				return err
+			}
+
+			if len(dirs) != 0 {
+				return Warnf("found %d stale mount cache directories: %s", len(dirs), strings.Join(dirs, ", "))
+			}
+
+			return nil
+		},
+		Suggestion: "Remove stale cache directories.",
+		Fix: func() error {
+			dirs, err := staleCaches()
+			if err != nil {
+				return err
+			}
+
+			for _, dir := range dirs {
+				if err := os.RemoveAll(dir); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Requires: []string{"daemon"},
+	}}
+}
+
+// checkKite checks whether the given URL is served by a kite.
+func checkKite(u string) error {
+	resp, err := defaultClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("%s: unexpected status code: %d", u, resp.StatusCode)
+	}
+
+	p, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: unable to read response: %s", u, err)
+	}
+
+	if !bytes.Equal(kiteHTTPResponse, bytes.TrimSpace(p)) {
+		return fmt.Errorf("%s: unexpected response: %q", u, p)
+	}
+
+	return nil
+}
+
+// checkClock compares local time with the time reported
+// by the given URL.
+func checkClock(u string) error {
+	resp, err := defaultClient.Head(u)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return Warnf("unable to read remote time: %s", err)
+	}
+
+	skew := time.Now().Sub(date)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	// Date header has a second precision.
+	if skew > MaxClockSkew+time.Second {
+		return fmt.Errorf("local clock is off by %s", skew)
+	}
+
+	return nil
+}
+
+func checkKiteKey(k *config.Konfig) error {
+	var (
+		tok *jwt.Token
+		err error
+	)
+
+	switch {
+	case k.KiteKey != "":
+		tok, err = jwt.ParseWithClaims(k.KiteKey, &kitekey.KiteClaims{}, kitekey.GetKontrolKey)
+	case k.KiteKeyFile != "":
+		tok, err = kitekey.ParseFile(k.KiteKeyFile)
+	default:
+		return errors.New("kite key is not configured")
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid kite key: %s", err)
+	}
+
+	if !tok.Valid {
+		return errors.New("invalid kite key")
+	}
+
+	return nil
+}
+
+func checkFuse() error {
+	var path string
+
+	switch runtime.GOOS {
+	case "linux":
+		path = "/dev/fuse"
+	case "darwin":
+		path = "/Library/Filesystems/osxfuse.fs"
+	default:
+		return Warnf("FUSE is not supported on %s", runtime.GOOS)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return Warnf("FUSE is not available: %s", err)
+	}
+
+	return nil
+}
+
+func checkBinary(name string) error {
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s was not found: %s", name, err)
+	}
+	return nil
+}
+
+// checkMounts runs filesystem diagnostics of each mount.
+func checkMounts() error {
+	mounts, err := machine.ListMount(&machine.ListMountOptions{})
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+
+	for _, infos := range mounts {
+		for _, info := range infos {
+			opts := &machine.InspectMountOptions{
+				Identifier: string(info.ID),
+				Filesystem: true,
+			}
+
+			resp, err := machine.InspectMount(opts)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", info.Mount.Path, err))
+				continue
+			}
+
+			for _, p := range resp.Filesystem {
+				problems = append(problems, fmt.Sprintf("%s: %s", info.Mount.Path, p))
+			}
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("found %d problems:\n%s", len(problems), strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// staleCaches gives cache directories of mounts, which no longer exist.
+func staleCaches() ([]string, error) {
+	mounts, err := machine.ListMount(&machine.ListMountOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[mount.ID]struct{})
+
+	for _, infos := range mounts {
+		for _, info := range infos {
+			ids[info.ID] = struct{}{}
+		}
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(config.KodingMounts(), "mount-*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+
+	for _, dir := range dirs {
+		id := mount.ID(strings.TrimPrefix(filepath.Base(dir), "mount-"))
+
+		if _, ok := ids[id]; !ok {
+			stale = append(stale, dir)
+		}
+	}
+
+	return stale, nil
+}
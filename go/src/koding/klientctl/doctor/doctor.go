@@ -0,0 +1,146 @@
+// Package doctor implements diagnostics for KD installation
+// and its mounts, which are run with "kd doctor" command.
+package doctor
+
+import "fmt"
+
+// Status describes a result of a single check.
+type Status string
+
+// Check statuses.
+const (
+	StatusPass Status = "pass" // check succeeded
+	StatusWarn Status = "warn" // check found a problem, which does not break KD
+	StatusFail Status = "fail" // check found a problem
+	StatusSkip Status = "skip" // check was not run as its requirement failed
+)
+
+// Check is a single diagnostic.
+type Check struct {
+	Name        string // unique name of the check
+	Description string // what is being checked
+
+	// Run performs the check. A nil error means the check passed,
+	// an error created with Warnf means the check found a non-critical
+	// problem and any other error means the check failed.
+	Run func() error
+
+	// Suggestion is a suggested fix, reported when the check fails.
+	Suggestion string
+
+	// Fix is an optional fix action, which is run on failure or
+	// warning when fixing is enabled. After successful fix the check
+	// is run again.
+	Fix func() error
+
+	// Requires names checks that need to pass for this check to run.
+	Requires []string
+}
+
+// Result represents an outcome of a single check.
+type Result struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      Status `json:"status"`
+	Message     string `json:"message,omitempty"`
+	Suggestion  string `json:"suggestion,omitempty"`
+	Fixable     bool   `json:"fixable,omitempty"`
+	Fixed       bool   `json:"fixed,omitempty"`
+	FixError    string `json:"fixError,omitempty"`
+}
+
+// Doctor runs a set of checks.
+type Doctor struct {
+	Checks []*Check // checks to run, in order
+	Fix    bool     // whether to run fix actions of failed checks
+}
+
+// Run runs all the checks in order and gives their results.
+func (d *Doctor) Run() []*Result {
+	results := make([]*Result, 0, len(d.Checks))
+	statuses := make(map[string]Status, len(d.Checks))
+
+	for _, c := range d.Checks {
+		res := d.run(c, statuses)
+		statuses[c.Name] = res.Status
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func (d *Doctor) run(c *Check, statuses map[string]Status) *Result {
+	res := &Result{
+		Name:        c.Name,
+		Description: c.Description,
+		Fixable:     c.Fix != nil,
+	}
+
+	for _, name := range c.Requires {
+		if s := statuses[name]; s != StatusPass && s != StatusWarn {
+			res.Status = StatusSkip
+			res.Message = fmt.Sprintf("requires %q check to pass", name)
+			return res
+		}
+	}
+
+	res.Status, res.Message = status(c.Run())
+
+	if res.Status == StatusPass {
+		return res
+	}
+
+	if d.Fix && c.Fix != nil {
+		if err := c.Fix(); err != nil {
+			res.FixError = err.Error()
+		} else {
+			res.Fixed = true
+			res.Status, res.Message = status(c.Run())
+		}
+	}
+
+	if res.Status != StatusPass {
+		res.Suggestion = c.Suggestion
+	}
+
+	return res
+}
+
+// Failed gives a number of failed checks.
+func Failed(results []*Result) (n int) {
+	for _, res := range results {
+		if res.Status == StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+type warning struct {
+	err error
+}
+
+func (w *warning) Error() string { return w.err.Error() }
+
+// Warnf creates an error, which makes a check report
+// a warning instead of a failure.
+func Warnf(format string, args ...interface{}) error {
+	return &warning{err: fmt.Errorf(format, args...)}
+}
+
+// IsWarning tells whether err was created with Warnf.
+func IsWarning(err error) bool {
+	_, ok := err.(*warning)
+	return ok
+}
+
+func status(err error) (Status, string) {
+	switch {
+	case err == nil:
+		return StatusPass, ""
+	case IsWarning(err):
+		return StatusWarn, err.Error()
+	default:
+		return StatusFail, err.Error()
+	}
+}
@@ -0,0 +1,101 @@
+package doctor_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"koding/klientctl/doctor"
+)
+
+func TestDoctor(t *testing.T) {
+	fixed := false
+
+	checks := []*doctor.Check{{
+		Name:       "pass",
+		Run:        func() error { return nil },
+		Suggestion: "never shown",
+	}, {
+		Name:       "fail",
+		Run:        func() error { return errors.New("failed") },
+		Suggestion: "fix it",
+	}, {
+		Name:     "skip",
+		Run:      func() error { return nil },
+		Requires: []string{"fail"},
+	}, {
+		Name:       "warn",
+		Run:        func() error { return doctor.Warnf("warning %d", 1) },
+		Suggestion: "fix it",
+		Requires:   []string{"pass"},
+	}, {
+		Name: "fixable",
+		Run: func() error {
+			if !fixed {
+				return errors.New("not fixed")
+			}
+			return nil
+		},
+		Fix: func() error {
+			fixed = true
+			return nil
+		},
+	}, {
+		Name: "unfixable",
+		Run:  func() error { return errors.New("failed") },
+		Fix:  func() error { return errors.New("fix failed") },
+	}}
+
+	cases := map[string]struct {
+		fix    bool
+		failed int
+		want   []*doctor.Result
+	}{
+		"without fix": {
+			false,
+			3,
+			[]*doctor.Result{
+				{Name: "pass", Status: doctor.StatusPass},
+				{Name: "fail", Status: doctor.StatusFail, Message: "failed", Suggestion: "fix it"},
+				{Name: "skip", Status: doctor.StatusSkip, Message: `requires "fail" check to pass`},
+				{Name: "warn", Status: doctor.StatusWarn, Message: "warning 1", Suggestion: "fix it"},
+				{Name: "fixable", Status: doctor.StatusFail, Message: "not fixed", Fixable: true},
+				{Name: "unfixable", Status: doctor.StatusFail, Message: "failed", Fixable: true},
+			},
+		},
+		"with fix": {
+			true,
+			2,
+			[]*doctor.Result{
+				{Name: "pass", Status: doctor.StatusPass},
+				{Name: "fail", Status: doctor.StatusFail, Message: "failed", Suggestion: "fix it"},
+				{Name: "skip", Status: doctor.StatusSkip, Message: `requires "fail" check to pass`},
+				{Name: "warn", Status: doctor.StatusWarn, Message: "warning 1", Suggestion: "fix it"},
+				{Name: "fixable", Status: doctor.StatusPass, Fixable: true, Fixed: true},
+				{Name: "unfixable", Status: doctor.StatusFail, Message: "failed", Fixable: true, FixError: "fix failed"},
+			},
+		},
+	}
+
+	for name, cas := range cases {
+		fixed = false
+
+		d := &doctor.Doctor{
+			Checks: checks,
+			Fix:    cas.fix,
+		}
+
+		got := d.Run()
+
+		if !reflect.DeepEqual(got, cas.want) {
+			for i := range got {
+				t.Logf("%s: %d: got %+v, want %+v", name, i, got[i], cas.want[i])
+			}
+			t.Fatalf("%s: unexpected results", name)
+		}
+
+		if n := doctor.Failed(got); n != cas.failed {
+			t.Fatalf("%s: got %d failed, want %d", name, n, cas.failed)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"koding/kites/kloud/stack"
 	"koding/klientctl/ctlcli"
 	"koding/klientctl/endpoint/kloud"
+	"koding/klientctl/endpoint/remoteapi"
 )
 
 var DefaultClient = &Client{}
@@ -41,6 +42,24 @@ func (opts *CreateOptions) Valid() error {
 	return nil
 }
 
+type ValidateOptions struct {
+	Team        string
+	Identifiers []string
+}
+
+// Valid implements the stack.Validator interface.
+func (opts *ValidateOptions) Valid() error {
+	if opts == nil {
+		return errors.New("credential: arguments are missing")
+	}
+
+	if len(opts.Identifiers) == 0 {
+		return errors.New("credential: identifiers are missing")
+	}
+
+	return nil
+}
+
 type Client struct {
 	Kloud *kloud.Client
 
@@ -110,6 +129,89 @@ func (c *Client) Create(opts *CreateOptions) (*stack.CredentialItem, error) {
 	}, nil
 }
 
+// Validate verifies the given credentials against their providers,
+// without storing or bootstrapping them.
+//
+// The result is keyed by credential identifiers.
+func (c *Client) Validate(opts *ValidateOptions) (stack.AuthenticateResponse, error) {
+	c.init()
+
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string][]string)
+
+	for _, identifier := range opts.Identifiers {
+		provider, err := c.Provider(identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		providers[provider] = append(providers[provider], identifier)
+	}
+
+	result := make(stack.AuthenticateResponse, len(opts.Identifiers))
+
+	for provider, identifiers := range providers {
+		req := &stack.AuthenticateRequest{
+			Provider:    provider,
+			Identifiers: identifiers,
+			GroupName:   opts.Team,
+		}
+
+		var resp stack.AuthenticateResponse
+
+		if err := c.kloud().Call("authenticate", req, &resp); err != nil {
+			return nil, err
+		}
+
+		for identifier, res := range resp {
+			result[identifier] = res
+		}
+	}
+
+	return result, nil
+}
+
+// Delete removes a credential given by the identifier.
+func (c *Client) Delete(identifier string) error {
+	c.init()
+
+	provider, err := c.Provider(identifier)
+	if err != nil {
+		return err
+	}
+
+	if err := remoteapi.DeleteCredential(identifier); err != nil {
+		if err == remoteapi.ErrNotFound {
+			return fmt.Errorf("credential: %q does not exist or is not shared with the user", identifier)
+		}
+
+		return err
+	}
+
+	creds := c.cached[provider][:0]
+
+	for _, cred := range c.cached[provider] {
+		if cred.Identifier != identifier {
+			creds = append(creds, cred)
+		}
+	}
+
+	c.cached[provider] = creds
+
+	if c.used[provider] == identifier {
+		if len(creds) != 0 {
+			c.used[provider] = creds[0].Identifier
+		} else {
+			delete(c.used, provider)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) Use(identifier string) error {
 	c.init()
 
@@ -246,5 +348,10 @@ func List(opts *ListOptions) (stack.Credentials, error)         { return Default
 func Create(opts *CreateOptions) (*stack.CredentialItem, error) { return DefaultClient.Create(opts) }
 func Describe() (stack.Descriptions, error)                     { return DefaultClient.Describe() }
 func Use(identifier string) error                               { return DefaultClient.Use(identifier) }
+func Delete(identifier string) error                            { return DefaultClient.Delete(identifier) }
 func Used() map[string]string                                   { return DefaultClient.Used() }
 func Provider(identifier string) (string, error)                { return DefaultClient.Provider(identifier) }
+
+func Validate(opts *ValidateOptions) (stack.AuthenticateResponse, error) {
+	return DefaultClient.Validate(opts)
+}
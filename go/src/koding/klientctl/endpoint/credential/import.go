@@ -0,0 +1,412 @@
+package credential
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"koding/kites/config"
+	"koding/kites/kloud/stack"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ImportOptions are used to import credential from
+// configuration of local cloud tools.
+type ImportOptions struct {
+	Provider string // provider name
+	Profile  string // profile, configuration or context name; default one if empty
+	File     string // source file; read from default location if empty
+	Home     string // home directory; current user's one if empty
+}
+
+// Valid implements the stack.Validator interface.
+func (opts *ImportOptions) Valid() error {
+	if opts == nil {
+		return errors.New("credential: arguments are missing")
+	}
+
+	if _, ok := importers[opts.Provider]; !ok {
+		return fmt.Errorf("credential: importing %q credentials is not supported - supported providers are: %s",
+			opts.Provider, strings.Join(ImportProviders(), ", "))
+	}
+
+	return nil
+}
+
+// Imported represents a credential read from local configuration.
+type Imported struct {
+	Provider string                 // provider name
+	Source   string                 // file the credential was read from
+	Data     map[string]interface{} // credential fields, keyed by their names
+}
+
+type importer func(opts *ImportOptions) (*Imported, error)
+
+var importers = map[string]importer{
+	"aws":          importAWS,
+	"azure":        importAzure,
+	"digitalocean": importDigitalOcean,
+	"google":       importGoogle,
+}
+
+// ImportProviders gives names of providers, which support
+// importing credentials.
+func ImportProviders() []string {
+	providers := make([]string, 0, len(importers))
+
+	for provider := range importers {
+		providers = append(providers, provider)
+	}
+
+	sort.Strings(providers)
+
+	return providers
+}
+
+// Import reads a credential from local configuration of the provider's
+// command line tool. It reads the following sources:
+//
+//   - aws: shared credentials (~/.aws/credentials) and config (~/.aws/config)
+//   - azure: Azure CLI profile (~/.azure/azureProfile.json), publish settings
+//     file needs to be passed with opts.File
+//   - digitalocean: doctl config (~/.config/doctl/config.yaml)
+//   - google: service account JSON key, given by opts.File or
+//     GOOGLE_APPLICATION_CREDENTIALS, and gcloud configuration
+//
+// Credential fields are validated against the given provider description.
+func Import(desc *stack.Description, opts *ImportOptions) (*Imported, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	imp, err := importers[opts.Provider](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.Provider = opts.Provider
+
+	if desc != nil {
+		if err := Fields(desc, imp.Data); err != nil {
+			return nil, fmt.Errorf("credential: invalid %s: %s", imp.Source, err)
+		}
+	}
+
+	return imp, nil
+}
+
+// Fields checks whether data conforms to the credential
+// schema of the given provider description.
+func Fields(desc *stack.Description, data map[string]interface{}) error {
+	fields := make(map[string]stack.Value, len(desc.Credential))
+
+	for _, field := range desc.Credential {
+		fields[field.Name] = field
+	}
+
+	for name, value := range data {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown %q field", name)
+		}
+
+		if field.Type == "enum" && !field.Values.Contains(value) {
+			return fmt.Errorf("invalid %v value for %q field - valid values are: %v", value, field.Label, field.Values.Values())
+		}
+	}
+
+	return nil
+}
+
+func importAWS(opts *ImportOptions) (*Imported, error) {
+	profile := nonempty(opts.Profile, os.Getenv("AWS_PROFILE"), "default")
+	file := nonempty(opts.File, os.Getenv("AWS_SHARED_CREDENTIALS_FILE"), filepath.Join(home(opts), ".aws", "credentials"))
+
+	creds, err := readINI(file)
+	if err != nil {
+		return nil, err
+	}
+
+	section, ok := creds[profile]
+	if !ok {
+		return nil, fmt.Errorf("credential: profile %q not found in %s", profile, file)
+	}
+
+	imp := &Imported{
+		Source: file,
+		Data: map[string]interface{}{
+			"access_key": section["aws_access_key_id"],
+			"secret_key": section["aws_secret_access_key"],
+		},
+	}
+
+	if section["aws_access_key_id"] == "" || section["aws_secret_access_key"] == "" {
+		return nil, fmt.Errorf("credential: profile %q in %s has no access keys", profile, file)
+	}
+
+	region := nonempty(section["region"], os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+
+	if region == "" {
+		cfgFile := nonempty(os.Getenv("AWS_CONFIG_FILE"), filepath.Join(home(opts), ".aws", "config"))
+
+		// Config file is optional.
+		if cfg, err := readINI(cfgFile); err == nil {
+			name := "profile " + profile
+			if profile == "default" {
+				name = profile
+			}
+
+			region = cfg[name]["region"]
+		}
+	}
+
+	if region != "" {
+		imp.Data["region"] = region
+	}
+
+	return imp, nil
+}
+
+func importGoogle(opts *ImportOptions) (*Imported, error) {
+	gcloud := filepath.Join(home(opts), ".config", "gcloud")
+	file := nonempty(opts.File, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"), filepath.Join(gcloud, "application_default_credentials.json"))
+
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var key struct {
+		Type      string `json:"type"`
+		ProjectID string `json:"project_id"`
+	}
+
+	if err := json.Unmarshal(p, &key); err != nil {
+		return nil, fmt.Errorf("credential: unable to read %s: %s", file, err)
+	}
+
+	if key.Type != "service_account" {
+		return nil, fmt.Errorf("credential: %s is not a service account key", file)
+	}
+
+	imp := &Imported{
+		Source: file,
+		Data: map[string]interface{}{
+			"credentials": string(p),
+			"project":     key.ProjectID,
+		},
+	}
+
+	// Read project and region from active gcloud configuration, if any.
+	active := opts.Profile
+
+	if active == "" {
+		p, err := ioutil.ReadFile(filepath.Join(gcloud, "active_config"))
+		if err != nil {
+			return imp, nil
+		}
+
+		active = string(bytes.TrimSpace(p))
+	}
+
+	cfg, err := readINI(filepath.Join(gcloud, "configurations", "config_"+active))
+	if err != nil {
+		if opts.Profile != "" {
+			return nil, fmt.Errorf("credential: unable to read gcloud configuration %q: %s", opts.Profile, err)
+		}
+
+		return imp, nil
+	}
+
+	if project := cfg["core"]["project"]; project != "" {
+		imp.Data["project"] = project
+	}
+
+	if region := cfg["compute"]["region"]; region != "" {
+		imp.Data["region"] = region
+	}
+
+	return imp, nil
+}
+
+func importAzure(opts *ImportOptions) (*Imported, error) {
+	if opts.File == "" {
+		return nil, errors.New("credential: publish settings file is required for azure credential")
+	}
+
+	settings, err := ioutil.ReadFile(opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &Imported{
+		Source: opts.File,
+		Data: map[string]interface{}{
+			"publish_settings": string(settings),
+		},
+	}
+
+	file := filepath.Join(home(opts), ".azure", "azureProfile.json")
+
+	p, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) && opts.Profile == "" {
+		// Subscription is required only if publish settings
+		// contains multiple subscriptions.
+		return imp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profile struct {
+		Subscriptions []struct {
+			ID        string `json:"id"`
+			Name      string `json:"name"`
+			IsDefault bool   `json:"isDefault"`
+		} `json:"subscriptions"`
+	}
+
+	// Azure CLI writes the profile with a byte order mark.
+	p = bytes.TrimPrefix(p, []byte("\xef\xbb\xbf"))
+
+	if err := json.Unmarshal(p, &profile); err != nil {
+		return nil, fmt.Errorf("credential: unable to read %s: %s", file, err)
+	}
+
+	for _, sub := range profile.Subscriptions {
+		if (opts.Profile == "" && sub.IsDefault) || (opts.Profile != "" && (opts.Profile == sub.ID || opts.Profile == sub.Name)) {
+			imp.Data["subscription_id"] = sub.ID
+			return imp, nil
+		}
+	}
+
+	if opts.Profile != "" {
+		return nil, fmt.Errorf("credential: subscription %q not found in %s", opts.Profile, file)
+	}
+
+	return imp, nil
+}
+
+func importDigitalOcean(opts *ImportOptions) (*Imported, error) {
+	file := opts.File
+
+	if file == "" {
+		file = filepath.Join(home(opts), ".config", "doctl", "config.yaml")
+
+		// doctl on macOS keeps its config in application support directory.
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			if f := filepath.Join(home(opts), "Library", "Application Support", "doctl", "config.yaml"); exists(f) {
+				file = f
+			}
+		}
+	}
+
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		AccessToken  string            `yaml:"access-token"`
+		Context      string            `yaml:"context"`
+		AuthContexts map[string]string `yaml:"auth-contexts"`
+	}
+
+	if err := yaml.Unmarshal(p, &cfg); err != nil {
+		return nil, fmt.Errorf("credential: unable to read %s: %s", file, err)
+	}
+
+	token := cfg.AccessToken
+
+	if context := nonempty(opts.Profile, cfg.Context, "default"); context != "default" {
+		var ok bool
+		if token, ok = cfg.AuthContexts[context]; !ok {
+			return nil, fmt.Errorf("credential: context %q not found in %s", context, file)
+		}
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("credential: no access token found in %s", file)
+	}
+
+	return &Imported{
+		Source: file,
+		Data: map[string]interface{}{
+			"access_token": token,
+		},
+	}, nil
+}
+
+// readINI reads sections of the given INI file.
+//
+// Keys outside of any section are stored under empty name.
+func readINI(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		sections = map[string]map[string]string{"": {}}
+		section  = sections[""]
+		scanner  = bufio.NewScanner(f)
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "", line[0] == '#', line[0] == ';':
+		case line[0] == '[' && line[len(line)-1] == ']':
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			if section = sections[name]; section == nil {
+				section = make(map[string]string)
+				sections[name] = section
+			}
+		default:
+			i := strings.IndexAny(line, "=:")
+			if i == -1 {
+				continue
+			}
+
+			section[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("credential: unable to read %s: %s", file, err)
+	}
+
+	return sections, nil
+}
+
+func home(opts *ImportOptions) string {
+	if opts.Home != "" {
+		return opts.Home
+	}
+	return config.CurrentUser.HomeDir
+}
+
+func nonempty(s ...string) string {
+	for _, s := range s {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
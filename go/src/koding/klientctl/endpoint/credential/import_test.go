@@ -0,0 +1,211 @@
+package credential_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"koding/kites/kloud/stack"
+	"koding/klientctl/endpoint/credential"
+)
+
+var files = map[string]string{
+	".aws/credentials": `[default]
+aws_access_key_id = AKIDEFAULT
+aws_secret_access_key = secretdefault
+
+[dev]
+aws_access_key_id=AKIDEV
+aws_secret_access_key=secretdev
+`,
+	".aws/config": `[default]
+region = us-east-1
+
+[profile dev]
+region = eu-west-1
+`,
+	".config/gcloud/active_config":              "work\n",
+	".config/gcloud/configurations/config_work": "[core]\nproject = work-project\n\n[compute]\nregion = europe-west1\n",
+	"key.json":  `{"type": "service_account", "project_id": "key-project"}`,
+	"user.json": `{"type": "authorized_user"}`,
+	".azure/azureProfile.json": "\xef\xbb\xbf" + `{"subscriptions": [
+		{"id": "sub-1", "name": "Free Trial", "isDefault": false},
+		{"id": "sub-2", "name": "Production", "isDefault": true}
+	]}`,
+	"azure.publishsettings": "<PublishData></PublishData>",
+	".config/doctl/config.yaml": `access-token: default-token
+context: default
+auth-contexts:
+  work: work-token
+`,
+}
+
+func TestImport(t *testing.T) {
+	home, err := ioutil.TempDir("", "credential")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+	defer os.RemoveAll(home)
+
+	for file, content := range files {
+		file = filepath.Join(home, filepath.FromSlash(file))
+
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("MkdirAll()=%s", err)
+		}
+
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile()=%s", err)
+		}
+	}
+
+	for _, env := range []string{
+		"AWS_PROFILE", "AWS_SHARED_CREDENTIALS_FILE", "AWS_CONFIG_FILE",
+		"AWS_REGION", "AWS_DEFAULT_REGION", "GOOGLE_APPLICATION_CREDENTIALS",
+	} {
+		defer os.Setenv(env, os.Getenv(env))
+		os.Unsetenv(env)
+	}
+
+	cases := map[string]struct {
+		opts *credential.ImportOptions
+		want map[string]interface{}
+	}{
+		"aws default profile": {
+			&credential.ImportOptions{Provider: "aws"},
+			map[string]interface{}{
+				"access_key": "AKIDEFAULT",
+				"secret_key": "secretdefault",
+				"region":     "us-east-1",
+			},
+		},
+		"aws named profile": {
+			&credential.ImportOptions{Provider: "aws", Profile: "dev"},
+			map[string]interface{}{
+				"access_key": "AKIDEV",
+				"secret_key": "secretdev",
+				"region":     "eu-west-1",
+			},
+		},
+		"google service account": {
+			&credential.ImportOptions{Provider: "google", File: filepath.Join(home, "key.json")},
+			map[string]interface{}{
+				"credentials": files["key.json"],
+				"project":     "work-project",
+				"region":      "europe-west1",
+			},
+		},
+		"azure default subscription": {
+			&credential.ImportOptions{Provider: "azure", File: filepath.Join(home, "azure.publishsettings")},
+			map[string]interface{}{
+				"publish_settings": files["azure.publishsettings"],
+				"subscription_id":  "sub-2",
+			},
+		},
+		"azure named subscription": {
+			&credential.ImportOptions{Provider: "azure", Profile: "Free Trial", File: filepath.Join(home, "azure.publishsettings")},
+			map[string]interface{}{
+				"publish_settings": files["azure.publishsettings"],
+				"subscription_id":  "sub-1",
+			},
+		},
+		"digitalocean default context": {
+			&credential.ImportOptions{Provider: "digitalocean"},
+			map[string]interface{}{
+				"access_token": "default-token",
+			},
+		},
+		"digitalocean named context": {
+			&credential.ImportOptions{Provider: "digitalocean", Profile: "work"},
+			map[string]interface{}{
+				"access_token": "work-token",
+			},
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			cas.opts.Home = home
+
+			imp, err := credential.Import(nil, cas.opts)
+			if err != nil {
+				t.Fatalf("Import()=%s", err)
+			}
+
+			if imp.Provider != cas.opts.Provider {
+				t.Fatalf("got %q, want %q", imp.Provider, cas.opts.Provider)
+			}
+
+			if !reflect.DeepEqual(imp.Data, cas.want) {
+				t.Fatalf("got %#v, want %#v", imp.Data, cas.want)
+			}
+		})
+	}
+
+	failures := map[string]*credential.ImportOptions{
+		"unsupported provider":     {Provider: "softlayer"},
+		"missing aws profile":      {Provider: "aws", Profile: "prod"},
+		"non service account key":  {Provider: "google", File: filepath.Join(home, "user.json")},
+		"missing publish settings": {Provider: "azure"},
+		"missing doctl context":    {Provider: "digitalocean", Profile: "prod"},
+	}
+
+	for name, opts := range failures {
+		// capture range variable here
+		opts := opts
+		t.Run(name, func(t *testing.T) {
+			opts.Home = home
+
+			if _, err := credential.Import(nil, opts); err == nil {
+				t.Fatal("want err != nil")
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	desc := &stack.Description{
+		Provider: "aws",
+		Credential: []stack.Value{
+			{Name: "access_key", Type: "string", Label: "Access Key ID"},
+			{Name: "secret_key", Type: "string", Label: "Secret Access Key"},
+			{Name: "region", Type: "enum", Label: "Region", Values: stack.Enums{
+				{Title: "US East (N. Virginia) (us-east-1)", Value: "us-east-1"},
+			}},
+		},
+	}
+
+	cases := map[string]struct {
+		data map[string]interface{}
+		ok   bool
+	}{
+		"valid": {
+			map[string]interface{}{"access_key": "AKID", "secret_key": "secret", "region": "us-east-1"},
+			true,
+		},
+		"unknown field": {
+			map[string]interface{}{"access_key": "AKID", "token": "token"},
+			false,
+		},
+		"invalid enum value": {
+			map[string]interface{}{"region": "mars-north-1"},
+			false,
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			err := credential.Fields(desc, cas.data)
+
+			if ok := err == nil; ok != cas.ok {
+				t.Fatalf("got %t, want %t (err=%v)", ok, cas.ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package machine
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultParallel is a default number of machines
+// on which ExecAll runs a command concurrently.
+const DefaultParallel = 8
+
+// ExecAllOptions represents available parameters for the ExecAll method.
+type ExecAllOptions struct {
+	MachineIDs []string // machines to run the command on
+	Cmd        string   // binary to execute
+	Args       []string // command line flags for the binary
+	Parallel   int      // max number of concurrent commands; DefaultParallel if 0
+	FailFast   bool     // stops after first failure, killing running commands
+
+	// Stdout and Stderr callbacks are called in-order for each
+	// machine if not nil, but may be called concurrently for
+	// different machines.
+	Stdout func(machineID, line string)
+	Stderr func(machineID, line string)
+}
+
+// Valid checks if provided options are correct.
+func (opts *ExecAllOptions) Valid() error {
+	if opts == nil {
+		return errors.New("invalid nil options")
+	}
+	if len(opts.MachineIDs) == 0 {
+		return errors.New("no machines to run the command on")
+	}
+	if opts.Cmd == "" {
+		return errors.New("command is empty")
+	}
+	if opts.Parallel < 0 {
+		return errors.New("invalid negative parallel limit")
+	}
+	return nil
+}
+
+// ExecResult describes a result of a command executed
+// on a single machine.
+type ExecResult struct {
+	MachineID string `json:"machineID"`
+	Exit      int    `json:"exit"`              // exit code of the command
+	Err       error  `json:"-"`                 // non-nil if the command failed to run
+	Skipped   bool   `json:"skipped,omitempty"` // true if not run due to a fail-fast
+	Killed    bool   `json:"killed,omitempty"`  // true if killed due to a fail-fast
+}
+
+// Failed tells whether the command did not succeed.
+func (r *ExecResult) Failed() bool {
+	return r.Err != nil || r.Exit != 0 || r.Skipped || r.Killed
+}
+
+// ExecAll runs the given command on multiple machines concurrently.
+//
+// The returned results are in the same order as opts.MachineIDs.
+func (c *Client) ExecAll(opts *ExecAllOptions) ([]*ExecResult, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	n := opts.Parallel
+	if n == 0 {
+		n = DefaultParallel
+	}
+
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		failed  = make(chan struct{})
+		sem     = make(chan struct{}, n)
+		results = make([]*ExecResult, len(opts.MachineIDs))
+	)
+
+	fail := func() {
+		if opts.FailFast {
+			once.Do(func() { close(failed) })
+		}
+	}
+
+	for i, id := range opts.MachineIDs {
+		res := &ExecResult{
+			MachineID: id,
+		}
+
+		results[i] = res
+
+		select {
+		case sem <- struct{}{}:
+			select {
+			case <-failed:
+				<-sem
+				res.Skipped = true
+				continue
+			default:
+			}
+		case <-failed:
+			res.Skipped = true
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			done := make(chan int, 1)
+
+			execOpts := &ExecOptions{
+				MachineID: id,
+				Cmd:       opts.Cmd,
+				Args:      opts.Args,
+				Exit: func(exit int) {
+					done <- exit
+				},
+			}
+
+			if opts.Stdout != nil {
+				execOpts.Stdout = func(line string) { opts.Stdout(id, line) }
+			}
+
+			if opts.Stderr != nil {
+				execOpts.Stderr = func(line string) { opts.Stderr(id, line) }
+			}
+
+			pid, err := c.Exec(execOpts)
+			if err != nil {
+				res.Err = err
+				fail()
+				return
+			}
+
+			select {
+			case res.Exit = <-done:
+			case <-failed:
+				select {
+				case res.Exit = <-done:
+				default:
+					// Command was killed, its output may be incomplete.
+					res.Killed = true
+					c.Kill(&KillOptions{MachineID: id, PID: pid})
+				}
+			}
+
+			if res.Failed() {
+				fail()
+			}
+		}(id)
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// ExecAll runs the given command on multiple machines using DefaultClient.
+func ExecAll(opts *ExecAllOptions) ([]*ExecResult, error) { return DefaultClient.ExecAll(opts) }
@@ -0,0 +1,154 @@
+package machine
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"koding/klient/machine/machinegroup"
+	"koding/klientctl/endpoint/kloud"
+
+	"github.com/koding/kite/dnode"
+)
+
+// execTransport fakes "machine.exec" and "machine.kill" calls. Each
+// command exits with a code given by its machine ID after a delay;
+// a machine ID of "hang" never exits unless killed.
+type execTransport struct {
+	mu     sync.Mutex
+	killed []string
+	kill   map[string]chan struct{}
+}
+
+var _ kloud.Transport = (*execTransport)(nil)
+
+func (et *execTransport) Connect(string) (kloud.Transport, error) { return et, nil }
+
+func (et *execTransport) Call(method string, arg, reply interface{}) error {
+	switch method {
+	case "machine.exec":
+		req := arg.(*machinegroup.ExecRequest)
+		id := string(req.MachineID)
+
+		et.mu.Lock()
+		if et.kill == nil {
+			et.kill = make(map[string]chan struct{})
+		}
+		kill := make(chan struct{})
+		et.kill[id] = kill
+		et.mu.Unlock()
+
+		go func() {
+			call(req.Stdout, strconv.Quote("output of "+id))
+
+			exit := -1
+			if id != "hang" {
+				time.Sleep(10 * time.Millisecond)
+				exit, _ = strconv.Atoi(id)
+			} else {
+				<-kill
+			}
+
+			call(req.Exit, strconv.Itoa(exit))
+		}()
+
+		reply.(*machinegroup.ExecResponse).PID = 1
+		return nil
+	case "machine.kill":
+		req := arg.(*machinegroup.KillRequest)
+		id := string(req.MachineID)
+
+		et.mu.Lock()
+		et.killed = append(et.killed, id)
+		close(et.kill[id])
+		et.mu.Unlock()
+
+		return nil
+	default:
+		return fmt.Errorf("unexpected method: %s", method)
+	}
+}
+
+func call(fn dnode.Function, arg string) {
+	if fn.IsValid() {
+		reflect.ValueOf(fn.Caller).Call([]reflect.Value{
+			reflect.ValueOf(&dnode.Partial{Raw: []byte("[" + arg + "]")}),
+		})
+	}
+}
+
+func TestExecAll(t *testing.T) {
+	et := &execTransport{}
+	c := &Client{Klient: et}
+
+	var mu sync.Mutex
+	stdout := make(map[string]string)
+
+	results, err := c.ExecAll(&ExecAllOptions{
+		MachineIDs: []string{"0", "1", "2", "0"},
+		Cmd:        "true",
+		Parallel:   2,
+		Stdout: func(id, line string) {
+			mu.Lock()
+			stdout[id] = line
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExecAll()=%s", err)
+	}
+
+	want := []int{0, 1, 2, 0}
+
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+
+	for i, res := range results {
+		if res.Exit != want[i] {
+			t.Errorf("%d: got exit %d, want %d", i, res.Exit, want[i])
+		}
+
+		if res.Failed() != (want[i] != 0) {
+			t.Errorf("%d: got failed %t", i, res.Failed())
+		}
+
+		if got, want := stdout[res.MachineID], "output of "+res.MachineID; got != want {
+			t.Errorf("%d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestExecAllFailFast(t *testing.T) {
+	et := &execTransport{}
+	c := &Client{Klient: et}
+
+	results, err := c.ExecAll(&ExecAllOptions{
+		MachineIDs: []string{"hang", "1", "0"},
+		Cmd:        "false",
+		Parallel:   2,
+		FailFast:   true,
+	})
+	if err != nil {
+		t.Fatalf("ExecAll()=%s", err)
+	}
+
+	if !results[0].Killed {
+		t.Errorf("want %q to be killed", results[0].MachineID)
+	}
+
+	if results[1].Exit != 1 {
+		t.Errorf("got exit %d, want 1", results[1].Exit)
+	}
+
+	if !results[2].Skipped {
+		t.Errorf("want %q to be skipped", results[2].MachineID)
+	}
+
+	if want := []string{"hang"}; !reflect.DeepEqual(et.killed, want) {
+		t.Errorf("got %v killed, want %v", et.killed, want)
+	}
+}
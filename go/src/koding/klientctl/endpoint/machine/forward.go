@@ -0,0 +1,129 @@
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"koding/kites/config"
+	"koding/klient/machine"
+)
+
+// ForwardOptions stores options for port forwarding calls.
+type ForwardOptions struct {
+	Identifier string // Machine identifier.
+	LocalPort  int    // Local port to listen on.
+	RemotePort int    // Remote machine port to forward connections to.
+
+	AskList func(is, ds []string) (string, error) // Ask for multiple choices.
+}
+
+// Valid checks if provided options are correct.
+func (opts *ForwardOptions) Valid() error {
+	if opts == nil {
+		return errors.New("invalid nil options")
+	}
+	if opts.LocalPort <= 0 || opts.LocalPort > 65535 {
+		return fmt.Errorf("invalid local port: %d", opts.LocalPort)
+	}
+	if opts.RemotePort <= 0 || opts.RemotePort > 65535 {
+		return fmt.Errorf("invalid remote port: %d", opts.RemotePort)
+	}
+
+	return nil
+}
+
+// Forward forwards connections from local port to a port on remote machine.
+//
+// Forwarding is done by a background ssh process, which is controlled
+// through a control socket. If the forwarding already exists, the method
+// is a nop.
+func (c *Client) Forward(options *ForwardOptions) error {
+	if err := options.Valid(); err != nil {
+		return err
+	}
+
+	// Translate identifier to machine ID.
+	id, err := c.getMachineID(options.Identifier, options.AskList)
+	if err != nil {
+		return err
+	}
+
+	sock := forwardSocket(id, options.LocalPort)
+
+	if isForwarded(sock) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(sock), 0700); err != nil {
+		return err
+	}
+
+	args, err := c.sshArgs(id, "")
+	if err != nil {
+		return err
+	}
+
+	args = append([]string{
+		"-M", "-S", sock, // control socket used to check and stop forwarding
+		"-f", "-N", "-T", // go to background without executing remote command
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", fmt.Sprintf("%d:127.0.0.1:%d", options.LocalPort, options.RemotePort),
+	}, args...)
+
+	c.stream().Log().Info("Executing command: ssh %s", strings.Join(args, " "))
+
+	if p, err := exec.Command("ssh", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to forward port %d: %s: %s", options.LocalPort, err, strings.TrimSpace(string(p)))
+	}
+
+	return nil
+}
+
+// Unforward stops forwarding created by Forward method. If the
+// forwarding does not exist, the method is a nop.
+func (c *Client) Unforward(options *ForwardOptions) error {
+	if err := options.Valid(); err != nil {
+		return err
+	}
+
+	// Translate identifier to machine ID.
+	id, err := c.getMachineID(options.Identifier, options.AskList)
+	if err != nil {
+		return err
+	}
+
+	sock := forwardSocket(id, options.LocalPort)
+
+	if !isForwarded(sock) {
+		return nil
+	}
+
+	if p, err := exec.Command("ssh", "-S", sock, "-O", "exit", "kd").CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to stop forwarding port %d: %s: %s", options.LocalPort, err, strings.TrimSpace(string(p)))
+	}
+
+	return nil
+}
+
+func forwardSocket(id machine.ID, port int) string {
+	return filepath.Join(config.KodingHome(), "forward", string(id)+"-"+strconv.Itoa(port)+".sock")
+}
+
+func isForwarded(sock string) bool {
+	if _, err := os.Stat(sock); err != nil {
+		return false
+	}
+
+	return exec.Command("ssh", "-S", sock, "-O", "check", "kd").Run() == nil
+}
+
+// Forward forwards local port to remote machine using DefaultClient.
+func Forward(opts *ForwardOptions) error { return DefaultClient.Forward(opts) }
+
+// Unforward stops port forwarding using DefaultClient.
+func Unforward(opts *ForwardOptions) error { return DefaultClient.Unforward(opts) }
@@ -119,6 +119,11 @@ func (c *Client) List(options *ListOptions) ([]*Info, error) {
 	// Sort items before we return.
 	sort.Sort(InfoSlice(infos))
 
+	// Keep ssh config entries in sync with user's machines.
+	if err := c.updateSSHConfig(infos); err != nil {
+		c.stream().Log().Warning("unable to update ssh config: %s", err)
+	}
+
 	return infos, nil
 }
 
@@ -23,9 +23,10 @@ import (
 
 // MountOptions stores options for `machine mount` call.
 type MountOptions struct {
-	Identifier string // Machine identifier.
-	Path       string // Machine local path - absolute and cleaned.
-	RemotePath string // Remote machine path - raw format.
+	Identifier string   // Machine identifier.
+	Path       string   // Machine local path - absolute and cleaned.
+	RemotePath string   // Remote machine path - raw format.
+	Ignore     []string // Shell patterns of paths that are not synced - optional.
 
 	AskList func(is, ds []string) (string, error) // Ask for multiple choices.
 }
@@ -75,6 +76,7 @@ func (c *Client) Mount(options *MountOptions) (err error) {
 	m := mount.Mount{
 		Path:       options.Path,
 		RemotePath: options.RemotePath,
+		Ignore:     options.Ignore,
 	}
 
 	// First head the remote machine directory in order to get basic mount info.
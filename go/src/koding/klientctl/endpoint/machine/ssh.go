@@ -2,19 +2,28 @@ package machine
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
+	"koding/klient/machine"
 	"koding/klient/machine/machinegroup"
 	"koding/klientctl/ssh"
+
+	"github.com/kardianos/osext"
 )
 
 // SSHOptions stores options for `machine ssh` call.
 type SSHOptions struct {
 	Identifier string // Machine identifier.
 	Username   string // Remote machine user to log as.
+	Proxy      bool   // Connect stdin and stdout to remote SSH server, for use as ProxyCommand.
 
 	AskList func(is, ds []string) (string, error) // Ask for multiple choices.
 }
@@ -31,21 +40,86 @@ func (c *Client) SSH(options *SSHOptions) error {
 		return err
 	}
 
-	pubKey, _, privPath, err := sshGetKeyPath()
+	if options.Proxy {
+		return c.sshProxy(id, options.Username)
+	}
+
+	args, err := c.sshArgs(id, options.Username)
 	if err != nil {
 		return err
 	}
 
+	c.stream().Log().Info("Executing command: ssh %s", strings.Join(args, " "))
+	cmd := exec.Command("ssh", args...)
+	cmd.Stdin = c.stream().In()
+	cmd.Stdout = c.stream().Out()
+	cmd.Stderr = c.stream().Err()
+	return cmd.Run()
+}
+
+// sshProxy connects stream's input and output to the SSH server
+// of the remote machine.
+func (c *Client) sshProxy(id machine.ID, username string) error {
+	res, _, err := c.sshInfo(id, username)
+	if err != nil {
+		return err
+	}
+
+	port := res.Port
+	if port <= 0 {
+		port = 22
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(res.Host, strconv.Itoa(port)), 7*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	done := make(chan error, 2)
+
+	go func() {
+		_, err := io.Copy(conn, c.stream().In())
+		done <- err
+	}()
+
+	go func() {
+		_, err := io.Copy(c.stream().Out(), conn)
+		done <- err
+	}()
+
+	return <-done
+}
+
+// sshInfo authorizes local SSH key on the remote machine and gives
+// its SSH address together with a path to the local private key.
+func (c *Client) sshInfo(id machine.ID, username string) (*machinegroup.SSHResponse, string, error) {
+	pubKey, _, privPath, err := sshGetKeyPath()
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Add created key to authorized hosts on remote machine.
 	sshReq := &machinegroup.SSHRequest{
 		ID:        id,
-		Username:  options.Username,
+		Username:  username,
 		PublicKey: pubKey,
 	}
 	var sshRes machinegroup.SSHResponse
 
 	if err := c.klient().Call("machine.ssh", sshReq, &sshRes); err != nil {
-		return err
+		return nil, "", err
+	}
+
+	return &sshRes, privPath, nil
+}
+
+// sshArgs authorizes local SSH key on the remote machine and
+// gives ssh command line arguments needed to connect to it.
+func (c *Client) sshArgs(id machine.ID, username string) ([]string, error) {
+	sshRes, privPath, err := c.sshInfo(id, username)
+	if err != nil {
+		return nil, err
 	}
 
 	// TODO(ppknap): move this to ssh package.
@@ -64,12 +138,7 @@ func (c *Client) SSH(options *SSHOptions) error {
 		args = append(args, "-p", strconv.Itoa(sshRes.Port))
 	}
 
-	c.stream().Log().Info("Executing command: ssh %s", strings.Join(args, " "))
-	cmd := exec.Command("ssh", args...)
-	cmd.Stdin = c.stream().In()
-	cmd.Stdout = c.stream().Out()
-	cmd.Stderr = c.stream().Err()
-	return cmd.Run()
+	return args, nil
 }
 
 // sshGetKeyPath gets local public key in case we need to copy it to remote
@@ -112,5 +181,149 @@ func sshGetKeyPath() (pubKey, pubPath, privPath string, err error) {
 	return pubKey, pubPath, privPath, nil
 }
 
+// SSHConfigOptions stores options for `machine ssh-config` call.
+type SSHConfigOptions struct {
+	File   string // ssh config file; ~/.ssh/config if empty
+	Remove bool   // removes managed block from the config file
+}
+
+// SSHConfig writes a managed block to the ssh config file, with
+// a Host entry for each machine alias. The entries use
+// "kd machine ssh --proxy" as a ProxyCommand, so stock ssh tools
+// can connect to the machines.
+//
+// Once the block is written, it is kept up to date each time
+// machines are listed.
+func (c *Client) SSHConfig(options *SSHConfigOptions) ([]*ssh.Host, error) {
+	if options == nil {
+		return nil, errors.New("invalid nil options")
+	}
+
+	file := options.File
+	if file == "" {
+		var err error
+		if file, err = ssh.GetConfigPath(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Remove {
+		return nil, writeSSHConfig(file, nil)
+	}
+
+	infos, err := c.List(&ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := sshHosts(infos)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeSSHConfig(file, hosts); err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
+
+// updateSSHConfig updates the managed block of ssh config file,
+// if it was already written by SSHConfig.
+func (c *Client) updateSSHConfig(infos []*Info) error {
+	file, err := ssh.GetConfigPath(nil)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ssh.HasConfigBlock(content) {
+		return nil
+	}
+
+	hosts, err := sshHosts(infos)
+	if err != nil {
+		return err
+	}
+
+	return writeSSHConfig(file, hosts)
+}
+
+// writeSSHConfig writes the managed block of ssh config file and removes
+// host keys of machines, which are no longer in the block, from known_hosts
+// file managed by kd.
+func writeSSHConfig(file string, hosts []*ssh.Host) error {
+	if err := ssh.WriteConfig(file, hosts); err != nil {
+		return err
+	}
+
+	knownHosts, err := ssh.GetKnownHostsPath(nil)
+	if err != nil {
+		return err
+	}
+
+	aliases := make([]string, len(hosts))
+	for i, h := range hosts {
+		aliases[i] = h.Alias
+	}
+
+	return ssh.PruneKnownHosts(knownHosts, aliases)
+}
+
+// sshHosts gives ssh config entries for the given machines.
+func sshHosts(infos []*Info) ([]*ssh.Host, error) {
+	_, _, privPath, err := sshGetKeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	knownHosts, err := ssh.GetKnownHostsPath(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	kd, err := osext.Executable()
+	if err != nil {
+		kd = "kd"
+	}
+
+	if strings.ContainsAny(kd, " \t") {
+		kd = strconv.Quote(kd)
+	}
+
+	hosts := make([]*ssh.Host, 0, len(infos))
+
+	for _, info := range infos {
+		if info.Alias == "" {
+			continue
+		}
+
+		h := &ssh.Host{
+			Alias:          info.Alias,
+			IdentityFile:   privPath,
+			ProxyCommand:   fmt.Sprintf("%s machine ssh --proxy --username %%r %s", kd, info.Alias),
+			KnownHostsFile: knownHosts,
+		}
+
+		if info.Username != "" && info.Username != "<unknown>" {
+			h.User = info.Username
+		}
+
+		hosts = append(hosts, h)
+	}
+
+	return hosts, nil
+}
+
 // SSH connects to remote machine using SSH protocol using DefaultClient.
 func SSH(opts *SSHOptions) error { return DefaultClient.SSH(opts) }
+
+// SSHConfig writes ssh config entries for machines using DefaultClient.
+func SSHConfig(opts *SSHConfigOptions) ([]*ssh.Host, error) { return DefaultClient.SSHConfig(opts) }
@@ -0,0 +1,18 @@
+package machine
+
+import (
+	"koding/klient/usage"
+)
+
+// Usage gives usage counters of the local klient.
+func (c *Client) Usage() (*usage.Usage, error) {
+	var u usage.Usage
+
+	if err := c.klient().Call(usage.MethodName, nil, &u); err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func Usage() (*usage.Usage, error) { return DefaultClient.Usage() }
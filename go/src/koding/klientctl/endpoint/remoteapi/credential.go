@@ -0,0 +1,72 @@
+package remoteapi
+
+import (
+	"koding/remoteapi"
+	credential "koding/remoteapi/client/j_credential"
+	"koding/remoteapi/models"
+)
+
+// ListCredentials gives credentials with the given identifier.
+func (c *Client) ListCredentials(identifier string) ([]*models.JCredential, error) {
+	c.init()
+
+	params := &credential.JCredentialSomeParams{
+		Body: map[string]string{
+			"identifier": identifier,
+		},
+	}
+
+	params.SetTimeout(c.timeout())
+
+	resp, err := c.client().JCredential.JCredentialSome(params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []*models.JCredential
+
+	if err := remoteapi.Unmarshal(resp.Payload, &creds); err != nil {
+		return nil, err
+	}
+
+	if len(creds) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return creds, nil
+}
+
+// DeleteCredential deletes a credential given by the identifier.
+func (c *Client) DeleteCredential(identifier string) error {
+	creds, err := c.ListCredentials(identifier)
+	if err != nil {
+		return err
+	}
+
+	params := &credential.JCredentialDeleteParams{
+		ID: creds[0].ID,
+	}
+
+	params.SetTimeout(c.timeout())
+
+	resp, err := c.client().JCredential.JCredentialDelete(params, nil)
+	if err != nil {
+		return err
+	}
+
+	return remoteapi.Unmarshal(&resp.Payload.DefaultResponse, nil)
+}
+
+// ListCredentials gives credentials with the given identifier.
+//
+// The function uses DefaultClient.
+func ListCredentials(identifier string) ([]*models.JCredential, error) {
+	return DefaultClient.ListCredentials(identifier)
+}
+
+// DeleteCredential deletes a credential given by the identifier.
+//
+// The function uses DefaultClient.
+func DeleteCredential(identifier string) error {
+	return DefaultClient.DeleteCredential(identifier)
+}
@@ -64,3 +64,10 @@ func (c *Client) UpdateMachineAlwaysOn(m *models.JMachine, on bool) error {
 
 	return remoteapi.Unmarshal(resp.Payload, nil)
 }
+
+// ListMachines gives all machines, filtered by the given f filter.
+//
+// The functions uses DefaultClient.
+func ListMachines(f *Filter) ([]*models.JMachine, error) {
+	return DefaultClient.ListMachines(f)
+}
@@ -47,3 +47,29 @@ func (c *Client) ListStacks(f *Filter) ([]*models.JComputeStack, error) {
 func ListStacks(f *Filter) ([]*models.JComputeStack, error) {
 	return DefaultClient.ListStacks(f)
 }
+
+// StackMachineIDs gives IDs of machines, which belong to the given stack.
+//
+// The jComputeStack.machines field holds either plain IDs
+// or machine documents, depending on whether it was populated.
+func StackMachineIDs(s *models.JComputeStack) []string {
+	machines, ok := s.Machines.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	ids := make([]string, 0, len(machines))
+
+	for _, m := range machines {
+		switch v := m.(type) {
+		case string:
+			ids = append(ids, v)
+		case map[string]interface{}:
+			if id, ok := v["_id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
@@ -82,6 +82,39 @@ func (c *Client) DeleteTemplate(id string) error {
 	return remoteapi.Unmarshal(&resp.Payload.DefaultResponse, nil)
 }
 
+// GenerateStack creates a new stack out of the template given by the id.
+//
+// The stack needs to be built afterwards.
+func (c *Client) GenerateStack(id string) (*models.JComputeStack, error) {
+	c.init()
+
+	params := &stacktemplate.JStackTemplateGenerateStackParams{
+		ID:   id,
+		Body: map[string]interface{}{},
+	}
+
+	params.SetTimeout(c.timeout())
+
+	resp, err := c.client().JStackTemplate.JStackTemplateGenerateStack(params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var v struct {
+		Stack *models.JComputeStack `json:"stack"`
+	}
+
+	if err := remoteapi.Unmarshal(&resp.Payload.DefaultResponse, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Stack == nil || v.Stack.ID == "" {
+		return nil, errors.New("invalid empty stack generated")
+	}
+
+	return v.Stack, nil
+}
+
 // SampleTemplate returns a content of a sample stack template
 // for the given provider.
 func (c *Client) SampleTemplate(provider string) (string, map[string]interface{}, error) {
@@ -167,3 +200,10 @@ func ListTemplates(f *Filter) ([]*models.JStackTemplate, error) {
 func DeleteTemplate(id string) error {
 	return DefaultClient.DeleteTemplate(id)
 }
+
+// GenerateStack creates a new stack out of the template given by the id.
+//
+// The functions uses DefaultClient.
+func GenerateStack(id string) (*models.JComputeStack, error) {
+	return DefaultClient.GenerateStack(id)
+}
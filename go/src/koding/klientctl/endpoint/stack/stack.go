@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 
+	"koding/db/models"
 	"koding/kites/kloud/stack"
 	kloudstack "koding/kites/kloud/stack"
+	"koding/kites/kloud/terraformer"
 	"koding/kites/kloud/utils/object"
 	"koding/klientctl/endpoint/credential"
 	"koding/klientctl/endpoint/kloud"
@@ -36,6 +38,47 @@ func (opts *CreateOptions) Valid() error {
 	return nil
 }
 
+// ValidateOptions are used to validate a stack template.
+type ValidateOptions struct {
+	Team        string
+	Credentials []string
+	Variables   map[string]string
+	Template    []byte
+}
+
+// Valid implements the stack.Validator interface.
+func (opts *ValidateOptions) Valid() error {
+	if opts == nil {
+		return errors.New("stack: arguments are missing")
+	}
+
+	if len(opts.Template) == 0 {
+		return errors.New("stack: template data is missing")
+	}
+
+	return nil
+}
+
+// ApplyOptions are used to build or destroy an existing stack.
+type ApplyOptions struct {
+	StackID string
+	Team    string
+	Destroy bool
+}
+
+// Valid implements the stack.Validator interface.
+func (opts *ApplyOptions) Valid() error {
+	if opts == nil {
+		return errors.New("stack: arguments are missing")
+	}
+
+	if opts.StackID == "" {
+		return errors.New("stack: stack ID is missing")
+	}
+
+	return nil
+}
+
 var DefaultClient = &Client{}
 
 type Client struct {
@@ -106,6 +149,178 @@ func (c *Client) Create(opts *CreateOptions) (*stack.ImportResponse, error) {
 	return &resp, nil
 }
 
+// Validate checks the given template for errors, without building it.
+//
+// If no credential is given for a provider used by the template,
+// the default one is used, if any.
+func (c *Client) Validate(opts *ValidateOptions) (*stack.ValidateResponse, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	data, err := c.jsonReencode(opts.Template)
+	if err != nil {
+		return nil, fmt.Errorf("stack: template encoding error: %s", err)
+	}
+
+	req := &stack.ValidateRequest{
+		Template:    data,
+		Team:        opts.Team,
+		Variables:   opts.Variables,
+		Credentials: make(map[string][]string),
+	}
+
+	if req.Team == "" {
+		req.Team = team.Used().Name
+	}
+
+	for _, identifier := range opts.Credentials {
+		provider, err := c.credential().Provider(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("stack: unable to read provider of %q: %s", identifier, err)
+		}
+
+		req.Credentials[provider] = append(req.Credentials[provider], identifier)
+	}
+
+	// Unreadable providers are going to be reported by Kloud.
+	providers, _ := kloudstack.ReadProviders(data)
+	used := c.credential().Used()
+
+	for _, provider := range providers {
+		if _, ok := req.Credentials[provider]; ok {
+			continue
+		}
+
+		if identifier, ok := used[provider]; ok && identifier != "" {
+			req.Credentials[provider] = []string{identifier}
+		}
+	}
+
+	var resp stack.ValidateResponse
+
+	if err := c.kloud().Call("stack.validate", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return &resp, nil
+}
+
+// Outputs gives Terraform outputs of the given stack.
+//
+// Values of sensitive outputs are returned only when
+// sensitive is true.
+func (c *Client) Outputs(stackID string, sensitive bool) (map[string]*models.StackOutput, error) {
+	req := &stack.OutputsRequest{
+		StackID:   stackID,
+		Sensitive: sensitive,
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, err
+	}
+
+	var resp stack.OutputsResponse
+
+	if err := c.kloud().Call("stack.outputs", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp.Outputs, nil
+}
+
+// StateVersions gives previous versions of Terraform state
+// of the given stack, starting from the most recent one.
+func (c *Client) StateVersions(stackID string) ([]*terraformer.StateVersion, error) {
+	req := &stack.StateRequest{
+		StackID: stackID,
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, err
+	}
+
+	var resp stack.StateVersionsResponse
+
+	if err := c.kloud().Call("stack.state.versions", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp.Versions, nil
+}
+
+// RollbackState replaces Terraform state of the given stack
+// with the given version of it.
+func (c *Client) RollbackState(stackID, version string) error {
+	req := &stack.StateRequest{
+		StackID: stackID,
+		Version: version,
+	}
+
+	if err := req.Valid(); err != nil {
+		return err
+	}
+
+	if version == "" {
+		return errors.New("stack: version is not passed")
+	}
+
+	if err := c.kloud().Call("stack.state.rollback", req, nil); err != nil {
+		return fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return nil
+}
+
+// Apply builds or destroys the given stack.
+//
+// The returned event ID can be used with kloud.Wait to track
+// the progress of the operation.
+func (c *Client) Apply(opts *ApplyOptions) (*stack.ControlResult, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	req := &stack.ApplyRequest{
+		StackID:   opts.StackID,
+		GroupName: opts.Team,
+		Destroy:   opts.Destroy,
+	}
+
+	if req.GroupName == "" {
+		req.GroupName = team.Used().Name
+	}
+
+	var resp stack.ControlResult
+
+	if err := c.kloud().Call("apply", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return &resp, nil
+}
+
+// Events gives the most recent event of a build or destroy
+// operation of the given stack.
+func (c *Client) Events(stackID string) ([]stack.EventResponse, error) {
+	if stackID == "" {
+		return nil, errors.New("stack: stack ID is missing")
+	}
+
+	req := stack.EventArgs{{
+		Type:    "apply",
+		EventId: stackID,
+	}}
+
+	var resp []stack.EventResponse
+
+	if err := c.kloud().Call("event", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp, nil
+}
+
 func (c *Client) kloud() *kloud.Client {
 	if c.Kloud != nil {
 		return c.Kloud
@@ -150,6 +365,36 @@ func Create(opts *CreateOptions) (*stack.ImportResponse, error) {
 	return DefaultClient.Create(opts)
 }
 
+func Validate(opts *ValidateOptions) (*stack.ValidateResponse, error) {
+	return DefaultClient.Validate(opts)
+}
+
+func Outputs(stackID string, sensitive bool) (map[string]*models.StackOutput, error) {
+	return DefaultClient.Outputs(stackID, sensitive)
+}
+
+func StateVersions(stackID string) ([]*terraformer.StateVersion, error) {
+	return DefaultClient.StateVersions(stackID)
+}
+
+func RollbackState(stackID, version string) error {
+	return DefaultClient.RollbackState(stackID, version)
+}
+
+func Apply(opts *ApplyOptions) (*stack.ControlResult, error) {
+	return DefaultClient.Apply(opts)
+}
+
+func Events(stackID string) ([]stack.EventResponse, error) {
+	return DefaultClient.Events(stackID)
+}
+
+// EventID gives an ID of the event, which tracks build or destroy
+// operation of the given stack.
+func EventID(stackID string) string {
+	return "apply-" + stackID
+}
+
 func jsonMarshal(v interface{}) ([]byte, error) {
 	var buf bytes.Buffer
 
@@ -0,0 +1,141 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"koding/kites/kloud/stack"
+)
+
+// UpdateTemplateOptions are used to update content of a stack template.
+type UpdateTemplateOptions struct {
+	TemplateID string
+	Template   []byte // template in JSON, YAML or HCL format
+	Message    string
+}
+
+// Valid implements the stack.Validator interface.
+func (opts *UpdateTemplateOptions) Valid() error {
+	if opts == nil {
+		return errors.New("stack: arguments are missing")
+	}
+
+	if opts.TemplateID == "" {
+		return errors.New("stack: template ID is missing")
+	}
+
+	if len(opts.Template) == 0 {
+		return errors.New("stack: template data is missing")
+	}
+
+	return nil
+}
+
+// TemplateHistory gives revisions of the given stack template,
+// starting from the newest one.
+func (c *Client) TemplateHistory(templateID string) ([]*stack.TemplateRevision, error) {
+	req := &stack.TemplateHistoryRequest{
+		TemplateID: templateID,
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, errors.New("stack: " + err.Error())
+	}
+
+	var resp stack.TemplateHistoryResponse
+
+	if err := c.kloud().Call("template.history", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp.Revisions, nil
+}
+
+// TemplateDiff gives a structural difference between two revisions
+// of the given stack template. If to is 0, the revision is compared
+// with current content of the template.
+func (c *Client) TemplateDiff(templateID string, from, to int) (*stack.TemplateDiffResponse, error) {
+	req := &stack.TemplateDiffRequest{
+		TemplateID: templateID,
+		From:       from,
+		To:         to,
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, errors.New("stack: " + err.Error())
+	}
+
+	var resp stack.TemplateDiffResponse
+
+	if err := c.kloud().Call("template.diff", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateTemplate updates content of the stack template, recording
+// the change as a new revision.
+func (c *Client) UpdateTemplate(opts *UpdateTemplateOptions) (*stack.TemplateRevision, error) {
+	if err := opts.Valid(); err != nil {
+		return nil, err
+	}
+
+	data, err := c.jsonReencode(opts.Template)
+	if err != nil {
+		return nil, fmt.Errorf("stack: template encoding error: %s", err)
+	}
+
+	req := &stack.TemplateUpdateRequest{
+		TemplateID: opts.TemplateID,
+		Template:   data,
+		RawContent: string(opts.Template),
+		Message:    opts.Message,
+	}
+
+	var resp stack.TemplateRevisionResponse
+
+	if err := c.kloud().Call("template.update", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp.Revision, nil
+}
+
+// RollbackTemplate restores content of the stack template
+// from the given revision, recording it as a new revision.
+func (c *Client) RollbackTemplate(templateID string, revision int, message string) (*stack.TemplateRevision, error) {
+	req := &stack.TemplateRollbackRequest{
+		TemplateID: templateID,
+		Revision:   revision,
+		Message:    message,
+	}
+
+	if err := req.Valid(); err != nil {
+		return nil, errors.New("stack: " + err.Error())
+	}
+
+	var resp stack.TemplateRevisionResponse
+
+	if err := c.kloud().Call("template.rollback", req, &resp); err != nil {
+		return nil, fmt.Errorf("stack: unable to communicate with Kloud: %s", err)
+	}
+
+	return resp.Revision, nil
+}
+
+func TemplateHistory(templateID string) ([]*stack.TemplateRevision, error) {
+	return DefaultClient.TemplateHistory(templateID)
+}
+
+func TemplateDiff(templateID string, from, to int) (*stack.TemplateDiffResponse, error) {
+	return DefaultClient.TemplateDiff(templateID, from, to)
+}
+
+func UpdateTemplate(opts *UpdateTemplateOptions) (*stack.TemplateRevision, error) {
+	return DefaultClient.UpdateTemplate(opts)
+}
+
+func RollbackTemplate(templateID string, revision int, message string) (*stack.TemplateRevision, error) {
+	return DefaultClient.RollbackTemplate(templateID, revision, message)
+}
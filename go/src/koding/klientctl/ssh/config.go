@@ -0,0 +1,241 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Markers of the ssh config block managed by kd.
+const (
+	ConfigBegin = "# BEGIN kd managed block - do not edit, use \"kd machine ssh-config\""
+	ConfigEnd   = "# END kd managed block"
+)
+
+// Host describes a single Host entry of the ssh config.
+type Host struct {
+	Alias          string // Host pattern
+	User           string // remote username
+	IdentityFile   string // path to private key
+	ProxyCommand   string // command used to connect to the host
+	KnownHostsFile string // path to known_hosts file; ~/.ssh/known_hosts if empty
+}
+
+// GetConfigPath returns a path to ssh config file of a given user.
+// If user is nil, the current user will be used.
+func GetConfigPath(u *user.User) (string, error) {
+	path, err := GetKeyPath(u)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(path, "config"), nil
+}
+
+// ConfigBlock gives managed config block for the given hosts.
+//
+// Hosts are sorted by their aliases.
+func ConfigBlock(hosts []*Host) []byte {
+	hosts = append([]*Host(nil), hosts...)
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Alias < hosts[j].Alias })
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, ConfigBegin)
+
+	for _, h := range hosts {
+		fmt.Fprintf(&buf, "Host %s\n", h.Alias)
+		fmt.Fprintf(&buf, "  HostName %s\n", h.Alias)
+
+		if h.User != "" {
+			fmt.Fprintf(&buf, "  User %s\n", h.User)
+		}
+
+		if h.IdentityFile != "" {
+			fmt.Fprintf(&buf, "  IdentityFile %q\n", h.IdentityFile)
+			fmt.Fprintln(&buf, "  IdentitiesOnly yes")
+		}
+
+		fmt.Fprintf(&buf, "  ProxyCommand %s\n", h.ProxyCommand)
+
+		if h.KnownHostsFile != "" {
+			fmt.Fprintf(&buf, "  UserKnownHostsFile %q\n", h.KnownHostsFile)
+		}
+
+		// Host key of a new machine is trusted on first use,
+		// a changed key of a known machine is rejected.
+		fmt.Fprintln(&buf, "  StrictHostKeyChecking accept-new")
+		fmt.Fprintln(&buf, "  LogLevel ERROR")
+	}
+
+	fmt.Fprintln(&buf, ConfigEnd)
+
+	return buf.Bytes()
+}
+
+// HasConfigBlock tells whether content has a managed config block.
+func HasConfigBlock(content []byte) bool {
+	_, _, ok := configBlock(content)
+	return ok
+}
+
+// UpdateConfig replaces managed block in the given ssh config content
+// with a new block for the given hosts. If the content has no managed
+// block yet, the new one is appended. If hosts is empty, the managed
+// block is removed.
+//
+// Content outside of the managed block is left intact.
+func UpdateConfig(content []byte, hosts []*Host) []byte {
+	var block []byte
+
+	if len(hosts) != 0 {
+		block = ConfigBlock(hosts)
+	}
+
+	begin, end, ok := configBlock(content)
+	if !ok {
+		if len(block) == 0 {
+			return content
+		}
+
+		var buf bytes.Buffer
+
+		buf.Write(content)
+
+		if len(content) != 0 {
+			if content[len(content)-1] != '\n' {
+				buf.WriteByte('\n')
+			}
+			buf.WriteByte('\n')
+		}
+
+		buf.Write(block)
+
+		return buf.Bytes()
+	}
+
+	var buf bytes.Buffer
+
+	buf.Write(content[:begin])
+	buf.Write(block)
+	buf.Write(content[end:])
+
+	return buf.Bytes()
+}
+
+// WriteConfig updates managed block of the ssh config file
+// with the given hosts. See UpdateConfig for details.
+//
+// If the file is a symlink, the file it points to is updated
+// instead and the link is kept. The mode of the file is kept
+// as well.
+//
+// The file and its parent directory are created if they
+// do not exist.
+func WriteConfig(file string, hosts []*Host) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	updated := UpdateConfig(content, hosts)
+
+	if bytes.Equal(content, updated) {
+		return nil
+	}
+
+	target, mode, err := configTarget(file)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(target), ".config")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(updated)
+	if e := f.Close(); e != nil && err == nil {
+		err = e
+	}
+
+	if err == nil {
+		err = os.Chmod(f.Name(), mode)
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), target)
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// configTarget gives the file the ssh config should be written to,
+// following symlinks, and its mode.
+//
+// If the file does not exist, 0600 mode is used.
+func configTarget(file string) (string, os.FileMode, error) {
+	target, err := filepath.EvalSymlinks(file)
+	if os.IsNotExist(err) {
+		// The file may be a dangling symlink, which should
+		// be kept too.
+		link, e := os.Readlink(file)
+		if e != nil {
+			return file, 0600, nil
+		}
+
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(file), link)
+		}
+
+		return configTarget(link)
+	}
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	fi, err := os.Stat(target)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return target, fi.Mode().Perm(), nil
+}
+
+// configBlock gives offsets of the managed block in content, including
+// the end marker line.
+func configBlock(content []byte) (begin, end int, ok bool) {
+	s := string(content)
+
+	if begin = strings.Index(s, ConfigBegin); begin == -1 {
+		return 0, 0, false
+	}
+
+	n := strings.Index(s[begin:], ConfigEnd)
+	if n == -1 {
+		return 0, 0, false
+	}
+
+	end = begin + n + len(ConfigEnd)
+
+	if end < len(s) && s[end] == '\n' {
+		end++
+	}
+
+	return begin, end, true
+}
@@ -0,0 +1,193 @@
+package ssh
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpdateConfig(t *testing.T) {
+	hosts := []*Host{{
+		Alias:        "banana",
+		User:         "koding",
+		IdentityFile: "/home/koding/.ssh/kd-ssh-key",
+		ProxyCommand: "kd machine ssh --proxy --username %r banana",
+	}, {
+		Alias:        "apple",
+		ProxyCommand: "kd machine ssh --proxy --username %r apple",
+	}}
+
+	block := string(ConfigBlock(hosts))
+
+	if i, j := strings.Index(block, "Host apple\n"), strings.Index(block, "Host banana\n"); i == -1 || j == -1 || i > j {
+		t.Fatalf("want hosts to be sorted, got:\n%s", block)
+	}
+
+	if !strings.Contains(block, "StrictHostKeyChecking accept-new\n") || strings.Contains(block, "/dev/null") {
+		t.Fatalf("want host keys to be verified, got:\n%s", block)
+	}
+
+	known := string(ConfigBlock([]*Host{{Alias: "apple", KnownHostsFile: "/home/koding/.ssh/kd_known_hosts"}}))
+
+	if !strings.Contains(known, `UserKnownHostsFile "/home/koding/.ssh/kd_known_hosts"`) {
+		t.Fatalf("want known hosts file to be set, got:\n%s", known)
+	}
+
+	user := "Host example.com\n  User root\n"
+
+	cases := map[string]struct {
+		content string
+		hosts   []*Host
+		want    string
+	}{
+		"empty config": {
+			"",
+			hosts,
+			block,
+		},
+		"append block": {
+			user,
+			hosts,
+			user + "\n" + block,
+		},
+		"append block without trailing newline": {
+			strings.TrimSuffix(user, "\n"),
+			hosts,
+			user + "\n" + block,
+		},
+		"replace block": {
+			user + "\n" + string(ConfigBlock(hosts[:1])) + user,
+			hosts,
+			user + "\n" + block + user,
+		},
+		"remove block": {
+			user + "\n" + block + user,
+			nil,
+			user + "\n" + user,
+		},
+		"no block to remove": {
+			user,
+			nil,
+			user,
+		},
+	}
+
+	for name, cas := range cases {
+		// capture range variable here
+		cas := cas
+		t.Run(name, func(t *testing.T) {
+			got := string(UpdateConfig([]byte(cas.content), cas.hosts))
+
+			if got != cas.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, cas.want)
+			}
+		})
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh")
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, ".ssh", "config")
+
+	hosts := []*Host{{
+		Alias:        "apple",
+		ProxyCommand: "kd machine ssh --proxy apple",
+	}}
+
+	if err := WriteConfig(file, hosts); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if !bytes.Equal(p, ConfigBlock(hosts)) {
+		t.Fatalf("want config = %s; got %s", ConfigBlock(hosts), p)
+	}
+
+	if !HasConfigBlock(p) {
+		t.Fatal("want config to have managed block")
+	}
+
+	if err := WriteConfig(file, nil); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if p, err = ioutil.ReadFile(file); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if len(p) != 0 {
+		t.Fatalf("want empty config; got %s", p)
+	}
+}
+
+func TestWriteConfigSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh")
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "dotfiles", "ssh_config")
+	file := filepath.Join(dir, ".ssh", "config")
+
+	for _, d := range []string{filepath.Dir(target), filepath.Dir(file)} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			t.Fatalf("want err = nil; got %v", err)
+		}
+	}
+
+	if err := ioutil.WriteFile(target, []byte("Host *\n"), 0644); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if err := os.Symlink(filepath.Join("..", "dotfiles", "ssh_config"), file); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	hosts := []*Host{{
+		Alias:        "apple",
+		ProxyCommand: "kd machine ssh --proxy apple",
+	}}
+
+	if err := WriteConfig(file, hosts); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	fi, err := os.Lstat(file)
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("want %s to be a symlink; got %s", file, fi.Mode())
+	}
+
+	if fi, err = os.Stat(target); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if fi.Mode().Perm() != 0644 {
+		t.Fatalf("want mode = 0644; got %s", fi.Mode().Perm())
+	}
+
+	p, err := ioutil.ReadFile(target)
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if !HasConfigBlock(p) {
+		t.Fatalf("want config to have managed block; got %s", p)
+	}
+}
@@ -0,0 +1,121 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultKnownHostsName is the name of known_hosts file managed by kd.
+const DefaultKnownHostsName = "kd_known_hosts"
+
+// GetKnownHostsPath returns a path to known_hosts file, which stores
+// host keys of remote machines. If user is nil, the current user
+// will be used.
+func GetKnownHostsPath(u *user.User) (string, error) {
+	path, err := GetKeyPath(u)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(path, DefaultKnownHostsName), nil
+}
+
+// PruneKnownHosts removes host keys of hosts, which are not in the given
+// aliases, from the known_hosts file. Keys of removed machines are
+// dropped, so a new machine with the same alias is trusted on first use.
+//
+// Comments and marker lines are left intact. It is not an error if
+// the file does not exist.
+func PruneKnownHosts(file string, aliases []string) error {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if keepKnownHost(line, aliases) {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if bytes.Equal(content, buf.Bytes()) {
+		return nil
+	}
+
+	return ioutil.WriteFile(file, buf.Bytes(), 0600)
+}
+
+func keepKnownHost(line string, aliases []string) bool {
+	fields := strings.Fields(line)
+
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "@") {
+		return true
+	}
+
+	for _, host := range strings.Split(fields[0], ",") {
+		for _, alias := range aliases {
+			if matchKnownHost(host, alias) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchKnownHost tells whether the host field of known_hosts entry
+// matches the alias. Hashed entries, written when HashKnownHosts
+// option is enabled, are matched too.
+func matchKnownHost(host, alias string) bool {
+	if strings.HasPrefix(host, "|1|") {
+		parts := strings.Split(host[len("|1|"):], "|")
+		if len(parts) != 2 {
+			return false
+		}
+
+		salt, err := base64.StdEncoding.DecodeString(parts[0])
+		if err != nil {
+			return false
+		}
+
+		sum, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return false
+		}
+
+		mac := hmac.New(sha1.New, salt)
+		mac.Write([]byte(alias))
+
+		return hmac.Equal(mac.Sum(nil), sum)
+	}
+
+	// Host with non-default port is written as [host]:port.
+	if strings.HasPrefix(host, "[") {
+		if i := strings.Index(host, "]:"); i != -1 {
+			host = host[1:i]
+		}
+	}
+
+	return host == alias
+}
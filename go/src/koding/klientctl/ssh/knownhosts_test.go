@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func hashKnownHost(host string) string {
+	salt := []byte("0123456789abcdefghij")
+
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(host))
+
+	return "|1|" + base64.StdEncoding.EncodeToString(salt) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestPruneKnownHosts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh")
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, DefaultKnownHostsName)
+
+	if err := PruneKnownHosts(file, nil); err != nil {
+		t.Fatalf("want err = nil for missing file; got %v", err)
+	}
+
+	content := "# comment\n" +
+		"apple ssh-ed25519 AAAA1\n" +
+		"banana ssh-ed25519 AAAA2\n" +
+		"[cherry]:2222 ssh-ed25519 AAAA3\n" +
+		hashKnownHost("durian") + " ssh-ed25519 AAAA4\n" +
+		hashKnownHost("banana") + " ssh-ed25519 AAAA5\n"
+
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	if err := PruneKnownHosts(file, []string{"apple", "cherry", "durian"}); err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("want err = nil; got %v", err)
+	}
+
+	want := "# comment\n" +
+		"apple ssh-ed25519 AAAA1\n" +
+		"[cherry]:2222 ssh-ed25519 AAAA3\n" +
+		hashKnownHost("durian") + " ssh-ed25519 AAAA4\n"
+
+	if string(p) != want {
+		t.Fatalf("want known hosts:\n%s\ngot:\n%s", want, p)
+	}
+}
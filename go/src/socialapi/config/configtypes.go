@@ -68,6 +68,29 @@ type (
 		ForcedRecipientUsername string `env:"key=KONFIG_SOCIALAPI_EMAIL_FORCEDRECIPIENTUSERNAME"`
 		Username                string `env:"key=KONFIG_SOCIALAPI_EMAIL_USERNAME                 required"`
 		Password                string `env:"key=KONFIG_SOCIALAPI_EMAIL_PASSWORD                 required"`
+
+		// Sender selects the transport of rendered emails: "mailgun"
+		// (default), "smtp" or "file", which writes .eml files into
+		// OutputDir instead of sending them. With "smtp" and "file"
+		// all notifications are rendered from the local templates.
+		Sender    string `env:"key=KONFIG_SOCIALAPI_EMAIL_SENDER"`
+		OutputDir string `env:"key=KONFIG_SOCIALAPI_EMAIL_OUTPUTDIR"`
+		SMTP      SMTP
+	}
+
+	// SMTP holds configuration of the SMTP email transport
+	SMTP struct {
+		Host     string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_HOST"`
+		Port     string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_PORT"`
+		Username string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_USERNAME"`
+		Password string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_PASSWORD"`
+		StartTLS bool   `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_STARTTLS"`
+
+		// DKIMPrivateKey is a PEM encoded RSA key or a path to it,
+		// messages are not signed when it is empty.
+		DKIMDomain     string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_DKIMDOMAIN"`
+		DKIMSelector   string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_DKIMSELECTOR"`
+		DKIMPrivateKey string `env:"key=KONFIG_SOCIALAPI_EMAIL_SMTP_DKIMPRIVATEKEY"`
 	}
 
 	// Mixpanel holds mixpanel credentials
@@ -88,9 +111,11 @@ type (
 	}
 
 	GateKeeper struct {
-		Host   string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_HOST"`
-		Port   string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_PORT"`
-		Pubnub Pubnub
+		Host     string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_HOST"`
+		Port     string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_PORT"`
+		Provider string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_PROVIDER"` // "pubnub" (default) or "hub"
+		Pubnub   Pubnub
+		Hub      Hub
 	}
 
 	Pubnub struct {
@@ -102,6 +127,12 @@ type (
 		Origin        string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_PUBNUB_ORIGIN"`
 	}
 
+	// Hub configures the self-hosted realtime provider.
+	Hub struct {
+		HistorySize int    `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_HUB_HISTORYSIZE"`
+		HistoryTTL  string `env:"key=KONFIG_SOCIALAPI_GATEKEEPER_HUB_HISTORYTTL"`
+	}
+
 	CustomDomain struct {
 		Public string `env:"key=KONFIG_SOCIALAPI_CUSTOMDOMAIN_PUBLIC"`
 		Local  string `env:"key=KONFIG_SOCIALAPI_CUSTOMDOMAIN_LOCAL"`
@@ -134,6 +165,7 @@ type (
 		ClientSecret      string `env:"key=KONFIG_SOCIALAPI_SLACK_CLIENTSECRET"`
 		RedirectUri       string `env:"key=KONFIG_SOCIALAPI_SLACK_REDIRECTURI"`
 		VerificationToken string `env:"key=KONFIG_SOCIALAPI_SLACK_VERIFICATIONTOKEN"`
+		SigningSecret     string `env:"key=KONFIG_SOCIALAPI_SLACK_SIGNINGSECRET"`
 	}
 
 	SneakerS3 struct {
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS "webhook"."webhook_delivery_endpoint_id_id_idx";
+DROP TABLE IF EXISTS "webhook"."delivery";
+
+DROP INDEX IF EXISTS "webhook"."webhook_endpoint_group_name_idx";
+DROP TABLE IF EXISTS "webhook"."endpoint";
+
+DROP SEQUENCE "webhook"."delivery_id_seq";
+DROP SEQUENCE "webhook"."endpoint_id_seq";
+
+--
+-- drop schema
+--
+DO $$
+  BEGIN
+    BEGIN
+      DROP SCHEMA webhook;
+    END;
+  END;
+$$;
@@ -0,0 +1,96 @@
+--
+-- create schema
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SCHEMA IF NOT EXISTS webhook;
+    END;
+  END;
+$$;
+
+GRANT usage ON SCHEMA webhook to social;
+
+--
+-- create the sequences
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "webhook"."endpoint_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "webhook"."endpoint_id_seq" TO "social";
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "webhook"."delivery_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "webhook"."delivery_id_seq" TO "social";
+
+--
+-- create endpoint table for storing the webhook urls of the teams
+--
+CREATE TABLE IF NOT EXISTS "webhook"."endpoint" (
+    "id" BIGINT NOT NULL DEFAULT nextval('webhook.endpoint_id_seq'::regclass),
+    "group_name" VARCHAR (200) NOT NULL CHECK ("group_name" <> ''),
+    "url" VARCHAR (2000) NOT NULL CHECK ("url" <> ''),
+    "secret" VARCHAR (200) NOT NULL CHECK ("secret" <> ''),
+    "events" TEXT[] NOT NULL DEFAULT '{}',
+    "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+    "creator_id" BIGINT NOT NULL,
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "webhook"."endpoint" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX  "webhook_endpoint_group_name_idx" ON webhook.endpoint USING btree(group_name DESC);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'webhook_endpoint_group_name_idx already exists';
+  END;
+$$;
+
+--
+-- create delivery table for logging the delivery attempts
+--
+CREATE TABLE IF NOT EXISTS "webhook"."delivery" (
+    "id" BIGINT NOT NULL DEFAULT nextval('webhook.delivery_id_seq'::regclass),
+    "endpoint_id" BIGINT NOT NULL,
+    "event_id" VARCHAR (100) NOT NULL,
+    "event" VARCHAR (100) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "attempt" INTEGER NOT NULL DEFAULT 1,
+    "status_code" INTEGER NOT NULL DEFAULT 0,
+    "error" TEXT NOT NULL DEFAULT '',
+    "is_delivered" BOOLEAN NOT NULL DEFAULT FALSE,
+    "duration" BIGINT NOT NULL DEFAULT 0,
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "webhook_delivery_endpoint_id_fkey" FOREIGN KEY ("endpoint_id") REFERENCES webhook.endpoint (id) ON UPDATE NO ACTION ON DELETE CASCADE NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "webhook"."delivery" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX  "webhook_delivery_endpoint_id_id_idx" ON webhook.delivery USING btree(endpoint_id DESC, id DESC);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'webhook_delivery_endpoint_id_id_idx already exists';
+  END;
+$$;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "api"."channel_message_mentions_idx";
+DROP INDEX IF EXISTS "api"."channel_message_search_vector_idx";
+
+DROP TRIGGER IF EXISTS "channel_message_search_update" ON api.channel_message;
+DROP FUNCTION IF EXISTS api.channel_message_search_update();
+
+ALTER TABLE api.channel_message DROP COLUMN IF EXISTS "mentions";
+ALTER TABLE api.channel_message DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,70 @@
+--
+-- add full-text search columns to messages
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      ALTER TABLE api.channel_message ADD COLUMN "search_vector" tsvector;
+    EXCEPTION
+      WHEN duplicate_column THEN RAISE NOTICE 'column search_vector already exists';
+    END;
+  END;
+$$;
+
+DO $$
+  BEGIN
+    BEGIN
+      ALTER TABLE api.channel_message ADD COLUMN "mentions" TEXT[] NOT NULL DEFAULT '{}';
+    EXCEPTION
+      WHEN duplicate_column THEN RAISE NOTICE 'column mentions already exists';
+    END;
+  END;
+$$;
+
+--
+-- keep the search columns up to date on insert and update, mentions are
+-- extracted with the same pattern as ChannelMessage.GetMentionedUsernames
+--
+CREATE OR REPLACE FUNCTION api.channel_message_search_update() RETURNS TRIGGER AS $$
+  BEGIN
+    IF TG_OP = 'INSERT' OR NEW.body IS DISTINCT FROM OLD.body OR NEW.search_vector IS NULL THEN
+      NEW.search_vector := to_tsvector('simple', coalesce(NEW.body, ''));
+      NEW.mentions := ARRAY(
+        SELECT DISTINCT lower(m[1]) FROM regexp_matches(coalesce(NEW.body, ''), '@(\w+-?\.?\w+)', 'g') AS m
+      );
+    END IF;
+
+    RETURN NEW;
+  END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "channel_message_search_update" ON api.channel_message;
+CREATE TRIGGER "channel_message_search_update" BEFORE INSERT OR UPDATE ON api.channel_message
+  FOR EACH ROW EXECUTE PROCEDURE api.channel_message_search_update();
+
+--
+-- index the existing messages
+--
+UPDATE api.channel_message SET
+  search_vector = to_tsvector('simple', coalesce(body, '')),
+  mentions = ARRAY(
+    SELECT DISTINCT lower(m[1]) FROM regexp_matches(coalesce(body, ''), '@(\w+-?\.?\w+)', 'g') AS m
+  )
+WHERE search_vector IS NULL;
+
+DO $$
+  BEGIN
+    CREATE INDEX "channel_message_search_vector_idx" ON api.channel_message USING gin(search_vector);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'channel_message_search_vector_idx already exists';
+  END;
+$$;
+
+DO $$
+  BEGIN
+    CREATE INDEX "channel_message_mentions_idx" ON api.channel_message USING gin(mentions);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'channel_message_mentions_idx already exists';
+  END;
+$$;
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS "metering"."metering_machine_usage_machine_id_open_idx";
+DROP INDEX IF EXISTS "metering"."metering_machine_usage_group_name_started_at_idx";
+DROP TABLE IF EXISTS "metering"."machine_usage";
+
+DROP SEQUENCE "metering"."machine_usage_id_seq";
+
+--
+-- drop schema
+--
+DO $$
+  BEGIN
+    BEGIN
+      DROP SCHEMA metering;
+    END;
+  END;
+$$;
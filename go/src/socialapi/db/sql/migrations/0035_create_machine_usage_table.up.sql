@@ -0,0 +1,62 @@
+--
+-- create schema
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SCHEMA IF NOT EXISTS metering;
+    END;
+  END;
+$$;
+
+GRANT usage ON SCHEMA metering to social;
+
+--
+-- create the sequence
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "metering"."machine_usage_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "metering"."machine_usage_id_seq" TO "social";
+
+--
+-- create machine usage table for storing the running intervals of the
+-- machines, an interval is open until the machine is stopped
+--
+CREATE TABLE IF NOT EXISTS "metering"."machine_usage" (
+    "id" BIGINT NOT NULL DEFAULT nextval('metering.machine_usage_id_seq'::regclass),
+    "group_name" VARCHAR (200) NOT NULL CHECK ("group_name" <> ''),
+    "machine_id" VARCHAR (24) NOT NULL CHECK ("machine_id" <> ''),
+    "started_at" timestamp(6) WITH TIME ZONE NOT NULL,
+    "stopped_at" timestamp(6) WITH TIME ZONE,
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CHECK ("stopped_at" IS NULL OR "stopped_at" >= "started_at")
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "metering"."machine_usage" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "metering_machine_usage_group_name_started_at_idx" ON metering.machine_usage USING btree(group_name, started_at DESC);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'metering_machine_usage_group_name_started_at_idx already exists';
+  END;
+$$;
+
+-- a machine can only have one open interval
+DO $$
+  BEGIN
+    CREATE UNIQUE INDEX "metering_machine_usage_machine_id_open_idx" ON metering.machine_usage USING btree(machine_id) WHERE stopped_at IS NULL;
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'metering_machine_usage_machine_id_open_idx already exists';
+  END;
+$$;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS "slack"."slack_team_team_id_idx";
+DROP TABLE IF EXISTS "slack"."team";
+
+DROP SEQUENCE "slack"."team_id_seq";
+
+--
+-- drop schema
+--
+DO $$
+  BEGIN
+    BEGIN
+      DROP SCHEMA slack;
+    END;
+  END;
+$$;
@@ -0,0 +1,56 @@
+--
+-- create schema
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SCHEMA IF NOT EXISTS slack;
+    END;
+  END;
+$$;
+
+GRANT usage ON SCHEMA slack to social;
+
+--
+-- create the sequence
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "slack"."team_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "slack"."team_id_seq" TO "social";
+
+--
+-- create team table for linking the slack teams to the groups, it also holds
+-- the channel notification settings of the group
+--
+CREATE TABLE IF NOT EXISTS "slack"."team" (
+    "id" BIGINT NOT NULL DEFAULT nextval('slack.team_id_seq'::regclass),
+    "group_name" VARCHAR (200) NOT NULL CHECK ("group_name" <> ''),
+    "team_id" VARCHAR (50) NOT NULL CHECK ("team_id" <> ''),
+    "channel_id" VARCHAR (50) NOT NULL DEFAULT '',
+    "events" TEXT[] NOT NULL DEFAULT '{}',
+    "notifier_id" BIGINT NOT NULL DEFAULT 0,
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+    "updated_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "slack_team_group_name_key" UNIQUE ("group_name") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "slack"."team" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "slack_team_team_id_idx" ON slack.team USING btree(team_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'slack_team_team_id_idx already exists';
+  END;
+$$;
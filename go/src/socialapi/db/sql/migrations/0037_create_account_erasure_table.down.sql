@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "api"."api_account_erasure_old_id_idx";
+DROP TABLE IF EXISTS "api"."account_erasure";
+
+DROP SEQUENCE "api"."account_erasure_id_seq";
@@ -0,0 +1,43 @@
+--
+-- create the sequence
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "api"."account_erasure_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "api"."account_erasure_id_seq" TO "social";
+
+--
+-- create account erasure table for auditing the erasure requests, it does
+-- not hold any personal data of the erased accounts, only their ids and the
+-- number of the records that are deleted or anonymized
+--
+CREATE TABLE IF NOT EXISTS "api"."account_erasure" (
+    "id" BIGINT NOT NULL DEFAULT nextval('api.account_erasure_id_seq'::regclass),
+    "account_id" BIGINT NOT NULL DEFAULT 0,
+    "old_id" VARCHAR (24) NOT NULL CHECK ("old_id" <> ''),
+    "requested_by" VARCHAR (200) NOT NULL CHECK ("requested_by" <> ''),
+    "dry_run" BOOLEAN NOT NULL DEFAULT FALSE,
+    "summary" hstore,
+    "error" TEXT NOT NULL DEFAULT '',
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+    "finished_at" timestamp(6) WITH TIME ZONE,
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE ON "api"."account_erasure" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "api_account_erasure_old_id_idx" ON api.account_erasure USING btree(old_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'api_account_erasure_old_id_idx already exists';
+  END;
+$$;
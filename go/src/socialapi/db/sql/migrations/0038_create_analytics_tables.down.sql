@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS "analytics"."analytics_machine_daily_group_name_day_idx";
+DROP TABLE IF EXISTS "analytics"."machine_daily";
+
+DROP INDEX IF EXISTS "analytics"."analytics_member_daily_group_name_day_idx";
+DROP TABLE IF EXISTS "analytics"."member_daily";
+
+DROP SEQUENCE "analytics"."machine_daily_id_seq";
+DROP SEQUENCE "analytics"."member_daily_id_seq";
+
+--
+-- drop schema
+--
+DO $$
+  BEGIN
+    BEGIN
+      DROP SCHEMA analytics;
+    END;
+  END;
+$$;
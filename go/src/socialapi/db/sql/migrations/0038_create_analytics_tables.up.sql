@@ -0,0 +1,92 @@
+--
+-- create schema
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SCHEMA IF NOT EXISTS analytics;
+    END;
+  END;
+$$;
+
+GRANT usage ON SCHEMA analytics to social;
+
+--
+-- create the sequences
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "analytics"."member_daily_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "analytics"."member_daily_id_seq" TO "social";
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "analytics"."machine_daily_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "analytics"."machine_daily_id_seq" TO "social";
+
+--
+-- create member daily table, it holds a row for each day a member of a team
+-- was active, rows are rolled up from the presence pings
+--
+CREATE TABLE IF NOT EXISTS "analytics"."member_daily" (
+    "id" BIGINT NOT NULL DEFAULT nextval('analytics.member_daily_id_seq'::regclass),
+    "group_name" VARCHAR (200) NOT NULL CHECK ("group_name" <> ''),
+    "account_id" BIGINT NOT NULL,
+    "day" DATE NOT NULL,
+    "last_seen_at" timestamp(6) WITH TIME ZONE NOT NULL,
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "analytics_member_daily_group_name_account_id_day_key" UNIQUE ("group_name", "account_id", "day") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "analytics"."member_daily" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "analytics_member_daily_group_name_day_idx" ON analytics.member_daily USING btree(group_name, day);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'analytics_member_daily_group_name_day_idx already exists';
+  END;
+$$;
+
+--
+-- create machine daily table, it holds the running seconds of a machine in a
+-- day along with its owner and stack, rows are rolled up from the machine
+-- usage intervals
+--
+CREATE TABLE IF NOT EXISTS "analytics"."machine_daily" (
+    "id" BIGINT NOT NULL DEFAULT nextval('analytics.machine_daily_id_seq'::regclass),
+    "group_name" VARCHAR (200) NOT NULL CHECK ("group_name" <> ''),
+    "machine_id" VARCHAR (24) NOT NULL CHECK ("machine_id" <> ''),
+    "account_id" BIGINT NOT NULL DEFAULT 0,
+    "stack_id" VARCHAR (24) NOT NULL DEFAULT '',
+    "day" DATE NOT NULL,
+    "seconds" BIGINT NOT NULL DEFAULT 0,
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "analytics_machine_daily_machine_id_day_key" UNIQUE ("machine_id", "day") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "analytics"."machine_daily" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "analytics_machine_daily_group_name_day_idx" ON analytics.machine_daily USING btree(group_name, day);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'analytics_machine_daily_group_name_day_idx already exists';
+  END;
+$$;
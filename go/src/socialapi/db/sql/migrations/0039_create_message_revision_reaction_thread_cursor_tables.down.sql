@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "api"."api_thread_cursor_account_id_idx";
+DROP TABLE IF EXISTS "api"."thread_cursor";
+
+DROP INDEX IF EXISTS "api"."api_message_reaction_account_id_idx";
+DROP TABLE IF EXISTS "api"."message_reaction";
+
+DROP INDEX IF EXISTS "api"."api_message_revision_message_id_created_at_idx";
+DROP TABLE IF EXISTS "api"."message_revision";
+
+DROP SEQUENCE "api"."thread_cursor_id_seq";
+DROP SEQUENCE "api"."message_reaction_id_seq";
+DROP SEQUENCE "api"."message_revision_id_seq";
@@ -0,0 +1,110 @@
+--
+-- create the sequences
+--
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "api"."message_revision_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "api"."message_revision_id_seq" TO "social";
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "api"."message_reaction_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "api"."message_reaction_id_seq" TO "social";
+
+DO $$
+  BEGIN
+    BEGIN
+      CREATE SEQUENCE "api"."thread_cursor_id_seq" INCREMENT 1 START 1 MAXVALUE 9223372036854775807 MINVALUE 1 CACHE 1;
+    EXCEPTION WHEN duplicate_table THEN
+    END;
+  END;
+$$;
+
+GRANT USAGE ON SEQUENCE "api"."thread_cursor_id_seq" TO "social";
+
+--
+-- create message revision table, it holds the previous bodies of the edited
+-- messages, a row is added before every edit
+--
+CREATE TABLE IF NOT EXISTS "api"."message_revision" (
+    "id" BIGINT NOT NULL DEFAULT nextval('api.message_revision_id_seq'::regclass),
+    "message_id" BIGINT NOT NULL,
+    "account_id" BIGINT NOT NULL,
+    "body" TEXT NOT NULL DEFAULT '',
+    "payload" hstore,
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, DELETE ON "api"."message_revision" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "api_message_revision_message_id_created_at_idx" ON api.message_revision USING btree(message_id, created_at DESC);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'api_message_revision_message_id_created_at_idx already exists';
+  END;
+$$;
+
+--
+-- create message reaction table, an account can react to a message once with
+-- each reaction
+--
+CREATE TABLE IF NOT EXISTS "api"."message_reaction" (
+    "id" BIGINT NOT NULL DEFAULT nextval('api.message_reaction_id_seq'::regclass),
+    "message_id" BIGINT NOT NULL,
+    "account_id" BIGINT NOT NULL,
+    "reaction" VARCHAR (50) NOT NULL CHECK ("reaction" <> ''),
+    "created_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "api_message_reaction_message_id_account_id_reaction_key" UNIQUE ("message_id", "account_id", "reaction") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, DELETE ON "api"."message_reaction" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "api_message_reaction_account_id_idx" ON api.message_reaction USING btree(account_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'api_message_reaction_account_id_idx already exists';
+  END;
+$$;
+
+--
+-- create thread cursor table, it holds the last time a participant read the
+-- replies of a message
+--
+CREATE TABLE IF NOT EXISTS "api"."thread_cursor" (
+    "id" BIGINT NOT NULL DEFAULT nextval('api.thread_cursor_id_seq'::regclass),
+    "message_id" BIGINT NOT NULL,
+    "account_id" BIGINT NOT NULL,
+    "last_read_at" timestamp(6) WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    -- create constraints along with table creation
+    PRIMARY KEY ("id") NOT DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT "api_thread_cursor_message_id_account_id_key" UNIQUE ("message_id", "account_id") NOT DEFERRABLE INITIALLY IMMEDIATE
+) WITH (OIDS = FALSE);
+GRANT SELECT, INSERT, UPDATE, DELETE ON "api"."thread_cursor" TO "social";
+
+DO $$
+  BEGIN
+    CREATE INDEX "api_thread_cursor_account_id_idx" ON api.thread_cursor USING btree(account_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'api_thread_cursor_account_id_idx already exists';
+  END;
+$$;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "slack"."slack_team_team_id_key";
+
+DO $$
+  BEGIN
+    CREATE INDEX "slack_team_team_id_idx" ON slack.team USING btree(team_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'slack_team_team_id_idx already exists';
+  END;
+$$;
@@ -0,0 +1,10 @@
+-- a slack team can be linked to a single group
+DROP INDEX IF EXISTS "slack"."slack_team_team_id_idx";
+
+DO $$
+  BEGIN
+    CREATE UNIQUE INDEX "slack_team_team_id_key" ON slack.team USING btree(team_id);
+  EXCEPTION WHEN duplicate_table THEN
+    RAISE NOTICE 'slack_team_team_id_key already exists';
+  END;
+$$;
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	ErrAccountErasureOldIdIsNotSet     = errors.New("account erasure old id is not set")
+	ErrAccountErasureRequesterIsNotSet = errors.New("account erasure requester is not set")
+	ErrAccountErasureIsAlreadyFinished = errors.New("account erasure is already finished")
+)
+
+// AccountErasure is the audit record of an account erasure request. It does
+// not hold any personal data of the account, the summary only has the number
+// of the deleted or anonymized records
+type AccountErasure struct {
+	// Id unique identifier of the record
+	Id int64 `json:"id,string"`
+
+	// AccountId is the social api id of the account, it is zero when the
+	// account does not exist in postgres
+	AccountId int64 `json:"accountId,string"`
+
+	// OldId is the mongo id of the account
+	OldId string `json:"oldId" sql:"NOT NULL;TYPE:VARCHAR(24);"`
+
+	// RequestedBy is the operator, who run the erasure
+	RequestedBy string `json:"requestedBy" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// DryRun is set when the records are only counted
+	DryRun bool `json:"dryRun"`
+
+	// Summary holds the number of the records by their store and collection,
+	// keys are like "postgres.api.channel_message:delete"
+	Summary gorm.Hstore `json:"summary"`
+
+	// Error holds the reason of the failed erasures
+	Error string `json:"error"`
+
+	// Creation date of the record
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+
+	// FinishedAt is set when the erasure is done, failed erasures are
+	// finished too
+	FinishedAt *time.Time `json:"finishedAt"`
+}
+
+// Validate checks the required fields of the record
+func (a *AccountErasure) Validate() error {
+	if a.OldId == "" {
+		return ErrAccountErasureOldIdIsNotSet
+	}
+
+	if a.RequestedBy == "" {
+		return ErrAccountErasureRequesterIsNotSet
+	}
+
+	return nil
+}
+
+// Count adds n to the summary of the key
+func (a *AccountErasure) Count(key string, n int) {
+	if a.Summary == nil {
+		a.Summary = gorm.Hstore{}
+	}
+
+	total := n
+	if v, ok := a.Summary[key]; ok && v != nil {
+		prev, _ := strconv.Atoi(*v)
+		total += prev
+	}
+
+	s := strconv.Itoa(total)
+	a.Summary[key] = &s
+}
+
+// Finish marks the erasure as done, err is recorded when it is not nil
+func (a *AccountErasure) Finish(err error) error {
+	if a.FinishedAt != nil {
+		return ErrAccountErasureIsAlreadyFinished
+	}
+
+	if err != nil {
+		a.Error = err.Error()
+	}
+
+	now := time.Now().UTC()
+	a.FinishedAt = &now
+
+	return a.Update()
+}
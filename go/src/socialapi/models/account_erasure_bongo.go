@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/koding/bongo"
+)
+
+// NewAccountErasure creates a new AccountErasure item
+func NewAccountErasure() *AccountErasure {
+	return &AccountErasure{
+		Summary:   gorm.Hstore{},
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// GetId returns the id
+func (a AccountErasure) GetId() int64 {
+	return a.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (a AccountErasure) BongoName() string {
+	return "api.account_erasure"
+}
+
+// BeforeCreate validates the record
+func (a *AccountErasure) BeforeCreate() error {
+	return a.Validate()
+}
+
+// BeforeUpdate validates the record
+func (a *AccountErasure) BeforeUpdate() error {
+	return a.Validate()
+}
+
+// One fetches the item from db
+func (a *AccountErasure) One(q *bongo.Query) error {
+	return bongo.B.One(a, a, q)
+}
+
+// Some fetches items from db
+func (a *AccountErasure) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(a, data, q)
+}
+
+// Create inserts into db
+func (a *AccountErasure) Create() error {
+	return bongo.B.Create(a)
+}
+
+// Update updates the item in db
+func (a *AccountErasure) Update() error {
+	return bongo.B.Update(a)
+}
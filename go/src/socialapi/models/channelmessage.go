@@ -193,13 +193,23 @@ func (c *ChannelMessage) BuildMessages(query *request.Query, messages []ChannelM
 		}
 	}
 
+	if err := populateReactions(containers, query); err != nil {
+		return containers, err
+	}
+
+	if err := populateThreadCursors(containers, query); err != nil {
+		return containers, err
+	}
+
 	return containers, nil
 }
 
 // TODO - remove this function
 func (c *ChannelMessage) BuildMessage(query *request.Query) (*ChannelMessageContainer, error) {
-	cmc := NewChannelMessageContainer()
-	if err := cmc.Fetch(c.Id, query); err != nil {
+	// reactions and thread cursors are not added here, callers building
+	// a list of messages add them with a single query for the whole list
+	cmc, err := BuildChannelMessageContainer(c.Id, query)
+	if err != nil {
 		return nil, err
 	}
 
@@ -207,7 +217,6 @@ func (c *ChannelMessage) BuildMessage(query *request.Query) (*ChannelMessageCont
 		return cmc, nil
 	}
 
-	var err error
 	cmc.Message, err = cmc.Message.PopulatePayload()
 	if err != nil {
 		return nil, err
@@ -466,10 +475,109 @@ func (c *ChannelMessage) DeleteMessageAndDependencies(deleteReplies bool) error
 	if err != nil {
 		return err
 	}
+
+	if err := NewMessageRevision().DeleteByMessageId(c.Id); err != nil {
+		return err
+	}
+
+	if err := NewMessageReaction().DeleteByMessageId(c.Id); err != nil {
+		return err
+	}
+
+	if err := NewInteraction().DeleteByMessageId(c.Id); err != nil {
+		return err
+	}
+
+	if err := NewThreadCursor().DeleteByMessageId(c.Id); err != nil {
+		return err
+	}
+
 	// delete channel message itself
 	return c.Delete()
 }
 
+// Edit updates the body and the payload of the message, previous ones are
+// recorded as a revision of the message when they are changed. The update
+// and the revision are written in a single transaction
+func (c *ChannelMessage) Edit(editorId int64, body string, payload gorm.Hstore) error {
+	if c.Id == 0 {
+		return ErrChannelMessageIdIsNotSet
+	}
+
+	if editorId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	if err := bodyLenCheck(body); err != nil {
+		return err
+	}
+
+	tx := bongo.B.DB.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	// lock the message, so concurrent edits record the body they replace
+	cm := NewChannelMessage()
+	sql := "SELECT * FROM " + cm.BongoName() + " WHERE id = ? FOR UPDATE"
+	if err := tx.Raw(sql, c.Id).Scan(cm).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if cm.TypeConstant == ChannelMessage_TYPE_JOIN ||
+		cm.TypeConstant == ChannelMessage_TYPE_LEAVE ||
+		cm.TypeConstant == ChannelMessage_TYPE_SYSTEM {
+		tx.Rollback()
+		return ErrChannelMessageUpdatedNotAllowed
+	}
+
+	if cm.Body != body || !hstoreEqual(cm.Payload, payload) {
+		mr := NewMessageRevision()
+		mr.MessageId = cm.Id
+		mr.AccountId = editorId
+		mr.Body = cm.Body
+		mr.Payload = cm.Payload
+
+		if err := tx.Table(mr.BongoName()).Create(mr).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	cm.Body = body
+	cm.Payload = payload
+	cm.UpdatedAt = time.Now().UTC()
+
+	if err := cm.MarkIfExempt(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// columns are updated without the hooks, AfterUpdate publishes the
+	// update event, which must not be sent before the commit
+	err := tx.Model(cm).UpdateColumns(map[string]interface{}{
+		"body":       cm.Body,
+		"payload":    cm.Payload,
+		"meta_bits":  cm.MetaBits,
+		"updated_at": cm.UpdatedAt,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	cm.AfterUpdate()
+
+	*c = *cm
+
+	return nil
+}
+
 // AddReply adds the reply message to db ,
 // according to message id
 func (c *ChannelMessage) AddReply(reply *ChannelMessage) (*MessageReply, error) {
@@ -11,8 +11,13 @@ type ChannelMessageContainer struct {
 	AccountOldId       string                   `json:"accountOldId"`
 	IsFollowed         bool                     `json:"isFollowed"`
 	UnreadRepliesCount int                      `json:"unreadRepliesCount,omitempty"`
+	Reactions          []ReactionSummary        `json:"reactions"`
 	ParentID           int64                    `json:"parentId,omitempty,string"`
 	Err                error                    `json:"-"`
+
+	// hasThreadCursor is set when the unread replies count is calculated
+	// from the thread cursor of the requester
+	hasThreadCursor bool
 }
 
 // Tests are done.
@@ -49,6 +54,17 @@ func (c *ChannelMessageContainer) Fetch(id int64, q *request.Query) error {
 	if err != nil {
 		return err
 	}
+
+	messageList := []*ChannelMessageContainer{cmc}
+
+	if err := populateReactions(messageList, q); err != nil {
+		return err
+	}
+
+	if err := populateThreadCursors(messageList, q); err != nil {
+		return err
+	}
+
 	*c = *cmc
 
 	return nil
@@ -168,12 +184,6 @@ func (cc *ChannelMessageContainer) AddIsFollowed(query *request.Query) *ChannelM
 	})
 }
 
-func (cc *ChannelMessageContainer) AddUnreadRepliesCount() *ChannelMessageContainer {
-	return withChannelMessageContainerChecks(cc, func(c *ChannelMessageContainer) error {
-		panic("method not implemented")
-	})
-}
-
 type ChannelMessageContainers []ChannelMessageContainer
 
 func NewChannelMessageContainers() *ChannelMessageContainers {
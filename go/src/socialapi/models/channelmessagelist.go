@@ -94,6 +94,11 @@ func (c *ChannelMessageList) populateUnreadCount(messageList []*ChannelMessageCo
 	channel.Id = c.ChannelId
 
 	for i, message := range messageList {
+		// thread cursor of the requester takes precedence
+		if message.hasThreadCursor {
+			continue
+		}
+
 		cml, err := channel.FetchMessageList(message.Message.Id)
 		if err != nil {
 			// runner.MustGetLogger().Error(err.Error())
@@ -111,6 +116,79 @@ func (c *ChannelMessageList) populateUnreadCount(messageList []*ChannelMessageCo
 	return messageList
 }
 
+// populateReactions adds the reactions into message containers and their
+// replies with a single query
+func populateReactions(messageList []*ChannelMessageContainer, q *request.Query) error {
+	var containers []*ChannelMessageContainer
+	for _, message := range messageList {
+		if message == nil || message.Message == nil {
+			continue
+		}
+
+		containers = append(containers, message)
+
+		for i := range message.Replies {
+			if message.Replies[i].Message != nil {
+				containers = append(containers, &message.Replies[i])
+			}
+		}
+	}
+
+	messageIds := make([]int64, len(containers))
+	for i, message := range containers {
+		messageIds[i] = message.Message.Id
+	}
+
+	summaries, err := NewMessageReaction().SummariesByMessageIds(messageIds, q.AccountId)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range containers {
+		message.Reactions = summaries[message.Message.Id]
+	}
+
+	return nil
+}
+
+// populateThreadCursors adds the number of the replies which are created
+// after the thread cursor of the requester into message containers with a
+// single query, threads which are not read by the requester yet are skipped
+func populateThreadCursors(messageList []*ChannelMessageContainer, q *request.Query) error {
+	if q.AccountId == 0 {
+		return nil
+	}
+
+	var messageIds []int64
+	for _, message := range messageList {
+		if message == nil || message.Message == nil {
+			continue
+		}
+
+		if message.Message.TypeConstant != ChannelMessage_TYPE_REPLY {
+			messageIds = append(messageIds, message.Message.Id)
+		}
+	}
+
+	counts, err := NewThreadCursor().UnreadCounts(messageIds, q.AccountId, q.ShowExempt)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messageList {
+		if message == nil || message.Message == nil {
+			continue
+		}
+
+		if count, ok := counts[message.Message.Id]; ok {
+			message.UnreadRepliesCount = count
+			message.hasThreadCursor = true
+		}
+	}
+
+	return nil
+}
+
 func (c *ChannelMessageList) getMessages(q *request.Query) ([]*ChannelMessageContainer, error) {
 	if c.ChannelId == 0 {
 		return nil, ErrChannelIdIsNotSet
@@ -221,6 +299,14 @@ func (c *ChannelMessageList) PopulateChannelMessages(channelMessageIds []int64,
 		return nil, processErr
 	}
 
+	if err := populateReactions(populatedChannelMessages, query); err != nil {
+		return nil, err
+	}
+
+	if err := populateThreadCursors(populatedChannelMessages, query); err != nil {
+		return nil, err
+	}
+
 	return populatedChannelMessages, nil
 }
 
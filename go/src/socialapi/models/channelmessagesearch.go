@@ -0,0 +1,385 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/koding/bongo"
+)
+
+var (
+	ErrSearchQueryIsNotSet = errors.New("search query is not set")
+	ErrInvalidSearchCursor = errors.New("search cursor is not valid")
+)
+
+const (
+	// markers are replaced with <mark> tags after the snippets are escaped
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+
+	highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+		", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" ... \""
+)
+
+// MessageSearchRequest holds the filters of a message search
+type MessageSearchRequest struct {
+	// Query is the searched text, every word is matched as a prefix
+	Query string
+
+	// GroupName is the group messages are searched in
+	GroupName string
+
+	// AccountId is the searching account, only messages of the channels
+	// the account can open are searched
+	AccountId int64
+
+	// AuthorId filters messages by their creator
+	AuthorId int64
+
+	// ChannelId filters messages by their channel
+	ChannelId int64
+
+	// Mention filters messages by the mentioned nick
+	Mention string
+
+	// From and To filter messages by their creation date
+	From time.Time
+	To   time.Time
+
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+
+	Limit      int
+	ShowExempt bool
+}
+
+// MapURL fills the request from the query parameters
+func (r *MessageSearchRequest) MapURL(u *url.URL) *MessageSearchRequest {
+	q := u.Query()
+
+	r.Query = strings.TrimSpace(q.Get("q"))
+	r.AuthorId, _ = strconv.ParseInt(q.Get("authorId"), 10, 64)
+	r.ChannelId, _ = strconv.ParseInt(q.Get("channelId"), 10, 64)
+	r.Mention = strings.TrimPrefix(strings.TrimSpace(q.Get("mention")), "@")
+	r.Cursor = q.Get("cursor")
+	r.Limit, _ = strconv.Atoi(q.Get("limit"))
+
+	if from := q.Get("from"); from != "" {
+		r.From, _ = time.Parse(time.RFC3339, from)
+	}
+
+	if to := q.Get("to"); to != "" {
+		r.To, _ = time.Parse(time.RFC3339, to)
+	}
+
+	return r
+}
+
+// MessageSearchResult is a single matching message
+type MessageSearchResult struct {
+	Message *ChannelMessage `json:"message"`
+
+	// ChannelId is the channel the message was created in
+	ChannelId int64 `json:"channelId,string"`
+
+	// Snippet is an HTML escaped excerpt of the body, matching words
+	// are wrapped with <mark> tags
+	Snippet string `json:"snippet"`
+
+	Rank float64 `json:"rank"`
+}
+
+// MessageSearchResponse holds a page of search results
+type MessageSearchResponse struct {
+	Results []MessageSearchResult `json:"results"`
+
+	// NextCursor fetches the next page, it is empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// SearchMessages searches the posts, replies and private messages of the
+// channels that the account can open. Results are sorted by their creation
+// date, newest first.
+func (c *ChannelMessage) SearchMessages(r *MessageSearchRequest) (*MessageSearchResponse, error) {
+	if r.GroupName == "" {
+		return nil, ErrGroupNameIsNotSet
+	}
+
+	if r.AccountId == 0 {
+		return nil, ErrAccountIdIsNotSet
+	}
+
+	tsquery := buildTSQuery(r.Query)
+	if tsquery == "" {
+		return nil, ErrSearchQueryIsNotSet
+	}
+
+	if r.Limit <= 0 || r.Limit > 50 {
+		r.Limit = 25
+	}
+
+	channelIds, err := searchableChannelsQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		where []string
+		args  []interface{}
+	)
+
+	add := func(cond string, a ...interface{}) {
+		where = append(where, cond)
+		args = append(args, a...)
+	}
+
+	add("cm.search_vector @@ to_tsquery('simple', ?)", tsquery)
+	add("cm.type_constant IN (?, ?, ?)",
+		ChannelMessage_TYPE_POST,
+		ChannelMessage_TYPE_REPLY,
+		ChannelMessage_TYPE_PRIVATE_MESSAGE,
+	)
+	add("cm.deleted_at IS NULL OR cm.deleted_at < '0001-01-02 00:00:00+00'")
+
+	// replies are not listed in channels, they are searched
+	// within the channel they were created in
+	add(`cm.initial_channel_id IN (`+channelIds.sql+`) OR EXISTS (
+		SELECT 1 FROM api.channel_message_list cml
+		WHERE cml.message_id = cm.id AND cml.channel_id IN (`+channelIds.sql+`))`,
+		append(channelIds.args, channelIds.args...)...,
+	)
+
+	if !r.ShowExempt {
+		add("cm.meta_bits <> ? OR cm.account_id = ?", Troll, r.AccountId)
+	}
+
+	if r.AuthorId != 0 {
+		add("cm.account_id = ?", r.AuthorId)
+	}
+
+	if r.Mention != "" {
+		add("cm.mentions @> ARRAY[?]::text[]", strings.ToLower(r.Mention))
+	}
+
+	if !r.From.IsZero() {
+		add("cm.created_at >= ?", r.From.UTC())
+	}
+
+	if !r.To.IsZero() {
+		add("cm.created_at <= ?", r.To.UTC())
+	}
+
+	if r.Cursor != "" {
+		createdAt, id, err := decodeSearchCursor(r.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		add("(cm.created_at, cm.id) < (?, ?)", createdAt, id)
+	}
+
+	query := fmt.Sprintf(`SELECT cm.id, cm.initial_channel_id, cm.created_at,
+		ts_headline('simple', coalesce(cm.body, ''), to_tsquery('simple', ?), ?),
+		ts_rank(cm.search_vector, to_tsquery('simple', ?))
+	FROM %s cm
+	WHERE (%s)
+	ORDER BY cm.created_at DESC, cm.id DESC
+	LIMIT ?`, c.BongoName(), strings.Join(where, ") AND ("))
+
+	args = append([]interface{}{tsquery, highlightOptions, tsquery}, args...)
+	args = append(args, r.Limit+1)
+
+	rows, err := bongo.B.DB.Raw(query, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &MessageSearchResponse{
+		Results: make([]MessageSearchResult, 0),
+	}
+
+	var (
+		ids       []int64
+		createdAt []time.Time
+	)
+
+	for rows.Next() {
+		var (
+			result MessageSearchResult
+			id     int64
+			t      time.Time
+		)
+
+		if err := rows.Scan(&id, &result.ChannelId, &t, &result.Snippet, &result.Rank); err != nil {
+			return nil, err
+		}
+
+		result.Snippet = highlight(result.Snippet)
+
+		ids = append(ids, id)
+		createdAt = append(createdAt, t)
+		res.Results = append(res.Results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res.Results) > r.Limit {
+		res.Results = res.Results[:r.Limit]
+		ids = ids[:r.Limit]
+		res.NextCursor = encodeSearchCursor(createdAt[r.Limit-1], ids[r.Limit-1])
+	}
+
+	messages, err := c.FetchByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[int64]*ChannelMessage, len(messages))
+	for i := range messages {
+		byId[messages[i].Id] = &messages[i]
+	}
+
+	results := res.Results[:0]
+	for i, result := range res.Results {
+		// message might be deleted in the meantime
+		if result.Message = byId[ids[i]]; result.Message != nil {
+			results = append(results, result)
+		}
+	}
+
+	res.Results = results
+
+	return res, nil
+}
+
+type sqlFragment struct {
+	sql  string
+	args []interface{}
+}
+
+// searchableChannelsQuery gives the query of channel ids, which are searched
+// for the request. When a channel is requested, it is checked with CanOpen.
+func searchableChannelsQuery(r *MessageSearchRequest) (*sqlFragment, error) {
+	if r.ChannelId != 0 {
+		ch, err := Cache.Channel.ById(r.ChannelId)
+		if err != nil {
+			return nil, err
+		}
+
+		if ch.GroupName != r.GroupName {
+			return nil, ErrCannotOpenChannel
+		}
+
+		canOpen, err := ch.CanOpen(r.AccountId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canOpen {
+			return nil, ErrCannotOpenChannel
+		}
+
+		return &sqlFragment{sql: "?", args: []interface{}{ch.Id}}, nil
+	}
+
+	isMember, err := isGroupMember(r.GroupName, r.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := NewChannelParticipant()
+	participated := "SELECT cp.channel_id FROM " + cp.BongoName() + " cp WHERE cp.account_id = ? AND cp.status_constant = ?"
+
+	f := &sqlFragment{
+		sql:  "SELECT ch.id FROM api.channel ch WHERE ch.group_name = ? AND ch.deleted_at < '0001-01-02 00:00:00+00' AND (ch.id IN (" + participated + ")",
+		args: []interface{}{r.GroupName, r.AccountId, ChannelParticipant_STATUS_ACTIVE},
+	}
+
+	// publicly accessible channels can be searched by the group members,
+	// see (*Channel).CanOpen
+	if isMember {
+		f.sql += " OR ch.type_constant IN (?, ?, ?)"
+		f.args = append(f.args, Channel_TYPE_GROUP, Channel_TYPE_ANNOUNCEMENT, Channel_TYPE_TOPIC)
+	}
+
+	f.sql += ")"
+
+	return f, nil
+}
+
+func isGroupMember(groupName string, accountId int64) (bool, error) {
+	if groupName == Channel_KODING_NAME {
+		return true, nil
+	}
+
+	groupChan := NewChannel()
+	if err := groupChan.FetchGroupChannel(groupName); err != nil {
+		return false, err
+	}
+
+	cp := NewChannelParticipant()
+	cp.ChannelId = groupChan.Id
+
+	return cp.IsParticipant(accountId)
+}
+
+// buildTSQuery converts the search text into a tsquery, where all words
+// must match as a prefix. Operators of the tsquery syntax are removed.
+func buildTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+// highlight escapes the snippet and replaces the highlight markers
+func highlight(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.Replace(snippet, highlightStart, "<mark>", -1)
+	return strings.Replace(snippet, highlightStop, "</mark>", -1)
+}
+
+func encodeSearchCursor(createdAt time.Time, id int64) string {
+	s := strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func decodeSearchCursor(cursor string) (time.Time, int64, error) {
+	p, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidSearchCursor
+	}
+
+	parts := strings.Split(string(p), ":")
+	if len(parts) != 2 {
+		return time.Time{}, 0, ErrInvalidSearchCursor
+	}
+
+	nsec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidSearchCursor
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidSearchCursor
+	}
+
+	return time.Unix(0, nsec).UTC(), id, nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBuildTSQuery(t *testing.T) {
+	testData := []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"Deploy", "deploy:*"},
+		{"deploy failed", "deploy:* & failed:*"},
+		{"a & b | !c", "a:* & b:* & c:*"},
+		{"it's (broken):*", "it:* & s:* & broken:*"},
+		{"go_test ünïcode", "go_test:* & ünïcode:*"},
+	}
+
+	for _, test := range testData {
+		if got := buildTSQuery(test.text); got != test.expected {
+			t.Errorf("buildTSQuery(%q)=%q, want %q", test.text, got, test.expected)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	snippet := "run <script>" + highlightStart + "deploy" + highlightStop + "</script> & wait"
+	expected := "run &lt;script&gt;<mark>deploy</mark>&lt;/script&gt; &amp; wait"
+
+	if got := highlight(snippet); got != expected {
+		t.Errorf("highlight()=%q, want %q", got, expected)
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	createdAt := time.Date(2016, 10, 3, 12, 30, 0, 123456000, time.UTC)
+
+	cursor := encodeSearchCursor(createdAt, 42)
+
+	gotTime, gotId, err := decodeSearchCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodeSearchCursor()=%s", err)
+	}
+
+	if !gotTime.Equal(createdAt) || gotId != 42 {
+		t.Errorf("got (%s, %d), want (%s, 42)", gotTime, gotId, createdAt)
+	}
+
+	for _, invalid := range []string{"!", "MTIz", "YTpi"} {
+		if _, _, err := decodeSearchCursor(invalid); err != ErrInvalidSearchCursor {
+			t.Errorf("decodeSearchCursor(%q)=%v, want %v", invalid, err, ErrInvalidSearchCursor)
+		}
+	}
+}
+
+func TestMessageSearchRequestMapURL(t *testing.T) {
+	u, err := url.Parse("/message/search?q=+deploy+&authorId=3&channelId=7&mention=@Rafal&from=2016-10-01T00:00:00Z&limit=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := (&MessageSearchRequest{}).MapURL(u)
+
+	if r.Query != "deploy" || r.AuthorId != 3 || r.ChannelId != 7 || r.Mention != "Rafal" || r.Limit != 10 {
+		t.Errorf("unexpected request: %+v", r)
+	}
+
+	if !r.From.Equal(time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)) || !r.To.IsZero() {
+		t.Errorf("unexpected date range: %s - %s", r.From, r.To)
+	}
+}
@@ -193,6 +193,13 @@ func (c *ChannelParticipant) Unblock() error {
 	return c.Delete()
 }
 
+// DeleteByAccountId removes all participations of the account, unlike Delete
+// it does not keep the participants with the left status
+func (c *ChannelParticipant) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + c.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
+
 func (c *ChannelParticipant) List(q *request.Query) ([]ChannelParticipant, error) {
 	var participants []ChannelParticipant
 
@@ -19,5 +19,9 @@ const (
 	SlackOauthCallback = "slack-oauth-callback"
 	SlackOauthSuccess  = "slack-oauth-succeess"
 	SlackOauthSend     = "slack-oauth-send"
-	MailPublishEvent   = "mail-publish-event"
+
+	SlackNotifications       = "slack-notifications"
+	SlackUpdateNotifications = "slack-update-notifications"
+
+	MailPublishEvent = "mail-publish-event"
 )
@@ -31,6 +31,7 @@ var (
 
 	ErrChannelMessageIdIsNotSet        = errors.New("channel message id is not set")
 	ErrChannelMessageUpdatedNotAllowed = errors.New("join/leave message update is not allowed")
+	ErrReplyHasNoThread                = errors.New("replies do not have threads")
 
 	ErrNameIsNotSet       = errors.New("name is not set")
 	ErrGroupNameIsNotSet  = errors.New("group name is not set")
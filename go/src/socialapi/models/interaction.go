@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+const (
+	Interaction_TYPE_LIKE     = "like"
+	Interaction_TYPE_UPVOTE   = "upvote"
+	Interaction_TYPE_DOWNVOTE = "downvote"
+)
+
+// Interaction is a like or a vote of an account to a message
+type Interaction struct {
+	// Id unique identifier of the interaction
+	Id int64 `json:"id,string"`
+
+	// MessageId is the id of the liked message
+	MessageId int64 `json:"messageId,string" sql:"NOT NULL"`
+
+	// AccountId is the liker
+	AccountId int64 `json:"accountId,string" sql:"NOT NULL"`
+
+	// Type of the interaction
+	TypeConstant string `json:"typeConstant" sql:"NOT NULL;TYPE:VARCHAR(100);"`
+
+	// Is the interaction troll or not
+	MetaBits MetaBits `json:"metaBits"`
+
+	// Creation date of the interaction
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+}
+
+// DeleteByMessageId deletes the interactions of the message
+func (i *Interaction) DeleteByMessageId(messageId int64) error {
+	if messageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	sql := "DELETE FROM " + i.BongoName() + " WHERE message_id = ?"
+	return bongo.B.DB.Exec(sql, messageId).Error
+}
+
+// DeleteByAccountId deletes the interactions of the account
+func (i *Interaction) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + i.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
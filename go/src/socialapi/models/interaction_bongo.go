@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// NewInteraction creates a new Interaction item
+func NewInteraction() *Interaction {
+	return &Interaction{
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// GetId returns the id
+func (i Interaction) GetId() int64 {
+	return i.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (i Interaction) BongoName() string {
+	return "api.interaction"
+}
+
+// One fetches the item from db
+func (i *Interaction) One(q *bongo.Query) error {
+	return bongo.B.One(i, i, q)
+}
+
+// Some fetches items from db
+func (i *Interaction) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(i, data, q)
+}
+
+// Create inserts into db
+func (i *Interaction) Create() error {
+	return bongo.B.Create(i)
+}
+
+// Delete deletes the item from db
+func (i *Interaction) Delete() error {
+	return bongo.B.Delete(i)
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// MachineDaily is the daily rollup of the running time of a machine, it
+// holds the owner and the stack of the machine for the per member and per
+// stack machine hours
+type MachineDaily struct {
+	// Id unique identifier of the record
+	Id int64 `json:"id,string"`
+
+	// GroupName is the team of the machine
+	GroupName string `json:"groupName" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// MachineId holds the mongo id of the machine
+	MachineId string `json:"machineId" sql:"NOT NULL;TYPE:VARCHAR(24);"`
+
+	// AccountId of the machine owner, it is zero when the owner is not known
+	AccountId int64 `json:"accountId,string"`
+
+	// StackId holds the mongo id of the stack of the machine
+	StackId string `json:"stackId" sql:"TYPE:VARCHAR(24);"`
+
+	// Day of the running time in UTC
+	Day time.Time `json:"day" sql:"NOT NULL;TYPE:DATE;"`
+
+	// Seconds the machine was running in the day
+	Seconds int64 `json:"seconds"`
+}
+
+// AccountMachineSeconds is the total running time of the machines of an
+// account
+type AccountMachineSeconds struct {
+	AccountId int64 `json:"accountId,string"`
+	Seconds   int64 `json:"seconds"`
+}
+
+// StackMachineSeconds is the total running time of the machines of a stack
+type StackMachineSeconds struct {
+	StackId string `json:"stackId"`
+	Seconds int64  `json:"seconds"`
+}
+
+// Set creates or replaces the record of the machine for its day, rollups are
+// recomputed from the usage intervals, so setting a day again is idempotent
+func (m *MachineDaily) Set() error {
+	if m.GroupName == "" {
+		return ErrGroupNameIsNotSet
+	}
+
+	if m.MachineId == "" {
+		return ErrMachineIdIsNotSet
+	}
+
+	day := FormatDay(m.Day)
+
+	sql := "UPDATE " + m.BongoName() + " SET group_name = ?, account_id = ?, stack_id = ?, seconds = ? WHERE machine_id = ? AND day = ?"
+	res := bongo.B.DB.Exec(sql, m.GroupName, m.AccountId, m.StackId, m.Seconds, m.MachineId, day)
+	if res.Error != nil || res.RowsAffected != 0 {
+		return res.Error
+	}
+
+	sql = "INSERT INTO " + m.BongoName() + " (group_name, machine_id, account_id, stack_id, day, seconds) " +
+		"SELECT ?, ?, ?::bigint, ?, ?::date, ?::bigint WHERE NOT EXISTS (SELECT 1 FROM " + m.BongoName() + " WHERE machine_id = ? AND day = ?)"
+
+	err := bongo.B.DB.Exec(sql, m.GroupName, m.MachineId, m.AccountId, m.StackId, day, m.Seconds, m.MachineId, day).Error
+	if IsUniqueConstraintError(err) {
+		return nil // set concurrently
+	}
+
+	return err
+}
+
+// SumByAccount sums the running time of the machines of the group by their
+// owners between the given days, both of them are included
+func (m *MachineDaily) SumByAccount(groupName string, from, to time.Time) ([]AccountMachineSeconds, error) {
+	sums := make([]AccountMachineSeconds, 0)
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Model(&MachineDaily{}).
+		Where("group_name = ? AND day >= ? AND day <= ?", groupName, FormatDay(from), FormatDay(to)).
+		Select("account_id, sum(seconds) AS seconds").
+		Group("account_id").
+		Order("seconds DESC").
+		Scan(&sums).Error
+
+	return sums, err
+}
+
+// SumByStack sums the running time of the machines of the group by their
+// stacks between the given days, both of them are included
+func (m *MachineDaily) SumByStack(groupName string, from, to time.Time) ([]StackMachineSeconds, error) {
+	sums := make([]StackMachineSeconds, 0)
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Model(&MachineDaily{}).
+		Where("group_name = ? AND day >= ? AND day <= ?", groupName, FormatDay(from), FormatDay(to)).
+		Select("stack_id, sum(seconds) AS seconds").
+		Group("stack_id").
+		Order("seconds DESC").
+		Scan(&sums).Error
+
+	return sums, err
+}
+
+// Series returns the running seconds of the machines of the group for each
+// day between the given days
+func (m *MachineDaily) Series(groupName string, from, to time.Time) ([]SeriesPoint, error) {
+	sql := "SELECT s.day AS day, coalesce(sum(m.seconds), 0) AS value " +
+		"FROM (SELECT generate_series(?::date, ?::date, '1 day')::date AS day) s " +
+		"LEFT JOIN " + m.BongoName() + " m ON m.day = s.day AND m.group_name = ? " +
+		"GROUP BY s.day ORDER BY s.day"
+
+	points := make([]SeriesPoint, 0)
+	err := bongo.B.DB.Raw(sql, FormatDay(from), FormatDay(to), groupName).Scan(&points).Error
+
+	return points, err
+}
+
+// DeleteByGroupName deletes the records of the group
+func (m *MachineDaily) DeleteByGroupName(groupName string) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE group_name = ?"
+	return bongo.B.DB.Exec(sql, groupName).Error
+}
+
+// DeleteByAccountId deletes the records of the machines of the account
+func (m *MachineDaily) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
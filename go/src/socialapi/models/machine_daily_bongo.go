@@ -0,0 +1,28 @@
+package models
+
+import "github.com/koding/bongo"
+
+// NewMachineDaily creates a new MachineDaily item
+func NewMachineDaily() *MachineDaily {
+	return &MachineDaily{}
+}
+
+// GetId returns the id
+func (m MachineDaily) GetId() int64 {
+	return m.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (m MachineDaily) BongoName() string {
+	return "analytics.machine_daily"
+}
+
+// One fetches the item from db
+func (m *MachineDaily) One(q *bongo.Query) error {
+	return bongo.B.One(m, m, q)
+}
+
+// Some fetches items from db
+func (m *MachineDaily) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(m, data, q)
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+var ErrMachineIdIsNotSet = errors.New("machine id is not set")
+
+// MachineUsage holds a running interval of a machine, the interval is open
+// until the machine is stopped
+type MachineUsage struct {
+	// Id unique identifier of the interval
+	Id int64 `json:"id,string"`
+
+	// GroupName is the team that is billed for the machine
+	GroupName string `json:"groupName" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// MachineId holds the mongo id of the machine
+	MachineId string `json:"machineId" sql:"NOT NULL;TYPE:VARCHAR(24);"`
+
+	// StartedAt is the time the machine started running
+	StartedAt time.Time `json:"startedAt" sql:"NOT NULL"`
+
+	// StoppedAt is the time the machine stopped, nil while it is running
+	StoppedAt *time.Time `json:"stoppedAt"`
+}
+
+// Start opens a new interval for the machine, if it is not running already
+func (m *MachineUsage) Start(groupName, machineId string, at time.Time) error {
+	if groupName == "" {
+		return ErrGroupNameIsNotSet
+	}
+
+	if machineId == "" {
+		return ErrMachineIdIsNotSet
+	}
+
+	sql := "INSERT INTO " + m.BongoName() + " (group_name, machine_id, started_at) " +
+		"SELECT ?, ?, ? WHERE NOT EXISTS (SELECT 1 FROM " + m.BongoName() + " WHERE machine_id = ? AND stopped_at IS NULL)"
+
+	err := bongo.B.DB.Exec(sql, groupName, machineId, at.UTC(), machineId).Error
+	if IsUniqueConstraintError(err) {
+		return nil // started concurrently
+	}
+
+	return err
+}
+
+// Stop closes the open interval of the machine
+func (m *MachineUsage) Stop(machineId string, at time.Time) error {
+	if machineId == "" {
+		return ErrMachineIdIsNotSet
+	}
+
+	// stop events might arrive before the start events are processed, in
+	// that case the interval is closed when it is started
+	sql := "UPDATE " + m.BongoName() + " SET stopped_at = GREATEST(started_at, ?) WHERE machine_id = ? AND stopped_at IS NULL"
+	return bongo.B.DB.Exec(sql, at.UTC(), machineId).Error
+}
+
+// SumSecondsByGroupName sums the running time of the machines of the group
+// between the given dates, open intervals are counted until the end date
+func (m *MachineUsage) SumSecondsByGroupName(groupName string, from, to time.Time) (int64, error) {
+	res := struct {
+		Seconds float64
+	}{}
+
+	sql := "SELECT coalesce(sum(extract(epoch FROM least(coalesce(stopped_at, ?), ?) - greatest(started_at, ?))), 0) AS seconds " +
+		"FROM " + m.BongoName() + " WHERE group_name = ? AND started_at < ? AND (stopped_at IS NULL OR stopped_at > ?)"
+
+	from, to = from.UTC(), to.UTC()
+	if err := bongo.B.DB.Raw(sql, to, to, from, groupName, to, from).Scan(&res).Error; err != nil {
+		return 0, err
+	}
+
+	return int64(res.Seconds), nil
+}
+
+// FetchGroupNamesSince fetches the groups, which had a running machine
+// after the given date
+func (m *MachineUsage) FetchGroupNamesSince(since time.Time) ([]string, error) {
+	var groupNames []string
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Model(&MachineUsage{}).
+		Where("stopped_at IS NULL OR stopped_at > ?", since.UTC()).
+		Pluck("DISTINCT group_name", &groupNames).Error
+
+	return groupNames, err
+}
+
+// FetchSince fetches the intervals of all groups, which were open after the
+// given date
+func (m *MachineUsage) FetchSince(since time.Time) ([]MachineUsage, error) {
+	usages := make([]MachineUsage, 0)
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Where("stopped_at IS NULL OR stopped_at > ?", since.UTC()).
+		Order("started_at").
+		Find(&usages).Error
+
+	return usages, err
+}
+
+// FetchOpen fetches the intervals of the machines, which are not stopped yet
+func (m *MachineUsage) FetchOpen() ([]MachineUsage, error) {
+	usages := make([]MachineUsage, 0)
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Where("stopped_at IS NULL").
+		Order("started_at").
+		Find(&usages).Error
+
+	return usages, err
+}
+
+// DeleteByGroupName deletes the intervals of the group
+func (m *MachineUsage) DeleteByGroupName(groupName string) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE group_name = ?"
+	return bongo.B.DB.Exec(sql, groupName).Error
+}
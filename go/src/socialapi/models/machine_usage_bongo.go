@@ -0,0 +1,28 @@
+package models
+
+import "github.com/koding/bongo"
+
+// NewMachineUsage creates a new MachineUsage item
+func NewMachineUsage() *MachineUsage {
+	return &MachineUsage{}
+}
+
+// GetId returns the id
+func (m MachineUsage) GetId() int64 {
+	return m.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (m MachineUsage) BongoName() string {
+	return "metering.machine_usage"
+}
+
+// One fetches the item from db
+func (m *MachineUsage) One(q *bongo.Query) error {
+	return bongo.B.One(m, m, q)
+}
+
+// Some fetches items from db
+func (m *MachineUsage) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(m, data, q)
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"socialapi/request"
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// DayLayout is the layout of the days of the analytics rollups
+const DayLayout = "2006-01-02"
+
+// MemberDaily is the daily activity rollup of a team member, there is a
+// record for each day the member was active in the team
+type MemberDaily struct {
+	// Id unique identifier of the record
+	Id int64 `json:"id,string"`
+
+	// Name of the group
+	GroupName string `json:"groupName" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// AccountId of the member
+	AccountId int64 `json:"accountId,string" sql:"NOT NULL"`
+
+	// Day of the activity in UTC
+	Day time.Time `json:"day" sql:"NOT NULL;TYPE:DATE;"`
+
+	// LastSeenAt is the last ping of the member in the day
+	LastSeenAt time.Time `json:"lastSeenAt" sql:"NOT NULL"`
+}
+
+// SeriesPoint is a daily value of an analytics trend
+type SeriesPoint struct {
+	Day   time.Time `json:"day"`
+	Value int64     `json:"value"`
+}
+
+// MemberActivity is the activity summary of a member within a period
+type MemberActivity struct {
+	AccountId  int64     `json:"accountId,string"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ActiveDays int       `json:"activeDays"`
+}
+
+// FormatDay formats the day of the given time in UTC
+func FormatDay(t time.Time) string {
+	return t.UTC().Format(DayLayout)
+}
+
+// Touch records the activity of the member at the given time, it is
+// idempotent and can be called for every ping
+func (m *MemberDaily) Touch(groupName string, accountId int64, at time.Time) error {
+	if groupName == "" {
+		return ErrGroupNameIsNotSet
+	}
+
+	if accountId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	day, at := FormatDay(at), at.UTC()
+
+	sql := "INSERT INTO " + m.BongoName() + " (group_name, account_id, day, last_seen_at) " +
+		"SELECT ?, ?::bigint, ?::date, ?::timestamptz WHERE NOT EXISTS (SELECT 1 FROM " + m.BongoName() + " WHERE group_name = ? AND account_id = ? AND day = ?)"
+
+	err := bongo.B.DB.Exec(sql, groupName, accountId, day, at, groupName, accountId, day).Error
+	if err != nil && !IsUniqueConstraintError(err) {
+		return err
+	}
+
+	sql = "UPDATE " + m.BongoName() + " SET last_seen_at = GREATEST(last_seen_at, ?) WHERE group_name = ? AND account_id = ? AND day = ?"
+	return bongo.B.DB.Exec(sql, at, groupName, accountId, day).Error
+}
+
+// RollupPresence creates the missing records from the presence pings after
+// the given date, presences are not kept after the payments of the teams so
+// the records of the pings which are not touched are recovered with it
+func (m *MemberDaily) RollupPresence(since time.Time) error {
+	p := NewPresenceDaily()
+
+	sql := "INSERT INTO " + m.BongoName() + " (group_name, account_id, day, last_seen_at) " +
+		"SELECT p.group_name, p.account_id, (p.created_at AT TIME ZONE 'UTC')::date, max(p.created_at) " +
+		"FROM " + p.BongoName() + " p WHERE p.created_at >= ? AND NOT EXISTS (" +
+		"SELECT 1 FROM " + m.BongoName() + " m WHERE m.group_name = p.group_name AND m.account_id = p.account_id " +
+		"AND m.day = (p.created_at AT TIME ZONE 'UTC')::date) " +
+		"GROUP BY 1, 2, 3"
+
+	return bongo.B.DB.Exec(sql, since.UTC()).Error
+}
+
+// CountActive counts the distinct active members of the group between the
+// given days, both of them are included
+func (m *MemberDaily) CountActive(groupName string, from, to time.Time) (int, error) {
+	res := struct {
+		Count int
+	}{}
+
+	return res.Count, bongo.B.DB.
+		Table(m.BongoName()).
+		Model(&MemberDaily{}).
+		Where("group_name = ? AND day >= ? AND day <= ?", groupName, FormatDay(from), FormatDay(to)).
+		Select("count(distinct account_id)").
+		Scan(&res).Error
+}
+
+// ActiveSeries returns the number of the active members for each day between
+// the given days, members are counted within the window of days ending at
+// each day. Window is 1 for the daily, 7 for the weekly and 30 for the
+// monthly active members.
+func (m *MemberDaily) ActiveSeries(groupName string, from, to time.Time, window int) ([]SeriesPoint, error) {
+	if window < 1 {
+		window = 1
+	}
+
+	sql := "SELECT s.day AS day, (SELECT count(DISTINCT m.account_id) FROM " + m.BongoName() + " m " +
+		"WHERE m.group_name = ? AND m.day > s.day - ?::int AND m.day <= s.day) AS value " +
+		"FROM (SELECT generate_series(?::date, ?::date, '1 day')::date AS day) s ORDER BY s.day"
+
+	points := make([]SeriesPoint, 0)
+	err := bongo.B.DB.Raw(sql, groupName, window, FormatDay(from), FormatDay(to)).Scan(&points).Error
+
+	return points, err
+}
+
+// FetchMembers fetches the activity summaries of the members of the group
+// between the given days, recently active members come first
+func (m *MemberDaily) FetchMembers(groupName string, from, to time.Time, q *request.Query) ([]MemberActivity, error) {
+	members := make([]MemberActivity, 0)
+
+	err := bongo.B.DB.
+		Table(m.BongoName()).
+		Model(&MemberDaily{}).
+		Where("group_name = ? AND day >= ? AND day <= ?", groupName, FormatDay(from), FormatDay(to)).
+		Select("account_id, max(last_seen_at) AS last_seen_at, count(*) AS active_days").
+		Group("account_id").
+		Order("last_seen_at DESC").
+		Limit(q.Limit).
+		Offset(q.Skip).
+		Scan(&members).Error
+
+	return members, err
+}
+
+// DeleteByGroupName deletes the records of the group
+func (m *MemberDaily) DeleteByGroupName(groupName string) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE group_name = ?"
+	return bongo.B.DB.Exec(sql, groupName).Error
+}
+
+// DeleteByAccountId deletes the records of the account
+func (m *MemberDaily) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
@@ -0,0 +1,28 @@
+package models
+
+import "github.com/koding/bongo"
+
+// NewMemberDaily creates a new MemberDaily item
+func NewMemberDaily() *MemberDaily {
+	return &MemberDaily{}
+}
+
+// GetId returns the id
+func (m MemberDaily) GetId() int64 {
+	return m.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (m MemberDaily) BongoName() string {
+	return "analytics.member_daily"
+}
+
+// One fetches the item from db
+func (m *MemberDaily) One(q *bongo.Query) error {
+	return bongo.B.One(m, m, q)
+}
+
+// Some fetches items from db
+func (m *MemberDaily) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(m, data, q)
+}
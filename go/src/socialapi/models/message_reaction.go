@@ -0,0 +1,212 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+var (
+	ErrReactionIsNotSet   = errors.New("reaction is not set")
+	ErrReactionIsNotValid = errors.New("reaction is not valid")
+
+	// reactions are stored as emoji short names like "+1" or "heart_eyes"
+	reactionRegex = regexp.MustCompile(`^[a-z0-9_+\-]{1,50}$`)
+)
+
+// MessageReaction is an emoji reaction of an account to a message, an
+// account can react to a message once with each reaction
+type MessageReaction struct {
+	// Id unique identifier of the reaction
+	Id int64 `json:"id,string"`
+
+	// MessageId is the id of the reacted message
+	MessageId int64 `json:"messageId,string" sql:"NOT NULL"`
+
+	// AccountId is the reactor
+	AccountId int64 `json:"accountId,string" sql:"NOT NULL"`
+
+	// Reaction is the short name of the emoji
+	Reaction string `json:"reaction" sql:"NOT NULL;TYPE:VARCHAR(50);"`
+
+	// Creation date of the reaction
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+}
+
+// ReactionSummary is the number of the reactions to a message with an emoji
+type ReactionSummary struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reactedByMe"`
+}
+
+// NormalizeReaction trims the colons around the emoji short name and
+// validates it, ":Heart:" is normalized as "heart"
+func NormalizeReaction(reaction string) (string, error) {
+	reaction = strings.ToLower(strings.Trim(strings.TrimSpace(reaction), ":"))
+	if reaction == "" {
+		return "", ErrReactionIsNotSet
+	}
+
+	if !reactionRegex.MatchString(reaction) {
+		return "", ErrReactionIsNotValid
+	}
+
+	return reaction, nil
+}
+
+// Validate checks the required fields of the reaction
+func (m *MessageReaction) Validate() error {
+	if m.MessageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	if m.AccountId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	reaction, err := NormalizeReaction(m.Reaction)
+	if err != nil {
+		return err
+	}
+
+	m.Reaction = reaction
+
+	return nil
+}
+
+// Add adds the reaction of the account to the message, it returns false when
+// the account has already reacted with the same emoji
+func (m *MessageReaction) Add() (bool, error) {
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
+
+	err := m.fetch()
+	if err == nil {
+		return false, nil
+	}
+
+	if err != bongo.RecordNotFound {
+		return false, err
+	}
+
+	if err := m.Create(); err != nil {
+		// concurrent requests of the same reaction
+		if IsUniqueConstraintError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Remove removes the reaction of the account from the message, it returns
+// false when there is no such reaction
+func (m *MessageReaction) Remove() (bool, error) {
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
+
+	err := m.fetch()
+	if err == bongo.RecordNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := m.Delete(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *MessageReaction) fetch() error {
+	query := &bongo.Query{
+		Selector: map[string]interface{}{
+			"message_id": m.MessageId,
+			"account_id": m.AccountId,
+			"reaction":   m.Reaction,
+		},
+	}
+
+	return m.One(query)
+}
+
+// Summaries returns the reaction counts of the message in the order of their
+// first usage, ReactedByMe is set for the reactions of the given account
+func (m *MessageReaction) Summaries(messageId, accountId int64) ([]ReactionSummary, error) {
+	if messageId == 0 {
+		return nil, ErrMessageIdIsNotSet
+	}
+
+	summaries := make([]ReactionSummary, 0)
+
+	sql := "SELECT reaction, count(*) AS count, bool_or(account_id = ?) AS reacted_by_me FROM " + m.BongoName() +
+		" WHERE message_id = ? GROUP BY reaction ORDER BY min(created_at)"
+
+	err := bongo.B.DB.Raw(sql, accountId, messageId).Scan(&summaries).Error
+
+	return summaries, err
+}
+
+// SummariesByMessageIds returns the reaction summaries of the given messages
+// with a single query, every message has an entry even if it has no reactions
+func (m *MessageReaction) SummariesByMessageIds(messageIds []int64, accountId int64) (map[int64][]ReactionSummary, error) {
+	summaries := make(map[int64][]ReactionSummary, len(messageIds))
+	for _, id := range messageIds {
+		summaries[id] = make([]ReactionSummary, 0)
+	}
+
+	if len(messageIds) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		MessageId   int64
+		Reaction    string
+		Count       int
+		ReactedByMe bool
+	}
+
+	sql := "SELECT message_id, reaction, count(*) AS count, bool_or(account_id = ?) AS reacted_by_me FROM " + m.BongoName() +
+		" WHERE message_id IN (?) GROUP BY message_id, reaction ORDER BY min(created_at)"
+
+	if err := bongo.B.DB.Raw(sql, accountId, messageIds).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summaries[row.MessageId] = append(summaries[row.MessageId], ReactionSummary{
+			Reaction:    row.Reaction,
+			Count:       row.Count,
+			ReactedByMe: row.ReactedByMe,
+		})
+	}
+
+	return summaries, nil
+}
+
+// DeleteByMessageId deletes the reactions to the message
+func (m *MessageReaction) DeleteByMessageId(messageId int64) error {
+	if messageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	sql := "DELETE FROM " + m.BongoName() + " WHERE message_id = ?"
+	return bongo.B.DB.Exec(sql, messageId).Error
+}
+
+// DeleteByAccountId deletes the reactions of the account
+func (m *MessageReaction) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + m.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
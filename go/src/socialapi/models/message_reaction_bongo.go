@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// NewMessageReaction creates a new MessageReaction item
+func NewMessageReaction() *MessageReaction {
+	return &MessageReaction{
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// GetId returns the id
+func (m MessageReaction) GetId() int64 {
+	return m.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (m MessageReaction) BongoName() string {
+	return "api.message_reaction"
+}
+
+// BeforeCreate validates the reaction
+func (m *MessageReaction) BeforeCreate() error {
+	return m.Validate()
+}
+
+// AfterCreate publishes the created event for the realtime updates
+func (m *MessageReaction) AfterCreate() {
+	bongo.B.AfterCreate(m)
+}
+
+// AfterDelete publishes the deleted event for the realtime updates
+func (m *MessageReaction) AfterDelete() {
+	bongo.B.AfterDelete(m)
+}
+
+// One fetches the item from db
+func (m *MessageReaction) One(q *bongo.Query) error {
+	return bongo.B.One(m, m, q)
+}
+
+// Some fetches items from db
+func (m *MessageReaction) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(m, data, q)
+}
+
+// Create inserts into db
+func (m *MessageReaction) Create() error {
+	return bongo.B.Create(m)
+}
+
+// Delete deletes the item from db
+func (m *MessageReaction) Delete() error {
+	return bongo.B.Delete(m)
+}
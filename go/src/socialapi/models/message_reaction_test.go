@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+func TestNormalizeReaction(t *testing.T) {
+	tests := []struct {
+		reaction string
+		want     string
+		err      error
+	}{
+		{"heart", "heart", nil},
+		{":+1:", "+1", nil},
+		{" :Heart_Eyes: ", "heart_eyes", nil},
+		{"-1", "-1", nil},
+		{"", "", ErrReactionIsNotSet},
+		{"::", "", ErrReactionIsNotSet},
+		{"heart eyes", "", ErrReactionIsNotValid},
+		{"<script>", "", ErrReactionIsNotValid},
+		{"a123456789a123456789a123456789a123456789a123456789a", "", ErrReactionIsNotValid},
+	}
+
+	for _, test := range tests {
+		got, err := NormalizeReaction(test.reaction)
+		if err != test.err {
+			t.Errorf("%q: got error %v, want %v", test.reaction, err, test.err)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", test.reaction, got, test.want)
+		}
+	}
+}
+
+func TestMessageReactionValidate(t *testing.T) {
+	mr := NewMessageReaction()
+	if err := mr.Validate(); err != ErrMessageIdIsNotSet {
+		t.Fatalf("got %v, want %v", err, ErrMessageIdIsNotSet)
+	}
+
+	mr.MessageId = 1
+	if err := mr.Validate(); err != ErrAccountIdIsNotSet {
+		t.Fatalf("got %v, want %v", err, ErrAccountIdIsNotSet)
+	}
+
+	mr.AccountId = 2
+	mr.Reaction = ":Tada:"
+	if err := mr.Validate(); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	if mr.Reaction != "tada" {
+		t.Fatalf("got %q, want tada", mr.Reaction)
+	}
+}
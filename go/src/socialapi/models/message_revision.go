@@ -0,0 +1,137 @@
+package models
+
+import (
+	"socialapi/request"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/koding/bongo"
+)
+
+// MessageRevision holds a previous version of an edited message, a revision
+// is recorded before every edit of the message
+type MessageRevision struct {
+	// Id unique identifier of the revision
+	Id int64 `json:"id,string"`
+
+	// MessageId is the id of the edited message
+	MessageId int64 `json:"messageId,string" sql:"NOT NULL"`
+
+	// AccountId is the editor of the message, it is not the creator of the
+	// message when it is edited by an admin
+	AccountId int64 `json:"accountId,string" sql:"NOT NULL"`
+
+	// Body of the message before the edit
+	Body string `json:"body"`
+
+	// Payload of the message before the edit
+	Payload gorm.Hstore `json:"payload"`
+
+	// CreatedAt is the time of the edit
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+}
+
+// Validate checks the required fields of the revision
+func (m *MessageRevision) Validate() error {
+	if m.MessageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	if m.AccountId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	return nil
+}
+
+// List lists the revisions of the message, recent edits come first
+func (m *MessageRevision) List(messageId int64, q *request.Query) ([]MessageRevision, error) {
+	if messageId == 0 {
+		return nil, ErrMessageIdIsNotSet
+	}
+
+	revisions := make([]MessageRevision, 0)
+
+	query := &bongo.Query{
+		Selector: map[string]interface{}{
+			"message_id": messageId,
+		},
+		Sort: map[string]string{
+			"created_at": "DESC",
+		},
+		Pagination: *bongo.NewPagination(q.Limit, q.Skip),
+	}
+
+	if err := m.Some(&revisions, query); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// FetchByMessageIds fetches the revisions of the given messages, the oldest
+// revisions come first
+func (m *MessageRevision) FetchByMessageIds(ids []int64) ([]MessageRevision, error) {
+	revisions := make([]MessageRevision, 0)
+	if len(ids) == 0 {
+		return revisions, nil
+	}
+
+	res := bongo.B.DB.
+		Table(m.BongoName()).
+		Where("message_id IN (?)", ids).
+		Order("created_at ASC").
+		Find(&revisions)
+
+	if err := bongo.CheckErr(res); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// Count counts the revisions of the message
+func (m *MessageRevision) Count(messageId int64) (int, error) {
+	if messageId == 0 {
+		return 0, ErrMessageIdIsNotSet
+	}
+
+	return bongo.B.Count(m, "message_id = ?", messageId)
+}
+
+// DeleteByMessageId deletes the revisions of the message
+func (m *MessageRevision) DeleteByMessageId(messageId int64) error {
+	if messageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	return bongo.B.DB.Exec("DELETE FROM "+m.BongoName()+" WHERE message_id = ?", messageId).Error
+}
+
+// hstoreEqual checks if the given hstores have the same keys and values, nil
+// values are equal to each other only
+func hstoreEqual(a, b gorm.Hstore) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok {
+			return false
+		}
+
+		if av == nil || bv == nil {
+			if av != bv {
+				return false
+			}
+			continue
+		}
+
+		if *av != *bv {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// NewMessageRevision creates a new MessageRevision item
+func NewMessageRevision() *MessageRevision {
+	return &MessageRevision{
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// GetId returns the id
+func (m MessageRevision) GetId() int64 {
+	return m.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (m MessageRevision) BongoName() string {
+	return "api.message_revision"
+}
+
+// BeforeCreate validates the revision
+func (m *MessageRevision) BeforeCreate() error {
+	return m.Validate()
+}
+
+// One fetches the item from db
+func (m *MessageRevision) One(q *bongo.Query) error {
+	return bongo.B.One(m, m, q)
+}
+
+// Some fetches items from db
+func (m *MessageRevision) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(m, data, q)
+}
+
+// Create inserts into db
+func (m *MessageRevision) Create() error {
+	return bongo.B.Create(m)
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+)
+
+func TestHstoreEqual(t *testing.T) {
+	s := func(v string) *string { return &v }
+
+	tests := []struct {
+		a, b gorm.Hstore
+		want bool
+	}{
+		{nil, nil, true},
+		{nil, gorm.Hstore{}, true},
+		{gorm.Hstore{"a": s("1")}, gorm.Hstore{"a": s("1")}, true},
+		{gorm.Hstore{"a": nil}, gorm.Hstore{"a": nil}, true},
+		{gorm.Hstore{"a": s("1")}, gorm.Hstore{"a": s("2")}, false},
+		{gorm.Hstore{"a": s("1")}, gorm.Hstore{"b": s("1")}, false},
+		{gorm.Hstore{"a": s("1")}, gorm.Hstore{"a": nil}, false},
+		{gorm.Hstore{"a": s("1")}, gorm.Hstore{"a": s("1"), "b": s("2")}, false},
+	}
+
+	for i, test := range tests {
+		if got := hstoreEqual(test.a, test.b); got != test.want {
+			t.Errorf("%d: got %t, want %t", i, got, test.want)
+		}
+	}
+}
@@ -76,6 +76,12 @@ func (a *PresenceDaily) DeleteByGroupName(groupName string) error {
 	return bongo.B.DB.Exec(sql, groupName).Error
 }
 
+// DeleteByAccountId deletes the items of the account
+func (a *PresenceDaily) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + a.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
+
 // FetchActiveAccounts fetches active acounts that are not processed yet
 func (a *PresenceDaily) FetchActiveAccounts(query *request.Query) (*ActiveAccountResponse, error) {
 	res := make([]accountRes, 0)
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/koding/bongo"
+	"github.com/lib/pq"
+)
+
+// Stack events that can be posted to the slack channel of the team
+const (
+	SlackEventStackBuilt  = "stack.built"
+	SlackEventStackFailed = "stack.failed"
+)
+
+// SlackEvents lists the events that can be posted to slack
+var SlackEvents = []string{
+	SlackEventStackBuilt,
+	SlackEventStackFailed,
+}
+
+var (
+	ErrSlackTeamNotFound      = errors.New("slack team not found")
+	ErrSlackTeamIdIsNotSet    = errors.New("slack team id is not set")
+	ErrSlackEventIsNotValid   = errors.New("slack event is not valid")
+	ErrSlackChannelIdIsNotSet = errors.New("slack channel id is not set")
+	ErrSlackTeamLinked        = errors.New("slack team is already linked to another team")
+)
+
+// SlackTeam links a slack team to a group, it also holds the channel
+// notification settings of the group
+type SlackTeam struct {
+	// Id unique identifier of the link
+	Id int64 `json:"id,string"`
+
+	// Name of the group
+	GroupName string `json:"groupName" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// TeamId is the id of the slack team
+	TeamId string `json:"teamId" sql:"NOT NULL;TYPE:VARCHAR(50);"`
+
+	// ChannelId is the slack channel the notifications are posted to
+	ChannelId string `json:"channelId" sql:"NOT NULL;TYPE:VARCHAR(50);"`
+
+	// Events holds the opted-in notifications, nothing is posted when
+	// it is empty
+	Events pq.StringArray `json:"events"`
+
+	// NotifierId is the account id of the admin who enabled the
+	// notifications, they are posted with the admin's slack token
+	NotifierId int64 `json:"notifierId,string"`
+
+	// Creation date of the link
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+
+	// Modification date of the link
+	UpdatedAt time.Time `json:"updatedAt" sql:"NOT NULL"`
+}
+
+// IsValidSlackEvent reports whether name is a known slack event
+func IsValidSlackEvent(name string) bool {
+	for _, e := range SlackEvents {
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the required fields of the link
+func (s *SlackTeam) Validate() error {
+	if s.GroupName == "" {
+		return ErrGroupNameIsNotSet
+	}
+
+	if s.TeamId == "" {
+		return ErrSlackTeamIdIsNotSet
+	}
+
+	for _, e := range s.Events {
+		if !IsValidSlackEvent(e) {
+			return ErrSlackEventIsNotValid
+		}
+	}
+
+	if len(s.Events) != 0 && s.ChannelId == "" {
+		return ErrSlackChannelIdIsNotSet
+	}
+
+	return nil
+}
+
+// Notifies reports whether the given event is posted to the channel
+func (s *SlackTeam) Notifies(event string) bool {
+	if s.ChannelId == "" || s.NotifierId == 0 {
+		return false
+	}
+
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ByGroupName fetches the slack team of the group
+func (s *SlackTeam) ByGroupName(groupName string) error {
+	err := s.One(&bongo.Query{
+		Selector: map[string]interface{}{
+			"group_name": groupName,
+		},
+	})
+	if err == bongo.RecordNotFound {
+		return ErrSlackTeamNotFound
+	}
+
+	return err
+}
+
+// ByTeamId fetches the group, which is linked to the slack team
+func (s *SlackTeam) ByTeamId(teamId string) error {
+	err := s.One(&bongo.Query{
+		Selector: map[string]interface{}{
+			"team_id": teamId,
+		},
+	})
+	if err == bongo.RecordNotFound {
+		return ErrSlackTeamNotFound
+	}
+
+	return err
+}
+
+// Link links the slack team to the group, the previous link of the group is
+// replaced. A slack team can be linked to a single group, ErrSlackTeamLinked
+// is returned when it is linked to another group.
+func (s *SlackTeam) Link(groupName, teamId string) error {
+	linked := NewSlackTeam()
+	err := linked.ByTeamId(teamId)
+	if err != nil && err != ErrSlackTeamNotFound {
+		return err
+	}
+
+	if err == nil && linked.GroupName != groupName {
+		return ErrSlackTeamLinked
+	}
+
+	err = s.ByGroupName(groupName)
+	if err == ErrSlackTeamNotFound {
+		s.GroupName = groupName
+		s.TeamId = teamId
+		return s.create()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if s.TeamId != teamId {
+		// notification channel belongs to the previous team
+		s.ChannelId = ""
+		s.Events = pq.StringArray{}
+	}
+
+	s.TeamId = teamId
+
+	return s.update()
+}
+
+// create inserts the link, the unique index of the team id guards against
+// the concurrent links of the slack team to different groups
+func (s *SlackTeam) create() error {
+	return linkError(s.Create())
+}
+
+func (s *SlackTeam) update() error {
+	return linkError(s.Update())
+}
+
+func linkError(err error) error {
+	if e, ok := err.(*pq.Error); ok && e.Constraint == "slack_team_team_id_key" {
+		return ErrSlackTeamLinked
+	}
+
+	return err
+}
+
+// DeleteByGroupName deletes the slack team of the group
+func (s *SlackTeam) DeleteByGroupName(groupName string) error {
+	sql := "DELETE FROM " + s.BongoName() + " WHERE group_name = ?"
+	return bongo.B.DB.Exec(sql, groupName).Error
+}
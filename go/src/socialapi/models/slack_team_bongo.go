@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+	"github.com/lib/pq"
+)
+
+// NewSlackTeam creates a new SlackTeam item
+func NewSlackTeam() *SlackTeam {
+	now := time.Now().UTC()
+
+	return &SlackTeam{
+		Events:    pq.StringArray{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// GetId returns the id
+func (s SlackTeam) GetId() int64 {
+	return s.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (s SlackTeam) BongoName() string {
+	return "slack.team"
+}
+
+// BeforeCreate validates the link
+func (s *SlackTeam) BeforeCreate() error {
+	return s.Validate()
+}
+
+// BeforeUpdate validates the link and updates its modification date
+func (s *SlackTeam) BeforeUpdate() error {
+	s.UpdatedAt = time.Now().UTC()
+	return s.Validate()
+}
+
+// One fetches the item from db
+func (s *SlackTeam) One(q *bongo.Query) error {
+	return bongo.B.One(s, s, q)
+}
+
+// Create inserts into db
+func (s *SlackTeam) Create() error {
+	return bongo.B.Create(s)
+}
+
+// Update updates the item in db
+func (s *SlackTeam) Update() error {
+	return bongo.B.Update(s)
+}
+
+// Delete deletes the item from db
+func (s *SlackTeam) Delete() error {
+	return bongo.B.Delete(s)
+}
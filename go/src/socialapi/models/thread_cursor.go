@@ -0,0 +1,193 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// ThreadCursor holds the last time a participant read the replies of a
+// message, unread reply counts of the threads are calculated from it
+type ThreadCursor struct {
+	// Id unique identifier of the cursor
+	Id int64 `json:"id,string"`
+
+	// MessageId is the id of the message which the replies belong to
+	MessageId int64 `json:"messageId,string" sql:"NOT NULL"`
+
+	// AccountId is the reader of the thread
+	AccountId int64 `json:"accountId,string" sql:"NOT NULL"`
+
+	// LastReadAt is the creation date of the latest read reply
+	LastReadAt time.Time `json:"lastReadAt" sql:"NOT NULL"`
+}
+
+// Validate checks the required fields of the cursor
+func (t *ThreadCursor) Validate() error {
+	if t.MessageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	if t.AccountId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	return nil
+}
+
+// ByMessageIdAndAccountId fetches the cursor of the account for the thread
+func (t *ThreadCursor) ByMessageIdAndAccountId(messageId, accountId int64) error {
+	if messageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	if accountId == 0 {
+		return ErrAccountIdIsNotSet
+	}
+
+	query := &bongo.Query{
+		Selector: map[string]interface{}{
+			"message_id": messageId,
+			"account_id": accountId,
+		},
+	}
+
+	return t.One(query)
+}
+
+// MarkRead moves the cursor of the account forward to the given time, the
+// cursor is created when it does not exist. It returns false when the cursor
+// is already at or after the given time
+func (t *ThreadCursor) MarkRead(at time.Time) (bool, error) {
+	if err := t.Validate(); err != nil {
+		return false, err
+	}
+
+	at = at.UTC()
+
+	cursor := NewThreadCursor()
+	err := cursor.ByMessageIdAndAccountId(t.MessageId, t.AccountId)
+	if err == bongo.RecordNotFound {
+		cursor.MessageId = t.MessageId
+		cursor.AccountId = t.AccountId
+		cursor.LastReadAt = at
+
+		err = cursor.Create()
+		if err == nil {
+			*t = *cursor
+			return true, nil
+		}
+
+		// cursor is created by a concurrent request, move it
+		if IsUniqueConstraintError(err) {
+			err = cursor.ByMessageIdAndAccountId(t.MessageId, t.AccountId)
+		}
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if !at.After(cursor.LastReadAt) {
+		*t = *cursor
+		return false, nil
+	}
+
+	cursor.LastReadAt = at
+	if err := cursor.Update(); err != nil {
+		return false, err
+	}
+
+	*t = *cursor
+
+	return true, nil
+}
+
+// UnreadCount counts the replies of the thread which are created after the
+// cursor, replies of the reader are not counted
+func (t *ThreadCursor) UnreadCount(showExempt bool) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	mr := NewMessageReply()
+	cm := NewChannelMessage()
+
+	sql := "SELECT count(*) AS count FROM " + mr.BongoName() + " mr " +
+		"JOIN " + cm.BongoName() + " cm ON cm.id = mr.reply_id " +
+		"WHERE mr.message_id = ? AND mr.created_at > ? AND cm.account_id <> ?"
+
+	if !showExempt {
+		sql += " AND mr.meta_bits = 0"
+	}
+
+	res := struct {
+		Count int
+	}{}
+
+	err := bongo.B.DB.Raw(
+		sql,
+		t.MessageId,
+		t.LastReadAt.UTC().Format(time.RFC3339Nano),
+		t.AccountId,
+	).Scan(&res).Error
+
+	return res.Count, err
+}
+
+// UnreadCounts counts the unread replies of the given threads for the account
+// with a single query, threads which do not have a cursor of the account are
+// not included
+func (t *ThreadCursor) UnreadCounts(messageIds []int64, accountId int64, showExempt bool) (map[int64]int, error) {
+	counts := make(map[int64]int)
+	if len(messageIds) == 0 {
+		return counts, nil
+	}
+
+	if accountId == 0 {
+		return nil, ErrAccountIdIsNotSet
+	}
+
+	mr := NewMessageReply()
+	cm := NewChannelMessage()
+
+	join := "LEFT JOIN " + mr.BongoName() + " mr ON mr.message_id = tc.message_id AND mr.created_at > tc.last_read_at"
+	if !showExempt {
+		join += " AND mr.meta_bits = 0"
+	}
+
+	sql := "SELECT tc.message_id, count(cm.id) AS count FROM " + t.BongoName() + " tc " + join + " " +
+		"LEFT JOIN " + cm.BongoName() + " cm ON cm.id = mr.reply_id AND cm.account_id <> tc.account_id " +
+		"WHERE tc.account_id = ? AND tc.message_id IN (?) GROUP BY tc.message_id"
+
+	var rows []struct {
+		MessageId int64
+		Count     int
+	}
+
+	if err := bongo.B.DB.Raw(sql, accountId, messageIds).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.MessageId] = row.Count
+	}
+
+	return counts, nil
+}
+
+// DeleteByMessageId deletes the cursors of the thread
+func (t *ThreadCursor) DeleteByMessageId(messageId int64) error {
+	if messageId == 0 {
+		return ErrMessageIdIsNotSet
+	}
+
+	sql := "DELETE FROM " + t.BongoName() + " WHERE message_id = ?"
+	return bongo.B.DB.Exec(sql, messageId).Error
+}
+
+// DeleteByAccountId deletes the cursors of the account
+func (t *ThreadCursor) DeleteByAccountId(accountId int64) error {
+	sql := "DELETE FROM " + t.BongoName() + " WHERE account_id = ?"
+	return bongo.B.DB.Exec(sql, accountId).Error
+}
@@ -0,0 +1,58 @@
+package models
+
+import "github.com/koding/bongo"
+
+// NewThreadCursor creates a new ThreadCursor item
+func NewThreadCursor() *ThreadCursor {
+	return &ThreadCursor{}
+}
+
+// GetId returns the id
+func (t ThreadCursor) GetId() int64 {
+	return t.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (t ThreadCursor) BongoName() string {
+	return "api.thread_cursor"
+}
+
+// BeforeCreate validates the cursor
+func (t *ThreadCursor) BeforeCreate() error {
+	return t.Validate()
+}
+
+// BeforeUpdate validates the cursor
+func (t *ThreadCursor) BeforeUpdate() error {
+	return t.Validate()
+}
+
+// AfterCreate publishes the created event for the realtime updates
+func (t *ThreadCursor) AfterCreate() {
+	bongo.B.AfterCreate(t)
+}
+
+// AfterUpdate publishes the updated event for the realtime updates
+func (t *ThreadCursor) AfterUpdate() {
+	bongo.B.AfterUpdate(t)
+}
+
+// One fetches the item from db
+func (t *ThreadCursor) One(q *bongo.Query) error {
+	return bongo.B.One(t, t, q)
+}
+
+// Some fetches items from db
+func (t *ThreadCursor) Some(data interface{}, q *bongo.Query) error {
+	return bongo.B.Some(t, data, q)
+}
+
+// Create inserts into db
+func (t *ThreadCursor) Create() error {
+	return bongo.B.Create(t)
+}
+
+// Update updates the item in db
+func (t *ThreadCursor) Update() error {
+	return bongo.B.Update(t)
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/koding/bongo"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrWebhookURLIsNotValid    = errors.New("webhook url is not valid")
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+)
+
+// WebhookEndpoint is an url registered by a team, which receives the
+// team events as signed HTTP requests
+type WebhookEndpoint struct {
+	// Id unique identifier of the endpoint
+	Id int64 `json:"id,string"`
+
+	// Name of the group
+	GroupName string `json:"groupName" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// URL receives the events
+	URL string `json:"url" sql:"NOT NULL;TYPE:VARCHAR(2000);"`
+
+	// Secret is used for signing the payloads
+	Secret string `json:"secret" sql:"NOT NULL;TYPE:VARCHAR(200);"`
+
+	// Events filters the delivered events, all of them are
+	// delivered when it is empty
+	Events pq.StringArray `json:"events"`
+
+	// IsActive is false when the deliveries are paused
+	IsActive bool `json:"isActive"`
+
+	// CreatorId is the account id of the admin who registered the endpoint
+	CreatorId int64 `json:"creatorId,string" sql:"NOT NULL"`
+
+	// Creation date of the endpoint
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+
+	// Modification date of the endpoint
+	UpdatedAt time.Time `json:"updatedAt" sql:"NOT NULL"`
+}
+
+// Validate checks the required fields of the endpoint
+func (w *WebhookEndpoint) Validate() error {
+	if w.GroupName == "" {
+		return ErrGroupNameIsNotSet
+	}
+
+	if w.CreatorId == 0 {
+		return ErrCreatorIdIsNotSet
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWebhookURLIsNotValid
+	}
+
+	return nil
+}
+
+// Accepts reports whether the endpoint is subscribed to the given event
+func (w *WebhookEndpoint) Accepts(event string) bool {
+	if !w.IsActive {
+		return false
+	}
+
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ByIdAndGroupName fetches the endpoint of the given group
+func (w *WebhookEndpoint) ByIdAndGroupName(id int64, groupName string) error {
+	err := w.One(&bongo.Query{
+		Selector: map[string]interface{}{
+			"id":         id,
+			"group_name": groupName,
+		},
+	})
+	if err == bongo.RecordNotFound {
+		return ErrWebhookEndpointNotFound
+	}
+
+	return err
+}
+
+// FetchByGroupName fetches all endpoints of the group
+func (w *WebhookEndpoint) FetchByGroupName(groupName string) ([]WebhookEndpoint, error) {
+	var endpoints []WebhookEndpoint
+
+	err := bongo.B.DB.
+		Table(w.BongoName()).
+		Where("group_name = ?", groupName).
+		Order("id ASC").
+		Find(&endpoints).Error
+	if err != nil && err != bongo.RecordNotFound {
+		return nil, err
+	}
+
+	return endpoints, nil
+}
+
+// FetchActiveByGroupName fetches the endpoints of the group that
+// receive the given event
+func (w *WebhookEndpoint) FetchActiveByGroupName(groupName, event string) ([]WebhookEndpoint, error) {
+	endpoints, err := w.FetchByGroupName(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	active := endpoints[:0]
+	for _, e := range endpoints {
+		if e.Accepts(event) {
+			active = append(active, e)
+		}
+	}
+
+	return active, nil
+}
+
+// DeleteByGroupName deletes endpoints of the group along with their
+// delivery logs
+func (w *WebhookEndpoint) DeleteByGroupName(groupName string) error {
+	d := &WebhookDelivery{}
+	sql := "DELETE FROM " + d.BongoName() + " WHERE endpoint_id IN (SELECT id FROM " + w.BongoName() + " WHERE group_name = ?)"
+	if err := bongo.B.DB.Exec(sql, groupName).Error; err != nil {
+		return err
+	}
+
+	sql = "DELETE FROM " + w.BongoName() + " WHERE group_name = ?"
+	return bongo.B.DB.Exec(sql, groupName).Error
+}
+
+// NewWebhookSecret generates a random signing secret
+func NewWebhookSecret() string {
+	p := make([]byte, 32)
+	rand.Read(p)
+
+	return hex.EncodeToString(p)
+}
+
+// WebhookDelivery is a log record of a single delivery attempt
+type WebhookDelivery struct {
+	// Id unique identifier of the delivery
+	Id int64 `json:"id,string"`
+
+	// EndpointId is the id of the receiving endpoint
+	EndpointId int64 `json:"endpointId,string" sql:"NOT NULL"`
+
+	// EventId is same for all attempts of an event
+	EventId string `json:"eventId" sql:"NOT NULL;TYPE:VARCHAR(100);"`
+
+	// Event is the name of the delivered event
+	Event string `json:"event" sql:"NOT NULL;TYPE:VARCHAR(100);"`
+
+	// Payload is the delivered request body
+	Payload string `json:"payload" sql:"NOT NULL;TYPE:TEXT;"`
+
+	// Attempt is the number of the attempt, starting from 1
+	Attempt int `json:"attempt" sql:"NOT NULL"`
+
+	// StatusCode of the response, 0 when the request has failed
+	StatusCode int `json:"statusCode"`
+
+	// Error describes the failure of the attempt
+	Error string `json:"error,omitempty"`
+
+	// IsDelivered is true when the endpoint responded with 2xx
+	IsDelivered bool `json:"isDelivered"`
+
+	// Duration of the request in milliseconds
+	Duration int64 `json:"duration"`
+
+	// Creation date of the record
+	CreatedAt time.Time `json:"createdAt" sql:"NOT NULL"`
+}
+
+// FetchByEndpointId fetches the latest deliveries of the endpoint
+func (d *WebhookDelivery) FetchByEndpointId(endpointId int64, limit, skip int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+
+	err := bongo.B.DB.
+		Table(d.BongoName()).
+		Where("endpoint_id = ?", endpointId).
+		Order("id DESC").
+		Limit(limit).
+		Offset(skip).
+		Find(&deliveries).Error
+	if err != nil && err != bongo.RecordNotFound {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// DeleteByEndpointId deletes the delivery logs of the endpoint
+func (d *WebhookDelivery) DeleteByEndpointId(endpointId int64) error {
+	sql := "DELETE FROM " + d.BongoName() + " WHERE endpoint_id = ?"
+	return bongo.B.DB.Exec(sql, endpointId).Error
+}
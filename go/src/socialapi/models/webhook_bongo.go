@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// NewWebhookEndpoint creates a new endpoint with a random secret
+func NewWebhookEndpoint() *WebhookEndpoint {
+	now := time.Now().UTC()
+
+	return &WebhookEndpoint{
+		Secret:    NewWebhookSecret(),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// GetId returns the id
+func (w WebhookEndpoint) GetId() int64 {
+	return w.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (w WebhookEndpoint) BongoName() string {
+	return "webhook.endpoint"
+}
+
+// BeforeCreate validates the endpoint
+func (w *WebhookEndpoint) BeforeCreate() error {
+	return w.Validate()
+}
+
+// BeforeUpdate validates the endpoint and updates its modification date
+func (w *WebhookEndpoint) BeforeUpdate() error {
+	w.UpdatedAt = time.Now().UTC()
+	return w.Validate()
+}
+
+// One fetches the item from db
+func (w *WebhookEndpoint) One(q *bongo.Query) error {
+	return bongo.B.One(w, w, q)
+}
+
+// ById fetches the item by its id
+func (w *WebhookEndpoint) ById(id int64) error {
+	return bongo.B.ById(w, id)
+}
+
+// Create inserts into db
+func (w *WebhookEndpoint) Create() error {
+	return bongo.B.Create(w)
+}
+
+// Update updates the item in db
+func (w *WebhookEndpoint) Update() error {
+	return bongo.B.Update(w)
+}
+
+// Delete deletes the item from db
+func (w *WebhookEndpoint) Delete() error {
+	return bongo.B.Delete(w)
+}
+
+// NewWebhookDelivery create new WebhookDelivery item
+func NewWebhookDelivery() *WebhookDelivery {
+	return &WebhookDelivery{
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// GetId returns the id
+func (d WebhookDelivery) GetId() int64 {
+	return d.Id
+}
+
+// BongoName returns the unique name for the bongo operations
+func (d WebhookDelivery) BongoName() string {
+	return "webhook.delivery"
+}
+
+// One fetches the item from db
+func (d *WebhookDelivery) One(q *bongo.Query) error {
+	return bongo.B.One(d, d, q)
+}
+
+// Create inserts into db
+func (d *WebhookDelivery) Create() error {
+	return bongo.B.Create(d)
+}
@@ -0,0 +1,104 @@
+// Package accountdata provides the export and the erasure jobs of the data
+// tied to an account, both of them cover the mongo and the postgres records.
+package accountdata
+
+import (
+	"errors"
+	mongomodels "koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"socialapi/models"
+
+	"github.com/koding/bongo"
+)
+
+// ErrTargetIsNotSet is returned when neither the username nor the account id
+// is given
+var ErrTargetIsNotSet = errors.New("username or account id is required")
+
+// Target holds the records that identify an account in both stores
+type Target struct {
+	User    *mongomodels.User
+	Account *mongomodels.Account
+
+	// SocialAccount is nil when the account is not synced to postgres
+	SocialAccount *models.Account
+}
+
+// Username returns the current username of the account
+func (t *Target) Username() string {
+	return t.User.Name
+}
+
+// SocialId returns the postgres id of the account, it is zero when the
+// account is not synced to postgres
+func (t *Target) SocialId() int64 {
+	if t.SocialAccount == nil {
+		return 0
+	}
+
+	return t.SocialAccount.Id
+}
+
+// Find fetches the target by the username or by the postgres account id
+func Find(username string, accountId int64) (*Target, error) {
+	switch {
+	case username != "":
+		return FindByUsername(username)
+	case accountId != 0:
+		return FindByAccountId(accountId)
+	default:
+		return nil, ErrTargetIsNotSet
+	}
+}
+
+// FindByUsername fetches the target by the username
+func FindByUsername(username string) (*Target, error) {
+	user, err := modelhelper.GetUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := modelhelper.GetAccount(username)
+	if err != nil {
+		return nil, err
+	}
+
+	socialAccount := models.NewAccount()
+	err = socialAccount.ByOldId(account.Id.Hex())
+	switch {
+	case err == bongo.RecordNotFound:
+		socialAccount = nil
+	case err != nil:
+		return nil, err
+	}
+
+	return &Target{
+		User:          user,
+		Account:       account,
+		SocialAccount: socialAccount,
+	}, nil
+}
+
+// FindByAccountId fetches the target by the postgres id of the account
+func FindByAccountId(accountId int64) (*Target, error) {
+	socialAccount, err := models.FetchAccountById(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := modelhelper.GetAccountById(socialAccount.OldId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := modelhelper.GetUser(account.Profile.Nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Target{
+		User:          user,
+		Account:       account,
+		SocialAccount: socialAccount,
+	}, nil
+}
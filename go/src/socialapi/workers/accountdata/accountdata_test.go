@@ -0,0 +1,290 @@
+package accountdata
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	mongomodels "koding/db/models"
+	"socialapi/models"
+	"strings"
+	"testing"
+
+	"github.com/koding/logging"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func newTestTarget() *Target {
+	user := &mongomodels.User{
+		ObjectId: bson.NewObjectId(),
+		Name:     "jane",
+		Email:    "jane@example.com",
+		Password: "secret-password",
+		Salt:     "secret-salt",
+	}
+	user.ForeignAuth.Slack = map[string]mongomodels.Slack{
+		"acme": {Token: "secret-token"},
+	}
+
+	account := &mongomodels.Account{Id: bson.NewObjectId()}
+	account.Profile.Nickname = "jane"
+
+	return &Target{
+		User:          user,
+		Account:       account,
+		SocialAccount: &models.Account{Id: 42, Nick: "jane"},
+	}
+}
+
+func readArchive(t *testing.T, r io.Reader) ([]string, map[string][]byte) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("gzip.NewReader()=%s", err)
+	}
+
+	var names []string
+	files := make(map[string][]byte)
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("Next()=%s", err)
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("ReadAll()=%s", err)
+		}
+
+		names = append(names, hdr.Name)
+		files[hdr.Name] = data
+	}
+
+	return names, files
+}
+
+func TestExportWrite(t *testing.T) {
+	target := newTestTarget()
+
+	e := NewExport(target)
+	e.Add("mongo/user.json", newUserData(target.User), 1)
+	e.Add("social/messages.json", []models.ChannelMessage{{Id: 1}, {Id: 2}}, 2)
+
+	var buf bytes.Buffer
+	if err := e.Write(&buf); err != nil {
+		t.Fatalf("Write()=%s", err)
+	}
+
+	names, files := readArchive(t, &buf)
+
+	want := []string{ManifestName, "mongo/user.json", "social/messages.json"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("got files %v, want %v", names, want)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(files[ManifestName], &m); err != nil {
+		t.Fatalf("Unmarshal()=%s", err)
+	}
+
+	if m.Version != ExportVersion || m.AccountId != 42 || m.OldId != target.Account.Id.Hex() {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+
+	if m.Files["social/messages.json"] != 2 {
+		t.Errorf("got %d messages, want 2", m.Files["social/messages.json"])
+	}
+
+	user := string(files["mongo/user.json"])
+	for _, secret := range []string{"secret-password", "secret-salt", "secret-token"} {
+		if strings.Contains(user, secret) {
+			t.Errorf("user data contains %q: %s", secret, user)
+		}
+	}
+
+	if !strings.Contains(user, `"slack"`) {
+		t.Errorf("user data does not contain the linked slack account: %s", user)
+	}
+}
+
+func newTestPlan(calls *[]string, failAt string) *Plan {
+	p := &Plan{}
+
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		p.add(StoreMongo, name, OpDelete, len(name), func() error {
+			*calls = append(*calls, name)
+			if name == failAt {
+				return errors.New("failed")
+			}
+
+			return nil
+		})
+	}
+
+	return p
+}
+
+func TestEraserRun(t *testing.T) {
+	log := logging.NewLogger("test")
+
+	var calls []string
+	audit := models.NewAccountErasure()
+
+	if err := NewEraser(log, "admin", false).Run(newTestPlan(&calls, ""), audit); err != nil {
+		t.Fatalf("Run()=%s", err)
+	}
+
+	if strings.Join(calls, ",") != "first,second,third" {
+		t.Errorf("got calls %v", calls)
+	}
+
+	if v := audit.Summary["mongo.second:delete"]; v == nil || *v != "6" {
+		t.Errorf("got summary %v, want 6 records of second", v)
+	}
+}
+
+func TestEraserDryRun(t *testing.T) {
+	log := logging.NewLogger("test")
+
+	var calls []string
+	audit := models.NewAccountErasure()
+
+	p := newTestPlan(&calls, "")
+	p.block("machine is running")
+
+	if err := NewEraser(log, "admin", true).Run(p, audit); err != nil {
+		t.Fatalf("Run()=%s", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("dry run called the actions %v", calls)
+	}
+
+	if len(audit.Summary) != 3 {
+		t.Errorf("got summary %v, want 3 keys", audit.Summary)
+	}
+}
+
+func TestEraserBlocked(t *testing.T) {
+	log := logging.NewLogger("test")
+
+	var calls []string
+	p := newTestPlan(&calls, "")
+	p.block("machine %s is %s", "m1", "Running")
+
+	err := NewEraser(log, "admin", false).Run(p, models.NewAccountErasure())
+	if err == nil || !strings.Contains(err.Error(), "machine m1 is Running") {
+		t.Fatalf("got error %v, want blocked", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("blocked erasure called the actions %v", calls)
+	}
+}
+
+func TestEraserFailure(t *testing.T) {
+	log := logging.NewLogger("test")
+
+	var calls []string
+	audit := models.NewAccountErasure()
+
+	err := NewEraser(log, "admin", false).Run(newTestPlan(&calls, "second"), audit)
+	if err == nil || !strings.HasPrefix(err.Error(), "mongo.second:delete") {
+		t.Fatalf("got error %v, want failure of second", err)
+	}
+
+	if strings.Join(calls, ",") != "first,second" {
+		t.Errorf("got calls %v", calls)
+	}
+
+	if _, ok := audit.Summary["mongo.second:delete"]; ok {
+		t.Errorf("failed action is counted: %v", audit.Summary)
+	}
+}
+
+func TestIsDestroyed(t *testing.T) {
+	cases := map[string]bool{
+		"NotInitialized": true,
+		"Terminated":     true,
+		"Running":        false,
+		"Stopped":        false,
+		"":               false,
+	}
+
+	for state, want := range cases {
+		m := &mongomodels.Machine{}
+		m.Status.State = state
+
+		if got := isDestroyed(m); got != want {
+			t.Errorf("%q: got %t, want %t", state, got, want)
+		}
+	}
+}
+
+func TestChannelOp(t *testing.T) {
+	cases := []struct {
+		typ    string
+		others int
+		want   string
+	}{
+		{models.Channel_TYPE_GROUP, 0, ""},
+		{models.Channel_TYPE_TOPIC, 0, ""},
+		{models.Channel_TYPE_ANNOUNCEMENT, 3, ""},
+		{models.Channel_TYPE_PRIVATE_MESSAGE, 0, OpDelete},
+		{models.Channel_TYPE_PRIVATE_MESSAGE, 1, OpAnonymize},
+		{models.Channel_TYPE_COLLABORATION, 2, OpAnonymize},
+		{models.Channel_TYPE_PINNED_ACTIVITY, 0, OpDelete},
+		{models.Channel_TYPE_FOLLOWERS, 5, OpDelete},
+	}
+
+	for _, cas := range cases {
+		c := &models.Channel{TypeConstant: cas.typ}
+
+		if got := channelOp(c, cas.others); got != cas.want {
+			t.Errorf("%s (others=%d): got %q, want %q", cas.typ, cas.others, got, cas.want)
+		}
+	}
+}
+
+func TestTemplateOp(t *testing.T) {
+	cases := map[string]string{
+		mongomodels.AccessPrivate: OpDelete,
+		"":                        OpDelete,
+		mongomodels.AccessGroup:   "",
+		mongomodels.AccessPublic:  "",
+	}
+
+	for level, want := range cases {
+		tmpl := &mongomodels.StackTemplate{AccessLevel: level}
+
+		if got := templateOp(tmpl); got != want {
+			t.Errorf("%q: got %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestAnonymousUsername(t *testing.T) {
+	username := anonymousUsername()
+
+	if !strings.HasPrefix(username, "guest-") || !strings.HasSuffix(username, "-rm") {
+		t.Errorf("unexpected username %q", username)
+	}
+
+	// nicks of the social accounts are limited to 25 characters
+	if len(username) > 25 {
+		t.Errorf("username %q is longer than 25 characters", username)
+	}
+
+	if username == anonymousUsername() {
+		t.Errorf("usernames are not random")
+	}
+}
@@ -0,0 +1,611 @@
+package accountdata
+
+import (
+	"errors"
+	"fmt"
+	mongomodels "koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"koding/kites/kloud/machinestate"
+	"koding/tools/utils"
+	"socialapi/models"
+	"strings"
+
+	"github.com/koding/logging"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Stores of the erased records
+const (
+	StoreMongo    = "mongo"
+	StorePostgres = "postgres"
+)
+
+// Operations of the erasure actions
+const (
+	// OpDelete removes the records
+	OpDelete = "delete"
+
+	// OpAnonymize keeps the records but removes the personal data in them,
+	// they are kept when other records depend on them
+	OpAnonymize = "anonymize"
+
+	// OpDetach removes the account from the records of the other accounts
+	OpDetach = "detach"
+)
+
+// ErrErasureBlocked is returned when the account has resources which should
+// be destroyed before its data is erased
+var ErrErasureBlocked = errors.New("account can not be erased yet")
+
+// Action is a step of the erasure
+type Action struct {
+	Store string
+	Name  string
+	Op    string
+
+	// Count is the number of the records the action changes
+	Count int
+
+	do func() error
+}
+
+// Key returns the summary key of the action
+func (a *Action) Key() string {
+	return a.Store + "." + a.Name + ":" + a.Op
+}
+
+// Plan holds the actions of an erasure in their order, records which are
+// depended on are changed last, so a failed erasure can be run again
+type Plan struct {
+	Actions []*Action
+
+	// Blockers lists the reasons the account can not be erased yet
+	Blockers []string
+}
+
+func (p *Plan) add(store, name, op string, count int, do func() error) {
+	p.Actions = append(p.Actions, &Action{
+		Store: store,
+		Name:  name,
+		Op:    op,
+		Count: count,
+		do:    do,
+	})
+}
+
+func (p *Plan) block(format string, args ...interface{}) {
+	p.Blockers = append(p.Blockers, fmt.Sprintf(format, args...))
+}
+
+// Eraser deletes or anonymizes the data tied to an account
+type Eraser struct {
+	// DryRun only counts the records, nothing but the audit record is
+	// written
+	DryRun bool
+
+	// RequestedBy is the operator, who is recorded to the audit record
+	RequestedBy string
+
+	log logging.Logger
+}
+
+// NewEraser creates an eraser
+func NewEraser(log logging.Logger, requestedBy string, dryRun bool) *Eraser {
+	return &Eraser{
+		DryRun:      dryRun,
+		RequestedBy: requestedBy,
+		log:         log,
+	}
+}
+
+// Erase erases the data of the target, every erasure is recorded to the audit
+// records, including the dry runs and the failed ones
+func (e *Eraser) Erase(t *Target) (*models.AccountErasure, *Plan, error) {
+	audit := models.NewAccountErasure()
+	audit.AccountId = t.SocialId()
+	audit.OldId = t.Account.Id.Hex()
+	audit.RequestedBy = e.RequestedBy
+	audit.DryRun = e.DryRun
+
+	if err := audit.Create(); err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := NewPlan(t)
+	if err == nil {
+		err = e.Run(plan, audit)
+	}
+
+	if ferr := audit.Finish(err); ferr != nil {
+		e.log.Error("could not finish the audit record %d: %s", audit.Id, ferr)
+	}
+
+	return audit, plan, err
+}
+
+// Run runs the actions of the plan and counts them in the audit record, the
+// actions are only counted in dry runs
+func (e *Eraser) Run(p *Plan, audit *models.AccountErasure) error {
+	if len(p.Blockers) != 0 && !e.DryRun {
+		return fmt.Errorf("%s: %s", ErrErasureBlocked, strings.Join(p.Blockers, ", "))
+	}
+
+	for _, a := range p.Actions {
+		if !e.DryRun {
+			if err := a.do(); err != nil {
+				return fmt.Errorf("%s: %s", a.Key(), err)
+			}
+
+			e.log.Info("%s %d records of %s.%s", a.Op, a.Count, a.Store, a.Name)
+		}
+
+		audit.Count(a.Key(), a.Count)
+	}
+
+	return nil
+}
+
+// NewPlan fetches the records of the target and creates the erasure plan,
+// it does not change any record
+func NewPlan(t *Target) (*Plan, error) {
+	p := &Plan{}
+
+	if t.SocialAccount != nil {
+		if err := planPostgres(p, t); err != nil {
+			return nil, err
+		}
+	}
+
+	username := anonymousUsername()
+
+	if err := planMongo(p, t, username); err != nil {
+		return nil, err
+	}
+
+	planAnonymize(p, t, username)
+
+	return p, nil
+}
+
+func planPostgres(p *Plan, t *Target) error {
+	accountId := t.SocialId()
+
+	var messages []models.ChannelMessage
+	if err := models.NewChannelMessage().Some(&messages, byAccountId(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.ChannelMessage{}.BongoName(), OpDelete, len(messages), func() error {
+		return deleteMessages(messages)
+	})
+
+	var reactions []models.MessageReaction
+	if err := models.NewMessageReaction().Some(&reactions, byAccountId(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.MessageReaction{}.BongoName(), OpDelete, len(reactions), func() error {
+		return models.NewMessageReaction().DeleteByAccountId(accountId)
+	})
+
+	var cursors []models.ThreadCursor
+	if err := models.NewThreadCursor().Some(&cursors, sortedByAccountId(accountId, "last_read_at")); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.ThreadCursor{}.BongoName(), OpDelete, len(cursors), func() error {
+		return models.NewThreadCursor().DeleteByAccountId(accountId)
+	})
+
+	var interactions []models.Interaction
+	if err := models.NewInteraction().Some(&interactions, byAccountId(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.Interaction{}.BongoName(), OpDelete, len(interactions), func() error {
+		return models.NewInteraction().DeleteByAccountId(accountId)
+	})
+
+	if err := planChannels(p, accountId); err != nil {
+		return err
+	}
+
+	participantCount, err := models.NewChannelParticipant().Count("account_id = ?", accountId)
+	if err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.ChannelParticipant{}.BongoName(), OpDelete, participantCount, func() error {
+		return models.NewChannelParticipant().DeleteByAccountId(accountId)
+	})
+
+	var presences []models.PresenceDaily
+	if err := models.NewPresenceDaily().Some(&presences, byAccountId(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.PresenceDaily{}.BongoName(), OpDelete, len(presences), func() error {
+		return models.NewPresenceDaily().DeleteByAccountId(accountId)
+	})
+
+	var activities []models.MemberDaily
+	if err := models.NewMemberDaily().Some(&activities, byDay(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.MemberDaily{}.BongoName(), OpDelete, len(activities), func() error {
+		return models.NewMemberDaily().DeleteByAccountId(accountId)
+	})
+
+	var machineDays []models.MachineDaily
+	if err := models.NewMachineDaily().Some(&machineDays, byDay(accountId)); err != nil {
+		return err
+	}
+
+	p.add(StorePostgres, models.MachineDaily{}.BongoName(), OpDelete, len(machineDays), func() error {
+		return models.NewMachineDaily().DeleteByAccountId(accountId)
+	})
+
+	return nil
+}
+
+// planChannels deletes the private channels created by the account, the ones
+// which other accounts take part in are anonymized instead
+func planChannels(p *Plan, accountId int64) error {
+	var channels []models.Channel
+	if err := models.NewChannel().Some(&channels, byCreatorId(accountId)); err != nil {
+		return err
+	}
+
+	var deleted, anonymized []models.Channel
+	for _, c := range channels {
+		others, err := models.NewChannelParticipant().Count(
+			"channel_id = ? AND account_id <> ? AND status_constant = ?",
+			c.Id, accountId, models.ChannelParticipant_STATUS_ACTIVE,
+		)
+		if err != nil {
+			return err
+		}
+
+		switch channelOp(&c, others) {
+		case OpDelete:
+			deleted = append(deleted, c)
+		case OpAnonymize:
+			anonymized = append(anonymized, c)
+		}
+	}
+
+	p.add(StorePostgres, models.Channel{}.BongoName(), OpDelete, len(deleted), func() error {
+		for i := range deleted {
+			if err := deleted[i].Delete(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	p.add(StorePostgres, models.Channel{}.BongoName(), OpAnonymize, len(anonymized), func() error {
+		for i := range anonymized {
+			c := &anonymized[i]
+			c.Purpose = ""
+			c.Payload = nil
+
+			if err := c.Update(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+// channelOp returns the erasure operation of a channel created by the
+// account. Private channels are kept only when other accounts still take
+// part in them, and their purpose written by the account is removed. Team
+// wide channels belong to the team, they are kept as they are and refer to
+// the anonymized account, empty operation is returned for them.
+func channelOp(c *models.Channel, others int) string {
+	switch c.TypeConstant {
+	case models.Channel_TYPE_GROUP,
+		models.Channel_TYPE_ANNOUNCEMENT,
+		models.Channel_TYPE_TOPIC,
+		models.Channel_TYPE_LINKED_TOPIC:
+		return ""
+	case models.Channel_TYPE_PRIVATE_MESSAGE,
+		models.Channel_TYPE_COLLABORATION:
+		if others > 0 {
+			return OpAnonymize
+		}
+	}
+
+	return OpDelete
+}
+
+// deleteMessages deletes the messages with their dependencies. Replies are
+// deleted first, since deleting a post deletes its replies too.
+func deleteMessages(messages []models.ChannelMessage) error {
+	for _, replies := range []bool{true, false} {
+		for i := range messages {
+			m := &messages[i]
+			if (m.TypeConstant == models.ChannelMessage_TYPE_REPLY) != replies {
+				continue
+			}
+
+			if err := m.DeleteMessageAndDependencies(!replies); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func planMongo(p *Plan, t *Target, username string) error {
+	machines, err := modelhelper.GetParticipatedMachinesByUsername(t.Username())
+	if err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+
+	var owned, shared []*mongomodels.Machine
+	for _, m := range machines {
+		if isOwner(m, t.User.ObjectId) {
+			owned = append(owned, m)
+		} else {
+			shared = append(shared, m)
+		}
+	}
+
+	for _, m := range owned {
+		if !isDestroyed(m) {
+			p.block("machine %s is %s", m.ObjectId.Hex(), m.Status.State)
+		}
+	}
+
+	p.add(StoreMongo, modelhelper.MachinesColl, OpDelete, len(owned), func() error {
+		for _, m := range owned {
+			if err := modelhelper.DeleteMachine(m.ObjectId); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	p.add(StoreMongo, modelhelper.MachinesColl, OpDetach, len(shared), func() error {
+		for _, m := range shared {
+			err := modelhelper.RemoveUsersFromMachineByIds(m.Uid, []bson.ObjectId{t.User.ObjectId})
+			if err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	workspaces, err := modelhelper.GetWorkspaces(t.Account.Id)
+	if err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+
+	p.add(StoreMongo, modelhelper.WorkspaceColl, OpDelete, len(workspaces), func() error {
+		for _, w := range workspaces {
+			if err := modelhelper.RemoveWorkspace(w.ObjectId); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	stacks, err := modelhelper.GetComputeStacksByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	p.add(StoreMongo, modelhelper.ComputeStackColl, OpDelete, len(stacks), func() error {
+		for _, s := range stacks {
+			if err := modelhelper.DeleteComputeStack(s.Id.Hex()); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err := planStackTemplates(p, t, username); err != nil {
+		return err
+	}
+
+	creds, err := modelhelper.GetCredentialsByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	identifiers := make([]string, 0, len(creds))
+	for _, c := range creds {
+		identifiers = append(identifiers, c.Identifier)
+	}
+
+	p.add(StoreMongo, modelhelper.CredentialsColl, OpDelete, len(creds), func() error {
+		if len(identifiers) == 0 {
+			return nil
+		}
+
+		return modelhelper.RemoveCredentials(identifiers...)
+	})
+
+	memberships := modelhelper.Selector{
+		"targetId":   t.Account.Id,
+		"sourceName": "JGroup",
+	}
+
+	membershipCount, err := modelhelper.RelationshipCount(memberships)
+	if err != nil {
+		return err
+	}
+
+	p.add(StoreMongo, modelhelper.RelationshipColl, OpDelete, membershipCount, func() error {
+		return modelhelper.DeleteRelationships(memberships)
+	})
+
+	sessions, err := modelhelper.GetSessionsByUsername(t.Username())
+	if err != nil {
+		return err
+	}
+
+	p.add(StoreMongo, modelhelper.SessionColl, OpDelete, len(sessions), func() error {
+		_, err := modelhelper.RemoveSessionsByUsername(t.Username())
+		return err
+	})
+
+	return nil
+}
+
+// planStackTemplates deletes the private stack templates created by the
+// account with all their revisions. The revisions the account made to the
+// templates which are kept are anonymized, they are written with the
+// username of the account.
+func planStackTemplates(p *Plan, t *Target, username string) error {
+	tmpls, err := modelhelper.GetStackTemplatesByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	var deleted []*mongomodels.StackTemplate
+	var deletedRevisions int
+	isDeleted := make(map[bson.ObjectId]bool)
+
+	for _, tmpl := range tmpls {
+		if templateOp(tmpl) != OpDelete {
+			continue
+		}
+
+		revisions, err := modelhelper.GetStackTemplateRevisions(tmpl.Id.Hex())
+		if err != nil {
+			return err
+		}
+
+		deleted = append(deleted, tmpl)
+		deletedRevisions += len(revisions)
+		isDeleted[tmpl.Id] = true
+	}
+
+	authored, err := modelhelper.GetStackTemplateRevisionsByAuthor(t.Username())
+	if err != nil {
+		return err
+	}
+
+	var anonymized int
+	for _, r := range authored {
+		if !isDeleted[r.TemplateID] {
+			anonymized++
+		}
+	}
+
+	p.add(StoreMongo, modelhelper.StackTemplateRevisionColl, OpDelete, deletedRevisions, func() error {
+		for _, tmpl := range deleted {
+			if err := modelhelper.DeleteStackTemplateRevisions(tmpl.Id.Hex()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	p.add(StoreMongo, modelhelper.StackTemplateRevisionColl, OpAnonymize, anonymized, func() error {
+		return modelhelper.SetStackTemplateRevisionsAuthor(t.Username(), username)
+	})
+
+	p.add(StoreMongo, modelhelper.StackTemplateColl, OpDelete, len(deleted), func() error {
+		for _, tmpl := range deleted {
+			if err := modelhelper.DeleteStackTemplate(tmpl.Id.Hex()); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+// templateOp returns the erasure operation of a stack template created by
+// the account. Templates shared with the team or public ones are used by the
+// other accounts, they are kept and refer to the anonymized account, empty
+// operation is returned for them.
+func templateOp(tmpl *mongomodels.StackTemplate) string {
+	switch tmpl.AccessLevel {
+	case mongomodels.AccessGroup, mongomodels.AccessPublic:
+		return ""
+	}
+
+	return OpDelete
+}
+
+// planAnonymize anonymizes the accounts and the user with the given username,
+// they are kept for the records which still refer to them, like the replies
+// of the other accounts
+func planAnonymize(p *Plan, t *Target, username string) {
+	if t.SocialAccount != nil {
+		p.add(StorePostgres, models.Account{}.BongoName(), OpAnonymize, 1, func() error {
+			t.SocialAccount.Nick = username
+			t.SocialAccount.Settings = nil
+			return t.SocialAccount.Update()
+		})
+	}
+
+	p.add(StoreMongo, modelhelper.AccountsColl, OpAnonymize, 1, func() error {
+		return modelhelper.AnonymizeAccount(t.Account.Id, username)
+	})
+
+	p.add(StoreMongo, "jNames", OpDelete, 1, func() error {
+		name, err := modelhelper.GetNameBySlug(t.Username())
+		if err == mgo.ErrNotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return modelhelper.RemoveName(name.ID)
+	})
+
+	// the user is anonymized last, the username is used to find the target
+	// when a failed erasure is run again
+	p.add(StoreMongo, modelhelper.UserColl, OpAnonymize, 1, func() error {
+		return modelhelper.AnonymizeUser(t.Username(), username)
+	})
+}
+
+// anonymousUsername creates a username like the ones of JUser.unregister,
+// -rm suffix separates them from the real guests
+func anonymousUsername() string {
+	return "guest-" + utils.StringN(12) + "-rm"
+}
+
+func isOwner(m *mongomodels.Machine, userId bson.ObjectId) bool {
+	for _, u := range m.Users {
+		if u.Id == userId && u.Owner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDestroyed reports whether the machine has no resources left in its
+// provider, machines are destroyed by kloud and erasing them before that
+// would leak the resources
+func isDestroyed(m *mongomodels.Machine) bool {
+	switch machinestate.States[m.Status.State] {
+	case machinestate.NotInitialized, machinestate.Terminated:
+		return true
+	default:
+		return false
+	}
+}
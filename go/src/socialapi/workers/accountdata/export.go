@@ -0,0 +1,464 @@
+package accountdata
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	mongomodels "koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"socialapi/models"
+	"time"
+
+	"github.com/koding/bongo"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ExportVersion is the version of the archive layout, it is increased when
+// the files change in an incompatible way
+const ExportVersion = 1
+
+// ManifestName is the name of the manifest in the archive
+const ManifestName = "manifest.json"
+
+// excluded lists the data that is never exported, the secrets are only
+// usable by koding and exporting them would leak them to the archive
+var excluded = []string{
+	"password and salt of the user",
+	"two factor authentication key",
+	"oauth tokens of the foreign accounts",
+	"data of the credentials",
+	"session tokens",
+}
+
+// Manifest describes the archive
+type Manifest struct {
+	Version   int       `json:"version"`
+	Username  string    `json:"username"`
+	OldId     string    `json:"oldId"`
+	AccountId int64     `json:"accountId,string"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Files holds the number of the records by the file names
+	Files map[string]int `json:"files"`
+
+	// Excluded lists the data which is intentionally left out
+	Excluded []string `json:"excluded"`
+}
+
+// Export is the structured archive of the data tied to an account
+type Export struct {
+	Manifest *Manifest
+
+	files []*exportFile
+}
+
+type exportFile struct {
+	name string
+	data interface{}
+}
+
+type userData struct {
+	Id             bson.ObjectId               `json:"id"`
+	Username       string                      `json:"username"`
+	Email          string                      `json:"email"`
+	Status         string                      `json:"status"`
+	RegisteredAt   time.Time                   `json:"registeredAt"`
+	LastLoginDate  time.Time                   `json:"lastLoginDate"`
+	Shell          string                      `json:"shell"`
+	SshKeys        []sshKey                    `json:"sshKeys"`
+	ForeignAuth    []string                    `json:"foreignAuth"`
+	EmailFrequency *mongomodels.EmailFrequency `json:"emailFrequency"`
+}
+
+type sshKey struct {
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+type machineData struct {
+	Id        bson.ObjectId `json:"id"`
+	Uid       string        `json:"uid"`
+	Label     string        `json:"label"`
+	Provider  string        `json:"provider"`
+	Domain    string        `json:"domain"`
+	IpAddress string        `json:"ipAddress"`
+	State     string        `json:"state"`
+	Owner     bool          `json:"owner"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type credentialData struct {
+	Identifier  string    `json:"identifier"`
+	Provider    string    `json:"provider"`
+	Title       string    `json:"title"`
+	Verified    bool      `json:"verified"`
+	AccessLevel string    `json:"accessLevel"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type stackData struct {
+	Id          bson.ObjectId   `json:"id"`
+	Group       string          `json:"group"`
+	BaseStackId bson.ObjectId   `json:"baseStackId"`
+	Machines    []bson.ObjectId `json:"machines"`
+	State       string          `json:"state"`
+}
+
+type stackTemplateData struct {
+	Id          bson.ObjectId `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Group       string        `json:"group"`
+	AccessLevel string        `json:"accessLevel"`
+	Content     string        `json:"content"`
+	RawContent  string        `json:"rawContent"`
+}
+
+// NewExport creates an empty export of the target
+func NewExport(t *Target) *Export {
+	return &Export{
+		Manifest: &Manifest{
+			Version:   ExportVersion,
+			Username:  t.Username(),
+			OldId:     t.Account.Id.Hex(),
+			AccountId: t.SocialId(),
+			CreatedAt: time.Now().UTC(),
+			Files:     make(map[string]int),
+			Excluded:  excluded,
+		},
+	}
+}
+
+// Add adds a file to the export, count is the number of the records in it
+func (e *Export) Add(name string, data interface{}, count int) {
+	e.files = append(e.files, &exportFile{name: name, data: data})
+	e.Manifest.Files[name] = count
+}
+
+// Collect fetches all the data of the target into an export
+func Collect(t *Target) (*Export, error) {
+	e := NewExport(t)
+
+	if err := collectMongo(e, t); err != nil {
+		return nil, err
+	}
+
+	if t.SocialAccount == nil {
+		return e, nil
+	}
+
+	if err := collectPostgres(e, t); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func collectMongo(e *Export, t *Target) error {
+	e.Add("mongo/user.json", newUserData(t.User), 1)
+	e.Add("mongo/account.json", t.Account, 1)
+
+	machines, err := modelhelper.GetParticipatedMachinesByUsername(t.Username())
+	if err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+
+	md := make([]*machineData, 0, len(machines))
+	for _, m := range machines {
+		md = append(md, newMachineData(m, t.User.ObjectId))
+	}
+	e.Add("mongo/machines.json", md, len(md))
+
+	creds, err := modelhelper.GetCredentialsByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	cd := make([]*credentialData, 0, len(creds))
+	for _, c := range creds {
+		cd = append(cd, newCredentialData(c))
+	}
+	e.Add("mongo/credentials.json", cd, len(cd))
+
+	workspaces, err := modelhelper.GetWorkspaces(t.Account.Id)
+	if err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+	e.Add("mongo/workspaces.json", workspaces, len(workspaces))
+
+	stacks, err := modelhelper.GetComputeStacksByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	sd := make([]*stackData, 0, len(stacks))
+	for _, s := range stacks {
+		sd = append(sd, &stackData{
+			Id:          s.Id,
+			Group:       s.Group,
+			BaseStackId: s.BaseStackId,
+			Machines:    s.Machines,
+			State:       s.Status.State,
+		})
+	}
+	e.Add("mongo/stacks.json", sd, len(sd))
+
+	tmpls, err := modelhelper.GetStackTemplatesByOriginID(t.Account.Id)
+	if err != nil {
+		return err
+	}
+
+	td := make([]*stackTemplateData, 0, len(tmpls))
+	for _, tmpl := range tmpls {
+		td = append(td, newStackTemplateData(tmpl))
+	}
+	e.Add("mongo/stacktemplates.json", td, len(td))
+
+	// revisions are exported by their author, the account may have changed
+	// the templates of the other accounts of its teams
+	revisions, err := modelhelper.GetStackTemplateRevisionsByAuthor(t.Username())
+	if err != nil {
+		return err
+	}
+	e.Add("mongo/stacktemplaterevisions.json", revisions, len(revisions))
+
+	return nil
+}
+
+func collectPostgres(e *Export, t *Target) error {
+	accountId := t.SocialId()
+
+	e.Add("social/account.json", t.SocialAccount, 1)
+
+	var messages []models.ChannelMessage
+	if err := models.NewChannelMessage().Some(&messages, byAccountId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/messages.json", messages, len(messages))
+
+	messageIds := make([]int64, len(messages))
+	for i, m := range messages {
+		messageIds[i] = m.Id
+	}
+
+	revisions, err := models.NewMessageRevision().FetchByMessageIds(messageIds)
+	if err != nil {
+		return err
+	}
+	e.Add("social/revisions.json", revisions, len(revisions))
+
+	var reactions []models.MessageReaction
+	if err := models.NewMessageReaction().Some(&reactions, byAccountId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/reactions.json", reactions, len(reactions))
+
+	var cursors []models.ThreadCursor
+	if err := models.NewThreadCursor().Some(&cursors, sortedByAccountId(accountId, "last_read_at")); err != nil {
+		return err
+	}
+	e.Add("social/threadcursors.json", cursors, len(cursors))
+
+	var interactions []models.Interaction
+	if err := models.NewInteraction().Some(&interactions, byAccountId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/interactions.json", interactions, len(interactions))
+
+	var channels []models.Channel
+	if err := models.NewChannel().Some(&channels, byCreatorId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/channels.json", channels, len(channels))
+
+	var participants []models.ChannelParticipant
+	if err := models.NewChannelParticipant().Some(&participants, byAccountId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/participations.json", participants, len(participants))
+
+	var presences []models.PresenceDaily
+	if err := models.NewPresenceDaily().Some(&presences, byAccountId(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/presence.json", presences, len(presences))
+
+	var activities []models.MemberDaily
+	if err := models.NewMemberDaily().Some(&activities, byDay(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/activity.json", activities, len(activities))
+
+	var machineDays []models.MachineDaily
+	if err := models.NewMachineDaily().Some(&machineDays, byDay(accountId)); err != nil {
+		return err
+	}
+	e.Add("social/machineactivity.json", machineDays, len(machineDays))
+
+	return nil
+}
+
+// byAccountId creates a query for the records of the account, the oldest
+// records come first
+func byAccountId(accountId int64) *bongo.Query {
+	return sortedByAccountId(accountId, "created_at")
+}
+
+// byCreatorId creates a query for the channels created by the account, the
+// oldest channels come first
+func byCreatorId(accountId int64) *bongo.Query {
+	return &bongo.Query{
+		Selector: map[string]interface{}{
+			"creator_id": accountId,
+		},
+		Sort: map[string]string{
+			"created_at": "ASC",
+		},
+	}
+}
+
+// byDay creates a query for the daily rollups of the account, the oldest
+// days come first
+func byDay(accountId int64) *bongo.Query {
+	return sortedByAccountId(accountId, "day")
+}
+
+// sortedByAccountId creates a query for the records of the account sorted by
+// the given field in ascending order
+func sortedByAccountId(accountId int64, field string) *bongo.Query {
+	return &bongo.Query{
+		Selector: map[string]interface{}{
+			"account_id": accountId,
+		},
+		Sort: map[string]string{
+			field: "ASC",
+		},
+	}
+}
+
+// Write writes the export as a gzipped tar archive, the manifest is the first
+// file of the archive
+func (e *Export) Write(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	if err := writeJSON(tw, ManifestName, e.Manifest, e.Manifest.CreatedAt); err != nil {
+		return err
+	}
+
+	for _, f := range e.files {
+		if err := writeJSON(tw, f.name, f.data, e.Manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func writeJSON(tw *tar.Writer, name string, v interface{}, modTime time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err = tw.Write(data)
+	return err
+}
+
+func newUserData(u *mongomodels.User) *userData {
+	ud := &userData{
+		Id:             u.ObjectId,
+		Username:       u.Name,
+		Email:          u.Email,
+		Status:         string(u.Status),
+		RegisteredAt:   u.RegisteredAt,
+		LastLoginDate:  u.LastLoginDate,
+		Shell:          u.Shell,
+		SshKeys:        make([]sshKey, 0, len(u.SshKeys)),
+		ForeignAuth:    make([]string, 0),
+		EmailFrequency: u.EmailFrequency,
+	}
+
+	for _, key := range u.SshKeys {
+		ud.SshKeys = append(ud.SshKeys, sshKey{Title: key.Title, Key: key.Key})
+	}
+
+	// only the names of the linked accounts are exported, not their tokens
+	if u.ForeignAuth.Github.Token != "" {
+		ud.ForeignAuth = append(ud.ForeignAuth, "github")
+	}
+
+	if len(u.ForeignAuth.Slack) != 0 {
+		ud.ForeignAuth = append(ud.ForeignAuth, "slack")
+	}
+
+	return ud
+}
+
+func newMachineData(m *mongomodels.Machine, userId bson.ObjectId) *machineData {
+	md := &machineData{
+		Id:        m.ObjectId,
+		Uid:       m.Uid,
+		Label:     m.Label,
+		Provider:  m.Provider,
+		Domain:    m.Domain,
+		IpAddress: m.IpAddress,
+		State:     m.Status.State,
+		CreatedAt: m.CreatedAt,
+	}
+
+	for _, u := range m.Users {
+		if u.Id == userId && u.Owner {
+			md.Owner = true
+		}
+	}
+
+	return md
+}
+
+func newCredentialData(c *mongomodels.Credential) *credentialData {
+	cd := &credentialData{
+		Identifier:  c.Identifier,
+		Provider:    c.Provider,
+		Title:       c.Title,
+		Verified:    c.Verified,
+		AccessLevel: c.AccessLevel,
+	}
+
+	if c.Meta != nil {
+		cd.CreatedAt = c.Meta.CreatedAt
+	}
+
+	return cd
+}
+
+// newStackTemplateData gives the content authored by the account, the
+// credentials used by the template are left out
+func newStackTemplateData(tmpl *mongomodels.StackTemplate) *stackTemplateData {
+	return &stackTemplateData{
+		Id:          tmpl.Id,
+		Title:       tmpl.Title,
+		Description: tmpl.Description,
+		Group:       tmpl.Group,
+		AccessLevel: tmpl.AccessLevel,
+		Content:     tmpl.Template.Content,
+		RawContent:  tmpl.Template.RawContent,
+	}
+}
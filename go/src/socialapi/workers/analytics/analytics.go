@@ -0,0 +1,144 @@
+// Package analytics provides the logical part of the analytics worker, which
+// maintains the daily rollups of the team activity and the machine usage
+package analytics
+
+import (
+	"socialapi/models"
+	"socialapi/workers/presence"
+	"time"
+
+	"github.com/koding/logging"
+	"github.com/robfig/cron"
+	"github.com/streadway/amqp"
+)
+
+// Schedule rolls up the machine usage at every hour
+const Schedule = "0 15 * * * *"
+
+// RollupDays is the number of the days, which are recomputed at every run.
+// Rollups are idempotent, so yesterday is recomputed too for the intervals
+// that are closed after midnight.
+const RollupDays = 2
+
+// Controller holds the basic context data for handlers
+type Controller struct {
+	log     logging.Logger
+	cronJob *cron.Cron
+	ready   chan bool
+
+	// resolve finds the owner and the stack of the machine, it is replaced
+	// in tests
+	resolve func(machineId string) (*machineOwner, error)
+}
+
+// New creates a controller
+func New(log logging.Logger) *Controller {
+	c := &Controller{
+		log:     log,
+		ready:   make(chan bool, 1),
+		resolve: resolveMachineOwner,
+	}
+
+	c.ready <- true
+
+	return c
+}
+
+// DefaultErrHandler handles the errors for analytics worker
+func (c *Controller) DefaultErrHandler(delivery amqp.Delivery, err error) bool {
+	c.log.Error("an error occurred putting message back to queue: %s", err)
+	delivery.Nack(false, true)
+	return false
+}
+
+// Ping records the activity of the member for the day of the ping
+func (c *Controller) Ping(ping *presence.Ping) error {
+	if ping.GroupName == "" || ping.AccountID == 0 {
+		c.log.Error("invalid ping %+v", ping)
+		return nil
+	}
+
+	at := ping.CreatedAt
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	return models.NewMemberDaily().Touch(ping.GroupName, ping.AccountID, at)
+}
+
+// Schedule starts rolling up the machine usage periodically
+func (c *Controller) Schedule() error {
+	c.cronJob = cron.New()
+	if err := c.cronJob.AddFunc(Schedule, c.CronStart); err != nil {
+		return err
+	}
+
+	c.cronJob.Start()
+
+	return nil
+}
+
+// Shutdown stops the scheduled rollups
+func (c *Controller) Shutdown() {
+	if c.cronJob != nil {
+		c.cronJob.Stop()
+	}
+}
+
+// CronStart rolls up the recent days, unless the previous run is ongoing
+func (c *Controller) CronStart() {
+	select {
+	case <-c.ready:
+		if err := c.Rollup(time.Now().UTC(), RollupDays); err != nil {
+			c.log.Error("could not roll up the analytics: %s", err)
+		}
+		c.ready <- true
+	default:
+		c.log.Debug("Ongoing rollup process")
+	}
+}
+
+// Rollup recomputes the rollups of the given number of the days ending at
+// now. Member activity is recovered from the presence pings and the machine
+// hours are computed from the machine usage intervals.
+func (c *Controller) Rollup(now time.Time, days int) error {
+	if days < 1 {
+		days = 1
+	}
+
+	since := startOfDay(now).AddDate(0, 0, -(days - 1))
+
+	if err := models.NewMemberDaily().RollupPresence(since); err != nil {
+		return err
+	}
+
+	usages, err := models.NewMachineUsage().FetchSince(since)
+	if err != nil {
+		return err
+	}
+
+	owners := make(map[string]*machineOwner)
+
+	for _, md := range splitByDay(usages, since, now) {
+		owner, ok := owners[md.MachineId]
+		if !ok {
+			if owner, err = c.resolve(md.MachineId); err != nil {
+				c.log.Error("could not find the owner of machine %s: %s", md.MachineId, err)
+				owner = &machineOwner{}
+			}
+
+			owners[md.MachineId] = owner
+		}
+
+		md.AccountId = owner.AccountId
+		md.StackId = owner.StackId
+
+		if err := md.Set(); err != nil {
+			return err
+		}
+	}
+
+	c.log.Debug("rolled up %d machines since %s", len(owners), since)
+
+	return nil
+}
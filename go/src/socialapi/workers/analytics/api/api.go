@@ -0,0 +1,274 @@
+// Package api provides the team analytics endpoints, they are served from
+// the rollups of the analytics worker
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"socialapi/models"
+	"socialapi/request"
+	"socialapi/workers/common/response"
+	"time"
+)
+
+// MaxPeriod is the longest period a report can cover
+const MaxPeriod = 366
+
+// DefaultPeriod is the number of the days covered when the period is not
+// given
+const DefaultPeriod = 30
+
+// Windows of the active member counts in days
+const (
+	WindowDaily   = 1
+	WindowWeekly  = 7
+	WindowMonthly = 30
+)
+
+// Metrics of the trends
+const (
+	MetricDaily          = "daily"
+	MetricWeekly         = "weekly"
+	MetricMonthly        = "monthly"
+	MetricMachineSeconds = "machine-seconds"
+)
+
+var (
+	ErrInvalidDay    = errors.New("days should be formatted as YYYY-MM-DD or RFC3339")
+	ErrInvalidPeriod = errors.New("period should end after it starts and can not exceed 366 days")
+	ErrInvalidMetric = errors.New("metric should be one of daily, weekly, monthly or machine-seconds")
+)
+
+// ActivityResponse holds the active member counts of the team as of a day
+type ActivityResponse struct {
+	Day     time.Time `json:"day"`
+	Daily   int       `json:"daily"`
+	Weekly  int       `json:"weekly"`
+	Monthly int       `json:"monthly"`
+}
+
+// MemberResponse holds the activity summary of a member within a period
+type MemberResponse struct {
+	models.MemberActivity
+
+	Nick           string `json:"nick"`
+	MachineSeconds int64  `json:"machineSeconds"`
+}
+
+// MachinesResponse holds the machine hours of the team within a period
+type MachinesResponse struct {
+	From    time.Time                      `json:"from"`
+	To      time.Time                      `json:"to"`
+	Members []models.AccountMachineSeconds `json:"members"`
+	Stacks  []models.StackMachineSeconds   `json:"stacks"`
+}
+
+// Activity returns the daily, weekly and monthly active members of the team
+func Activity(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	day, err := parseDay(u.Query().Get("day"), time.Now().UTC())
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(fetchActivity(context.GroupName, day))
+}
+
+// Members lists the activity summaries of the members, recently active
+// members come first
+func Members(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	from, to, err := parsePeriod(u.Query(), time.Now().UTC())
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(fetchMembers(context.GroupName, from, to, request.GetQuery(u)))
+}
+
+// Machines returns the machine hours of the team by member and stack
+func Machines(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	from, to, err := parsePeriod(u.Query(), time.Now().UTC())
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(fetchMachines(context.GroupName, from, to))
+}
+
+// Trend returns the daily series of the metric
+func Trend(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	from, to, err := parsePeriod(u.Query(), time.Now().UTC())
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(fetchTrend(context.GroupName, u.Query().Get("metric"), from, to))
+}
+
+func fetchActivity(groupName string, day time.Time) (*ActivityResponse, error) {
+	res := &ActivityResponse{Day: day}
+	m := models.NewMemberDaily()
+
+	counts := []struct {
+		window int
+		count  *int
+	}{
+		{WindowDaily, &res.Daily},
+		{WindowWeekly, &res.Weekly},
+		{WindowMonthly, &res.Monthly},
+	}
+
+	for _, c := range counts {
+		count, err := m.CountActive(groupName, windowStart(day, c.window), day)
+		if err != nil {
+			return nil, err
+		}
+
+		*c.count = count
+	}
+
+	return res, nil
+}
+
+func fetchMembers(groupName string, from, to time.Time, q *request.Query) ([]*MemberResponse, error) {
+	activities, err := models.NewMemberDaily().FetchMembers(groupName, from, to, q)
+	if err != nil {
+		return nil, err
+	}
+
+	sums, err := models.NewMachineDaily().SumByAccount(groupName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := make(map[int64]int64, len(sums))
+	for _, s := range sums {
+		seconds[s.AccountId] = s.Seconds
+	}
+
+	ids := make([]int64, 0, len(activities))
+	for _, a := range activities {
+		ids = append(ids, a.AccountId)
+	}
+
+	accounts, err := models.NewAccount().FetchByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	nicks := make(map[int64]string, len(accounts))
+	for _, acc := range accounts {
+		nicks[acc.Id] = acc.Nick
+	}
+
+	members := make([]*MemberResponse, 0, len(activities))
+	for _, a := range activities {
+		members = append(members, &MemberResponse{
+			MemberActivity: a,
+			Nick:           nicks[a.AccountId],
+			MachineSeconds: seconds[a.AccountId],
+		})
+	}
+
+	return members, nil
+}
+
+func fetchMachines(groupName string, from, to time.Time) (*MachinesResponse, error) {
+	m := models.NewMachineDaily()
+
+	members, err := m.SumByAccount(groupName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := m.SumByStack(groupName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MachinesResponse{
+		From:    from,
+		To:      to,
+		Members: members,
+		Stacks:  stacks,
+	}, nil
+}
+
+func fetchTrend(groupName, metric string, from, to time.Time) ([]models.SeriesPoint, error) {
+	switch metric {
+	case "", MetricDaily:
+		return models.NewMemberDaily().ActiveSeries(groupName, from, to, WindowDaily)
+	case MetricWeekly:
+		return models.NewMemberDaily().ActiveSeries(groupName, from, to, WindowWeekly)
+	case MetricMonthly:
+		return models.NewMemberDaily().ActiveSeries(groupName, from, to, WindowMonthly)
+	case MetricMachineSeconds:
+		return models.NewMachineDaily().Series(groupName, from, to)
+	default:
+		return nil, ErrInvalidMetric
+	}
+}
+
+// parsePeriod parses the from and to days of the query, the period ends
+// today and covers the last 30 days by default
+func parsePeriod(v url.Values, now time.Time) (time.Time, time.Time, error) {
+	to, err := parseDay(v.Get("to"), now)
+	if err != nil {
+		return to, to, err
+	}
+
+	from, err := parseDay(v.Get("from"), windowStart(to, DefaultPeriod))
+	if err != nil {
+		return from, to, err
+	}
+
+	if to.Before(from) || to.Sub(from) >= MaxPeriod*24*time.Hour {
+		return from, to, ErrInvalidPeriod
+	}
+
+	return from, to, nil
+}
+
+// parseDay parses the day, def is used when it is empty
+func parseDay(s string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return truncateDay(def), nil
+	}
+
+	if t, err := time.Parse(models.DayLayout, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, ErrInvalidDay
+	}
+
+	return truncateDay(t), nil
+}
+
+// windowStart returns the first day of the window ending at day
+func windowStart(day time.Time, window int) time.Time {
+	return day.AddDate(0, 0, -(window - 1))
+}
+
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"net/url"
+	"socialapi/models"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, _ := time.Parse(models.DayLayout, s)
+	return t
+}
+
+func TestParsePeriod(t *testing.T) {
+	now := time.Date(2016, time.March, 31, 15, 4, 5, 0, time.UTC)
+
+	cases := map[string]struct {
+		query    string
+		from, to time.Time
+		err      error
+	}{
+		"default":  {"", day("2016-03-02"), day("2016-03-31"), nil},
+		"to only":  {"to=2016-02-29", day("2016-01-31"), day("2016-02-29"), nil},
+		"both":     {"from=2016-01-01&to=2016-01-07", day("2016-01-01"), day("2016-01-07"), nil},
+		"rfc3339":  {"from=2016-01-01T23:00:00Z&to=2016-01-02T01:00:00Z", day("2016-01-01"), day("2016-01-02"), nil},
+		"single":   {"from=2016-01-01&to=2016-01-01", day("2016-01-01"), day("2016-01-01"), nil},
+		"reversed": {"from=2016-01-07&to=2016-01-01", time.Time{}, time.Time{}, ErrInvalidPeriod},
+		"too long": {"from=2015-01-01&to=2016-01-02", time.Time{}, time.Time{}, ErrInvalidPeriod},
+		"invalid":  {"from=yesterday", time.Time{}, time.Time{}, ErrInvalidDay},
+	}
+
+	for name, c := range cases {
+		v, _ := url.ParseQuery(c.query)
+
+		from, to, err := parsePeriod(v, now)
+		if err != c.err {
+			t.Errorf("%s: got error %v, want %v", name, err, c.err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if !from.Equal(c.from) || !to.Equal(c.to) {
+			t.Errorf("%s: got %s - %s, want %s - %s", name, from, to, c.from, c.to)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	members := []*MemberResponse{
+		{
+			MemberActivity: models.MemberActivity{
+				AccountId:  42,
+				LastSeenAt: time.Date(2016, time.March, 1, 10, 0, 0, 0, time.UTC),
+				ActiveDays: 3,
+			},
+			Nick:           "jane, the admin",
+			MachineSeconds: 7200,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, memberRows(members)); err != nil {
+		t.Fatalf("writeCSV()=%s", err)
+	}
+
+	want := "account_id,nick,last_seen_at,active_days,machine_seconds\n" +
+		"42,\"jane, the admin\",2016-03-01T10:00:00Z,3,7200\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMachineRows(t *testing.T) {
+	rows := machineRows(&MachinesResponse{
+		Members: []models.AccountMachineSeconds{{AccountId: 42, Seconds: 60}},
+		Stacks:  []models.StackMachineSeconds{{StackId: "s1", Seconds: 60}},
+	})
+
+	if len(rows) != 3 || rows[1][0] != "member" || rows[2][0] != "stack" || rows[2][1] != "s1" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"socialapi/models"
+	"socialapi/request"
+	"strconv"
+	"time"
+)
+
+// MaxExportRows limits the member rows of the exports
+const MaxExportRows = 10000
+
+// Reports that can be exported
+const (
+	ReportMembers  = "members"
+	ReportMachines = "machines"
+	ReportTrend    = "trend"
+)
+
+var ErrInvalidReport = errors.New("report should be one of members, machines or trend")
+
+// Export exports the report as CSV, the report query parameter selects the
+// members (default), machines or trend report. Reports take the same query
+// parameters as their JSON endpoints.
+func Export(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, io.Reader, error) {
+	if err := context.CanManage(); err != nil {
+		return http.StatusBadRequest, nil, nil, err
+	}
+
+	v := u.Query()
+
+	from, to, err := parsePeriod(v, time.Now().UTC())
+	if err != nil {
+		return http.StatusBadRequest, nil, nil, err
+	}
+
+	report := v.Get("report")
+	if report == "" {
+		report = ReportMembers
+	}
+
+	var rows [][]string
+
+	switch report {
+	case ReportMembers:
+		q := request.NewQuery()
+		q.Limit = MaxExportRows
+
+		members, err := fetchMembers(context.GroupName, from, to, q)
+		if err != nil {
+			return http.StatusInternalServerError, nil, nil, err
+		}
+
+		rows = memberRows(members)
+	case ReportMachines:
+		machines, err := fetchMachines(context.GroupName, from, to)
+		if err != nil {
+			return http.StatusInternalServerError, nil, nil, err
+		}
+
+		rows = machineRows(machines)
+	case ReportTrend:
+		points, err := fetchTrend(context.GroupName, v.Get("metric"), from, to)
+		if err == ErrInvalidMetric {
+			return http.StatusBadRequest, nil, nil, err
+		}
+
+		if err != nil {
+			return http.StatusInternalServerError, nil, nil, err
+		}
+
+		rows = trendRows(points)
+	default:
+		return http.StatusBadRequest, nil, nil, ErrInvalidReport
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, rows); err != nil {
+		return http.StatusInternalServerError, nil, nil, err
+	}
+
+	filename := fmt.Sprintf("%s-%s-%s-%s.csv", context.GroupName, report, models.FormatDay(from), models.FormatDay(to))
+
+	header := http.Header{}
+	header.Set("Content-Type", "text/csv; charset=utf-8")
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	return http.StatusOK, header, &buf, nil
+}
+
+func writeCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+func memberRows(members []*MemberResponse) [][]string {
+	rows := [][]string{{"account_id", "nick", "last_seen_at", "active_days", "machine_seconds"}}
+
+	for _, m := range members {
+		rows = append(rows, []string{
+			strconv.FormatInt(m.AccountId, 10),
+			m.Nick,
+			m.LastSeenAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(m.ActiveDays),
+			strconv.FormatInt(m.MachineSeconds, 10),
+		})
+	}
+
+	return rows
+}
+
+func machineRows(machines *MachinesResponse) [][]string {
+	rows := [][]string{{"scope", "id", "seconds"}}
+
+	for _, m := range machines.Members {
+		rows = append(rows, []string{"member", strconv.FormatInt(m.AccountId, 10), strconv.FormatInt(m.Seconds, 10)})
+	}
+
+	for _, s := range machines.Stacks {
+		rows = append(rows, []string{"stack", s.StackId, strconv.FormatInt(s.Seconds, 10)})
+	}
+
+	return rows
+}
+
+func trendRows(points []models.SeriesPoint) [][]string {
+	rows := [][]string{{"day", "value"}}
+
+	for _, p := range points {
+		rows = append(rows, []string{models.FormatDay(p.Day), strconv.FormatInt(p.Value, 10)})
+	}
+
+	return rows
+}
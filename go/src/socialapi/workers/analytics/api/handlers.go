@@ -0,0 +1,76 @@
+package api
+
+import (
+	"socialapi/workers/common/handler"
+	"socialapi/workers/common/mux"
+)
+
+const (
+	// EndpointActivity returns the active member counts of the team
+	EndpointActivity = "/analytics/activity"
+
+	// EndpointMembers lists the activity summaries of the members
+	EndpointMembers = "/analytics/members"
+
+	// EndpointMachines returns the machine hours by member and stack
+	EndpointMachines = "/analytics/machines"
+
+	// EndpointTrend returns the daily series of a metric
+	EndpointTrend = "/analytics/trend"
+
+	// EndpointExport exports the reports as CSV
+	EndpointExport = "/analytics/export"
+)
+
+// AddHandlers added the internal handlers to the given Muxer
+func AddHandlers(m *mux.Mux) {
+	m.AddHandler(
+		handler.Request{
+			Handler:  Activity,
+			Name:     "analytics-activity",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointActivity,
+			Cost:     5,
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  Members,
+			Name:     "analytics-members",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointMembers,
+			Cost:     5,
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  Machines,
+			Name:     "analytics-machines",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointMachines,
+			Cost:     5,
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  Trend,
+			Name:     "analytics-trend",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointTrend,
+			Cost:     5,
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  Export,
+			Name:     "analytics-export",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointExport,
+			Cost:     10,
+		},
+	)
+}
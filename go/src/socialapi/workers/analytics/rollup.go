@@ -0,0 +1,135 @@
+package analytics
+
+import (
+	"koding/db/mongodb/modelhelper"
+	"socialapi/models"
+	"sort"
+	"time"
+
+	mgo "gopkg.in/mgo.v2"
+)
+
+// machineOwner holds the owner and the stack of a machine
+type machineOwner struct {
+	AccountId int64
+	StackId   string
+}
+
+// resolveMachineOwner finds the owner and the stack of the machine, the
+// machines which are deleted since are counted without them
+func resolveMachineOwner(machineId string) (*machineOwner, error) {
+	owner := &machineOwner{}
+
+	m, err := modelhelper.GetMachine(machineId)
+	if err == mgo.ErrNotFound {
+		return owner, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range m.Users {
+		if !u.Owner {
+			continue
+		}
+
+		acc, err := models.Cache.Account.ByNick(u.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		owner.AccountId = acc.Id
+		break
+	}
+
+	stack, err := modelhelper.GetComputeStackByMachineID(m.ObjectId)
+	switch err {
+	case nil:
+		owner.StackId = stack.Id.Hex()
+	case mgo.ErrNotFound:
+		// machines of the old stacks are not in any stack
+	default:
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// startOfDay returns the beginning of the day of t in UTC
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// splitByDay splits the usage intervals into the days between from and to,
+// intervals are clipped to them and open intervals are counted until to. The
+// running seconds of a machine are summed, when it has multiple intervals in
+// a day.
+func splitByDay(usages []models.MachineUsage, from, to time.Time) []*models.MachineDaily {
+	type key struct {
+		machineId string
+		day       time.Time
+	}
+
+	from, to = from.UTC(), to.UTC()
+	rollups := make(map[key]*models.MachineDaily)
+
+	for _, u := range usages {
+		start, end := u.StartedAt.UTC(), to
+		if u.StoppedAt != nil && u.StoppedAt.Before(end) {
+			end = u.StoppedAt.UTC()
+		}
+
+		if start.Before(from) {
+			start = from
+		}
+
+		for start.Before(end) {
+			day := startOfDay(start)
+
+			next := day.AddDate(0, 0, 1)
+			if end.Before(next) {
+				next = end
+			}
+
+			k := key{u.MachineId, day}
+			md, ok := rollups[k]
+			if !ok {
+				md = &models.MachineDaily{
+					MachineId: u.MachineId,
+					Day:       day,
+				}
+				rollups[k] = md
+			}
+
+			// machines might be moved between the teams, the team of the
+			// latest interval gets the day
+			md.GroupName = u.GroupName
+			md.Seconds += int64(next.Sub(start) / time.Second)
+
+			start = next
+		}
+	}
+
+	res := make([]*models.MachineDaily, 0, len(rollups))
+	for _, md := range rollups {
+		res = append(res, md)
+	}
+
+	sort.Sort(byMachineAndDay(res))
+
+	return res
+}
+
+type byMachineAndDay []*models.MachineDaily
+
+func (b byMachineAndDay) Len() int      { return len(b) }
+func (b byMachineAndDay) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byMachineAndDay) Less(i, j int) bool {
+	if b[i].MachineId != b[j].MachineId {
+		return b[i].MachineId < b[j].MachineId
+	}
+
+	return b[i].Day.Before(b[j].Day)
+}
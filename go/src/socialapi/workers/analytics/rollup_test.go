@@ -0,0 +1,73 @@
+package analytics
+
+import (
+	"socialapi/models"
+	"testing"
+	"time"
+)
+
+func date(day, hour, min int) time.Time {
+	return time.Date(2016, time.March, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestSplitByDay(t *testing.T) {
+	stopped := func(tm time.Time) *time.Time { return &tm }
+
+	usages := []models.MachineUsage{
+		// spans midnight
+		{GroupName: "acme", MachineId: "m1", StartedAt: date(1, 22, 0), StoppedAt: stopped(date(2, 2, 0))},
+		// second interval of the same day is summed
+		{GroupName: "acme", MachineId: "m1", StartedAt: date(2, 10, 0), StoppedAt: stopped(date(2, 10, 30))},
+		// started before the window, still running
+		{GroupName: "acme", MachineId: "m2", StartedAt: date(1, 12, 0)},
+	}
+
+	got := splitByDay(usages, date(2, 0, 0), date(3, 6, 0))
+
+	want := []struct {
+		machineId string
+		day       time.Time
+		seconds   int64
+	}{
+		{"m1", date(2, 0, 0), 2*3600 + 1800},
+		{"m2", date(2, 0, 0), 24 * 3600},
+		{"m2", date(3, 0, 0), 6 * 3600},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rollups, want %d: %+v", len(got), len(want), got)
+	}
+
+	for i, w := range want {
+		g := got[i]
+		if g.MachineId != w.machineId || !g.Day.Equal(w.day) || g.Seconds != w.seconds {
+			t.Errorf("%d: got %s %s %d, want %s %s %d", i, g.MachineId, g.Day, g.Seconds, w.machineId, w.day, w.seconds)
+		}
+
+		if g.GroupName != "acme" {
+			t.Errorf("%d: got group %q, want acme", i, g.GroupName)
+		}
+	}
+}
+
+func TestSplitByDayOutsideWindow(t *testing.T) {
+	stoppedAt := date(1, 10, 0)
+
+	usages := []models.MachineUsage{
+		{GroupName: "acme", MachineId: "m1", StartedAt: date(1, 8, 0), StoppedAt: &stoppedAt},
+	}
+
+	if got := splitByDay(usages, date(2, 0, 0), date(2, 12, 0)); len(got) != 0 {
+		t.Errorf("got %+v, want no rollups", got)
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*3600)
+
+	// 01:30 in UTC+3 is the previous day in UTC
+	got := startOfDay(time.Date(2016, time.March, 2, 1, 30, 0, 0, loc))
+	if !got.Equal(date(1, 0, 0)) {
+		t.Errorf("got %s, want %s", got, date(1, 0, 0))
+	}
+}
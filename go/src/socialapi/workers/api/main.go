@@ -4,6 +4,7 @@ import (
 	"koding/db/mongodb/modelhelper"
 	"log"
 	"socialapi/config"
+	analyticsapi "socialapi/workers/analytics/api"
 	"socialapi/workers/api/handlers"
 	"socialapi/workers/api/modules/account"
 	"socialapi/workers/api/modules/channel"
@@ -15,6 +16,7 @@ import (
 	"socialapi/workers/api/modules/reply"
 	collaboration "socialapi/workers/collaboration/api"
 	"socialapi/workers/common/mux"
+	"socialapi/workers/common/quota"
 	countlyapi "socialapi/workers/countly/api"
 	credential "socialapi/workers/credentials/api"
 	emailapi "socialapi/workers/email/api"
@@ -25,9 +27,11 @@ import (
 	presenceapi "socialapi/workers/presence/api"
 	realtimeapi "socialapi/workers/realtime/api"
 	slackapi "socialapi/workers/slack/api"
+	webhookapi "socialapi/workers/webhook/api"
 
 	"github.com/koding/cache"
 	"github.com/koding/runner"
+	throttled "gopkg.in/throttled/throttled.v2"
 )
 
 var (
@@ -46,6 +50,7 @@ func main() {
 	mc := mux.NewConfig(Name, r.Conf.Host, r.Conf.Port)
 	mc.Debug = r.Conf.Debug
 	m := mux.New(mc, r.Log, r.Metrics)
+	m.Quota = quota.New(mustInitQuotaStore(r))
 
 	// init mongo connection
 	modelhelper.Initialize(c.Mongo)
@@ -76,6 +81,8 @@ func main() {
 	credential.AddHandlers(m, r.Log, c)
 	emailapi.AddHandlers(m)
 	countlyapi.AddHandlers(m, c)
+	webhookapi.AddHandlers(m)
+	analyticsapi.AddHandlers(m)
 
 	mmdb, err := helper.ReadGeoIPDB(c)
 	if err != nil {
@@ -98,3 +105,22 @@ func main() {
 	r.Listen()
 	r.Wait()
 }
+
+// mustInitQuotaStore creates the store of the API quotas, quotas are shared
+// between the instances through redis. Each instance counts the requests on
+// its own when redis is not available.
+func mustInitQuotaStore(r *runner.Runner) throttled.GCRAStore {
+	redisConn, err := runner.InitRedisConn(r.Conf)
+	if err == nil {
+		return quota.NewRedisStore(redisConn.Pool(), r.Conf.Redis.DB)
+	}
+
+	r.Log.Critical("quotas are not shared between the instances, redis is not available: %s", err)
+
+	store, err := quota.NewMemoryStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return store
+}
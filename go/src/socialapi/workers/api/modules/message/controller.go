@@ -305,10 +305,8 @@ func Update(u *url.URL, h http.Header, req *models.ChannelMessage, c *models.Con
 		return response.NewBadRequest(err)
 	}
 
-	req.Body = body
-	req.Payload = payload
-
-	if err := req.Update(); err != nil {
+	// previous body and payload are kept as a revision
+	if err := req.Edit(c.Client.Account.Id, body, payload); err != nil {
 		return response.NewBadRequest(err)
 	}
 
@@ -454,3 +452,165 @@ func GetBySlug(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (i
 
 	return response.HandleResultAndError(cmc, cmc.Err)
 }
+
+// Search makes a full-text search on the messages and replies of the channels
+// that the requester can open. Results can be filtered by the author, channel,
+// mentioned nick and creation date, and are paginated with the returned cursor.
+func Search(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (int, http.Header, interface{}, error) {
+	if !ctx.IsLoggedIn() {
+		return response.NewBadRequest(models.ErrNotLoggedIn)
+	}
+
+	req := (&models.MessageSearchRequest{}).MapURL(u)
+	req.GroupName = ctx.GroupName
+	req.AccountId = ctx.Client.Account.Id
+
+	if author := u.Query().Get("author"); author != "" && req.AuthorId == 0 {
+		acc, err := models.Cache.Account.ByNick(author)
+		if err != nil {
+			return response.NewBadRequest(err)
+		}
+
+		req.AuthorId = acc.Id
+	}
+
+	res, err := models.NewChannelMessage().SearchMessages(req)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewOK(res)
+}
+
+// ListRevisions lists the previous versions of an edited message, recent
+// edits come first
+func ListRevisions(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (int, http.Header, interface{}, error) {
+	cm, err := getMessageByUrl(u)
+	if err != nil {
+		if err == bongo.RecordNotFound {
+			return response.NewNotFound()
+		}
+		return response.NewBadRequest(err)
+	}
+
+	canOpen, err := canOpenMessage(cm, ctx)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if !canOpen {
+		return response.NewAccessDenied(models.ErrCannotOpenChannel)
+	}
+
+	return response.HandleResultAndError(
+		models.NewMessageRevision().List(cm.Id, request.GetQuery(u)),
+	)
+}
+
+// AddReaction adds the emoji reaction of the requester to the message and
+// returns the updated reaction counts of the message
+func AddReaction(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (int, http.Header, interface{}, error) {
+	return react(u, ctx, (*models.MessageReaction).Add)
+}
+
+// RemoveReaction removes the emoji reaction of the requester from the
+// message and returns the updated reaction counts of the message
+func RemoveReaction(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (int, http.Header, interface{}, error) {
+	return react(u, ctx, (*models.MessageReaction).Remove)
+}
+
+func react(u *url.URL, ctx *models.Context, op func(*models.MessageReaction) (bool, error)) (int, http.Header, interface{}, error) {
+	if !ctx.IsLoggedIn() {
+		return response.NewBadRequest(models.ErrNotLoggedIn)
+	}
+
+	reaction, err := models.NormalizeReaction(u.Query().Get("reaction"))
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	cm, err := getMessageByUrl(u)
+	if err != nil {
+		if err == bongo.RecordNotFound {
+			return response.NewNotFound()
+		}
+		return response.NewBadRequest(err)
+	}
+
+	canOpen, err := canOpenMessage(cm, ctx)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if !canOpen {
+		return response.NewAccessDenied(models.ErrCannotOpenChannel)
+	}
+
+	mr := models.NewMessageReaction()
+	mr.MessageId = cm.Id
+	mr.AccountId = ctx.Client.Account.Id
+	mr.Reaction = reaction
+
+	if _, err := op(mr); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(
+		mr.Summaries(cm.Id, ctx.Client.Account.Id),
+	)
+}
+
+// MarkThreadRead moves the thread cursor of the requester for the replies of
+// the message, unread replies count of the message is reset with it
+func MarkThreadRead(u *url.URL, h http.Header, _ interface{}, ctx *models.Context) (int, http.Header, interface{}, error) {
+	if !ctx.IsLoggedIn() {
+		return response.NewBadRequest(models.ErrNotLoggedIn)
+	}
+
+	cm, err := getMessageByUrl(u)
+	if err != nil {
+		if err == bongo.RecordNotFound {
+			return response.NewNotFound()
+		}
+		return response.NewBadRequest(err)
+	}
+
+	if cm.TypeConstant == models.ChannelMessage_TYPE_REPLY {
+		return response.NewBadRequest(models.ErrReplyHasNoThread)
+	}
+
+	canOpen, err := canOpenMessage(cm, ctx)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if !canOpen {
+		return response.NewAccessDenied(models.ErrCannotOpenChannel)
+	}
+
+	tc := models.NewThreadCursor()
+	tc.MessageId = cm.Id
+	tc.AccountId = ctx.Client.Account.Id
+
+	if _, err := tc.MarkRead(time.Now().UTC()); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewOK(tc)
+}
+
+// canOpenMessage checks if the requester can open the initial channel of the
+// message
+func canOpenMessage(cm *models.ChannelMessage, ctx *models.Context) (bool, error) {
+	ch, err := models.Cache.Channel.ById(cm.InitialChannelId)
+	if err != nil {
+		return false, err
+	}
+
+	var accountId int64
+	if ctx.IsLoggedIn() {
+		accountId = ctx.Client.Account.Id
+	}
+
+	return ch.CanOpen(accountId)
+}
@@ -36,6 +36,55 @@ func AddHandlers(m *mux.Mux) {
 		},
 	)
 
+	// lists the previous versions of the edited message
+	m.AddHandler(
+		handler.Request{
+			Handler:  ListRevisions,
+			Name:     "message-list-revisions",
+			Type:     handler.GetRequest,
+			Endpoint: "/message/{id}/revisions",
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  AddReaction,
+			Name:     "message-add-reaction",
+			Type:     handler.PostRequest,
+			Endpoint: "/message/{id}/reaction/{reaction}",
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  RemoveReaction,
+			Name:     "message-remove-reaction",
+			Type:     handler.DeleteRequest,
+			Endpoint: "/message/{id}/reaction/{reaction}",
+		},
+	)
+
+	// moves the thread cursor of the requester to now
+	m.AddHandler(
+		handler.Request{
+			Handler:  MarkThreadRead,
+			Name:     "message-mark-thread-read",
+			Type:     handler.PostRequest,
+			Endpoint: "/message/{id}/read",
+		},
+	)
+
+	// searches messages of the channels the requester can open
+	m.AddHandler(
+		handler.Request{
+			Handler:  Search,
+			Name:     "message-search",
+			Type:     handler.GetRequest,
+			Endpoint: "/message/search",
+			Cost:     5,
+		},
+	)
+
 	// exempt contents are filtered
 	// caching enabled
 	m.AddHandler(
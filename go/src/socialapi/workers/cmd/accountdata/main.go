@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"koding/db/mongodb/modelhelper"
+	"os"
+	"socialapi/config"
+	"socialapi/workers/accountdata"
+
+	"github.com/koding/runner"
+)
+
+var (
+	Name = "AccountData"
+
+	flagUsername    = flag.String("username", "", "Username of the account")
+	flagAccountId   = flag.Int64("account", 0, "Social api id of the account")
+	flagExport      = flag.String("export", "", "Writes the data of the account to the given archive")
+	flagErase       = flag.Bool("erase", false, "Erases the data of the account")
+	flagDryRun      = flag.Bool("dry-run", false, "Only counts the records to be erased")
+	flagRequestedBy = flag.String("requested-by", os.Getenv("USER"), "Operator, who is recorded to the audit record of the erasure")
+)
+
+func main() {
+	r := runner.New(Name)
+	if err := r.Init(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer r.Close()
+
+	appConfig := config.MustRead(r.Conf.Path)
+	modelhelper.Initialize(appConfig.Mongo)
+	defer modelhelper.Close()
+
+	if *flagExport == "" && !*flagErase {
+		r.Log.Fatal("either -export or -erase is required")
+	}
+
+	target, err := accountdata.Find(*flagUsername, *flagAccountId)
+	if err != nil {
+		r.Log.Fatal("could not find the account: %s", err)
+	}
+
+	if *flagExport != "" {
+		if err := export(target, *flagExport); err != nil {
+			r.Log.Fatal("could not export the account: %s", err)
+		}
+
+		r.Log.Info("data of %s is exported to %s", target.Username(), *flagExport)
+	}
+
+	if !*flagErase {
+		return
+	}
+
+	eraser := accountdata.NewEraser(r.Log, *flagRequestedBy, *flagDryRun)
+
+	audit, plan, err := eraser.Erase(target)
+	if plan != nil {
+		for _, a := range plan.Actions {
+			fmt.Printf("%-50s %d\n", a.Key(), a.Count)
+		}
+
+		for _, blocker := range plan.Blockers {
+			fmt.Printf("blocked: %s\n", blocker)
+		}
+	}
+
+	if err != nil {
+		r.Log.Fatal("could not erase the account: %s", err)
+	}
+
+	r.Log.Info("erasure of %s is recorded with id %d, dry run: %t", audit.OldId, audit.Id, audit.DryRun)
+}
+
+func export(target *accountdata.Target, path string) error {
+	e, err := accountdata.Collect(target)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := e.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
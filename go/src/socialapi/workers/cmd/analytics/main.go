@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"koding/db/mongodb/modelhelper"
+	"log"
+	"socialapi/config"
+	"socialapi/workers/analytics"
+	"socialapi/workers/presence"
+	"time"
+
+	"github.com/koding/runner"
+)
+
+var (
+	name = "Analytics"
+
+	flagBackfill = flag.Int("backfill", 0, "Rolls up the given number of past days and exits")
+)
+
+func main() {
+	r := runner.New(name)
+	if err := r.Init(); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	appConfig := config.MustRead(r.Conf.Path)
+	modelhelper.Initialize(appConfig.Mongo)
+	defer modelhelper.Close()
+
+	c := analytics.New(r.Log)
+
+	if *flagBackfill > 0 {
+		if err := c.Rollup(time.Now().UTC(), *flagBackfill); err != nil {
+			log.Fatal(err.Error())
+		}
+
+		r.Close()
+		return
+	}
+
+	if err := c.Schedule(); err != nil {
+		log.Fatal(err.Error())
+	}
+	r.ShutdownHandler = c.Shutdown
+
+	r.SetContext(c)
+	r.Register(presence.Ping{}).On(presence.EventName).Handle((*analytics.Controller).Ping)
+	r.Listen()
+	r.Wait()
+}
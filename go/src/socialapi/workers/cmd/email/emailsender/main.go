@@ -32,7 +32,10 @@ func main() {
 
 	exporter := eventexporter.NewMultiExporter(segmentExporter, datadogExporter, countlyExporter)
 
-	constructor := emailsender.New(exporter, r.Log, appConfig)
+	constructor, err := emailsender.New(exporter, r.Log, appConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
 	r.ShutdownHandler = constructor.Close
 
 	r.SetContext(constructor)
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"koding/db/mongodb/modelhelper"
+	"log"
+	"socialapi/config"
+	"socialapi/workers/metering"
+	"socialapi/workers/payment"
+	"socialapi/workers/webhook"
+
+	"github.com/koding/runner"
+)
+
+var (
+	name = "Metering"
+)
+
+func main() {
+	r := runner.New(name)
+	if err := r.Init(); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	appConfig := config.MustRead(r.Conf.Path)
+	modelhelper.Initialize(appConfig.Mongo)
+	defer modelhelper.Close()
+
+	if err := payment.Initialize(appConfig); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	c := metering.New(r.Log)
+	if err := c.Schedule(); err != nil {
+		log.Fatal(err.Error())
+	}
+	r.ShutdownHandler = c.Shutdown
+
+	r.SetContext(c)
+	r.Register(webhook.Event{}).On(webhook.EventName).Handle((*metering.Controller).Handle)
+	r.Listen()
+	r.Wait()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"socialapi/config"
 	"socialapi/workers/realtime/dispatcher"
+	"socialapi/workers/realtime/hub"
 	"socialapi/workers/realtime/models"
 
 	"github.com/koding/runner"
@@ -20,10 +21,6 @@ func main() {
 
 	appConfig := config.MustRead(r.Conf.Path)
 
-	// create a realtime service provider instance.
-	pubnub := models.NewPubNub(appConfig.GateKeeper.Pubnub, r.Log)
-	defer pubnub.Close()
-
 	// When we use the same RMQ connection for both, we received
 	// 'Exception (504) Reason: "CHANNEL_ERROR - unexpected method in connection state running"'
 	// error at some point. It needs debugging.
@@ -34,9 +31,17 @@ func main() {
 	}
 	defer rmqBroker.Conn().Close()
 
+	// create a realtime service provider instance.
+	realtime, err := hub.NewProvider(appConfig.GateKeeper, rmqBroker, r.Log)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer realtime.Close()
+
 	broker := models.NewBroker(rmqBroker, r.Log)
 
-	r.SetContext(dispatcher.NewController(r.Bongo.Broker.MQ, pubnub, broker))
+	r.SetContext(dispatcher.NewController(r.Bongo.Broker.MQ, realtime, broker))
 	r.ListenFor("dispatcher_channel_updated", (*dispatcher.Controller).UpdateChannel)
 	r.ListenFor("dispatcher_message_updated", (*dispatcher.Controller).UpdateMessage)
 	r.ListenFor("dispatcher_notify_user", (*dispatcher.Controller).NotifyUser)
@@ -6,7 +6,7 @@ import (
 	"socialapi/config"
 	"socialapi/workers/common/mux"
 	api "socialapi/workers/realtime/gatekeeper"
-	"socialapi/workers/realtime/models"
+	"socialapi/workers/realtime/hub"
 
 	"github.com/koding/runner"
 )
@@ -25,13 +25,26 @@ func main() {
 	defer modelhelper.Close()
 
 	// create a realtime service provider instance.
-	pubnub := models.NewPubNub(appConfig.GateKeeper.Pubnub, r.Log)
-	defer pubnub.Close()
+	realtime, err := hub.NewProvider(appConfig.GateKeeper, r.Bongo.Broker.MQ, r.Log)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer realtime.Close()
+
+	// self-hosted provider delivers the events published
+	// by the dispatcher to its own subscribers
+	if h, ok := realtime.(*hub.Hub); ok {
+		if err := h.Listen(); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
 
 	mc := mux.NewConfig(Name, appConfig.GateKeeper.Host, appConfig.GateKeeper.Port)
 	m := mux.New(mc, r.Log, r.Metrics)
 
-	h := api.NewHandler(pubnub, appConfig, r.Log)
+	h := api.NewHandler(realtime, appConfig, r.Log)
 
 	h.AddHandlers(m)
 
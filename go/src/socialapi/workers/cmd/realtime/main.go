@@ -30,6 +30,10 @@ func main() {
 	r.Register(models.ChannelMessage{}).OnUpdate().Handle((*realtime.Controller).MessageUpdated)
 	r.Register(models.MessageReply{}).OnCreate().Handle((*realtime.Controller).MessageReplySaved)
 	r.Register(models.MessageReply{}).OnDelete().Handle((*realtime.Controller).MessageReplyDeleted)
+	r.Register(models.MessageReaction{}).OnCreate().Handle((*realtime.Controller).MessageReactionAdded)
+	r.Register(models.MessageReaction{}).OnDelete().Handle((*realtime.Controller).MessageReactionRemoved)
+	r.Register(models.ThreadCursor{}).OnCreate().Handle((*realtime.Controller).ThreadCursorUpdated)
+	r.Register(models.ThreadCursor{}).OnUpdate().Handle((*realtime.Controller).ThreadCursorUpdated)
 	r.Register(models.ChannelMessageList{}).OnCreate().Handle((*realtime.Controller).MessageListSaved)
 	r.Register(models.ChannelMessageList{}).OnUpdate().Handle((*realtime.Controller).ChannelMessageListUpdated)
 	r.Register(models.ChannelMessageList{}).OnDelete().Handle((*realtime.Controller).MessageListDeleted)
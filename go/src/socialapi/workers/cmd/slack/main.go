@@ -0,0 +1,31 @@
+package main
+
+import (
+	"koding/db/mongodb/modelhelper"
+	"log"
+	"socialapi/config"
+	"socialapi/workers/slack"
+	"socialapi/workers/webhook"
+
+	"github.com/koding/runner"
+)
+
+var (
+	name = "Slack"
+)
+
+func main() {
+	r := runner.New(name)
+	if err := r.Init(); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	appConfig := config.MustRead(r.Conf.Path)
+	modelhelper.Initialize(appConfig.Mongo)
+	defer modelhelper.Close()
+
+	r.SetContext(slack.New(r.Log))
+	r.Register(webhook.Event{}).On(webhook.EventName).Handle((*slack.Controller).Handle)
+	r.Listen()
+	r.Wait()
+}
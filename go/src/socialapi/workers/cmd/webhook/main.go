@@ -0,0 +1,32 @@
+package main
+
+import (
+	"log"
+	"socialapi/models"
+	"socialapi/workers/webhook"
+
+	"github.com/koding/runner"
+)
+
+var (
+	name = "Webhook"
+)
+
+func main() {
+	r := runner.New(name)
+	if err := r.Init(); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	c, err := webhook.New(r.Log, r.Bongo.Broker.MQ, r.Bongo.Broker.AppName)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	r.SetContext(c)
+	r.Register(webhook.Event{}).On(webhook.EventName).Handle((*webhook.Controller).Handle)
+	r.Register(webhook.Retry{}).On(webhook.RetryEventName).Handle((*webhook.Controller).HandleRetry)
+	r.Register(models.ChannelParticipant{}).OnCreate().Handle((*webhook.Controller).HandleParticipant)
+	r.Listen()
+	r.Wait()
+}
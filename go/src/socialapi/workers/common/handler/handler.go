@@ -16,6 +16,7 @@ import (
 
 	"koding/tools/utils"
 	"socialapi/models"
+	"socialapi/workers/common/quota"
 	"socialapi/workers/common/response"
 	"strconv"
 	"strings"
@@ -68,6 +69,10 @@ type Request struct {
 	Body      interface{}
 	Headers   map[string]string
 	Ratelimit *throttled.HTTPRateLimiter
+
+	// Cost is the weight of the request on the API quota, requests cost 1
+	// when it is not set, negative costs exempt the request from the quota
+	Cost int
 }
 
 var throttleErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
@@ -76,6 +81,12 @@ var throttleErrorHandler = func(w http.ResponseWriter, r *http.Request, err erro
 	writeJSONError(w, errGeneric)
 }
 
+// quotaErrorHandler only logs the error, the request is served without
+// being limited when the quota state can not be read
+var quotaErrorHandler = func(r *http.Request, err error) {
+	runner.MustGetLogger().Error("Quota error: %s", err)
+}
+
 var throttleDenyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 	writeJSONError(w, response.LimitRateExceededError{})
 })
@@ -304,6 +315,19 @@ func BuildHandlerWithRateLimit(handler http.Handler, t *throttled.HTTPRateLimite
 	return t.RateLimit(handler)
 }
 
+// BuildHandlerWithQuota counts the requests against the quotas of their
+// accounts, teams and API tokens
+func BuildHandlerWithQuota(handler http.Handler, q *quota.Limiter, cost int) http.Handler {
+	if cost == 0 {
+		cost = 1
+	}
+
+	q.Error = quotaErrorHandler
+	q.DeniedHandler = throttleDenyHandler
+
+	return q.Handler(handler, cost)
+}
+
 func DoRequest(request *Request) (*http.Response, error) {
 	if request.Cookie != "" {
 		request.Cookies = parseCookiesToArray(request.Cookie)
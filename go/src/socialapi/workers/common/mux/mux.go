@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"socialapi/models"
 	"socialapi/workers/common/handler"
+	"socialapi/workers/common/quota"
+	"strings"
 	"sync"
 
 	"github.com/koding/logging"
@@ -31,6 +33,10 @@ func NewConfig(name, host string, port string) *Config {
 type Mux struct {
 	Metrics *metrics.Metrics
 
+	// Quota limits the requests of the scoped handlers, private endpoints
+	// are not limited
+	Quota *quota.Limiter
+
 	mux    *tigertonic.TrieServeMux
 	nsMux  *tigertonic.TrieServeMux
 	server *tigertonic.Server
@@ -69,6 +75,10 @@ func (m *Mux) AddHandler(request handler.Request) {
 	hHandler := handler.Wrapper(request)
 	hHandler = handler.BuildHandlerWithContext(hHandler, m.log)
 
+	if m.Quota != nil && !strings.HasPrefix(request.Endpoint, "/private/") {
+		hHandler = handler.BuildHandlerWithQuota(hHandler, m.Quota, request.Cost)
+	}
+
 	m.mux.Handle(request.Type, request.Endpoint, hHandler)
 }
 
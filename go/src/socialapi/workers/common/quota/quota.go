@@ -0,0 +1,326 @@
+// Package quota limits the API requests by account, team and API token. The
+// limits are tiered by the payment plans of the teams and their state is
+// shared between the API instances through redis.
+package quota
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"koding/tools/utils"
+	"math"
+	"net/http"
+	"socialapi/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	throttled "gopkg.in/throttled/throttled.v2"
+	"gopkg.in/throttled/throttled.v2/store/memstore"
+	"gopkg.in/throttled/throttled.v2/store/redigostore"
+)
+
+// Response headers of the quota state, see draft-ietf-httpapi-ratelimit-headers
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Scopes of the limits
+const (
+	ScopeAccount = "account"
+	ScopeToken   = "token"
+	ScopeTeam    = "team"
+	ScopeIP      = "ip"
+)
+
+// keyPrefix is prepended to the redis keys of the quotas
+const keyPrefix = "socialapi:quota:"
+
+// Identity holds the parties a request is counted against
+type Identity struct {
+	// Account is the username of the requester
+	Account string
+
+	// Token is set when the request is authenticated with an API token
+	// instead of the session cookie
+	Token string
+
+	// Team is the group the request is made in
+	Team string
+
+	// IP is used for the anonymous requests
+	IP string
+}
+
+// Key is a limiter key of an identity
+type Key struct {
+	Scope string
+	Name  string
+}
+
+// Keys returns the limiter keys of the identity, requests of the accounts
+// and the API tokens are counted against their teams too.
+func (i *Identity) Keys() []Key {
+	var keys []Key
+
+	switch {
+	case i.Token != "":
+		keys = append(keys, Key{ScopeToken, ScopeToken + ":" + hash(i.Token)})
+	case i.Account != "":
+		keys = append(keys, Key{ScopeAccount, ScopeAccount + ":" + i.Account})
+	default:
+		return []Key{{ScopeIP, ScopeIP + ":" + i.IP}}
+	}
+
+	if i.Team != "" {
+		keys = append(keys, Key{ScopeTeam, ScopeTeam + ":" + i.Team})
+	}
+
+	return keys
+}
+
+// Limiter limits the requests with the quotas of their tiers
+type Limiter struct {
+	store throttled.GCRAStore
+
+	// TierFunc returns the tier of the team, Free is used when it is nil
+	TierFunc func(groupName string) *Tier
+
+	// IdentifyFunc finds the parties of the request, sessions are used when
+	// it is nil
+	IdentifyFunc func(r *http.Request) *Identity
+
+	// DeniedHandler responds the requests exceeding the quota
+	DeniedHandler http.Handler
+
+	// Error is called when the quota state can not be read, requests are
+	// not limited in that case. It must not write a response, since the
+	// request is served after it.
+	Error func(r *http.Request, err error)
+
+	mu       sync.Mutex
+	limiters map[string]*throttled.GCRARateLimiter
+}
+
+// New creates a limiter with the given quota store
+func New(store throttled.GCRAStore) *Limiter {
+	return &Limiter{
+		store:    store,
+		TierFunc: NewPlanTiers().Tier,
+		limiters: make(map[string]*throttled.GCRARateLimiter),
+	}
+}
+
+// NewRedisStore creates a quota store, which is shared between the API
+// instances using the same redis
+func NewRedisStore(pool *redis.Pool, db int) throttled.GCRAStore {
+	// redigostore.New never fails
+	store, _ := redigostore.New(pool, keyPrefix, db)
+	return store
+}
+
+// NewMemoryStore creates a process local quota store, it is used when redis
+// is not available
+func NewMemoryStore() (throttled.GCRAStore, error) {
+	return memstore.New(65536)
+}
+
+// Handler limits the requests of the given handler, each request consumes
+// cost units of the quota. Handlers with non positive costs are not limited.
+func (l *Limiter) Handler(h http.Handler, cost int) http.Handler {
+	if cost <= 0 {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limited, res, err := l.RateLimit(l.identify(r), cost)
+		if err != nil {
+			if l.Error != nil {
+				l.Error(r, err)
+			}
+
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		setHeaders(w.Header(), res, limited)
+
+		if limited {
+			l.deny(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// RateLimit counts the request against all the keys of the identity. The
+// longest wait is returned for the limited requests, otherwise the result of
+// the key with the least remaining quota is returned.
+func (l *Limiter) RateLimit(id *Identity, cost int) (bool, throttled.RateLimitResult, error) {
+	tier := l.tier(id.Team)
+
+	var result throttled.RateLimitResult
+	limited := false
+
+	for i, key := range id.Keys() {
+		rl, err := l.limiter(tier, key.Scope)
+		if err != nil {
+			return false, result, err
+		}
+
+		isLimited, res, err := rl.RateLimit(key.Name, cost)
+		if err != nil {
+			return false, result, err
+		}
+
+		switch {
+		case isLimited && (!limited || res.RetryAfter > result.RetryAfter):
+			result = res
+		case !isLimited && !limited && (i == 0 || res.Remaining < result.Remaining):
+			result = res
+		}
+
+		limited = limited || isLimited
+	}
+
+	return limited, result, nil
+}
+
+func (l *Limiter) tier(groupName string) *Tier {
+	if l.TierFunc == nil {
+		return Free
+	}
+
+	if t := l.TierFunc(groupName); t != nil {
+		return t
+	}
+
+	return Free
+}
+
+// limiter returns the rate limiter of the tier for the scope, they are
+// created once and shared by the keys
+func (l *Limiter) limiter(tier *Tier, scope string) (*throttled.GCRARateLimiter, error) {
+	name := tier.Name + ":" + scope
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if rl, ok := l.limiters[name]; ok {
+		return rl, nil
+	}
+
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerMin(tier.Rate),
+		MaxBurst: tier.Burst,
+	}
+
+	if scope == ScopeTeam {
+		quota = throttled.RateQuota{
+			MaxRate:  throttled.PerMin(tier.TeamRate),
+			MaxBurst: tier.TeamBurst,
+		}
+	}
+
+	rl, err := throttled.NewGCRARateLimiter(l.store, quota)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.limiters == nil {
+		l.limiters = make(map[string]*throttled.GCRARateLimiter)
+	}
+
+	l.limiters[name] = rl
+
+	return rl, nil
+}
+
+func (l *Limiter) identify(r *http.Request) *Identity {
+	if l.IdentifyFunc != nil {
+		return l.IdentifyFunc(r)
+	}
+
+	return Identify(r)
+}
+
+func (l *Limiter) deny(w http.ResponseWriter, r *http.Request) {
+	if l.DeniedHandler != nil {
+		l.DeniedHandler.ServeHTTP(w, r)
+		return
+	}
+
+	http.Error(w, "limit exceeded", 429)
+}
+
+// Identify finds the parties of the request from its session, requests
+// without a valid session are counted by their IPs
+func Identify(r *http.Request) *Identity {
+	id := &Identity{
+		IP: utils.GetIpAddress(r),
+	}
+
+	clientID, isToken := getClientID(r)
+	if clientID == "" {
+		return id
+	}
+
+	session, err := models.Cache.Session.ById(clientID)
+	if err != nil || session.Username == "" {
+		return id
+	}
+
+	id.Team = session.GroupName
+	if isToken {
+		id.Token = clientID
+	} else {
+		id.Account = session.Username
+	}
+
+	return id
+}
+
+// getClientID gets the client id of the request from its cookie or the
+// bearer token, it also reports whether the token is used
+func getClientID(r *http.Request) (string, bool) {
+	if cookie, err := r.Cookie("clientId"); err == nil {
+		return cookie.Value, false
+	}
+
+	auth := r.Header.Get("Authorization")
+	if strings.HasPrefix(auth, "Bearer ") {
+		return auth[len("Bearer "):], true
+	}
+
+	return "", false
+}
+
+func setHeaders(h http.Header, res throttled.RateLimitResult, limited bool) {
+	h.Set(HeaderLimit, strconv.Itoa(res.Limit))
+	h.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+	h.Set(HeaderReset, seconds(res.ResetAfter))
+
+	if limited && res.RetryAfter >= 0 {
+		h.Set(HeaderRetryAfter, seconds(res.RetryAfter))
+	}
+}
+
+// seconds rounds the duration up to seconds
+func seconds(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// hash hides the API tokens in the quota store
+func hash(token string) string {
+	sum := sha1.Sum([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
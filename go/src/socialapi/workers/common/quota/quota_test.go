@@ -0,0 +1,289 @@
+package quota
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"socialapi/workers/payment"
+	"testing"
+	"time"
+
+	"github.com/koding/cache"
+	stripe "github.com/stripe/stripe-go"
+)
+
+var testTier = &Tier{
+	Name:      "test",
+	Rate:      60,
+	Burst:     4,
+	TeamRate:  60,
+	TeamBurst: 7,
+}
+
+func newTestLimiter(t *testing.T, id *Identity) *Limiter {
+	store, err := NewMemoryStore()
+	if err != nil {
+		t.Fatalf("NewMemoryStore()=%s", err)
+	}
+
+	l := New(store)
+	l.TierFunc = func(string) *Tier { return testTier }
+	l.IdentifyFunc = func(*http.Request) *Identity { return id }
+
+	return l
+}
+
+func serve(l *Limiter, cost int) *httptest.ResponseRecorder {
+	h := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), cost)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestIdentityKeys(t *testing.T) {
+	cases := map[string]struct {
+		id   *Identity
+		keys []string
+	}{
+		"anonymous": {&Identity{IP: "1.2.3.4"}, []string{"ip:1.2.3.4"}},
+		"account":   {&Identity{Account: "jane", Team: "acme"}, []string{"account:jane", "team:acme"}},
+		"token":     {&Identity{Account: "jane", Token: "secret", Team: "acme"}, []string{"token:" + hash("secret"), "team:acme"}},
+	}
+
+	for name, c := range cases {
+		keys := c.id.Keys()
+		if len(keys) != len(c.keys) {
+			t.Fatalf("%s: got %+v, want %v", name, keys, c.keys)
+		}
+
+		for i, key := range keys {
+			if key.Name != c.keys[i] {
+				t.Errorf("%s: got key %q, want %q", name, key.Name, c.keys[i])
+			}
+		}
+	}
+}
+
+func TestHandlerHeaders(t *testing.T) {
+	l := newTestLimiter(t, &Identity{Account: "jane", Team: "acme"})
+
+	rec := serve(l, 1)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// account quota is the most restrictive one
+	if got := rec.Header().Get(HeaderLimit); got != "5" {
+		t.Errorf("got limit %q, want 5", got)
+	}
+
+	if got := rec.Header().Get(HeaderRemaining); got != "4" {
+		t.Errorf("got remaining %q, want 4", got)
+	}
+
+	if got := rec.Header().Get(HeaderReset); got != "1" {
+		t.Errorf("got reset %q, want 1", got)
+	}
+}
+
+func TestHandlerCost(t *testing.T) {
+	l := newTestLimiter(t, &Identity{Account: "jane"})
+
+	for i := 0; i < 2; i++ {
+		if rec := serve(l, 2); rec.Code != http.StatusOK {
+			t.Fatalf("%d: got status %d, want %d", i, rec.Code, http.StatusOK)
+		}
+	}
+
+	rec := serve(l, 2)
+	if rec.Code != 429 {
+		t.Fatalf("got status %d, want 429", rec.Code)
+	}
+
+	if got := rec.Header().Get(HeaderRetryAfter); got != "1" {
+		t.Errorf("got retry after %q, want 1", got)
+	}
+
+	// cheaper requests still fit into the quota
+	if rec := serve(l, 1); rec.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestHandlerTeamQuota(t *testing.T) {
+	id := &Identity{Account: "jane", Team: "acme"}
+	l := newTestLimiter(t, id)
+
+	for i := 0; i < 5; i++ {
+		if rec := serve(l, 1); rec.Code != http.StatusOK {
+			t.Fatalf("%d: got status %d, want %d", i, rec.Code, http.StatusOK)
+		}
+	}
+
+	// jane used the account quota, the team has 3 requests left
+	id.Account = "john"
+
+	for i := 0; i < 3; i++ {
+		if rec := serve(l, 1); rec.Code != http.StatusOK {
+			t.Fatalf("%d: got status %d, want %d", i, rec.Code, http.StatusOK)
+		}
+	}
+
+	if rec := serve(l, 1); rec.Code != 429 {
+		t.Errorf("got status %d, want 429", rec.Code)
+	}
+}
+
+func TestHandlerExempt(t *testing.T) {
+	l := newTestLimiter(t, &Identity{Account: "jane"})
+
+	for i := 0; i < 10; i++ {
+		if rec := serve(l, 0); rec.Code != http.StatusOK || rec.Header().Get(HeaderLimit) != "" {
+			t.Fatalf("%d: expected the request not to be limited", i)
+		}
+	}
+}
+
+// errStore fails all the operations, like redis when it is down
+type errStore struct{}
+
+var errStoreDown = errors.New("store is down")
+
+func (errStore) GetWithTime(string) (int64, time.Time, error) {
+	return 0, time.Time{}, errStoreDown
+}
+
+func (errStore) SetIfNotExistsWithTTL(string, int64, time.Duration) (bool, error) {
+	return false, errStoreDown
+}
+
+func (errStore) CompareAndSwapWithTTL(string, int64, int64, time.Duration) (bool, error) {
+	return false, errStoreDown
+}
+
+func TestHandlerStoreError(t *testing.T) {
+	l := New(errStore{})
+	l.TierFunc = func(string) *Tier { return testTier }
+	l.IdentifyFunc = func(*http.Request) *Identity { return &Identity{Account: "jane"} }
+
+	var errs []error
+	l.Error = func(_ *http.Request, err error) {
+		errs = append(errs, err)
+	}
+
+	for i := 0; i < testTier.Burst+2; i++ {
+		rec := serve(l, 1)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%d: got status %d, want %d", i, rec.Code, http.StatusOK)
+		}
+
+		if rec.Header().Get(HeaderLimit) != "" {
+			t.Fatalf("%d: expected no quota headers", i)
+		}
+	}
+
+	if len(errs) != testTier.Burst+2 || errs[0] != errStoreDown {
+		t.Fatalf("got errors %v, want %d of %v", errs, testTier.Burst+2, errStoreDown)
+	}
+}
+
+func TestTierForPlan(t *testing.T) {
+	cases := map[string]*Tier{
+		"":              Free,
+		payment.Free:    Free,
+		payment.Solo:    Solo,
+		payment.General: Standard,
+		"p_c_acme":      Enterprise,
+	}
+
+	for planID, want := range cases {
+		if got := TierForPlan(planID); got != want {
+			t.Errorf("%q: got %s, want %s", planID, got.Name, want.Name)
+		}
+	}
+}
+
+func TestPlanTiers(t *testing.T) {
+	calls := 0
+	subs := map[string]*stripe.Sub{
+		"active":   {Status: payment.SubStatusActive, Plan: &stripe.Plan{ID: payment.General}},
+		"canceled": {Status: payment.SubStatusCanceled, Plan: &stripe.Plan{ID: payment.General}},
+	}
+
+	p := &PlanTiers{
+		cache:  cache.NewMemoryWithTTL(TierTTL),
+		failed: cache.NewMemoryWithTTL(TierErrorTTL),
+		known:  make(map[string]*Tier),
+		getSubscription: func(groupName string) (*stripe.Sub, error) {
+			calls++
+			if s, ok := subs[groupName]; ok {
+				return s, nil
+			}
+
+			return nil, payment.ErrCustomerNotSubscribedToAnyPlans
+		},
+	}
+
+	cases := map[string]*Tier{
+		"active":   Standard,
+		"canceled": Free,
+		"unknown":  Free,
+		"koding":   Free,
+	}
+
+	for i := 0; i < 2; i++ {
+		for groupName, want := range cases {
+			if got := p.Tier(groupName); got != want {
+				t.Errorf("%s: got %s, want %s", groupName, got.Name, want.Name)
+			}
+		}
+	}
+
+	if calls != 3 {
+		t.Errorf("got %d subscription calls, want 3", calls)
+	}
+}
+
+func TestPlanTiersLookupError(t *testing.T) {
+	var err error
+
+	p := &PlanTiers{
+		cache:  cache.NewMemoryWithTTL(TierTTL),
+		failed: cache.NewMemoryWithTTL(TierErrorTTL),
+		known:  make(map[string]*Tier),
+		getSubscription: func(string) (*stripe.Sub, error) {
+			if err != nil {
+				return nil, err
+			}
+
+			return &stripe.Sub{Status: payment.SubStatusActive, Plan: &stripe.Plan{ID: payment.General}}, nil
+		},
+	}
+
+	if got := p.Tier("acme"); got != Standard {
+		t.Fatalf("got %s, want %s", got.Name, Standard.Name)
+	}
+
+	// the successful lookup expires while the payment provider is down
+	p.cache.Delete("acme")
+	err = errors.New("stripe is down")
+
+	if got := p.Tier("acme"); got != Standard {
+		t.Fatalf("got %s, want last known %s", got.Name, Standard.Name)
+	}
+
+	if got := p.Tier("other"); got != Free {
+		t.Fatalf("got %s, want %s", got.Name, Free.Name)
+	}
+
+	// failed lookups are not cached as the successful ones
+	if _, e := p.cache.Get("other"); e == nil {
+		t.Fatal("expected failed lookup not to be cached for the full ttl")
+	}
+}
@@ -0,0 +1,168 @@
+package quota
+
+import (
+	"socialapi/workers/payment"
+	"sync"
+	"time"
+
+	"github.com/koding/cache"
+	stripe "github.com/stripe/stripe-go"
+)
+
+// Tier holds the request quotas of a payment plan, rates are the sustained
+// requests per minute and bursts are the requests permitted instantly
+type Tier struct {
+	// Name of the tier, it is used in the limiter keys
+	Name string
+
+	// Rate and Burst limit the requests of an account or an API token
+	Rate  int
+	Burst int
+
+	// TeamRate and TeamBurst limit the total requests of a team
+	TeamRate  int
+	TeamBurst int
+}
+
+var (
+	// Free is the tier of the anonymous requests, trials and free teams
+	Free = &Tier{
+		Name:      "free",
+		Rate:      660,
+		Burst:     12,
+		TeamRate:  1200,
+		TeamBurst: 60,
+	}
+
+	// Solo is the tier of the solo plan
+	Solo = &Tier{
+		Name:      "solo",
+		Rate:      1200,
+		Burst:     30,
+		TeamRate:  1200,
+		TeamBurst: 60,
+	}
+
+	// Standard is the tier of the general plan
+	Standard = &Tier{
+		Name:      "standard",
+		Rate:      1800,
+		Burst:     60,
+		TeamRate:  6000,
+		TeamBurst: 300,
+	}
+
+	// Enterprise is the tier of the custom plans
+	Enterprise = &Tier{
+		Name:      "enterprise",
+		Rate:      3600,
+		Burst:     120,
+		TeamRate:  18000,
+		TeamBurst: 600,
+	}
+)
+
+// TierTTL is the duration the tiers of the teams are cached for, plans are
+// fetched from the payment provider
+const TierTTL = 5 * time.Minute
+
+// TierErrorTTL is the duration the failed plan lookups are cached for, the
+// last known tier of the team is used until the next lookup
+const TierErrorTTL = 30 * time.Second
+
+// TierForPlan returns the tier of the payment plan
+func TierForPlan(planID string) *Tier {
+	switch {
+	case planID == payment.Solo:
+		return Solo
+	case planID == payment.General:
+		return Standard
+	case payment.IsCustomPlan(planID):
+		return Enterprise
+	default:
+		return Free
+	}
+}
+
+// PlanTiers resolves the tiers of the teams from their subscriptions, the
+// results are cached since they are needed for every request
+type PlanTiers struct {
+	cache cache.Cache
+
+	// failed caches the tiers of the failed lookups for a shorter time
+	failed cache.Cache
+
+	// known holds the last successfully looked up tiers
+	mu    sync.Mutex
+	known map[string]*Tier
+
+	// getSubscription is replaced in tests
+	getSubscription func(groupName string) (*stripe.Sub, error)
+}
+
+// NewPlanTiers creates a tier resolver
+func NewPlanTiers() *PlanTiers {
+	return &PlanTiers{
+		cache:           cache.NewMemoryWithTTL(TierTTL),
+		failed:          cache.NewMemoryWithTTL(TierErrorTTL),
+		known:           make(map[string]*Tier),
+		getSubscription: payment.GetSubscriptionForGroup,
+	}
+}
+
+// Tier returns the tier of the team, teams without an active or trialing
+// subscription use the free tier. When the subscription can not be looked
+// up, the last known tier of the team is used.
+func (p *PlanTiers) Tier(groupName string) *Tier {
+	if groupName == "" || groupName == "koding" {
+		return Free
+	}
+
+	if t, err := p.cache.Get(groupName); err == nil {
+		return t.(*Tier)
+	}
+
+	if t, err := p.failed.Get(groupName); err == nil {
+		return t.(*Tier)
+	}
+
+	s, err := p.getSubscription(groupName)
+	switch {
+	case err == payment.ErrCustomerNotSubscribedToAnyPlans:
+		s = nil
+	case err != nil:
+		// the payment provider is not called for every request
+		// of the team until it recovers
+		tier := p.lastKnown(groupName)
+		p.failed.Set(groupName, tier)
+		return tier
+	}
+
+	tier := Free
+	if s != nil && s.Plan != nil && isSubActive(s.Status) {
+		tier = TierForPlan(s.Plan.ID)
+	}
+
+	p.cache.Set(groupName, tier)
+
+	p.mu.Lock()
+	p.known[groupName] = tier
+	p.mu.Unlock()
+
+	return tier
+}
+
+func (p *PlanTiers) lastKnown(groupName string) *Tier {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.known[groupName]; ok {
+		return t
+	}
+
+	return Free
+}
+
+func isSubActive(status stripe.SubStatus) bool {
+	return status == payment.SubStatusActive || status == payment.SubStatusTrailing
+}
@@ -9,6 +9,8 @@ import (
 	"socialapi/workers/common/mux"
 	"socialapi/workers/common/response"
 	"socialapi/workers/email/emailsender"
+	"sort"
+	"strings"
 )
 
 // AddHandlers added the internal handlers to the given Muxer
@@ -21,6 +23,15 @@ func AddHandlers(m *mux.Mux) {
 			Endpoint: "/private/mail/publish",
 		},
 	)
+
+	m.AddUnscopedHandler(
+		handler.Request{
+			Handler:  Preview,
+			Name:     "mail-preview",
+			Type:     handler.GetRequest,
+			Endpoint: "/private/mail/preview/{name}",
+		},
+	)
 }
 
 func PublishEvent(u *url.URL, h http.Header, req *emailsender.Mail) (int, http.Header, interface{}, error) {
@@ -30,3 +41,52 @@ func PublishEvent(u *url.URL, h http.Header, req *emailsender.Mail) (int, http.H
 
 	return response.NewDefaultOK()
 }
+
+// Preview renders the named email template with its sample data, without
+// sending anything. The format query parameter selects the "html" (default),
+// "text" or "eml" output; the latter is the complete MIME message.
+func Preview(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	tpl, ok := emailsender.Templates[name]
+	if !ok {
+		var names []string
+		for name := range emailsender.Templates {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		http.Error(w, "template not found, available ones: "+strings.Join(names, ", "), http.StatusNotFound)
+		return
+	}
+
+	msg, err := tpl.Render(tpl.Sample)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(msg.HTML))
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(msg.Text))
+	case "eml":
+		msg.From = "Koding <preview@koding.com>"
+		msg.To = "user@example.com"
+
+		p, err := msg.Bytes()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "message/rfc822")
+		w.Write(p)
+	default:
+		http.Error(w, "unknown format "+format, http.StatusBadRequest)
+	}
+}
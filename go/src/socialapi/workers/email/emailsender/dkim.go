@@ -0,0 +1,175 @@
+package emailsender
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dkimHeaders are names of the headers covered by DKIM signatures.
+var dkimHeaders = []string{"from", "to", "subject", "date", "message-id", "mime-version", "content-type"}
+
+var wsp = regexp.MustCompile(`[ \t]+`)
+
+// DKIMSigner signs messages with the rsa-sha256 algorithm, using
+// the relaxed/relaxed canonicalization (RFC 6376).
+type DKIMSigner struct {
+	Domain   string
+	Selector string
+	Key      *rsa.PrivateKey
+}
+
+// NewDKIMSigner gives new signer with the given PEM encoded RSA key,
+// key is read from a file when it is not PEM encoded.
+func NewDKIMSigner(domain, selector, key string) (*DKIMSigner, error) {
+	p := []byte(key)
+
+	if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		var err error
+		if p, err = ioutil.ReadFile(key); err != nil {
+			return nil, err
+		}
+	}
+
+	block, _ := pem.Decode(p)
+	if block == nil {
+		return nil, errors.New("dkim: invalid PEM private key")
+	}
+
+	var rsaKey *rsa.PrivateKey
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		rsaKey = k
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		if rsaKey, ok = k.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("dkim: private key is not an RSA key")
+		}
+	default:
+		return nil, fmt.Errorf("dkim: unsupported key type %q", block.Type)
+	}
+
+	return &DKIMSigner{
+		Domain:   domain,
+		Selector: selector,
+		Key:      rsaKey,
+	}, nil
+}
+
+// Sign gives the message with the DKIM-Signature header prepended.
+func (s *DKIMSigner) Sign(msg []byte) ([]byte, error) {
+	i := bytes.Index(msg, []byte("\r\n\r\n"))
+	if i == -1 {
+		return nil, errors.New("dkim: message has no body")
+	}
+
+	headers := parseHeaders(msg[:i+2])
+
+	bh := sha256.Sum256(relaxedBody(msg[i+4:]))
+
+	var signed []string
+	h := sha256.New()
+
+	for _, name := range dkimHeaders {
+		if value, ok := headers[name]; ok {
+			signed = append(signed, name)
+			h.Write([]byte(relaxedHeader(name, value) + "\r\n"))
+		}
+	}
+
+	sig := fmt.Sprintf("v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		s.Domain, s.Selector, time.Now().Unix(), strings.Join(signed, ":"),
+		base64.StdEncoding.EncodeToString(bh[:]))
+
+	// the signature header is hashed with an empty b= tag
+	// and without the trailing CRLF
+	h.Write([]byte(relaxedHeader("dkim-signature", sig)))
+
+	b, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("DKIM-Signature: " + sig + base64.StdEncoding.EncodeToString(b) + "\r\n")
+	buf.Write(msg)
+
+	return buf.Bytes(), nil
+}
+
+// parseHeaders gives values of the headers by lowercase names,
+// folded values are kept as they are.
+func parseHeaders(p []byte) map[string]string {
+	headers := make(map[string]string)
+
+	var name string
+	for _, line := range strings.SplitAfter(string(p), "\r\n") {
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && name != "" {
+			headers[name] += line
+			continue
+		}
+
+		i := strings.IndexByte(line, ':')
+		if i == -1 {
+			continue
+		}
+
+		name = strings.ToLower(strings.TrimSpace(line[:i]))
+		headers[name] = line[i+1:]
+	}
+
+	return headers
+}
+
+func relaxedHeader(name, value string) string {
+	value = strings.Replace(value, "\r\n", "", -1)
+	value = wsp.ReplaceAllString(value, " ")
+
+	return strings.ToLower(name) + ":" + strings.TrimSpace(value)
+}
+
+func relaxedBody(p []byte) []byte {
+	lines := strings.Split(string(p), "\r\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(wsp.ReplaceAllString(line, " "), " ")
+	}
+
+	// ignore empty lines at the end of the body
+	for len(lines) != 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
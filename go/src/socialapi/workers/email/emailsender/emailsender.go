@@ -28,22 +28,33 @@ type Controller struct {
 	forcedRecipientEmail    string
 	env                     string
 	host                    string
-	mailgun                 *MailgunSender
+	vmHostname              string
+	sender                  Sender
+
+	// renderNotifications is set when there is no third party to render
+	// the notifications, they are rendered from the templates instead
+	renderNotifications bool
 }
 
-// New Creates a new controller for mail worker
-// func New(exporter eventexporter.Exporter, log logging.Logger, conf runner.Config) *Controller {
-func New(exporter eventexporter.Exporter, log logging.Logger, conf *config.Config) *Controller {
-	vmHostname := conf.Protocol + "//" + conf.Hostname
+// New Creates a new controller for mail worker, emails rendered from
+// the templates are delivered with the sender selected in the config.
+func New(exporter eventexporter.Exporter, log logging.Logger, conf *config.Config) (*Controller, error) {
+	sender, err := NewSender(conf, log)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Controller{
 		emailer:                 exporter,
 		log:                     log,
 		env:                     conf.Environment,
 		host:                    conf.Hostname,
+		vmHostname:              conf.Protocol + "//" + conf.Hostname,
 		forcedRecipientEmail:    conf.Email.ForcedRecipientEmail,
 		forcedRecipientUsername: conf.Email.ForcedRecipientUsername,
-		mailgun:                 NewMailgunSender(vmHostname, log, conf),
-	}
+		sender:                  sender,
+		renderNotifications:     conf.Email.Sender == SenderSMTP || conf.Email.Sender == SenderFile,
+	}, nil
 }
 
 // Send gets the mail struct that includes the message
@@ -70,7 +81,11 @@ func (c *Controller) Process(m *Mail) error {
 	m.SetOption("host", c.host)
 
 	if m.Properties.Options["subject"] == keyInvitedCreateTeam {
-		return c.mailgun.SendMailgunEmail(m)
+		return c.sendTeamInvite(m)
+	}
+
+	if c.renderNotifications {
+		return c.sendNotification(m, user.Email)
 	}
 
 	event := &eventexporter.Event{
@@ -48,9 +48,10 @@ func TestNew(t *testing.T) {
 			}
 
 			exporter := eventexporter.NewFakeExporter()
-			c := New(exporter, r.Log, appConfig)
+			c, err := New(exporter, r.Log, appConfig)
+			So(err, ShouldBeNil)
 
-			err := c.Process(mail)
+			err = c.Process(mail)
 			So(err, ShouldBeNil)
 
 			So(len(exporter.Events), ShouldEqual, 1)
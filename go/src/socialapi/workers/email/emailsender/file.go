@@ -0,0 +1,66 @@
+package emailsender
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/koding/logging"
+)
+
+// DefaultOutputDir is used by FileSender when no directory is configured.
+var DefaultOutputDir = filepath.Join(os.TempDir(), "koding-emails")
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9@._-]+`)
+
+// FileSender writes emails as .eml files into a local directory instead
+// of sending them, it is meant for development.
+type FileSender struct {
+	Dir string
+	Log logging.Logger
+}
+
+var _ Sender = (*FileSender)(nil)
+
+// NewFileSender gives new sender writing to the given directory.
+func NewFileSender(dir string, log logging.Logger) *FileSender {
+	if dir == "" {
+		dir = DefaultOutputDir
+	}
+
+	return &FileSender{
+		Dir: dir,
+		Log: log,
+	}
+}
+
+func (f *FileSender) Send(msg *Message) error {
+	p, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(f.Dir, 0755); err != nil {
+		return err
+	}
+
+	to := msg.To
+	if addr, err := mail.ParseAddress(msg.To); err == nil {
+		to = addr.Address
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), unsafeFileChars.ReplaceAllString(to, "_"))
+	file := filepath.Join(f.Dir, name)
+
+	if err := ioutil.WriteFile(file, p, 0644); err != nil {
+		return err
+	}
+
+	f.Log.Info("Email %q to %s was written to %s", msg.Subject, to, file)
+
+	return nil
+}
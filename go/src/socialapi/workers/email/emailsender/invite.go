@@ -0,0 +1,54 @@
+package emailsender
+
+import (
+	"errors"
+	"fmt"
+	"koding/db/mongodb/modelhelper"
+)
+
+type EmailInvitationUser struct {
+	UserID          string
+	Link            string
+	LinkUnsubscribe string
+	Pin             string
+}
+
+// sendTeamInvite renders the team invitation template and delivers
+// it with the configured sender.
+func (c *Controller) sendTeamInvite(mail *Mail) error {
+	email, ok := mail.Properties.Options["invitee"].(string)
+	if !ok {
+		return errors.New("invitee is not set")
+	}
+
+	link, _ := mail.Properties.Options["link"].(string)
+
+	userObj := EmailInvitationUser{
+		UserID: email,
+		Link:   link,
+	}
+
+	userId := "0"
+
+	user, err := modelhelper.FetchUserByEmail(email)
+	if err == nil {
+		userId = user.ObjectId.Hex()
+
+		if user.EmailFrequency != nil && !user.EmailFrequency.Global {
+			return errors.New("User is unsubscribed from all emails")
+		}
+	}
+
+	userObj.LinkUnsubscribe = fmt.Sprintf("%s/Unsubscribe/%s/%s", c.vmHostname, userId, email)
+
+	msg, err := Templates[TemplateNameTeamInvite].Render(userObj)
+	if err != nil {
+		c.log.Error("Sending email template execute err: %s", err)
+		return err
+	}
+
+	msg.From = emailFrom
+	msg.To = email
+
+	return c.sender.Send(msg)
+}
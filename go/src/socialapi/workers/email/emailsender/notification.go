@@ -0,0 +1,86 @@
+package emailsender
+
+import (
+	"fmt"
+	"koding/db/mongodb/modelhelper"
+	"sort"
+)
+
+// EmailNotification is the data of the notification template.
+type EmailNotification struct {
+	Subject  string
+	Username string
+	Text     string
+	Options  []NotificationOption
+}
+
+// NotificationOption is a single property of the notification.
+type NotificationOption struct {
+	Key   string
+	Value string
+}
+
+// notificationIgnored are the options which are set by the controller
+// itself and are not shown in the notification.
+var notificationIgnored = map[string]bool{
+	"subject": true,
+	"env":     true,
+	"host":    true,
+}
+
+// NewEmailNotification gives the notification data of the given mail,
+// options are sorted by their keys.
+func NewEmailNotification(m *Mail) *EmailNotification {
+	n := &EmailNotification{
+		Subject:  m.Subject,
+		Username: m.Properties.Username,
+		Text:     m.Text,
+	}
+
+	for key, value := range m.Properties.Options {
+		if notificationIgnored[key] || value == nil {
+			continue
+		}
+
+		n.Options = append(n.Options, NotificationOption{
+			Key:   key,
+			Value: fmt.Sprint(value),
+		})
+	}
+
+	sort.Slice(n.Options, func(i, j int) bool {
+		return n.Options[i].Key < n.Options[j].Key
+	})
+
+	return n
+}
+
+// sendNotification renders the notification template and delivers it
+// with the configured sender. It is used instead of the event exporter
+// when emails are sent with SMTP or written to files, as there is no
+// third party to render them.
+func (c *Controller) sendNotification(m *Mail, to string) error {
+	if m.Properties.Username != "" {
+		user, err := modelhelper.GetUser(m.Properties.Username)
+		if err == nil && user.EmailFrequency != nil && !user.EmailFrequency.Global {
+			c.log.Info("User %q is unsubscribed from all emails, skipping %q", user.Name, m.Subject)
+			return nil
+		}
+	}
+
+	msg, err := Templates[TemplateNameNotification].Render(NewEmailNotification(m))
+	if err != nil {
+		c.log.Error("Sending email template execute err: %s", err)
+		return err
+	}
+
+	msg.Subject = m.Subject
+	msg.From = m.From
+	msg.To = to
+
+	if msg.From == "" {
+		msg.From = emailFrom
+	}
+
+	return c.sender.Send(msg)
+}
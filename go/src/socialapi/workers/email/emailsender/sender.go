@@ -0,0 +1,140 @@
+package emailsender
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"socialapi/config"
+
+	"github.com/koding/logging"
+)
+
+const (
+	SenderMailgun = "mailgun"
+	SenderSMTP    = "smtp"
+	SenderFile    = "file"
+)
+
+// Sender delivers rendered emails.
+type Sender interface {
+	Send(msg *Message) error
+}
+
+// NewSender gives the sender selected with the Email.Sender configuration,
+// which is Mailgun by default.
+func NewSender(conf *config.Config, log logging.Logger) (Sender, error) {
+	switch conf.Email.Sender {
+	case "", SenderMailgun:
+		return NewMailgunSender(log, conf), nil
+	case SenderSMTP:
+		return NewSMTPSender(conf.Email.SMTP, log)
+	case SenderFile:
+		return NewFileSender(conf.Email.OutputDir, log), nil
+	default:
+		return nil, fmt.Errorf("unknown email sender %q", conf.Email.Sender)
+	}
+}
+
+// Message is a rendered email, which is ready to be delivered.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Bytes encodes the message as a multipart/alternative MIME message,
+// with both text and HTML parts.
+func (m *Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %s", m.From, err)
+	}
+
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %s", m.To, err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	}
+
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qw := quotedprintable.NewWriter(w)
+
+		if _, err := qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID(from.Address)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}
+
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+func messageID(from string) string {
+	p := make([]byte, 8)
+	rand.Read(p)
+
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i != -1 {
+		domain = from[i+1:]
+	}
+
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(p), domain)
+}
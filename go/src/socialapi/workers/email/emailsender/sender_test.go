@@ -0,0 +1,266 @@
+package emailsender
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/koding/eventexporter"
+	"github.com/koding/logging"
+)
+
+func TestTemplatesRender(t *testing.T) {
+	for name, tpl := range Templates {
+		msg, err := tpl.Render(tpl.Sample)
+		if err != nil {
+			t.Fatalf("%s: Render()=%s", name, err)
+		}
+
+		if msg.Subject == "" || msg.HTML == "" || msg.Text == "" {
+			t.Fatalf("%s: got empty subject or body: %+v", name, msg)
+		}
+	}
+
+	msg, err := Templates[TemplateNameTeamInvite].Render(EmailInvitationUser{
+		UserID: "user@example.com",
+		Link:   "https://koding.com/Teams/Create?token=<token>",
+	})
+	if err != nil {
+		t.Fatalf("Render()=%s", err)
+	}
+
+	if !strings.Contains(msg.HTML, "token=%3ctoken%3e") {
+		t.Errorf("expected link to be escaped in HTML: %s", msg.HTML)
+	}
+
+	if !strings.Contains(msg.Text, "token=<token>") {
+		t.Errorf("expected link to be unescaped in text: %s", msg.Text)
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	msg := &Message{
+		From:    "Koding <hello@koding.com>",
+		To:      "user@example.com",
+		Subject: "Hello, ünicode",
+		Text:    "text body",
+		HTML:    "<p>html body</p>",
+	}
+
+	p, err := msg.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes()=%s", err)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(p))
+	if err != nil {
+		t.Fatalf("ReadMessage()=%s", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("DecodeHeader()=%s", err)
+	}
+
+	if subject != msg.Subject {
+		t.Errorf("got subject %q, want %q", subject, msg.Subject)
+	}
+
+	_, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType()=%s", err)
+	}
+
+	mr := multipart.NewReader(m.Body, params["boundary"])
+
+	for _, want := range []string{msg.Text, msg.HTML} {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart()=%s", err)
+		}
+
+		got, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatalf("ReadAll()=%s", err)
+		}
+
+		if string(got) != want {
+			t.Errorf("got part %q, want %q", got, want)
+		}
+	}
+
+	if _, err := (&Message{From: "invalid", To: msg.To}).Bytes(); err == nil {
+		t.Error("expected error for invalid sender")
+	}
+}
+
+func TestFileSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "emailsender")
+	if err != nil {
+		t.Fatalf("TempDir()=%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	msg := &Message{
+		From:    "Koding <hello@koding.com>",
+		To:      "User <user@example.com>",
+		Subject: "Test",
+		Text:    "text body",
+	}
+
+	if err := NewFileSender(dir, logging.NewLogger("test")).Send(msg); err != nil {
+		t.Fatalf("Send()=%s", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-user@example.com.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("got %v files (err=%v), want 1", files, err)
+	}
+}
+
+func TestDKIMSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey()=%s", err)
+	}
+
+	pemKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	s, err := NewDKIMSigner("koding.com", "mail", string(pemKey))
+	if err != nil {
+		t.Fatalf("NewDKIMSigner()=%s", err)
+	}
+
+	msg := &Message{
+		From:    "Koding <hello@koding.com>",
+		To:      "user@example.com",
+		Subject: "Signed",
+		Text:    "text  body \r\n\r\n",
+		HTML:    "<p>html body</p>",
+	}
+
+	p, err := msg.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes()=%s", err)
+	}
+
+	signed, err := s.Sign(p)
+	if err != nil {
+		t.Fatalf("Sign()=%s", err)
+	}
+
+	if err := verifyDKIM(signed, &key.PublicKey); err != nil {
+		t.Fatalf("verifyDKIM()=%s", err)
+	}
+
+	// any change of the body must invalidate the signature
+	tampered := bytes.Replace(signed, []byte("html body"), []byte("html bodY"), 1)
+
+	if err := verifyDKIM(tampered, &key.PublicKey); err == nil {
+		t.Fatal("expected tampered message to fail verification")
+	}
+}
+
+// verifyDKIM verifies the signature of a message signed by DKIMSigner.
+func verifyDKIM(msg []byte, pub *rsa.PublicKey) error {
+	i := bytes.Index(msg, []byte("\r\n\r\n"))
+	headers := parseHeaders(msg[:i+2])
+
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(headers["dkim-signature"], ";") {
+		if kv := strings.SplitN(strings.TrimSpace(tag), "=", 2); len(kv) == 2 {
+			tags[kv[0]] = kv[1]
+		}
+	}
+
+	bh := sha256.Sum256(relaxedBody(msg[i+4:]))
+	if base64.StdEncoding.EncodeToString(bh[:]) != tags["bh"] {
+		return rsa.ErrVerification
+	}
+
+	h := sha256.New()
+	for _, name := range strings.Split(tags["h"], ":") {
+		h.Write([]byte(relaxedHeader(name, headers[name]) + "\r\n"))
+	}
+
+	sig := strings.TrimSpace(headers["dkim-signature"])
+	h.Write([]byte(relaxedHeader("dkim-signature", strings.TrimSuffix(sig, tags["b"]))))
+
+	b, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return err
+	}
+
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, h.Sum(nil), b)
+}
+
+type fakeSender struct {
+	messages []*Message
+}
+
+func (f *fakeSender) Send(msg *Message) error {
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func TestProcessRendersNotification(t *testing.T) {
+	sender := &fakeSender{}
+	exporter := eventexporter.NewFakeExporter()
+
+	c := &Controller{
+		log:                 logging.NewLogger("test"),
+		emailer:             exporter,
+		sender:              sender,
+		renderNotifications: true,
+	}
+
+	mail := &Mail{
+		To:      "user@example.com",
+		Subject: "credit card removed",
+		Properties: &Properties{
+			Options: map[string]interface{}{"groupName": "koding"},
+		},
+	}
+
+	if err := c.Process(mail); err != nil {
+		t.Fatalf("Process()=%s", err)
+	}
+
+	if len(exporter.Events) != 0 {
+		t.Errorf("got %d exported events, want 0", len(exporter.Events))
+	}
+
+	if len(sender.messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(sender.messages))
+	}
+
+	msg := sender.messages[0]
+
+	if msg.To != mail.To || msg.Subject != mail.Subject || msg.From != emailFrom {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if !strings.Contains(msg.Text, "groupName: koding") {
+		t.Errorf("expected options in text: %s", msg.Text)
+	}
+
+	if strings.Contains(msg.Text, "subject:") {
+		t.Errorf("expected controller options to be left out: %s", msg.Text)
+	}
+}
@@ -0,0 +1,113 @@
+package emailsender
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+
+	"socialapi/config"
+
+	"github.com/koding/logging"
+)
+
+// SMTPSender delivers emails through an SMTP relay.
+type SMTPSender struct {
+	Conf config.SMTP
+	DKIM *DKIMSigner // nil when messages are not signed
+	Log  logging.Logger
+}
+
+var _ Sender = (*SMTPSender)(nil)
+
+// NewSMTPSender gives new sender for the configured relay.
+func NewSMTPSender(conf config.SMTP, log logging.Logger) (*SMTPSender, error) {
+	if conf.Host == "" {
+		return nil, errors.New("smtp: host is not set")
+	}
+
+	if conf.Port == "" {
+		conf.Port = "25"
+	}
+
+	s := &SMTPSender{
+		Conf: conf,
+		Log:  log,
+	}
+
+	if conf.DKIMPrivateKey != "" {
+		signer, err := NewDKIMSigner(conf.DKIMDomain, conf.DKIMSelector, conf.DKIMPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		s.DKIM = signer
+	}
+
+	return s, nil
+}
+
+func (s *SMTPSender) Send(msg *Message) error {
+	p, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if s.DKIM != nil {
+		if p, err = s.DKIM.Sign(p); err != nil {
+			return err
+		}
+	}
+
+	// addresses were validated by msg.Bytes
+	from, _ := mail.ParseAddress(msg.From)
+	to, _ := mail.ParseAddress(msg.To)
+
+	c, err := smtp.Dial(net.JoinHostPort(s.Conf.Host, s.Conf.Port))
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if s.Conf.StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("smtp: server does not support STARTTLS")
+		}
+
+		if err := c.StartTLS(&tls.Config{ServerName: s.Conf.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.Conf.Username != "" {
+		auth := smtp.PlainAuth("", s.Conf.Username, s.Conf.Password, s.Conf.Host)
+
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(p); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
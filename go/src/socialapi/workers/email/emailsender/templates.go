@@ -1,6 +1,73 @@
 // Package sender provides an API for mail sending operations
 package emailsender
 
+import (
+	"bytes"
+	"html/template"
+	texttemplate "text/template"
+)
+
+const (
+	TemplateNameTeamInvite   = "teamInvite"
+	TemplateNameNotification = "notification"
+)
+
+// Template is an email rendered to both HTML and text.
+type Template struct {
+	Subject string
+	HTML    *template.Template
+	Text    *texttemplate.Template
+
+	// Sample is rendered by template previews.
+	Sample interface{}
+}
+
+// Templates are the emails, which are rendered by emailsender, by names.
+var Templates = map[string]*Template{
+	TemplateNameTeamInvite: {
+		Subject: subjectInvitedCreateTeam,
+		HTML:    template.Must(template.New(TemplateNameTeamInvite).Parse(TemplateTeamInvite)),
+		Text:    texttemplate.Must(texttemplate.New(TemplateNameTeamInvite).Parse(TemplateTeamInviteText)),
+		Sample: EmailInvitationUser{
+			UserID:          "user@example.com",
+			Link:            "https://koding.com/Teams/Create?token=sample",
+			LinkUnsubscribe: "https://koding.com/Unsubscribe/0/user@example.com",
+		},
+	},
+	TemplateNameNotification: {
+		Subject: "Koding notification",
+		HTML:    template.Must(template.New(TemplateNameNotification).Parse(TemplateNotification)),
+		Text:    texttemplate.Must(texttemplate.New(TemplateNameNotification).Parse(TemplateNotificationText)),
+		Sample: &EmailNotification{
+			Subject:  "credit card removed",
+			Username: "user",
+			Options: []NotificationOption{
+				{Key: "groupName", Value: "koding"},
+			},
+		},
+	},
+}
+
+// Render gives a message with the subject and bodies of the template
+// rendered with the given data. Sender and recipient are not set.
+func (t *Template) Render(data interface{}) (*Message, error) {
+	var html, text bytes.Buffer
+
+	if err := t.HTML.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	if err := t.Text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: t.Subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
 var TemplateTeamInvite = `<html>
   <head>
     <title></title>
@@ -173,3 +240,42 @@ Features you'll only find on Koding For Teams:
 
 : >
 Team Koding`
+
+var TemplateNotification = `<html>
+  <head>
+    <meta charset="UTF-8" />
+    <title>{{ .Subject }}</title>
+  </head>
+  <body style="background: #fafafa;">
+    <div style="background: #fafafa; color: #565656; font-family: 'HelveticaNeue', 'Helvetica Neue', Helvetica, Arial, 'Lucida Grande', sans-serif; font-size: 14px; padding: 40px 0;">
+      <div style="margin: 0 auto; max-width: 575px; background: #fff; border: 1px solid #e0e0e0; border-radius: 3px; padding: 35px 45px 41px;">
+        <p style="font-size: 20px; margin: 0 0 30px;">{{ .Subject }}</p>
+        {{ if .Username }}<p>Hi {{ .Username }},</p>{{ end }}
+        {{ if .Text }}<p style="line-height: 21px;">{{ .Text }}</p>{{ end }}
+        {{ if .Options }}
+        <table style="font-size: 14px; margin: 20px 0;">
+          <tbody>
+          {{ range .Options }}
+          <tr>
+            <td style="padding-right: 20px; vertical-align: top;">{{ .Key }}</td>
+            <td>{{ .Value }}</td>
+          </tr>
+          {{ end }}
+          </tbody>
+        </table>
+        {{ end }}
+        <p style="line-height: 23px; margin: 30px 0 0;">Team Koding</p>
+      </div>
+    </div>
+  </body>
+</html>`
+
+var TemplateNotificationText = `{{ .Subject }}
+{{ if .Username }}
+Hi {{ .Username }},
+{{ end }}{{ if .Text }}
+{{ .Text }}
+{{ end }}{{ if .Options }}
+{{ range .Options }}{{ .Key }}: {{ .Value }}
+{{ end }}{{ end }}
+Team Koding`
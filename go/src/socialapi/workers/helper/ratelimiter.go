@@ -8,6 +8,10 @@ import (
 )
 
 // NewDefaultRateLimiter creates rate limiter with sane configuration for koding
+//
+// It is process local and keyed by the client id, it is meant for the private
+// endpoints. Public endpoints are limited by the quotas of the mux, see the
+// socialapi/workers/common/quota package.
 func NewDefaultRateLimiter() *throttled.HTTPRateLimiter {
 	memStore, err := memstore.New(65536)
 	if err != nil {
@@ -0,0 +1,130 @@
+// Package metering provides the logical part of the metering worker, which
+// records the running intervals of the machines from their state transitions
+// and reports the machine hours of the teams to the payment provider
+package metering
+
+import (
+	"encoding/json"
+	"socialapi/models"
+	"socialapi/workers/payment"
+	"socialapi/workers/webhook"
+	"time"
+
+	"github.com/koding/logging"
+	"github.com/robfig/cron"
+	"github.com/streadway/amqp"
+)
+
+// Schedule reports the machine hours at every hour
+const Schedule = "0 5 * * * *"
+
+// ReportWindow is the duration, machine hours of the groups which had a
+// running machine within it are reported. Reports are idempotent, so it is
+// longer than the schedule to recover from the missed runs.
+const ReportWindow = 24 * time.Hour
+
+// Controller holds the basic context data for handlers
+type Controller struct {
+	log     logging.Logger
+	cronJob *cron.Cron
+	ready   chan bool
+}
+
+// New creates a controller
+func New(log logging.Logger) *Controller {
+	c := &Controller{
+		log:   log,
+		ready: make(chan bool, 1),
+	}
+
+	c.ready <- true
+
+	return c
+}
+
+// DefaultErrHandler handles the errors for metering worker
+func (c *Controller) DefaultErrHandler(delivery amqp.Delivery, err error) bool {
+	c.log.Error("an error occurred putting message back to queue: %s", err)
+	delivery.Nack(false, true)
+	return false
+}
+
+// machineData is the part of the machine events' data, which is required for
+// the metering
+type machineData struct {
+	MachineId string `json:"machineId"`
+}
+
+// Handle records the state transitions of the machines
+func (c *Controller) Handle(ev *webhook.Event) error {
+	if ev.Name != webhook.EventMachineStarted && ev.Name != webhook.EventMachineStopped {
+		return nil
+	}
+
+	var data machineData
+	if err := json.Unmarshal(ev.Data, &data); err != nil || data.MachineId == "" {
+		c.log.Error("invalid machine event %+v", ev)
+		return nil
+	}
+
+	if ev.Name == webhook.EventMachineStarted {
+		return models.NewMachineUsage().Start(ev.GroupName, data.MachineId, ev.CreatedAt)
+	}
+
+	return models.NewMachineUsage().Stop(data.MachineId, ev.CreatedAt)
+}
+
+// Schedule starts reporting the machine hours periodically
+func (c *Controller) Schedule() error {
+	c.cronJob = cron.New()
+	if err := c.cronJob.AddFunc(Schedule, c.CronStart); err != nil {
+		return err
+	}
+
+	c.cronJob.Start()
+
+	return nil
+}
+
+// Shutdown stops the scheduled reports
+func (c *Controller) Shutdown() {
+	if c.cronJob != nil {
+		c.cronJob.Stop()
+	}
+}
+
+// CronStart reports the machine hours, unless the previous run is ongoing
+func (c *Controller) CronStart() {
+	select {
+	case <-c.ready:
+		c.Report(time.Now().UTC())
+		c.ready <- true
+	default:
+		c.log.Debug("Ongoing report process")
+	}
+}
+
+// Report reports the machine hours of the groups, which had a running machine
+// within the report window. Intervals are reconciled with the machine states
+// first, so the lost machine events do not affect the reported hours.
+func (c *Controller) Report(now time.Time) {
+	c.Reconcile(now)
+
+	groupNames, err := models.NewMachineUsage().FetchGroupNamesSince(now.Add(-ReportWindow))
+	if err != nil {
+		c.log.Error("could not fetch metered groups: %s", err)
+		return
+	}
+
+	for _, groupName := range groupNames {
+		record, err := payment.ReportMachineUsageForGroup(groupName, now)
+		switch err {
+		case nil:
+			c.log.Debug("reported %d machine hours of %q", record.Quantity, groupName)
+		case payment.ErrSubscriptionNotActive, payment.ErrCustomerNotExists:
+			// trials and free teams are not metered
+		default:
+			c.log.Error("could not report machine hours of %q: %s", groupName, err)
+		}
+	}
+}
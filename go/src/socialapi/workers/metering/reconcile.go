@@ -0,0 +1,93 @@
+package metering
+
+import (
+	"koding/db/mongodb/modelhelper"
+	"koding/kites/kloud/machinestate"
+	"socialapi/models"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// halted reports whether the machine is not running in the given state
+func halted(s machinestate.State) bool {
+	return s.In(machinestate.Stopped, machinestate.Terminated, machinestate.NotInitialized)
+}
+
+// Reconcile syncs the running intervals with the persisted states of the
+// machines. Machine events are published in the background and they can be
+// lost, so the open intervals of the halted or deleted machines are closed
+// and the running machines without an open interval are started.
+func (c *Controller) Reconcile(now time.Time) {
+	c.closeHalted(now)
+	c.openRunning(now)
+}
+
+func (c *Controller) closeHalted(now time.Time) {
+	usages, err := models.NewMachineUsage().FetchOpen()
+	if err != nil {
+		c.log.Error("could not fetch open machine intervals: %s", err)
+		return
+	}
+
+	for _, u := range usages {
+		stoppedAt := now
+
+		m, err := modelhelper.GetMachine(u.MachineId)
+		switch {
+		case err == mgo.ErrNotFound:
+			// machine is deleted, it is not running anymore
+		case err != nil:
+			c.log.Error("could not fetch machine %q: %s", u.MachineId, err)
+			continue
+		case !halted(m.State()):
+			continue
+		case !m.Status.ModifiedAt.IsZero() && m.Status.ModifiedAt.Before(now):
+			stoppedAt = m.Status.ModifiedAt
+		}
+
+		if err := models.NewMachineUsage().Stop(u.MachineId, stoppedAt); err != nil {
+			c.log.Error("could not stop machine interval %q: %s", u.MachineId, err)
+		}
+	}
+}
+
+func (c *Controller) openRunning(now time.Time) {
+	machines, err := modelhelper.GetRunningMachines()
+	if err != nil {
+		c.log.Error("could not fetch running machines: %s", err)
+		return
+	}
+
+	groupNames := make(map[string]string)
+
+	for _, m := range machines {
+		if len(m.Groups) == 0 || !m.Groups[0].Id.Valid() {
+			continue
+		}
+
+		groupId := m.Groups[0].Id.Hex()
+
+		groupName, ok := groupNames[groupId]
+		if !ok {
+			group, err := modelhelper.GetGroupById(groupId)
+			if err != nil {
+				c.log.Error("could not fetch group %q: %s", groupId, err)
+				continue
+			}
+
+			groupName = group.Slug
+			groupNames[groupId] = groupName
+		}
+
+		startedAt := now
+		if !m.Status.ModifiedAt.IsZero() && m.Status.ModifiedAt.Before(now) {
+			startedAt = m.Status.ModifiedAt
+		}
+
+		// it is a no-op when the machine has an open interval
+		if err := models.NewMachineUsage().Start(groupName, m.ObjectId.Hex(), startedAt); err != nil {
+			c.log.Error("could not start machine interval %q: %s", m.ObjectId.Hex(), err)
+		}
+	}
+}
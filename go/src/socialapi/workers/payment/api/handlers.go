@@ -18,6 +18,7 @@ const (
 	EndpointCreditCardAuth       = "/payment/creditcard/auth"
 	EndpointWebhook              = "/payment/webhook"
 	EndpointInvoiceList          = "/payment/invoice/list"
+	EndpointInvoiceUpcoming      = "/payment/invoice/upcoming"
 	EndpointInfo                 = "/payment/info"
 	EndpointCustomCustomerCreate = "/payment/custom-customer/create"
 )
@@ -143,6 +144,15 @@ func AddHandlers(m *mux.Mux) {
 		},
 	)
 
+	m.AddHandler(
+		handler.Request{
+			Handler:  UpcomingInvoices,
+			Name:     "payment-upcoming-invoices",
+			Type:     handler.GetRequest,
+			Endpoint: EndpointInvoiceUpcoming,
+		},
+	)
+
 	m.AddHandler(
 		handler.Request{
 			Handler:  Info,
@@ -30,3 +30,14 @@ func ListInvoice(u *url.URL, h http.Header, _ interface{}, context *models.Conte
 		),
 	)
 }
+
+// UpcomingInvoices previews the next invoices of group
+func UpcomingInvoices(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(
+		payment.GetUpcomingInvoicesForGroup(context.GroupName),
+	)
+}
@@ -35,3 +35,52 @@ func ListInvoicesForGroup(groupName string, startingAfter string, limit int) ([]
 
 	return invoices, nil
 }
+
+// GetUpcomingInvoicesForGroup previews the next invoices of a group, one for
+// the team subscription and one for the machine hours, if the group is
+// subscribed to the metered plan.
+func GetUpcomingInvoicesForGroup(groupName string) ([]*stripe.Invoice, error) {
+	group, err := modelhelper.GetGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Payment.Customer.ID == "" {
+		return nil, ErrCustomerNotExists
+	}
+
+	if group.Payment.Subscription.ID == "" {
+		return nil, ErrCustomerNotSubscribedToAnyPlans
+	}
+
+	subIDs := []string{group.Payment.Subscription.ID}
+
+	metered, err := getMeteredSubscription(group.Payment.Customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if metered != nil {
+		subIDs = append(subIDs, metered.ID)
+	}
+
+	return getUpcomingInvoices(group.Payment.Customer.ID, subIDs...)
+}
+
+func getUpcomingInvoices(customerID string, subIDs ...string) ([]*stripe.Invoice, error) {
+	invoices := make([]*stripe.Invoice, 0, len(subIDs))
+
+	for _, subID := range subIDs {
+		i, err := invoice.GetNext(&stripe.InvoiceParams{
+			Customer: customerID,
+			Sub:      subID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		invoices = append(invoices, i)
+	}
+
+	return invoices, nil
+}
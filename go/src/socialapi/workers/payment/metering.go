@@ -0,0 +1,181 @@
+package payment
+
+import (
+	"errors"
+	"koding/db/mongodb/modelhelper"
+	"math"
+	"net/url"
+	"socialapi/models"
+	"strconv"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/sub"
+)
+
+// ErrSubscriptionNotActive is returned when the machine usage of a group is
+// reported while its subscription is not active, trials are not metered.
+var ErrSubscriptionNotActive = errors.New("subscription is not active")
+
+// UsageRecord is the resource representing a Stripe usage record, which holds
+// the machine hours of the billing period.
+type UsageRecord struct {
+	ID        string `json:"id"`
+	Quantity  int64  `json:"quantity"`
+	SubItem   string `json:"subscription_item"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// subItem is the resource representing a Stripe subscription item, the
+// vendored stripe client does not support them yet.
+type subItem struct {
+	ID   string       `json:"id"`
+	Plan *stripe.Plan `json:"plan"`
+}
+
+type subItemList struct {
+	stripe.ListMeta
+	Values []*subItem `json:"data"`
+}
+
+// ReportMachineUsageForGroup reports the running time of the machines of the
+// group in the current billing period. Machine hours are billed with a
+// separate subscription to the metered plan, because the rest of the payment
+// flow relies on the single plan of the team's subscription. Usage records
+// hold the total hours of the period, so reporting is idempotent.
+func ReportMachineUsageForGroup(groupName string, now time.Time) (*UsageRecord, error) {
+	group, err := modelhelper.GetGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Payment.Customer.ID == "" {
+		return nil, ErrCustomerNotExists
+	}
+
+	if stripe.SubStatus(group.Payment.Subscription.Status) != SubStatusActive {
+		return nil, ErrSubscriptionNotActive
+	}
+
+	s, err := ensureMeteredSubscription(group.Payment.Customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reportMachineUsage(groupName, s, now)
+}
+
+// reportMachineUsage sets the machine hours of the current billing period of
+// the metered subscription.
+func reportMachineUsage(groupName string, s *stripe.Sub, now time.Time) (*UsageRecord, error) {
+	seconds, err := (&models.MachineUsage{}).SumSecondsByGroupName(groupName, time.Unix(s.PeriodStart, 0), now)
+	if err != nil {
+		return nil, err
+	}
+
+	return reportMachineHours(s, machineHours(seconds), now)
+}
+
+// ensureMeteredSubscription fetches the metered subscription of the customer,
+// it is created if the customer is not subscribed to the metered plan yet.
+func ensureMeteredSubscription(customerID string) (*stripe.Sub, error) {
+	if s, err := getMeteredSubscription(customerID); err != nil || s != nil {
+		return s, err
+	}
+
+	return sub.New(&stripe.SubParams{
+		Customer: customerID,
+		Plan:     MachineHour,
+	})
+}
+
+// getMeteredSubscription fetches the metered subscription of the customer, it
+// returns nil if the customer is not subscribed to the metered plan.
+func getMeteredSubscription(customerID string) (*stripe.Sub, error) {
+	i := sub.List(&stripe.SubListParams{
+		Customer: customerID,
+		Plan:     MachineHour,
+	})
+
+	for i.Next() {
+		if s := i.Sub(); s.Status != SubStatusCanceled {
+			return s, nil
+		}
+	}
+
+	return nil, i.Err()
+}
+
+// cancelMeteredSubscription cancels the metered subscription of the group,
+// the usage of the current billing period is invoiced immediately.
+func cancelMeteredSubscription(groupName, customerID string) error {
+	s, err := getMeteredSubscription(customerID)
+	if err != nil || s == nil {
+		return err
+	}
+
+	if _, err := reportMachineUsage(groupName, s, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	params := &stripe.SubParams{Customer: customerID}
+	params.AddExtra("invoice_now", "true")
+
+	_, err = sub.Cancel(s.ID, params)
+	return err
+}
+
+// reportMachineHours creates the usage record of the metered subscription
+func reportMachineHours(s *stripe.Sub, hours int64, now time.Time) (*UsageRecord, error) {
+	itemID, err := getMeteredItemID(s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// usage can not be recorded after the period ends, the subscription
+	// might not be renewed yet
+	timestamp := now.Unix()
+	if s.PeriodEnd != 0 && timestamp >= s.PeriodEnd {
+		timestamp = s.PeriodEnd - 1
+	}
+
+	body := &stripe.RequestValues{}
+	body.Add("quantity", strconv.FormatInt(hours, 10))
+	body.Add("timestamp", strconv.FormatInt(timestamp, 10))
+	body.Add("action", "set")
+
+	record := &UsageRecord{}
+	path := "/subscription_items/" + url.QueryEscape(itemID) + "/usage_records"
+	err = stripe.GetBackend(stripe.APIBackend).Call("POST", path, stripe.Key, body, nil, record)
+
+	return record, err
+}
+
+// getMeteredItemID fetches the id of the subscription item of the metered plan
+func getMeteredItemID(subID string) (string, error) {
+	body := &stripe.RequestValues{}
+	body.Add("subscription", subID)
+
+	list := &subItemList{}
+	if err := stripe.GetBackend(stripe.APIBackend).Call("GET", "/subscription_items", stripe.Key, body, nil, list); err != nil {
+		return "", err
+	}
+
+	for _, item := range list.Values {
+		if item.Plan != nil && item.Plan.ID == MachineHour {
+			return item.ID, nil
+		}
+	}
+
+	return "", ErrCustomerNotSubscribedToAnyPlans
+}
+
+// machineHours converts the running time into billed hours, started hours
+// are billed as a whole.
+func machineHours(seconds int64) int64 {
+	if seconds <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(float64(seconds) / 3600))
+}
@@ -0,0 +1,176 @@
+package payment
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+// withStripeMock routes the stripe requests to the given handler
+func withStripeMock(t *testing.T, h http.HandlerFunc, f func()) {
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	stripe.SetBackend(stripe.APIBackend, stripe.BackendConfiguration{
+		Type:       stripe.APIBackend,
+		URL:        ts.URL + "/v1",
+		HTTPClient: http.DefaultClient,
+	})
+	defer stripe.SetBackend(stripe.APIBackend, nil)
+
+	f()
+}
+
+func TestMachineHours(t *testing.T) {
+	cases := map[int64]int64{
+		-1:   0,
+		0:    0,
+		1:    1,
+		3600: 1,
+		3601: 2,
+		7200: 2,
+	}
+
+	for seconds, want := range cases {
+		if got := machineHours(seconds); got != want {
+			t.Errorf("machineHours(%d)=%d, want %d", seconds, got, want)
+		}
+	}
+}
+
+func TestMachineHourPlan(t *testing.T) {
+	plan := GetPlan(MachineHour)
+	if plan == nil {
+		t.Fatal("expected the metered plan to be defined")
+	}
+
+	if got := plan.Extra.Get("usage_type"); got != "metered" {
+		t.Errorf("got usage_type %q, want metered", got)
+	}
+
+	if got := plan.Extra.Get("aggregate_usage"); got != "last_during_period" {
+		t.Errorf("got aggregate_usage %q, want last_during_period", got)
+	}
+}
+
+func TestReportMachineHours(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+
+	cases := map[string]struct {
+		periodEnd int64
+		timestamp string
+	}{
+		"within period": {now.Unix() + 3600, "1500000000"},
+		"period ended":  {now.Unix() - 10, "1499999989"},
+	}
+
+	for name, c := range cases {
+		var usageRecords int
+
+		h := func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "GET" && r.URL.Path == "/v1/subscription_items":
+				if got := r.URL.Query().Get("subscription"); got != "sub_metered" {
+					t.Errorf("%s: got subscription %q, want sub_metered", name, got)
+				}
+
+				fmt.Fprint(w, `{"object":"list","data":[
+					{"id":"si_seat","plan":{"id":"p_general"}},
+					{"id":"si_hours","plan":{"id":"p_machine_hour"}}
+				]}`)
+
+			case r.Method == "POST" && r.URL.Path == "/v1/subscription_items/si_hours/usage_records":
+				usageRecords++
+
+				if err := r.ParseForm(); err != nil {
+					t.Fatalf("%s: ParseForm()=%s", name, err)
+				}
+
+				if got := r.PostForm.Get("quantity"); got != "42" {
+					t.Errorf("%s: got quantity %q, want 42", name, got)
+				}
+
+				if got := r.PostForm.Get("timestamp"); got != c.timestamp {
+					t.Errorf("%s: got timestamp %q, want %s", name, got, c.timestamp)
+				}
+
+				if got := r.PostForm.Get("action"); got != "set" {
+					t.Errorf("%s: got action %q, want set", name, got)
+				}
+
+				fmt.Fprintf(w, `{"id":"mbur_1","quantity":42,"subscription_item":"si_hours","timestamp":%s}`, c.timestamp)
+
+			default:
+				t.Errorf("%s: unexpected request %s %s", name, r.Method, r.URL)
+				http.NotFound(w, r)
+			}
+		}
+
+		withStripeMock(t, h, func() {
+			s := &stripe.Sub{ID: "sub_metered", PeriodEnd: c.periodEnd}
+
+			record, err := reportMachineHours(s, 42, now)
+			if err != nil {
+				t.Fatalf("%s: reportMachineHours()=%s", name, err)
+			}
+
+			if record.ID != "mbur_1" || record.Quantity != 42 || record.SubItem != "si_hours" {
+				t.Errorf("%s: unexpected usage record %+v", name, record)
+			}
+		})
+
+		if usageRecords != 1 {
+			t.Errorf("%s: got %d usage records, want 1", name, usageRecords)
+		}
+	}
+}
+
+func TestGetUpcomingInvoices(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/v1/invoices/upcoming" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+
+		q := r.URL.Query()
+		if got := q.Get("customer"); got != "cus_1" {
+			t.Errorf("got customer %q, want cus_1", got)
+		}
+
+		fmt.Fprintf(w, `{"object":"invoice","amount_due":100,"subscription":%q}`, q.Get("subscription"))
+	}
+
+	withStripeMock(t, h, func() {
+		invoices, err := getUpcomingInvoices("cus_1", "sub_seat", "sub_metered")
+		if err != nil {
+			t.Fatalf("getUpcomingInvoices()=%s", err)
+		}
+
+		if len(invoices) != 2 {
+			t.Fatalf("got %d invoices, want 2", len(invoices))
+		}
+
+		for i, subID := range []string{"sub_seat", "sub_metered"} {
+			if invoices[i].Sub != subID || invoices[i].Amount != 100 {
+				t.Errorf("%d: unexpected invoice %+v", i, invoices[i])
+			}
+		}
+	})
+}
+
+func TestGetMeteredItemIDNotSubscribed(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"si_seat","plan":{"id":"p_general"}}]}`)
+	}
+
+	withStripeMock(t, h, func() {
+		if _, err := getMeteredItemID("sub_seat"); err != ErrCustomerNotSubscribedToAnyPlans {
+			t.Errorf("got %v, want %v", err, ErrCustomerNotSubscribedToAnyPlans)
+		}
+	})
+}
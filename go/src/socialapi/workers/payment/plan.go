@@ -1,7 +1,9 @@
 package payment
 
 import (
+	"net/url"
 	"socialapi/config"
+	"strings"
 
 	stripe "github.com/stripe/stripe-go"
 	"github.com/stripe/stripe-go/currency"
@@ -20,6 +22,10 @@ const (
 	Free    = "p_free"
 	Solo    = "p_solo"
 	General = "p_general"
+
+	// MachineHour is the metered plan of the machine running time, it is
+	// billed with a separate subscription, see ReportMachineUsageForGroup
+	MachineHour = "p_machine_hour"
 )
 
 // plans holds koding provided plans on stripe
@@ -57,6 +63,24 @@ var plans = map[string]*stripe.PlanParams{
 		ID:            General,
 		Statement:     "GENERAL",
 	},
+
+	MachineHour: {
+		Amount:        2, // 0.02$ per hour
+		Interval:      stripeplan.Month,
+		IntervalCount: 1,
+		TrialPeriod:   0,
+		Name:          "Machine Hour",
+		Currency:      currency.USD,
+		ID:            MachineHour,
+		Statement:     "MACHINE HOURS",
+		Params: stripe.Params{
+			// usage records hold the total hours of the billing period
+			Extra: url.Values{
+				"usage_type":      {"metered"},
+				"aggregate_usage": {"last_during_period"},
+			},
+		},
+	},
 }
 
 // GetPlan returns the plan by its name. User should check for existence.
@@ -64,6 +88,11 @@ func GetPlan(name string) *stripe.PlanParams {
 	return plans[name]
 }
 
+// IsCustomPlan reports whether the plan is created for a specific team
+func IsCustomPlan(planID string) bool {
+	return strings.HasPrefix(planID, customPlanPrefix)
+}
+
 // GetPlanID returns id of the plan according to the give user count
 func GetPlanID(userCount int) string {
 	switch {
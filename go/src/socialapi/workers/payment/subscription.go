@@ -38,6 +38,11 @@ func CancelSubscriptionForGroup(groupName string) (*stripe.Sub, error) {
 		return nil, err
 	}
 
+	// charge the machine hours of the current period along with the seats
+	if err := cancelMeteredSubscription(groupName, group.Payment.Customer.ID); err != nil {
+		return nil, err
+	}
+
 	if err := switchToNewSub(info); err != nil {
 		return nil, err
 	}
@@ -213,8 +218,16 @@ func syncGroupWithCustomerID(cusID string) error {
 		return err
 	}
 
+	// machine hours are billed with a separate subscription
+	var subs []*stripe.Sub
+	for _, s := range cus.Subs.Values {
+		if s.Plan == nil || s.Plan.ID != MachineHour {
+			subs = append(subs, s)
+		}
+	}
+
 	// here sub count might be 0, but should not be gt 1
-	if cus.Subs.Count > 1 {
+	if len(subs) > 1 {
 		return errors.New("customer should only have one subscription")
 	}
 
@@ -222,9 +235,9 @@ func syncGroupWithCustomerID(cusID string) error {
 	subStatus := SubStatusCanceled
 
 	// if we dont have any sub, set it as canceled
-	if cus.Subs.Count == 1 {
-		subID = cus.Subs.Values[0].ID
-		subStatus = cus.Subs.Values[0].Status
+	if len(subs) == 1 {
+		subID = subs[0].ID
+		subStatus = subs[0].Status
 	}
 
 	hasCard := checkCustomerHasSourceWithCustomer(cus) == nil
@@ -8,7 +8,7 @@ import (
 	"socialapi/models"
 	"socialapi/workers/api/realtimehelper"
 	"socialapi/workers/email/emailsender"
-	"strings"
+	"socialapi/workers/webhook"
 	"time"
 
 	stripe "github.com/stripe/stripe-go"
@@ -23,6 +23,8 @@ const (
 
 var mailSender = emailsender.Send
 
+var webhookPublisher = webhook.Publish
+
 // StripeHandler is the type of handlers for stripe webhook operations
 type StripeHandler func([]byte) error
 
@@ -224,6 +226,11 @@ func invoiceCreatedHandler(raw []byte) error {
 		return nil
 	}
 
+	// invoices of the machine hours are not based on the member count
+	if invoice.Sub != "" && invoice.Sub != group.Payment.Subscription.ID {
+		return nil
+	}
+
 	info, err := GetInfoForGroup(group)
 	if err == mgo.ErrNotFound {
 		return nil
@@ -233,7 +240,7 @@ func invoiceCreatedHandler(raw []byte) error {
 		return err
 	}
 
-	if strings.HasPrefix(info.Subscription.Plan.ID, customPlanPrefix) {
+	if IsCustomPlan(info.Subscription.Plan.ID) {
 		return (&models.PresenceDaily{}).ProcessByGroupName(group.Slug)
 	}
 
@@ -290,9 +297,42 @@ func switchToNewSub(info *Usage) error {
 }
 
 func invoicePaymentFailedHandler(raw []byte) error {
+	var invoice stripe.Invoice
+	if err := json.Unmarshal(raw, &invoice); err != nil {
+		return err
+	}
+
+	go publishInvoiceFailed(&invoice)
+
 	return invoicePaymentHandler(raw, "payment failed")
 }
 
+// publishInvoiceFailed notifies the webhooks of the team about the failed
+// invoice payment.
+func publishInvoiceFailed(invoice *stripe.Invoice) error {
+	if invoice.Customer == nil {
+		return nil
+	}
+
+	cus, err := customer.Get(invoice.Customer.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	groupName := cus.Meta["groupName"]
+	if groupName == "" {
+		return nil
+	}
+
+	return webhookPublisher(groupName, webhook.EventInvoiceFailed, map[string]interface{}{
+		"invoiceId":          invoice.ID,
+		"amount":             invoice.Amount,
+		"currency":           invoice.Currency,
+		"attemptCount":       invoice.Attempts,
+		"nextPaymentAttempt": invoice.NextAttempt,
+	})
+}
+
 func invoicePaymentSucceededHandler(raw []byte) error {
 	return invoicePaymentHandler(raw, "payment succeeded")
 }
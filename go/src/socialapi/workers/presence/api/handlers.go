@@ -22,20 +22,19 @@ func AddHandlers(m *mux.Mux) {
 
 	m.AddHandler(
 		handler.Request{
-			Handler:   Ping,
-			Name:      "presence-ping",
-			Type:      handler.GetRequest,
-			Endpoint:  presence.EndpointPresencePing,
-			Ratelimit: httpRateLimiter,
+			Handler:  Ping,
+			Name:     "presence-ping",
+			Type:     handler.GetRequest,
+			Endpoint: presence.EndpointPresencePing,
 		},
 	)
 	m.AddHandler(
 		handler.Request{
-			Handler:   ListMembers,
-			Name:      "presence-listmembers",
-			Type:      handler.GetRequest,
-			Endpoint:  presence.EndpointPresenceListMembers,
-			Ratelimit: httpRateLimiter,
+			Handler:  ListMembers,
+			Name:     "presence-listmembers",
+			Type:     handler.GetRequest,
+			Endpoint: presence.EndpointPresenceListMembers,
+			Cost:     5,
 		},
 	)
 	m.AddHandler(
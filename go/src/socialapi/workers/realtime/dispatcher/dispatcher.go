@@ -14,19 +14,19 @@ import (
 )
 
 type Controller struct {
-	Broker  *models.Broker
-	Pubnub  *models.PubNub
-	logger  logging.Logger
-	rmqConn *amqp.Connection
+	Broker   *models.Broker
+	Realtime models.Provider
+	logger   logging.Logger
+	rmqConn  *amqp.Connection
 }
 
-func NewController(rmqConn *rabbitmq.RabbitMQ, pubnub *models.PubNub, broker *models.Broker) *Controller {
+func NewController(rmqConn *rabbitmq.RabbitMQ, realtime models.Provider, broker *models.Broker) *Controller {
 
 	return &Controller{
-		Pubnub:  pubnub,
-		Broker:  broker,
-		logger:  runner.MustGetLogger(),
-		rmqConn: rmqConn.Conn(),
+		Realtime: realtime,
+		Broker:   broker,
+		logger:   runner.MustGetLogger(),
+		rmqConn:  rmqConn.Conn(),
 	}
 }
 
@@ -45,7 +45,7 @@ func (c *Controller) UpdateChannel(pm *models.PushMessage) error {
 
 	pm.EventId = createEventId()
 
-	return c.Pubnub.UpdateChannel(pm)
+	return c.Realtime.UpdateChannel(pm)
 }
 
 func (c *Controller) isPushMessageValid(pm *models.PushMessage) bool {
@@ -83,7 +83,7 @@ func (c *Controller) UpdateMessage(um *models.UpdateInstanceMessage) error {
 		}
 	}()
 
-	return c.Pubnub.UpdateInstance(um)
+	return c.Realtime.UpdateInstance(um)
 }
 
 // NotifyUser sends user notifications to related channel
@@ -95,7 +95,7 @@ func (c *Controller) NotifyUser(nm *models.NotificationMessage) error {
 
 	nm.EventId = createEventId()
 
-	return c.Pubnub.NotifyUser(nm)
+	return c.Realtime.NotifyUser(nm)
 }
 
 // NotifyGroup sends group broadcast notifications to related group channel
@@ -126,7 +126,7 @@ func (c *Controller) NotifyGroup(bm *models.BroadcastMessage) error {
 	pm.Body = bm.Body
 	pm.EventId = createEventId()
 
-	return c.Pubnub.UpdateChannel(pm)
+	return c.Realtime.UpdateChannel(pm)
 }
 
 func (c *Controller) RevokeChannelAccess(rca *models.RevokeChannelAccess) error {
@@ -139,7 +139,7 @@ func (c *Controller) RevokeChannelAccess(rca *models.RevokeChannelAccess) error
 		a := &models.Authenticate{
 			Account: &socialapimodels.Account{Token: token},
 		}
-		if err := c.Pubnub.RevokeAccess(a, pmc); err != nil {
+		if err := c.Realtime.RevokeAccess(a, pmc); err != nil {
 			return err
 		}
 	}
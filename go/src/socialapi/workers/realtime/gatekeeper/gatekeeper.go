@@ -9,6 +9,7 @@ import (
 	socialapimodels "socialapi/models"
 	"socialapi/workers/common/handler"
 	"socialapi/workers/common/response"
+	"socialapi/workers/realtime/hub"
 	"socialapi/workers/realtime/models"
 	"time"
 
@@ -21,18 +22,21 @@ const (
 )
 
 type Handler struct {
-	pubnub *models.PubNub
-	logger logging.Logger
+	realtime models.Provider
+	hub      *hub.Hub // set when the provider is self-hosted
+	logger   logging.Logger
 
 	checkParticipationEndpoint string
 	accountEndpoint            string
 }
 
-func NewHandler(p *models.PubNub, conf *config.Config, l logging.Logger) *Handler {
+func NewHandler(p models.Provider, conf *config.Config, l logging.Logger) *Handler {
 	rootPath := conf.CustomDomain.Local
+	hb, _ := p.(*hub.Hub)
 	return &Handler{
-		pubnub: p,
-		logger: l,
+		realtime:                   p,
+		hub:                        hb,
+		logger:                     l,
 		checkParticipationEndpoint: fmt.Sprintf("%s%s", rootPath, CheckParticipationPath),
 		accountEndpoint:            fmt.Sprintf("%s%s", rootPath, AccountPath),
 	}
@@ -46,18 +50,12 @@ func (h *Handler) SubscribeChannel(u *url.URL, header http.Header, req *models.C
 	}
 
 	req.Group = context.GroupName // override group name
-	res, err := h.checkParticipation(u, header, req)
+	a, err := h.authenticateChannel(header, req)
 	if err != nil {
 		return response.NewAccessDenied(err)
 	}
 
-	// user has access permission, now authenticate user to channel via pubnub
-	a := new(models.Authenticate)
-	a.Channel = models.NewPrivateMessageChannel(*res.Channel)
-	a.Account = res.Account
-	a.Account.Token = res.AccountToken
-
-	err = h.pubnub.Authenticate(a)
+	err = h.realtime.Authenticate(a)
 	if err != nil {
 		return response.NewBadRequest(err)
 	}
@@ -65,6 +63,22 @@ func (h *Handler) SubscribeChannel(u *url.URL, header http.Header, req *models.C
 	return responseWithCookie(req, a.Account.Token)
 }
 
+// authenticateChannel checks whether the requester participates in the channel
+func (h *Handler) authenticateChannel(header http.Header, req *models.Channel) (*models.Authenticate, error) {
+	res, err := h.checkParticipation(header, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// user has access permission, now authenticate user to channel via realtime provider
+	a := new(models.Authenticate)
+	a.Channel = models.NewPrivateMessageChannel(*res.Channel)
+	a.Account = res.Account
+	a.Account.Token = res.AccountToken
+
+	return a, nil
+}
+
 // SubscribeNotification grants notification channel access for user. User information is
 // fetched from session
 
@@ -81,7 +95,7 @@ func (h *Handler) SubscribeNotification(u *url.URL, header http.Header, temp *so
 	a.Account = account
 
 	// TODO need async requests. Re-try in case of an error
-	err := h.pubnub.Authenticate(a)
+	err := h.realtime.Authenticate(a)
 	if err != nil {
 		return response.NewBadRequest(err)
 	}
@@ -109,7 +123,7 @@ func responseWithCookie(req interface{}, token string) (int, http.Header, interf
 	return response.NewOKWithCookie(req, []*http.Cookie{cookie})
 }
 
-func (h *Handler) checkParticipation(u *url.URL, header http.Header, cr *models.Channel) (*models.CheckParticipationResponse, error) {
+func (h *Handler) checkParticipation(header http.Header, cr *models.Channel) (*models.CheckParticipationResponse, error) {
 	// relay the cookie to other endpoint
 	cookie := header.Get("Cookie")
 	request := &handler.Request{
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/http"
 	"socialapi/workers/common/handler"
 	"socialapi/workers/common/mux"
 )
@@ -33,4 +34,27 @@ func (h *Handler) AddHandlers(m *mux.Mux) {
 			Endpoint: "/token",
 		},
 	)
+
+	// event streams are served only by the self-hosted provider
+	if h.hub == nil {
+		return
+	}
+
+	m.AddUnscopedHandler(
+		handler.Request{
+			Handler:  handler.BuildHandlerWithContext(http.HandlerFunc(h.SubscribeWebSocket), h.logger).ServeHTTP,
+			Name:     "websocket-subscribe",
+			Type:     handler.GetRequest,
+			Endpoint: "/subscribe/websocket",
+		},
+	)
+
+	m.AddUnscopedHandler(
+		handler.Request{
+			Handler:  handler.BuildHandlerWithContext(http.HandlerFunc(h.SubscribeEvents), h.logger).ServeHTTP,
+			Name:     "events-subscribe",
+			Type:     handler.GetRequest,
+			Endpoint: "/subscribe/events",
+		},
+	)
 }
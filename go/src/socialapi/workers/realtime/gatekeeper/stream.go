@@ -0,0 +1,283 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	socialapimodels "socialapi/models"
+	"socialapi/workers/realtime/hub"
+	"socialapi/workers/realtime/models"
+
+	"github.com/gorilla/websocket"
+	tigertonic "github.com/rcrowley/go-tigertonic"
+)
+
+const (
+	streamPingPeriod = 30 * time.Second
+	streamWriteWait  = 10 * time.Second
+	streamReadLimit  = 4096
+)
+
+var errChannelNotSet = errors.New("channel is not set")
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// StreamRequest is sent by WebSocket clients to manage their subscriptions.
+type StreamRequest struct {
+	Action string `json:"action"` // subscribe or unsubscribe
+
+	// Channel is subscribed to with the same participation
+	// check as in /subscribe/channel.
+	Channel *models.Channel `json:"channel,omitempty"`
+
+	// Notification subscribes to the account's notification channel.
+	Notification bool `json:"notification,omitempty"`
+
+	// LastEventId is an id of the last received message event, the
+	// missed events are replayed from the channel history.
+	LastEventId string `json:"lastEventId,omitempty"`
+
+	// Name is a realtime channel name to unsubscribe from, as sent
+	// in the events.
+	Name string `json:"name,omitempty"`
+}
+
+// SubscribeWebSocket serves realtime events of the self-hosted provider over
+// a WebSocket connection. Subscriptions are managed with StreamRequest messages.
+func (h *Handler) SubscribeWebSocket(w http.ResponseWriter, r *http.Request) {
+	context := requestContext(r)
+	if !context.IsLoggedIn() {
+		http.Error(w, socialapimodels.ErrNotLoggedIn.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// upgrader has already replied with an error
+		h.logger.Debug("Could not upgrade websocket connection: %s", err)
+		return
+	}
+	defer conn.Close()
+
+	account := context.Client.Account
+	s := hub.NewSubscriber(account.Token, account.Nick)
+	defer h.hub.Leave(s)
+
+	go h.writeWebSocket(conn, s)
+
+	conn.SetReadLimit(streamReadLimit)
+	conn.SetReadDeadline(time.Now().Add(2 * streamPingPeriod))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * streamPingPeriod))
+	})
+
+	for {
+		var req StreamRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Action {
+		case "subscribe":
+			err = h.subscribeStream(r.Header, context, s, &req)
+		case "unsubscribe":
+			err = h.hub.Unsubscribe(s, req.Name)
+		default:
+			err = fmt.Errorf("unknown action %q", req.Action)
+		}
+
+		if err != nil {
+			s.Send(errorEvent(err))
+		}
+	}
+}
+
+func (h *Handler) writeWebSocket(conn *websocket.Conn, s *hub.Subscriber) {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case ev := <-s.Events():
+			conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+
+			if err := conn.WriteJSON(ev); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+		case <-s.Done():
+			return
+		}
+	}
+}
+
+// SubscribeEvents serves realtime events of the self-hosted provider as a
+// server-sent events stream. The channel is given with the name, typeConstant
+// and notification query parameters; reconnecting clients get the missed
+// events replayed after the Last-Event-ID.
+func (h *Handler) SubscribeEvents(w http.ResponseWriter, r *http.Request) {
+	context := requestContext(r)
+	if !context.IsLoggedIn() {
+		http.Error(w, socialapimodels.ErrNotLoggedIn.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	q := r.URL.Query()
+
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = q.Get("lastEventId")
+	}
+
+	var reqs []*StreamRequest
+
+	if name := q.Get("name"); name != "" {
+		reqs = append(reqs, &StreamRequest{
+			Action: "subscribe",
+			Channel: &models.Channel{
+				Name: name,
+				Type: q.Get("typeConstant"),
+			},
+			LastEventId: lastEventId,
+		})
+	}
+
+	if q.Get("notification") == "true" {
+		reqs = append(reqs, &StreamRequest{
+			Action:       "subscribe",
+			Notification: true,
+			LastEventId:  lastEventId,
+		})
+	}
+
+	if len(reqs) == 0 {
+		http.Error(w, errChannelNotSet.Error(), http.StatusBadRequest)
+		return
+	}
+
+	account := context.Client.Account
+	s := hub.NewSubscriber(account.Token, account.Nick)
+	defer h.hub.Leave(s)
+
+	for _, req := range reqs {
+		if err := h.subscribeStream(r.Header, context, s, req); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	var closed <-chan bool
+	if cn, ok := w.(http.CloseNotifier); ok {
+		closed = cn.CloseNotify()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ev := <-s.Events():
+			if err := writeEvent(w, ev); err != nil {
+				return
+			}
+		case <-ticker.C:
+			// comment lines keep idle connections open
+			if _, err := fmt.Fprint(w, ":\n\n"); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-s.Done():
+			return
+		}
+
+		flusher.Flush()
+	}
+}
+
+// subscribeStream authenticates the account to the requested channel
+// and subscribes s to it.
+func (h *Handler) subscribeStream(header http.Header, context *socialapimodels.Context, s *hub.Subscriber, req *StreamRequest) error {
+	var a *models.Authenticate
+
+	switch {
+	case req.Notification:
+		a = &models.Authenticate{
+			Account: context.Client.Account,
+			Channel: models.NewNotificationChannel(context.Client.Account),
+		}
+	case req.Channel != nil:
+		req.Channel.Group = context.GroupName // override group name
+
+		var err error
+		if a, err = h.authenticateChannel(header, req.Channel); err != nil {
+			return err
+		}
+	default:
+		return errChannelNotSet
+	}
+
+	if err := h.hub.Authenticate(a); err != nil {
+		return err
+	}
+
+	// invalid ids are ignored, so nothing is replayed
+	since, _ := strconv.ParseInt(req.LastEventId, 10, 64)
+
+	return h.hub.Subscribe(s, a.Channel, since)
+}
+
+func writeEvent(w http.ResponseWriter, ev *hub.Event) error {
+	p, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	// only message events are replayed, so only they have ids
+	if ev.Type == hub.EventMessage {
+		if _, err := fmt.Fprintf(w, "id: %d\n", ev.Id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, p)
+	return err
+}
+
+func errorEvent(err error) *hub.Event {
+	msg, _ := json.Marshal(map[string]string{"error": err.Error()})
+
+	return &hub.Event{
+		Type:    hub.EventError,
+		Message: msg,
+	}
+}
+
+func requestContext(r *http.Request) *socialapimodels.Context {
+	return tigertonic.Context(r).(*socialapimodels.Context)
+}
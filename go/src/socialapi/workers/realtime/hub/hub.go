@@ -0,0 +1,556 @@
+// Package hub implements a self-hosted realtime service provider, which
+// serves WebSocket and SSE subscriptions of the gatekeeper directly,
+// as an alternative to PubNub.
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"socialapi/config"
+	"socialapi/workers/realtime/models"
+
+	"github.com/koding/logging"
+	"github.com/koding/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+const (
+	// ExchangeName is a fanout exchange, which delivers the events
+	// to every gatekeeper instance.
+	ExchangeName = "realtime"
+
+	// ProviderPubnub and ProviderHub are values of the
+	// GateKeeper.Provider configuration.
+	ProviderPubnub = "pubnub"
+	ProviderHub    = "hub"
+
+	DefaultHistorySize = 100
+	DefaultHistoryTTL  = 5 * time.Minute
+
+	// SubscriberBuffer is a number of events queued for a single
+	// subscriber, before it is dropped as too slow.
+	SubscriberBuffer = 256
+)
+
+// ErrAccessDenied is returned when the subscriber was not granted
+// access to the channel.
+var ErrAccessDenied = errors.New("access denied")
+
+// envelope is a message sent over the fanout exchange.
+type envelope struct {
+	Event
+	Token string `json:"token,omitempty"` // revoked token
+}
+
+// channel is a local state of a single realtime channel.
+type channel struct {
+	public  bool
+	tokens  map[string]struct{} // granted tokens
+	subs    map[*Subscriber]struct{}
+	members map[string]int // connection count by nick
+	history []*Event
+	touched time.Time
+}
+
+// Hub is a Provider, which keeps channel access, presence and history
+// in memory and delivers events to subscribers connected to this
+// instance. Events are published to a RabbitMQ fanout exchange, so
+// every instance receives them and delivers to its own subscribers.
+//
+// Channel access is granted to the instance, which serves the
+// subscriber - clients are authenticated by the gatekeeper each time
+// they subscribe, so instances do not share grants. Revocations
+// are fanned out to all instances.
+type Hub struct {
+	rmq *amqp.Connection // nil when events are delivered locally
+	log logging.Logger
+
+	historySize int
+	historyTTL  time.Duration
+
+	mu       sync.Mutex
+	channels map[string]*channel
+
+	once  sync.Once
+	close chan struct{}
+}
+
+var _ models.Provider = (*Hub)(nil)
+
+// NewHub gives new hub, which publishes events to the fanout exchange
+// of the given RabbitMQ. When rmq is nil, events are delivered only to
+// the subscribers of the hub itself.
+func NewHub(rmq *rabbitmq.RabbitMQ, conf config.Hub, log logging.Logger) (*Hub, error) {
+	h := &Hub{
+		log:         log,
+		historySize: conf.HistorySize,
+		historyTTL:  DefaultHistoryTTL,
+		channels:    make(map[string]*channel),
+		close:       make(chan struct{}),
+	}
+
+	if h.historySize <= 0 {
+		h.historySize = DefaultHistorySize
+	}
+
+	if conf.HistoryTTL != "" {
+		ttl, err := time.ParseDuration(conf.HistoryTTL)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid history TTL %q", conf.HistoryTTL)
+		}
+
+		h.historyTTL = ttl
+	}
+
+	if rmq != nil {
+		h.rmq = rmq.Conn()
+
+		ch, err := h.rmq.Channel()
+		if err != nil {
+			return nil, err
+		}
+		defer ch.Close()
+
+		if err := ch.ExchangeDeclare(ExchangeName, "fanout", false, false, false, false, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	go h.cleanup()
+
+	return h, nil
+}
+
+// NewProvider gives the realtime provider selected in the configuration,
+// which is PubNub by default.
+func NewProvider(conf config.GateKeeper, rmq *rabbitmq.RabbitMQ, log logging.Logger) (models.Provider, error) {
+	switch conf.Provider {
+	case "", ProviderPubnub:
+		return models.NewPubNub(conf.Pubnub, log), nil
+	case ProviderHub:
+		h, err := NewHub(rmq, conf.Hub, log)
+		if err != nil {
+			return nil, err
+		}
+
+		return h, nil
+	default:
+		return nil, fmt.Errorf("unknown realtime provider %q", conf.Provider)
+	}
+}
+
+// Listen consumes events published by all the instances and delivers
+// them to the local subscribers. It is a no-op for a local hub.
+func (h *Hub) Listen() error {
+	if h.rmq == nil {
+		return nil
+	}
+
+	ch, err := h.rmq.Channel()
+	if err != nil {
+		return err
+	}
+
+	// every instance has its own queue, which is deleted
+	// as soon as the instance disconnects
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+
+	if err := ch.QueueBind(q.Name, "", ExchangeName, false, nil); err != nil {
+		ch.Close()
+		return err
+	}
+
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+
+	go func() {
+		defer ch.Close()
+
+		for {
+			select {
+			case d, ok := <-deliveries:
+				if !ok {
+					h.log.Error("Realtime hub consumer was closed")
+					return
+				}
+
+				var env envelope
+				if err := json.Unmarshal(d.Body, &env); err != nil {
+					h.log.Error("Could not decode realtime event: %s", err)
+					continue
+				}
+
+				h.dispatch(&env)
+			case <-h.close:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stops the hub and drops all its subscribers.
+func (h *Hub) Close() {
+	h.once.Do(func() {
+		close(h.close)
+
+		h.mu.Lock()
+		for _, c := range h.channels {
+			for s := range c.subs {
+				s.close()
+			}
+		}
+		h.mu.Unlock()
+	})
+}
+
+func (h *Hub) UpdateChannel(pm *models.PushMessage) error {
+	pmc := models.NewPrivateMessageChannel(*pm.Channel)
+
+	return h.publishMessage(pmc, pm)
+}
+
+func (h *Hub) UpdateInstance(um *models.UpdateInstanceMessage) error {
+	mc := models.NewMessageUpdateChannel(*um)
+
+	// the message is shared with the broker, so the
+	// changes are made on a copy of it
+	m := *um
+
+	// The same format as with PubNub - changes are applied
+	// via MongoOp in client side.
+	if m.EventName == "updateInstance" {
+		m.Body = map[string]interface{}{"$set": m.Body}
+	}
+
+	m.EventName = fmt.Sprintf("instance-%s.%s", m.Token, m.EventName)
+
+	if err := h.publishMessage(mc, m); err != nil {
+		h.log.Error("Could not push update instance event: %s", err)
+	}
+
+	return nil
+}
+
+func (h *Hub) NotifyUser(nm *models.NotificationMessage) error {
+	return h.publishMessage(models.NewNotificationChannel(nm.Account), nm)
+}
+
+func (h *Hub) Authenticate(a *models.Authenticate) error {
+	return a.Channel.GrantAccess(h, a)
+}
+
+// GrantAccess grants the token access to the channel on this instance.
+func (h *Hub) GrantAccess(a *models.Authenticate, c models.ChannelManager) error {
+	h.mu.Lock()
+	h.channel(c.PrepareName()).tokens[a.Account.Token] = struct{}{}
+	h.mu.Unlock()
+
+	return nil
+}
+
+// GrantPublicAccess grants everyone access to the channel on this instance.
+func (h *Hub) GrantPublicAccess(c models.ChannelManager) error {
+	h.mu.Lock()
+	h.channel(c.PrepareName()).public = true
+	h.mu.Unlock()
+
+	return nil
+}
+
+// RevokeAccess revokes access of the token on all the instances,
+// and removes its subscriptions of the channel.
+func (h *Hub) RevokeAccess(a *models.Authenticate, c models.ChannelManager) error {
+	return h.publish(&envelope{
+		Event: Event{
+			Type:    EventRevoke,
+			Channel: c.PrepareName(),
+		},
+		Token: a.Account.Token,
+	})
+}
+
+// Subscribe subscribes s to the channel, which the subscriber must
+// have been granted access to with Authenticate. When since is
+// non-zero, message events with greater ids kept in the channel
+// history are replayed.
+func (h *Hub) Subscribe(s *Subscriber, c models.ChannelManager, since int64) error {
+	name := c.PrepareName()
+
+	h.mu.Lock()
+	ch := h.channel(name)
+
+	if _, ok := ch.tokens[s.Token]; !ok && !ch.public {
+		h.mu.Unlock()
+		return ErrAccessDenied
+	}
+
+	_, subscribed := ch.subs[s]
+	presence := hasPresence(c)
+
+	ch.subs[s] = struct{}{}
+	s.channels[name] = presence
+
+	s.Send(&Event{
+		Type:    EventSubscribed,
+		Channel: name,
+	})
+
+	if since != 0 {
+		for _, ev := range ch.history {
+			if ev.Id > since {
+				s.Send(ev)
+			}
+		}
+	}
+
+	if presence {
+		s.Send(presenceEvent(name, &Presence{
+			Action:  "state",
+			Members: members(ch),
+		}))
+	}
+
+	h.mu.Unlock()
+
+	if subscribed || !presence {
+		return nil
+	}
+
+	return h.publishPresence(name, "join", s.Nick)
+}
+
+// Unsubscribe removes subscription of s to the channel with the given name.
+func (h *Hub) Unsubscribe(s *Subscriber, name string) error {
+	h.mu.Lock()
+	presence, ok := s.channels[name]
+	if ch, found := h.channels[name]; found {
+		delete(ch.subs, s)
+	}
+	delete(s.channels, name)
+	h.mu.Unlock()
+
+	if !ok || !presence {
+		return nil
+	}
+
+	return h.publishPresence(name, "leave", s.Nick)
+}
+
+// Leave removes all the subscriptions of s, it is called when
+// the connection of the subscriber is closed.
+func (h *Hub) Leave(s *Subscriber) {
+	h.mu.Lock()
+	names := make([]string, 0, len(s.channels))
+	for name := range s.channels {
+		names = append(names, name)
+	}
+	h.mu.Unlock()
+
+	for _, name := range names {
+		if err := h.Unsubscribe(s, name); err != nil {
+			h.log.Error("Could not leave channel %s: %s", name, err)
+		}
+	}
+
+	s.close()
+}
+
+// Presence gives nicks of the accounts subscribed to the channel.
+func (h *Hub) Presence(name string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ch, ok := h.channels[name]; ok {
+		return members(ch)
+	}
+
+	return nil
+}
+
+func (h *Hub) publishMessage(c models.ChannelManager, message interface{}) error {
+	p, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return h.publish(&envelope{
+		Event: Event{
+			Id:      time.Now().UnixNano(),
+			Type:    EventMessage,
+			Channel: c.PrepareName(),
+			Message: p,
+		},
+	})
+}
+
+func (h *Hub) publishPresence(name, action, nick string) error {
+	return h.publish(&envelope{
+		Event: *presenceEvent(name, &Presence{
+			Action: action,
+			Nick:   nick,
+		}),
+	})
+}
+
+func (h *Hub) publish(env *envelope) error {
+	if h.rmq == nil {
+		h.dispatch(env)
+		return nil
+	}
+
+	p, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	ch, err := h.rmq.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	return ch.Publish(
+		ExchangeName,             // exchange name
+		"",                       // routing key, ignored by fanout exchanges
+		false,                    // mandatory
+		false,                    // immediate
+		amqp.Publishing{Body: p}, // message
+	)
+}
+
+// dispatch applies the event to the local state and delivers
+// it to the local subscribers.
+func (h *Hub) dispatch(env *envelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := h.channel(env.Channel)
+	ev := &env.Event
+
+	switch env.Type {
+	case EventMessage:
+		ch.history = append(ch.history, ev)
+		if n := len(ch.history) - h.historySize; n > 0 {
+			ch.history = append(ch.history[:0], ch.history[n:]...)
+		}
+	case EventPresence:
+		var p Presence
+		if err := json.Unmarshal(env.Message, &p); err != nil {
+			h.log.Error("Could not decode presence event: %s", err)
+			return
+		}
+
+		switch p.Action {
+		case "join":
+			ch.members[p.Nick]++
+		case "leave":
+			if ch.members[p.Nick]--; ch.members[p.Nick] <= 0 {
+				delete(ch.members, p.Nick)
+			}
+		}
+	case EventRevoke:
+		delete(ch.tokens, env.Token)
+
+		for s := range ch.subs {
+			if s.Token == env.Token {
+				delete(ch.subs, s)
+				delete(s.channels, env.Channel)
+				s.Send(ev)
+			}
+		}
+
+		return
+	}
+
+	for s := range ch.subs {
+		if !s.Send(ev) {
+			delete(ch.subs, s)
+			delete(s.channels, env.Channel)
+		}
+	}
+}
+
+// channel gives the state of the channel, creating it if needed;
+// h.mu must be held.
+func (h *Hub) channel(name string) *channel {
+	ch, ok := h.channels[name]
+	if !ok {
+		ch = &channel{
+			tokens:  make(map[string]struct{}),
+			subs:    make(map[*Subscriber]struct{}),
+			members: make(map[string]int),
+		}
+
+		h.channels[name] = ch
+	}
+
+	ch.touched = time.Now()
+
+	return ch
+}
+
+// cleanup periodically drops state of the channels, which had neither
+// subscribers nor events for longer than the history TTL. Clients
+// reconnecting after that are authenticated again, but the missed
+// events are not replayed.
+func (h *Hub) cleanup() {
+	t := time.NewTicker(h.historyTTL / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			h.mu.Lock()
+			for name, ch := range h.channels {
+				if len(ch.subs) == 0 && time.Since(ch.touched) > h.historyTTL {
+					delete(h.channels, name)
+				}
+			}
+			h.mu.Unlock()
+		case <-h.close:
+			return
+		}
+	}
+}
+
+func presenceEvent(name string, p *Presence) *Event {
+	msg, _ := json.Marshal(p) // Presence is always encodable
+
+	return &Event{
+		Type:    EventPresence,
+		Channel: name,
+		Message: msg,
+	}
+}
+
+func members(ch *channel) []string {
+	nicks := make([]string, 0, len(ch.members))
+	for nick := range ch.members {
+		nicks = append(nicks, nick)
+	}
+
+	sort.Strings(nicks)
+
+	return nicks
+}
+
+// hasPresence tells whether presence events are sent for the channel,
+// notification channels are personal so they have none.
+func hasPresence(c models.ChannelManager) bool {
+	_, ok := c.(*models.NotificationChannel)
+	return !ok
+}
@@ -0,0 +1,230 @@
+package hub
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"socialapi/config"
+	socialapimodels "socialapi/models"
+	"socialapi/workers/realtime/models"
+
+	"github.com/koding/logging"
+)
+
+var (
+	privateChannel = models.Channel{Token: "private", Type: "privatemessage", Group: socialapimodels.Channel_KODING_NAME}
+	publicChannel  = models.Channel{Token: "public", Type: "topic", Group: socialapimodels.Channel_KODING_NAME}
+)
+
+func TestHubAccess(t *testing.T) {
+	h := newTestHub(t, 0)
+	defer h.Close()
+
+	private := authenticate(t, h, "alice", privateChannel)
+
+	if err := h.Subscribe(NewSubscriber("alice", "alice"), private, 0); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+
+	if err := h.Subscribe(NewSubscriber("bob", "bob"), private, 0); err != ErrAccessDenied {
+		t.Fatalf("got %v, want %v", err, ErrAccessDenied)
+	}
+
+	public := authenticate(t, h, "alice", publicChannel)
+
+	if err := h.Subscribe(NewSubscriber("bob", "bob"), public, 0); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+}
+
+func TestHubRevokeAccess(t *testing.T) {
+	h := newTestHub(t, 0)
+	defer h.Close()
+
+	pmc := authenticate(t, h, "alice", privateChannel)
+	s := NewSubscriber("alice", "alice")
+
+	if err := h.Subscribe(s, pmc, 0); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+
+	a := &models.Authenticate{
+		Account: &socialapimodels.Account{Token: "alice"},
+	}
+
+	if err := h.RevokeAccess(a, pmc); err != nil {
+		t.Fatalf("RevokeAccess()=%s", err)
+	}
+
+	pushMessage(t, h, privateChannel, "MessageAdded")
+
+	if events := drain(t, s, EventMessage); len(events) != 0 {
+		t.Fatalf("got %v events after revoke, want none", events)
+	}
+
+	if err := h.Subscribe(s, pmc, 0); err != ErrAccessDenied {
+		t.Fatalf("got %v, want %v", err, ErrAccessDenied)
+	}
+}
+
+func TestHubHistory(t *testing.T) {
+	h := newTestHub(t, 2)
+	defer h.Close()
+
+	c := models.Channel{Token: "history", Type: "privatemessage", Group: "team"}
+	pmc := authenticate(t, h, "alice", c)
+
+	s := NewSubscriber("alice", "alice")
+
+	if err := h.Subscribe(s, pmc, 0); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+
+	pushMessage(t, h, c, "first")
+
+	var last int64
+	for ev := range s.Events() {
+		if ev.Type == EventMessage {
+			last = ev.Id
+			break
+		}
+	}
+
+	h.Leave(s)
+
+	for _, name := range []string{"second", "third", "fourth"} {
+		pushMessage(t, h, c, name)
+	}
+
+	s = NewSubscriber("alice", "alice")
+
+	if err := h.Subscribe(s, pmc, last); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+
+	// history keeps only the 2 most recent events
+	want := []string{"third", "fourth"}
+
+	if got := drain(t, s, EventMessage); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	s = NewSubscriber("alice", "alice")
+
+	if err := h.Subscribe(s, pmc, 0); err != nil {
+		t.Fatalf("Subscribe()=%s", err)
+	}
+
+	if got := drain(t, s, EventMessage); len(got) != 0 {
+		t.Fatalf("got %v events for new subscriber, want none", got)
+	}
+}
+
+func TestHubPresence(t *testing.T) {
+	h := newTestHub(t, 0)
+	defer h.Close()
+
+	pmc := authenticate(t, h, "", publicChannel)
+	name := pmc.PrepareName()
+
+	alice := NewSubscriber("alice", "alice")
+	bob := NewSubscriber("bob", "bob")
+	bob2 := NewSubscriber("bob", "bob")
+
+	for _, s := range []*Subscriber{alice, bob, bob2} {
+		if err := h.Subscribe(s, pmc, 0); err != nil {
+			t.Fatalf("Subscribe()=%s", err)
+		}
+	}
+
+	if got, want := h.Presence(name), []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// bob is still present with the other connection
+	h.Leave(bob)
+
+	if got, want := h.Presence(name), []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	h.Leave(bob2)
+
+	if got, want := h.Presence(name), []string{"alice"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	want := []string{"state:", "join:alice", "join:bob", "join:bob", "leave:bob", "leave:bob"}
+
+	if got := drain(t, alice, EventPresence); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func newTestHub(t *testing.T, historySize int) *Hub {
+	h, err := NewHub(nil, config.Hub{HistorySize: historySize}, logging.NewLogger("test"))
+	if err != nil {
+		t.Fatalf("NewHub()=%s", err)
+	}
+
+	return h
+}
+
+func authenticate(t *testing.T, h *Hub, token string, c models.Channel) *models.PrivateMessageChannel {
+	pmc := models.NewPrivateMessageChannel(c)
+
+	a := &models.Authenticate{
+		Account: &socialapimodels.Account{Token: token},
+		Channel: pmc,
+	}
+
+	if err := h.Authenticate(a); err != nil {
+		t.Fatalf("Authenticate()=%s", err)
+	}
+
+	return pmc
+}
+
+func pushMessage(t *testing.T, h *Hub, c models.Channel, eventName string) {
+	pm := &models.PushMessage{Channel: &c}
+	pm.EventName = eventName
+
+	if err := h.UpdateChannel(pm); err != nil {
+		t.Fatalf("UpdateChannel()=%s", err)
+	}
+}
+
+// drain gives summaries of the queued events of the given type - event
+// names of messages and actions of presence events.
+func drain(t *testing.T, s *Subscriber, typ string) []string {
+	var events []string
+
+	for {
+		select {
+		case ev := <-s.Events():
+			if ev.Type != typ {
+				continue
+			}
+
+			switch typ {
+			case EventMessage:
+				var pm models.PushMessage
+				if err := json.Unmarshal(ev.Message, &pm); err != nil {
+					t.Fatalf("Unmarshal()=%s", err)
+				}
+
+				events = append(events, pm.EventName)
+			case EventPresence:
+				var p Presence
+				if err := json.Unmarshal(ev.Message, &p); err != nil {
+					t.Fatalf("Unmarshal()=%s", err)
+				}
+
+				events = append(events, p.Action+":"+p.Nick)
+			}
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package hub
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+const (
+	// EventMessage is a channel update, instance update or notification.
+	EventMessage = "message"
+
+	// EventSubscribed confirms a subscription, it is followed by
+	// the replayed message events.
+	EventSubscribed = "subscribed"
+
+	// EventPresence is sent when an account joins or leaves a channel.
+	EventPresence = "presence"
+
+	// EventRevoke is sent when the subscriber's access to a channel
+	// is revoked, the subscription is removed afterwards.
+	EventRevoke = "revoke"
+
+	// EventError is sent when a subscription request fails.
+	EventError = "error"
+)
+
+// Event is a single message delivered to subscribers of a channel.
+type Event struct {
+	// Id orders message events, it is used by reconnecting
+	// clients to replay the missed ones.
+	Id      int64           `json:"id,string,omitempty"`
+	Type    string          `json:"type"`
+	Channel string          `json:"channel,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+}
+
+// Presence is a message of presence events.
+type Presence struct {
+	Action  string   `json:"action"` // join, leave or state
+	Nick    string   `json:"nick,omitempty"`
+	Members []string `json:"members,omitempty"`
+}
+
+// Subscriber is a single WebSocket or SSE connection.
+type Subscriber struct {
+	Token string // realtime token of the account
+	Nick  string
+
+	events   chan *Event
+	done     chan struct{}
+	once     sync.Once
+	channels map[string]bool // subscribed channels with presence, guarded by Hub.mu
+}
+
+// NewSubscriber gives new subscriber for the account.
+func NewSubscriber(token, nick string) *Subscriber {
+	return &Subscriber{
+		Token:    token,
+		Nick:     nick,
+		events:   make(chan *Event, SubscriberBuffer),
+		done:     make(chan struct{}),
+		channels: make(map[string]bool),
+	}
+}
+
+// Events gives events, which are to be written to the connection.
+func (s *Subscriber) Events() <-chan *Event {
+	return s.events
+}
+
+// Done is closed when the subscriber was dropped, e.g. because it
+// is too slow to consume its events.
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+// Send queues the event without blocking. It returns false and
+// drops the subscriber when its buffer is full.
+func (s *Subscriber) Send(ev *Event) bool {
+	select {
+	case s.events <- ev:
+		return true
+	case <-s.done:
+		return false
+	default:
+		s.close()
+		return false
+	}
+}
+
+func (s *Subscriber) close() {
+	s.once.Do(func() { close(s.done) })
+}
@@ -5,3 +5,23 @@ type Realtimer interface {
 	UpdateInstance(req *UpdateInstanceMessage) error
 	NotifyUser(req *NotificationMessage) error
 }
+
+// AccessGranter manages access of subscribers to realtime channels.
+type AccessGranter interface {
+	GrantAccess(a *Authenticate, c ChannelManager) error
+	GrantPublicAccess(c ChannelManager) error
+	RevokeAccess(a *Authenticate, c ChannelManager) error
+}
+
+// Provider is a realtime service provider, which delivers events to
+// the subscribers and manages their channel access, e.g. PubNub.
+type Provider interface {
+	Realtimer
+	AccessGranter
+
+	// Authenticate grants the account access to the channel,
+	// according to the channel's GrantAccess rules.
+	Authenticate(a *Authenticate) error
+
+	Close()
+}
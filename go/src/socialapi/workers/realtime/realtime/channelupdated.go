@@ -28,6 +28,7 @@ type channelUpdatedEvent struct {
 	EventType            channelUpdatedEventType    `json:"event"`
 	ChannelParticipant   *models.ChannelParticipant `json:"-"`
 	UnreadCount          int                        `json:"unreadCount"`
+	ThreadUnreadCount    int                        `json:"threadUnreadCount,omitempty"`
 }
 
 // sendChannelUpdatedEvent sends channel updated events
@@ -202,6 +203,14 @@ func (cue *channelUpdatedEvent) sendForParticipant() error {
 
 	cue.UnreadCount = count
 
+	threadCount, err := cue.calculateThreadUnreadCount()
+	if err != nil {
+		threadCount = 0
+		cue.Controller.log.Notice("Error happened, setting thread unread count to 0 %s", err.Error())
+	}
+
+	cue.ThreadUnreadCount = threadCount
+
 	err = cue.Controller.sendNotification(
 		cue.ChannelParticipant.AccountId,
 		cue.Channel.GroupName,
@@ -272,3 +281,33 @@ func (cue *channelUpdatedEvent) calculateUnreadItemCount() (int, error) {
 	// specialcasing the pinned posts here
 	return models.NewMessageReply().UnreadCount(cml.MessageId, cml.RevisedAt, isRecieverTroll)
 }
+
+// calculateThreadUnreadCount calculates the unread replies count of the parent
+// message from the thread cursor of the participant, it is only calculated
+// for the reply events of the threads which are read by the participant
+func (cue *channelUpdatedEvent) calculateThreadUnreadCount() (int, error) {
+	if cue.EventType != channelUpdatedEventReplyAdded &&
+		cue.EventType != channelUpdatedEventReplyRemoved {
+		return 0, nil
+	}
+
+	if cue.ParentChannelMessage == nil {
+		return 0, models.ErrParentMessageIsNotSet
+	}
+
+	if cue.ChannelParticipant == nil {
+		return 0, models.ErrChannelParticipantIsNotSet
+	}
+
+	tc := models.NewThreadCursor()
+	err := tc.ByMessageIdAndAccountId(cue.ParentChannelMessage.Id, cue.ChannelParticipant.AccountId)
+	if err == bongo.RecordNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return tc.UnreadCount(cue.ChannelParticipant.MetaBits.Is(models.Troll))
+}
@@ -20,11 +20,14 @@ const (
 	MessageRemovedEventName     = "MessageRemoved"
 	ChannelDeletedEventName     = "ChannelDeleted"
 	ChannelUpdatedEventName     = "ChannelUpdated"
+	ThreadReadEventName         = "ThreadRead"
 
 	// instance events
-	ReplyRemovedEventName   = "ReplyRemoved"
-	ReplyAddedEventName     = "ReplyAdded"
-	UpdateInstanceEventName = "updateInstance"
+	ReplyRemovedEventName    = "ReplyRemoved"
+	ReplyAddedEventName      = "ReplyAdded"
+	ReactionAddedEventName   = "ReactionAdded"
+	ReactionRemovedEventName = "ReactionRemoved"
+	UpdateInstanceEventName  = "updateInstance"
 )
 
 var mongoAccounts map[int64]*mongomodels.Account
@@ -244,12 +247,32 @@ func (f *Controller) fetchNotifiedParticipantIds(c *models.Channel, pe *models.P
 // MessageReplySaved updates the channels , send messages in updated channel
 // and sends messages which is added
 func (f *Controller) MessageReplySaved(mr *models.MessageReply) error {
+	if err := f.markThreadReadByReplier(mr); err != nil {
+		f.log.Error("Error while moving thread cursor of the replier %s", err.Error())
+	}
+
 	f.sendReplyEventAsChannelUpdatedEvent(mr, channelUpdatedEventReplyAdded)
 	f.sendReplyAddedEvent(mr)
 
 	return nil
 }
 
+// markThreadReadByReplier moves the thread cursor of the replier to the
+// reply, replier has read the thread when they replied
+func (f *Controller) markThreadReadByReplier(mr *models.MessageReply) error {
+	reply, err := models.Cache.Message.ById(mr.ReplyId)
+	if err != nil {
+		return err
+	}
+
+	tc := models.NewThreadCursor()
+	tc.MessageId = mr.MessageId
+	tc.AccountId = reply.AccountId
+
+	_, err = tc.MarkRead(mr.CreatedAt)
+	return err
+}
+
 func (f *Controller) sendReplyAddedEvent(mr *models.MessageReply) error {
 	parent, err := models.Cache.Message.ById(mr.MessageId)
 	if err != nil {
@@ -538,6 +561,46 @@ func (f *Controller) ChannelDeletedEvent(c *models.Channel) error {
 	return f.publishToChannel(c.Id, ChannelDeletedEventName, &models.ChannelContainer{Channel: c})
 }
 
+// MessageReactionAdded sends the added reaction to the message instance
+func (f *Controller) MessageReactionAdded(mr *models.MessageReaction) error {
+	return f.sendReactionEvent(mr, ReactionAddedEventName)
+}
+
+// MessageReactionRemoved sends the removed reaction to the message instance
+func (f *Controller) MessageReactionRemoved(mr *models.MessageReaction) error {
+	return f.sendReactionEvent(mr, ReactionRemovedEventName)
+}
+
+func (f *Controller) sendReactionEvent(mr *models.MessageReaction, eventName string) error {
+	cm, err := models.Cache.Message.ById(mr.MessageId)
+	if err != nil {
+		return err
+	}
+
+	if err := f.sendInstanceEvent(cm, mr, eventName); err != nil {
+		f.log.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ThreadCursorUpdated notifies the reader about the moved thread cursor, so
+// the other sessions of the reader can reset the unread replies count
+func (f *Controller) ThreadCursorUpdated(tc *models.ThreadCursor) error {
+	cm, err := models.Cache.Message.ById(tc.MessageId)
+	if err != nil {
+		return err
+	}
+
+	ch, err := models.Cache.Channel.ById(cm.InitialChannelId)
+	if err != nil {
+		return err
+	}
+
+	return f.sendNotification(tc.AccountId, ch.GroupName, ThreadReadEventName, tc)
+}
+
 func (f *Controller) ChannelUpdatedEvent(c *models.Channel) error {
 	return f.publishToChannel(c.Id, ChannelUpdatedEventName, &models.ChannelContainer{Channel: c})
 }
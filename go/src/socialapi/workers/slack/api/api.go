@@ -1,10 +1,10 @@
 package api
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"koding/db/mongodb/modelhelper"
+	"koding/remoteapi"
 	"net/http"
 	"net/url"
 	"socialapi/models"
@@ -22,7 +22,12 @@ type Slack struct {
 	Hostname          string
 	Protocol          string
 	VerificationToken string
+	SigningSecret     string
 	OAuthConf         *oauth2.Config
+
+	// Remote calls kloud on behalf of the slack users, slash commands that
+	// manage machines are disabled when it is not set
+	Remote *remoteapi.Client
 }
 
 // SlackMessageRequest carries message creation request from client side
@@ -134,15 +139,39 @@ func (s *Slack) Success(u *url.URL, h http.Header, _ interface{}, context *model
 		return response.NewBadRequest(err)
 	}
 
+	// oauth.access response carries the slack identity of the user
+	slackUserID, _ := token.Extra("user_id").(string)
+	slackTeamID, _ := token.Extra("team_id").(string)
+
 	// update the slack data
-	if err := updateUserSlackToken(user, session.GroupName, token.AccessToken); err != nil {
+	if err := updateUserSlackToken(user, session.GroupName, token.AccessToken, slackUserID, slackTeamID); err != nil {
 		return response.NewBadRequest(err)
 	}
 
+	// link the slack team to the group, slash commands of the team are
+	// run in the group. Only the admins can link, members only connect
+	// their own slack accounts.
+	if slackTeamID != "" && canLink(context, session.GroupName) {
+		err := models.NewSlackTeam().Link(session.GroupName, slackTeamID)
+		if err == models.ErrSlackTeamLinked {
+			h.Set("Location", "/Home/My-Team/Slack?error="+url.QueryEscape(err.Error()))
+			return http.StatusTemporaryRedirect, h, nil, nil
+		}
+
+		if err != nil {
+			return response.NewBadRequest(err)
+		}
+	}
+
 	h.Set("Location", "/Home/My-Team/Slack")
 	return http.StatusTemporaryRedirect, h, nil, nil
 }
 
+// canLink reports whether the requester can link a slack team to the group
+func canLink(context *models.Context, groupName string) bool {
+	return context.GroupName == groupName && context.CanManage() == nil
+}
+
 // ListUsers lists users of a slack team
 func (s *Slack) ListUsers(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
 	if !context.IsLoggedIn() {
@@ -207,37 +236,56 @@ func (s *Slack) PostMessage(u *url.URL, h http.Header, req *SlackMessageRequest,
 	return response.HandleResultAndError(postMessage(token, req))
 }
 
-// SlashCommand handles slash commands coming from slack
-//
-// Note: this is experimental, on prod instances this will just say hi,
-// otherwise will output incoming request
-func (s *Slack) SlashCommand(w http.ResponseWriter, req *http.Request) {
-	if err := req.ParseForm(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+// SlackNotificationsRequest carries the channel notification settings of the
+// team
+type SlackNotificationsRequest struct {
+	ChannelId string   `json:"channelId"`
+	Events    []string `json:"events"`
+}
+
+// Notifications returns the channel notification settings of the team
+func (s *Slack) Notifications(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
 	}
 
-	command, err := newSlashCommandFromURLValues(req.PostForm)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	team := models.NewSlackTeam()
+	if err := team.ByGroupName(context.GroupName); err != nil {
+		return response.NewBadRequest(err)
 	}
 
-	if command.Token != s.VerificationToken {
-		errMessage := fmt.Sprintf("request from unidentified source: %s - %s", command.Token, req.Host)
-		http.Error(w, errMessage, http.StatusBadRequest)
-		return
+	return response.NewOK(team)
+}
+
+// UpdateNotifications opts the team in or out of the channel notifications,
+// notifications are posted with the slack token of the requester
+func (s *Slack) UpdateNotifications(u *url.URL, h http.Header, req *SlackNotificationsRequest, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
 	}
 
-	fmt.Fprintln(w, "hi from koding bot!")
+	if req == nil {
+		return response.NewBadRequest(errors.New("req should be set"))
+	}
 
-	if command.Robot != "koding" {
-		fmt.Fprintln(w, "here is some debug log for non-prod requests")
-		fmt.Fprintln(w)
+	team := models.NewSlackTeam()
+	if err := team.ByGroupName(context.GroupName); err != nil {
+		return response.NewBadRequest(err)
+	}
 
-		if err := json.NewEncoder(w).Encode(command); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
+	if len(req.Events) != 0 {
+		if _, err := getSlackToken(context); err != nil {
+			return response.NewBadRequest(err)
 		}
 	}
+
+	team.ChannelId = req.ChannelId
+	team.Events = req.Events
+	team.NotifierId = context.Client.Account.Id
+
+	if err := team.Update(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewOK(team)
 }
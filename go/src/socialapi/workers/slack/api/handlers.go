@@ -15,6 +15,7 @@ func AddHandlers(m *mux.Mux, config *config.Config) {
 		Hostname:          config.Hostname,
 		Protocol:          config.Protocol,
 		VerificationToken: config.Slack.VerificationToken,
+		SigningSecret:     config.Slack.SigningSecret,
 		OAuthConf: &oauth2.Config{
 			ClientID:     config.Slack.ClientId,
 			ClientSecret: config.Slack.ClientSecret,
@@ -75,6 +76,13 @@ func AddHandlers(m *mux.Mux, config *config.Config) {
 		},
 	}
 
+	remote, err := newRemoteClient(config.CustomDomain.Local)
+	if err != nil {
+		panic(err)
+	}
+
+	s.Remote = remote
+
 	m.AddHandler(
 		handler.Request{
 			Handler:  s.Send,
@@ -138,6 +146,24 @@ func AddHandlers(m *mux.Mux, config *config.Config) {
 		},
 	)
 
+	m.AddHandler(
+		handler.Request{
+			Handler:  s.Notifications,
+			Name:     models.SlackNotifications,
+			Type:     handler.GetRequest,
+			Endpoint: "/slack/notifications",
+		},
+	)
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  s.UpdateNotifications,
+			Name:     models.SlackUpdateNotifications,
+			Type:     handler.PostRequest,
+			Endpoint: "/slack/notifications",
+		},
+	)
+
 	m.AddUnscopedHandler(
 		handler.Request{
 			Handler:  s.SlashCommand,
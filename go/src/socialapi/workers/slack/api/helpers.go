@@ -206,10 +206,14 @@ func getTeamInfo(token string) (*slack.TeamInfo, error) {
 	return info, nil
 }
 
-func updateUserSlackToken(user *kodingmodels.User, groupName string, m string) error {
+func updateUserSlackToken(user *kodingmodels.User, groupName, token, slackUserID, slackTeamID string) error {
 	selector := bson.M{"username": user.Name}
-	key := fmt.Sprintf("foreignAuth.slack.%s.token", groupName)
-	update := bson.M{key: m}
+	key := fmt.Sprintf("foreignAuth.slack.%s", groupName)
+	update := bson.M{
+		key + ".token":  token,
+		key + ".userId": slackUserID,
+		key + ".teamId": slackTeamID,
+	}
 
 	return modelhelper.UpdateUser(selector, update)
 }
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"koding/api"
+	"koding/db/mongodb/modelhelper"
+	"koding/remoteapi"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newRemoteClient creates a remote.api client, which calls kloud on behalf of
+// the koding users linked to the slack users
+func newRemoteClient(endpoint string) (*remoteapi.Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	authFn := func(opts *api.AuthOptions) (*api.Session, error) {
+		s, err := modelhelper.FetchOrCreateSession(opts.User.Username, opts.User.Team)
+		if err != nil {
+			return nil, err
+		}
+
+		return &api.Session{
+			ClientID: s.ClientId,
+			User: &api.User{
+				Username: s.Username,
+				Team:     s.GroupName,
+			},
+		}, nil
+	}
+
+	return &remoteapi.Client{
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		Transport: &api.Transport{
+			AuthFunc: api.NewCache(authFn).Auth,
+		},
+		Endpoint: u,
+	}, nil
+}
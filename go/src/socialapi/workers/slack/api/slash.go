@@ -0,0 +1,543 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"koding/api"
+	kodingmodels "koding/db/models"
+	"koding/db/mongodb/modelhelper"
+	"koding/remoteapi"
+	"koding/remoteapi/client"
+	"koding/remoteapi/client/j_machine"
+	"koding/remoteapi/client/kloud"
+	remotemodels "koding/remoteapi/models"
+	"net/http"
+	"net/url"
+	"socialapi/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+const (
+	// headerSignature holds the signature of the slack requests
+	headerSignature = "X-Slack-Signature"
+
+	// headerTimestamp holds the time the slack request is sent
+	headerTimestamp = "X-Slack-Request-Timestamp"
+
+	// signatureVersion is the only signature version slack supports
+	signatureVersion = "v0"
+
+	// maxRequestAge is the age of the slack requests, older ones are
+	// rejected to prevent replay attacks
+	maxRequestAge = 5 * time.Minute
+
+	// maxSlashCommandSize limits the slash command request bodies
+	maxSlashCommandSize = 1 << 16
+)
+
+const (
+	// responseEphemeral responses are only visible to the requester
+	responseEphemeral = "ephemeral"
+
+	// responseInChannel responses are visible to the channel members
+	responseInChannel = "in_channel"
+)
+
+var (
+	errSignatureNotSet    = errors.New("slack signature is not set")
+	errSignatureNotValid  = errors.New("slack signature is not valid")
+	errTimestampNotValid  = errors.New("slack request timestamp is not valid")
+	errTokenNotValid      = errors.New("slack verification token is not valid")
+	errSlashNotConfigured = errors.New("slack request verification is not configured")
+	errTeamNotLinked      = errors.New("this slack team is not connected to a koding team")
+	errUserNotLinked      = errors.New("your slack account is not connected to koding, connect it from your team settings first")
+	errMachineNotFound    = errors.New("machine is not found")
+	errMachineNotSet      = errors.New("machine alias is not set")
+	errShareUserNotSet    = errors.New("user to share the machine with is not set")
+	errShareUserNotFound  = errors.New("user to share the machine with is not found")
+	errRemoteNotAvailable = errors.New("kloud is not available")
+)
+
+const slashCommandHelp = "Usage:\n" +
+	"`/koding machines` lists your machines\n" +
+	"`/koding start <machine>` starts the machine\n" +
+	"`/koding stop <machine>` stops the machine\n" +
+	"`/koding stack status` shows the status of your stacks\n" +
+	"`/koding share <machine> @user` shares the machine with the user"
+
+// SlashResponse is the message that is posted to slack as the response of
+// a slash command
+type SlashResponse struct {
+	ResponseType string `json:"response_type,omitempty"`
+	Text         string `json:"text"`
+}
+
+func newSlashResponse(format string, args ...interface{}) *SlashResponse {
+	return &SlashResponse{
+		ResponseType: responseEphemeral,
+		Text:         fmt.Sprintf(format, args...),
+	}
+}
+
+// slashCaller holds the koding identity of the slash command requester
+type slashCaller struct {
+	GroupName string
+	User      *kodingmodels.User
+	Account   *kodingmodels.Account
+}
+
+// SlashCommand handles /koding slash commands coming from slack
+//
+// Commands that call kloud are run in the background, their results are
+// posted to the response url of the command, since slack requires the
+// response within three seconds.
+func (s *Slack) SlashCommand(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxSlashCommandSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.verifyRequest(req.Header, body, time.Now()); err != nil {
+		status := http.StatusUnauthorized
+		if err == errSlashNotConfigured {
+			status = http.StatusServiceUnavailable
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := newSlashCommandFromURLValues(values)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeSlashResponse(w, s.runSlashCommand(command))
+}
+
+// verifyRequest checks the signature of the slack request. The legacy
+// verification token is only used when the signing secret is not configured,
+// requests are rejected when neither of them is configured
+func (s *Slack) verifyRequest(h http.Header, body []byte, now time.Time) error {
+	if s.SigningSecret != "" {
+		return verifySignature(s.SigningSecret, h, body, now)
+	}
+
+	if s.VerificationToken == "" {
+		return errSlashNotConfigured
+	}
+
+	return verifyToken(s.VerificationToken, body)
+}
+
+// verifyToken compares the verification token in the request body with the
+// configured one
+func verifyToken(token string, body []byte) error {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return errTokenNotValid
+	}
+
+	if subtle.ConstantTimeCompare([]byte(values.Get("token")), []byte(token)) != 1 {
+		return errTokenNotValid
+	}
+
+	return nil
+}
+
+// verifySignature verifies the request signature slack computes with the
+// signing secret of the app
+func verifySignature(secret string, h http.Header, body []byte, now time.Time) error {
+	signature := h.Get(headerSignature)
+	if signature == "" {
+		return errSignatureNotSet
+	}
+
+	timestamp := h.Get(headerTimestamp)
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errTimestampNotValid
+	}
+
+	if age := now.Sub(time.Unix(ts, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return errTimestampNotValid
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(sign(secret, timestamp, body))) {
+		return errSignatureNotValid
+	}
+
+	return nil
+}
+
+// sign computes the signature of the request body sent at the given time
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signatureVersion + ":" + timestamp + ":"))
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// runSlashCommand runs the subcommand of the slash command, long running
+// subcommands respond immediately and post their results afterwards
+func (s *Slack) runSlashCommand(command *SlashCommand) *SlashResponse {
+	name, args := parseSlashCommandText(command.Text)
+	if name == "" || name == "help" {
+		return newSlashResponse(slashCommandHelp)
+	}
+
+	caller, err := getSlashCaller(command)
+	if err != nil {
+		return newSlashResponse("%s", err)
+	}
+
+	switch name {
+	case "machines":
+		return listMachines(caller)
+	case "start", "stop":
+		if len(args) != 1 {
+			return newSlashResponse("Usage: `/koding %s <machine>`", name)
+		}
+
+		return s.toggleMachine(command, caller, name, args[0])
+	case "stack":
+		if len(args) != 1 || args[0] != "status" {
+			return newSlashResponse("Usage: `/koding stack status`")
+		}
+
+		return stackStatus(caller)
+	case "share":
+		if len(args) != 2 {
+			return newSlashResponse("Usage: `/koding share <machine> @user`")
+		}
+
+		return s.shareMachine(command, caller, args[0], args[1])
+	default:
+		return newSlashResponse("Unknown command `%s`\n%s", name, slashCommandHelp)
+	}
+}
+
+// getSlashCaller finds the koding user linked to the slack user, within the
+// group linked to the slack team
+func getSlashCaller(command *SlashCommand) (*slashCaller, error) {
+	team := models.NewSlackTeam()
+	if err := team.ByTeamId(command.TeamID); err != nil {
+		if err == models.ErrSlackTeamNotFound {
+			return nil, errTeamNotLinked
+		}
+
+		return nil, err
+	}
+
+	user, err := modelhelper.GetUserBySlackUserID(team.GroupName, command.UserID)
+	if err == mgo.ErrNotFound {
+		return nil, errUserNotLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := modelhelper.GetAccount(user.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slashCaller{
+		GroupName: team.GroupName,
+		User:      user,
+		Account:   account,
+	}, nil
+}
+
+func listMachines(caller *slashCaller) *SlashResponse {
+	machines, err := getMachines(caller)
+	if err != nil {
+		return newSlashResponse("Could not fetch your machines: %s", err)
+	}
+
+	if len(machines) == 0 {
+		return newSlashResponse("You do not have any machines in %s", caller.GroupName)
+	}
+
+	lines := make([]string, 0, len(machines))
+	for _, m := range machines {
+		lines = append(lines, fmt.Sprintf("• `%s` %s (%s) - %s", m.Slug, m.Label, m.Provider, m.Status.State))
+	}
+
+	return newSlashResponse("Your machines in %s:\n%s", caller.GroupName, strings.Join(lines, "\n"))
+}
+
+func stackStatus(caller *slashCaller) *SlashResponse {
+	stacks, err := modelhelper.GetComputeStacksByGroup(caller.GroupName, caller.Account.Id)
+	if err != nil {
+		return newSlashResponse("Could not fetch your stacks: %s", err)
+	}
+
+	if len(stacks) == 0 {
+		return newSlashResponse("You do not have any stacks in %s", caller.GroupName)
+	}
+
+	lines := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		lines = append(lines, formatStackStatus(stack))
+	}
+
+	return newSlashResponse("Your stacks in %s:\n%s", caller.GroupName, strings.Join(lines, "\n"))
+}
+
+func formatStackStatus(stack *kodingmodels.ComputeStack) string {
+	title := stack.Title
+	if title == "" {
+		title = stack.Id.Hex()
+	}
+
+	line := fmt.Sprintf("• %s - %s", title, stack.Status.State)
+	if stack.Status.Reason != "" {
+		line += " (" + stack.Status.Reason + ")"
+	}
+
+	return line
+}
+
+// toggleMachine starts or stops the machine of the caller through kloud
+func (s *Slack) toggleMachine(command *SlashCommand, caller *slashCaller, action, alias string) *SlashResponse {
+	m, err := findMachine(caller, alias)
+	if err != nil {
+		return newSlashResponse("%s", err)
+	}
+
+	if s.Remote == nil {
+		return newSlashResponse("%s", errRemoteNotAvailable)
+	}
+
+	body := map[string]interface{}{
+		"machineId": m.ObjectId.Hex(),
+		"provider":  m.Provider,
+	}
+
+	cli := s.remoteClient(caller)
+
+	go s.respondLater(command.ResponseURL, func() *SlashResponse {
+		var payload *remotemodels.DefaultResponse
+
+		if action == "start" {
+			params := kloud.NewKloudStartParams()
+			params.Body = body
+			params.SetTimeout(s.Remote.Timeout())
+
+			resp, err := cli.Kloud.KloudStart(params, nil)
+			if err != nil {
+				return newSlashResponse("Could not start `%s`: %s", m.Slug, err)
+			}
+
+			payload = resp.Payload
+		} else {
+			params := kloud.NewKloudStopParams()
+			params.Body = body
+			params.SetTimeout(s.Remote.Timeout())
+
+			resp, err := cli.Kloud.KloudStop(params, nil)
+			if err != nil {
+				return newSlashResponse("Could not stop `%s`: %s", m.Slug, err)
+			}
+
+			payload = resp.Payload
+		}
+
+		if err := remoteapi.Unmarshal(payload, nil); err != nil {
+			return newSlashResponse("Could not %s `%s`: %s", action, m.Slug, err)
+		}
+
+		return newSlashResponse("Kloud is processing the %s request of `%s`, it may take a few minutes", action, m.Slug)
+	})
+
+	return newSlashResponse("Sending %s request for `%s`...", action, m.Slug)
+}
+
+// shareMachine shares the machine of the caller with the mentioned user
+func (s *Slack) shareMachine(command *SlashCommand, caller *slashCaller, alias, mention string) *SlashResponse {
+	m, err := findMachine(caller, alias)
+	if err != nil {
+		return newSlashResponse("%s", err)
+	}
+
+	target, err := findShareTarget(caller.GroupName, mention)
+	if err != nil {
+		return newSlashResponse("%s", err)
+	}
+
+	if s.Remote == nil {
+		return newSlashResponse("%s", errRemoteNotAvailable)
+	}
+
+	cli := s.remoteClient(caller)
+
+	go s.respondLater(command.ResponseURL, func() *SlashResponse {
+		params := j_machine.NewJMachineShareParams()
+		params.ID = m.ObjectId.Hex()
+		params.Body = []interface{}{[]string{target.Name}}
+		params.SetTimeout(s.Remote.Timeout())
+
+		resp, err := cli.JMachine.JMachineShare(params, nil)
+		if err == nil {
+			err = remoteapi.Unmarshal(&resp.Payload.DefaultResponse, nil)
+		}
+
+		if err != nil {
+			return newSlashResponse("Could not share `%s` with %s: %s", m.Slug, target.Name, err)
+		}
+
+		return &SlashResponse{
+			ResponseType: responseInChannel,
+			Text:         fmt.Sprintf("%s shared `%s` with %s", caller.User.Name, m.Slug, target.Name),
+		}
+	})
+
+	return newSlashResponse("Sharing `%s` with %s...", m.Slug, target.Name)
+}
+
+func (s *Slack) remoteClient(caller *slashCaller) *client.Koding {
+	return s.Remote.New(&api.User{
+		Username: caller.User.Name,
+		Team:     caller.GroupName,
+	})
+}
+
+// respondLater posts the result of the given function to the response url of
+// the slash command
+func (s *Slack) respondLater(responseURL string, fn func() *SlashResponse) {
+	res := fn()
+	if responseURL == "" {
+		return
+	}
+
+	p, err := json.Marshal(res)
+	if err != nil {
+		return
+	}
+
+	resp, err := s.httpClient().Post(responseURL, "application/json", bytes.NewReader(p))
+	if err != nil {
+		return
+	}
+
+	resp.Body.Close()
+}
+
+func (s *Slack) httpClient() *http.Client {
+	if s.Remote != nil && s.Remote.Client != nil {
+		return s.Remote.Client
+	}
+
+	return http.DefaultClient
+}
+
+// getMachines fetches the machines of the caller within the group
+func getMachines(caller *slashCaller) ([]*modelhelper.MachineContainer, error) {
+	group, err := modelhelper.GetGroup(caller.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	return modelhelper.GetGroupMachines(caller.User.ObjectId, group)
+}
+
+// findMachine finds the machine of the caller with the given slug or label
+func findMachine(caller *slashCaller, alias string) (*kodingmodels.Machine, error) {
+	if alias == "" {
+		return nil, errMachineNotSet
+	}
+
+	machines, err := getMachines(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range machines {
+		if m.Slug == alias || m.Label == alias {
+			return m.Machine, nil
+		}
+	}
+
+	return nil, errMachineNotFound
+}
+
+// findShareTarget finds the koding user of the mentioned slack user, plain
+// mentions are treated as koding usernames
+func findShareTarget(groupName, mention string) (*kodingmodels.User, error) {
+	slackUserID, name := parseMention(mention)
+
+	var user *kodingmodels.User
+	var err error
+
+	switch {
+	case slackUserID != "":
+		user, err = modelhelper.GetUserBySlackUserID(groupName, slackUserID)
+	case name != "":
+		user, err = modelhelper.GetUser(name)
+	default:
+		return nil, errShareUserNotSet
+	}
+
+	if err == mgo.ErrNotFound {
+		return nil, errShareUserNotFound
+	}
+
+	return user, err
+}
+
+// parseSlashCommandText splits the text of the slash command into the
+// subcommand and its arguments
+func parseSlashCommandText(text string) (string, []string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	return strings.ToLower(fields[0]), fields[1:]
+}
+
+// parseMention parses the user mentions, slack escapes them as <@U123|name>
+// or <@U123>, unescaped ones are in @name form
+func parseMention(mention string) (slackUserID, name string) {
+	if strings.HasPrefix(mention, "<@") && strings.HasSuffix(mention, ">") {
+		mention = mention[2 : len(mention)-1]
+
+		if i := strings.IndexByte(mention, '|'); i != -1 {
+			return mention[:i], mention[i+1:]
+		}
+
+		return mention, ""
+	}
+
+	return "", strings.TrimPrefix(mention, "@")
+}
+
+func writeSlashResponse(w http.ResponseWriter, res *SlashResponse) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1531420618, 0)
+	body := []byte("token=xyz&team_id=T1&user_id=U1&command=%2Fkoding&text=machines")
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	header := func(signature, timestamp string) http.Header {
+		h := http.Header{}
+		h.Set(headerSignature, signature)
+		h.Set(headerTimestamp, timestamp)
+		return h
+	}
+
+	cases := map[string]struct {
+		header http.Header
+		now    time.Time
+		err    error
+	}{
+		"valid":             {header(sign("secret", timestamp, body), timestamp), now, nil},
+		"wrong secret":      {header(sign("other", timestamp, body), timestamp), now, errSignatureNotValid},
+		"missing signature": {header("", timestamp), now, errSignatureNotSet},
+		"invalid timestamp": {header(sign("secret", "abc", body), "abc"), now, errTimestampNotValid},
+		"replayed":          {header(sign("secret", timestamp, body), timestamp), now.Add(maxRequestAge + time.Second), errTimestampNotValid},
+	}
+
+	for name, c := range cases {
+		if err := verifySignature("secret", c.header, body, c.now); err != c.err {
+			t.Errorf("%s: got %v, want %v", name, err, c.err)
+		}
+	}
+}
+
+func TestVerifyRequestWithoutSecret(t *testing.T) {
+	body := []byte("token=xyz&team_id=T1&user_id=U1&command=%2Fkoding&text=machines")
+
+	cases := map[string]struct {
+		slack *Slack
+		body  []byte
+		err   error
+	}{
+		"not configured":    {&Slack{}, body, errSlashNotConfigured},
+		"missing token":     {&Slack{}, []byte("team_id=T1&user_id=U1"), errSlashNotConfigured},
+		"valid token":       {&Slack{VerificationToken: "xyz"}, body, nil},
+		"wrong token":       {&Slack{VerificationToken: "abc"}, body, errTokenNotValid},
+		"empty body token":  {&Slack{VerificationToken: "xyz"}, []byte("team_id=T1&user_id=U1"), errTokenNotValid},
+		"unsigned with key": {&Slack{SigningSecret: "secret", VerificationToken: "xyz"}, body, errSignatureNotSet},
+	}
+
+	for name, c := range cases {
+		if err := c.slack.verifyRequest(http.Header{}, c.body, time.Now()); err != c.err {
+			t.Errorf("%s: got %v, want %v", name, err, c.err)
+		}
+	}
+}
+
+func TestParseSlashCommandText(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		args []string
+	}{
+		"":                    {"", nil},
+		"  ":                  {"", nil},
+		"machines":            {"machines", []string{}},
+		"Start  koding-vm-0":  {"start", []string{"koding-vm-0"}},
+		"stack status":        {"stack", []string{"status"}},
+		"share vm <@U1|jane>": {"share", []string{"vm", "<@U1|jane>"}},
+	}
+
+	for text, c := range cases {
+		name, args := parseSlashCommandText(text)
+		if name != c.name || !reflect.DeepEqual(args, c.args) {
+			t.Errorf("%q: got %q %q, want %q %q", text, name, args, c.name, c.args)
+		}
+	}
+}
+
+func TestParseMention(t *testing.T) {
+	cases := map[string][2]string{
+		"<@U1|jane>": {"U1", "jane"},
+		"<@U1>":      {"U1", ""},
+		"@jane":      {"", "jane"},
+		"jane":       {"", "jane"},
+	}
+
+	for mention, want := range cases {
+		id, name := parseMention(mention)
+		if id != want[0] || name != want[1] {
+			t.Errorf("%q: got %q %q, want %q %q", mention, id, name, want[0], want[1])
+		}
+	}
+}
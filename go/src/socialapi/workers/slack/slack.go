@@ -0,0 +1,137 @@
+// Package slack provides the logical part of the slack worker, which posts
+// the stack events of the teams to their slack channels
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"koding/db/mongodb/modelhelper"
+	"socialapi/models"
+	"socialapi/workers/webhook"
+	"time"
+
+	"github.com/koding/logging"
+	"github.com/nlopes/slack"
+	"github.com/streadway/amqp"
+)
+
+// Controller holds the basic context data for handlers
+type Controller struct {
+	log logging.Logger
+
+	// post posts the message to the slack channel with the given token, it
+	// is replaced in tests
+	post func(token, channelId, text string) error
+}
+
+// New creates a controller
+func New(log logging.Logger) *Controller {
+	return &Controller{
+		log:  log,
+		post: postMessage,
+	}
+}
+
+// DefaultErrHandler handles the errors for slack worker
+func (c *Controller) DefaultErrHandler(delivery amqp.Delivery, err error) bool {
+	c.log.Error("an error occurred putting message back to queue: %s", err)
+	delivery.Nack(false, true)
+	return false
+}
+
+// stackData is the part of the stack.built event data, which is posted to
+// slack
+type stackData struct {
+	StackId  string `json:"stackId"`
+	Username string `json:"username"`
+	Duration int64  `json:"duration"`
+	Status   string `json:"status"`
+}
+
+// Handle posts the stack events to the slack channels of the teams, which
+// opted in for them
+func (c *Controller) Handle(ev *webhook.Event) error {
+	if ev.Name != webhook.EventStackBuilt {
+		return nil
+	}
+
+	var data stackData
+	if err := json.Unmarshal(ev.Data, &data); err != nil {
+		c.log.Error("invalid stack event %+v", ev)
+		return nil
+	}
+
+	team := models.NewSlackTeam()
+	if err := team.ByGroupName(ev.GroupName); err != nil {
+		if err == models.ErrSlackTeamNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	event := slackEvent(&data)
+	if !team.Notifies(event) {
+		return nil
+	}
+
+	token, err := notifierToken(team)
+	if err != nil {
+		c.log.Error("could not get the slack token of %q: %s", ev.GroupName, err)
+		return nil
+	}
+
+	if err := c.post(token, team.ChannelId, formatStackMessage(event, &data)); err != nil {
+		c.log.Error("could not post %s event of %q to slack: %s", event, ev.GroupName, err)
+	}
+
+	return nil
+}
+
+// slackEvent maps the build status to the slack events
+func slackEvent(data *stackData) string {
+	if data.Status == "failed" {
+		return models.SlackEventStackFailed
+	}
+
+	return models.SlackEventStackBuilt
+}
+
+// formatStackMessage formats the message of the stack event
+func formatStackMessage(event string, data *stackData) string {
+	duration := time.Duration(data.Duration) * time.Second
+
+	if event == models.SlackEventStackFailed {
+		return fmt.Sprintf(":x: Stack of %s failed to build after %s", data.Username, duration)
+	}
+
+	return fmt.Sprintf(":white_check_mark: Stack of %s is built in %s", data.Username, duration)
+}
+
+// notifierToken fetches the slack token of the admin, who enabled the
+// notifications of the team
+func notifierToken(team *models.SlackTeam) (string, error) {
+	account, err := models.Cache.Account.ById(team.NotifierId)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := modelhelper.GetUser(account.Nick)
+	if err != nil {
+		return "", err
+	}
+
+	if s, ok := user.ForeignAuth.Slack[team.GroupName]; ok && s.Token != "" {
+		return s.Token, nil
+	}
+
+	return "", models.ErrTokenIsNotFound
+}
+
+func postMessage(token, channelId, text string) error {
+	params := slack.NewPostMessageParameters()
+	params.AsUser = false
+
+	_, _, err := slack.New(token).PostMessage(channelId, text, params)
+	return err
+}
@@ -0,0 +1,58 @@
+package slack
+
+import (
+	"socialapi/models"
+	"strings"
+	"testing"
+)
+
+func TestSlackEvent(t *testing.T) {
+	cases := map[string]string{
+		"succeeded": models.SlackEventStackBuilt,
+		"failed":    models.SlackEventStackFailed,
+	}
+
+	for status, want := range cases {
+		if got := slackEvent(&stackData{Status: status}); got != want {
+			t.Errorf("%s: got %s, want %s", status, got, want)
+		}
+	}
+}
+
+func TestFormatStackMessage(t *testing.T) {
+	data := &stackData{
+		Username: "jane",
+		Duration: 90,
+	}
+
+	msg := formatStackMessage(models.SlackEventStackBuilt, data)
+	if !strings.Contains(msg, "jane") || !strings.Contains(msg, "1m30s") || strings.Contains(msg, "failed") {
+		t.Errorf("unexpected built message %q", msg)
+	}
+
+	msg = formatStackMessage(models.SlackEventStackFailed, data)
+	if !strings.Contains(msg, "failed") || !strings.Contains(msg, "jane") {
+		t.Errorf("unexpected failed message %q", msg)
+	}
+}
+
+func TestSlackTeamNotifies(t *testing.T) {
+	team := &models.SlackTeam{
+		ChannelId:  "C1",
+		NotifierId: 1,
+		Events:     []string{models.SlackEventStackFailed},
+	}
+
+	if team.Notifies(models.SlackEventStackBuilt) {
+		t.Error("expected built events not to be posted")
+	}
+
+	if !team.Notifies(models.SlackEventStackFailed) {
+		t.Error("expected failed events to be posted")
+	}
+
+	team.NotifierId = 0
+	if team.Notifies(models.SlackEventStackFailed) {
+		t.Error("expected events not to be posted without a notifier")
+	}
+}
@@ -77,6 +77,26 @@ func (c *Controller) HandleChannel(channel *models.Channel) error {
 
 	}
 
+	if err := (&models.WebhookEndpoint{}).DeleteByGroupName(channel.GroupName); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := models.NewMachineUsage().DeleteByGroupName(channel.GroupName); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := models.NewSlackTeam().DeleteByGroupName(channel.GroupName); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := models.NewMemberDaily().DeleteByGroupName(channel.GroupName); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := models.NewMachineDaily().DeleteByGroupName(channel.GroupName); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	if errs.ErrorOrNil() != nil {
 		return errs
 	}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"socialapi/models"
+	"socialapi/request"
+	"socialapi/workers/common/handler"
+	"socialapi/workers/common/mux"
+	"socialapi/workers/common/response"
+	"socialapi/workers/helper"
+	"socialapi/workers/webhook"
+	"socialapi/workers/webhook/client"
+)
+
+// AddHandlers added the internal handlers to the given Muxer
+func AddHandlers(m *mux.Mux) {
+	httpRateLimiter := helper.NewDefaultRateLimiter()
+
+	m.AddHandler(
+		handler.Request{
+			Handler:  CreateEndpoint,
+			Name:     "webhook-create",
+			Type:     handler.PostRequest,
+			Endpoint: webhook.EndpointWebhooks,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:  ListEndpoints,
+			Name:     "webhook-list",
+			Type:     handler.GetRequest,
+			Endpoint: webhook.EndpointWebhooks,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:  UpdateEndpoint,
+			Name:     "webhook-update",
+			Type:     handler.PostRequest,
+			Endpoint: webhook.EndpointWebhook,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:  DeleteEndpoint,
+			Name:     "webhook-delete",
+			Type:     handler.DeleteRequest,
+			Endpoint: webhook.EndpointWebhook,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:  ListDeliveries,
+			Name:     "webhook-list-deliveries",
+			Type:     handler.GetRequest,
+			Endpoint: webhook.EndpointWebhookDeliveries,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:  TestEndpoint,
+			Name:     "webhook-test",
+			Type:     handler.PostRequest,
+			Endpoint: webhook.EndpointWebhookTest,
+			Cost:     10,
+		},
+	)
+	m.AddHandler(
+		handler.Request{
+			Handler:   HandlePrivateEvent,
+			Name:      "webhook-event-private",
+			Type:      handler.PostRequest,
+			Endpoint:  webhook.EndpointWebhookEventPrivate,
+			Ratelimit: httpRateLimiter,
+		},
+	)
+}
+
+// CreateEndpoint registers a new endpoint for the team
+func CreateEndpoint(u *url.URL, h http.Header, req *webhook.EndpointRequest, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if req == nil {
+		return response.NewBadRequest(errors.New("req should be set"))
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	e := models.NewWebhookEndpoint()
+	e.GroupName = context.GroupName
+	e.CreatorId = context.Client.Account.Id
+	e.URL = req.URL
+	e.Events = req.Events
+
+	if req.IsActive != nil {
+		e.IsActive = *req.IsActive
+	}
+
+	if err := e.Create(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewOK(e)
+}
+
+// ListEndpoints lists the endpoints of the team
+func ListEndpoints(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	if err := context.CanManage(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError((&models.WebhookEndpoint{}).FetchByGroupName(context.GroupName))
+}
+
+// UpdateEndpoint updates the url, filters or state of an endpoint
+func UpdateEndpoint(u *url.URL, h http.Header, req *webhook.EndpointRequest, context *models.Context) (int, http.Header, interface{}, error) {
+	e, err := fetchEndpoint(u, context)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if req == nil {
+		return response.NewBadRequest(errors.New("req should be set"))
+	}
+
+	if err := req.Validate(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if req.URL != "" {
+		e.URL = req.URL
+	}
+
+	if req.Events != nil {
+		e.Events = req.Events
+	}
+
+	if req.IsActive != nil {
+		e.IsActive = *req.IsActive
+	}
+
+	if req.RotateSecret {
+		e.Secret = models.NewWebhookSecret()
+	}
+
+	if err := e.Update(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewOK(e)
+}
+
+// DeleteEndpoint deletes an endpoint along with its delivery log
+func DeleteEndpoint(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	e, err := fetchEndpoint(u, context)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if err := (&models.WebhookDelivery{}).DeleteByEndpointId(e.Id); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	if err := e.Delete(); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewDeleted()
+}
+
+// ListDeliveries lists the latest delivery attempts of an endpoint
+func ListDeliveries(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	e, err := fetchEndpoint(u, context)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	query := request.GetQuery(u)
+
+	return response.HandleResultAndError((&models.WebhookDelivery{}).FetchByEndpointId(e.Id, query.Limit, query.Skip))
+}
+
+// TestEndpoint sends a ping event to an endpoint and responds with the
+// result of the delivery, failed test deliveries are not retried
+func TestEndpoint(u *url.URL, h http.Header, _ interface{}, context *models.Context) (int, http.Header, interface{}, error) {
+	e, err := fetchEndpoint(u, context)
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	ev, err := webhook.NewEvent(e.GroupName, webhook.EventPing, map[string]string{
+		"endpointId": u.Query().Get("id"),
+		"nick":       context.Client.Account.Nick,
+	})
+	if err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.HandleResultAndError(webhook.Deliver(webhook.DefaultClient, e, ev, 1))
+}
+
+// HandlePrivateEvent publishes the events coming from internal services
+func HandlePrivateEvent(u *url.URL, h http.Header, req *client.PrivateEvent) (int, http.Header, interface{}, error) {
+	if req == nil {
+		return response.NewBadRequest(errors.New("req should be set"))
+	}
+
+	if req.GroupName == "" {
+		return response.NewBadRequest(errors.New("groupName should be set"))
+	}
+
+	if !webhook.IsValidEvent(req.Event) {
+		return response.NewBadRequest(errors.New("event is not valid"))
+	}
+
+	var data interface{}
+	if len(req.Data) != 0 {
+		data = req.Data
+	}
+
+	if err := webhook.Publish(req.GroupName, req.Event, data); err != nil {
+		return response.NewBadRequest(err)
+	}
+
+	return response.NewDefaultOK()
+}
+
+func fetchEndpoint(u *url.URL, context *models.Context) (*models.WebhookEndpoint, error) {
+	if err := context.CanManage(); err != nil {
+		return nil, err
+	}
+
+	id, err := request.GetId(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, models.ErrIdIsNotSet
+	}
+
+	e := &models.WebhookEndpoint{}
+	if err := e.ByIdAndGroupName(id, context.GroupName); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EndpointEventPrivate publishes events of the internal services
+const EndpointEventPrivate = "/private/webhook/event"
+
+// Team events that can be subscribed to
+const (
+	EventStackBuilt     = "stack.built"
+	EventMachineStarted = "machine.started"
+	EventMachineStopped = "machine.stopped"
+	EventMemberJoined   = "member.joined"
+	EventInvoiceFailed  = "invoice.failed"
+
+	// EventPing is only sent with the test endpoint
+	EventPing = "ping"
+)
+
+// Events lists the team events that can be subscribed to
+var Events = []string{
+	EventStackBuilt,
+	EventMachineStarted,
+	EventMachineStopped,
+	EventMemberJoined,
+	EventInvoiceFailed,
+}
+
+// IsValidEvent reports whether name is a known team event
+func IsValidEvent(name string) bool {
+	for _, e := range Events {
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PrivateEvent is used by the internal services for publishing events
+type PrivateEvent struct {
+	// GroupName holds group name
+	GroupName string `json:"groupName"`
+
+	// Event holds the event name
+	Event string `json:"event"`
+
+	// Data holds the event specific details
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+var defaultClient = &http.Client{
+	Transport: &http.Transport{
+		Dial: (&net.Dialer{
+			Timeout: time.Second,
+		}).Dial,
+	},
+	Timeout: 10 * time.Second,
+}
+
+// Client is an http client to publish team events to internal endpoint.
+type Client struct {
+	endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewInternal creates a new client for publishing team events.
+func NewInternal(host string) *Client {
+	fullURL := host + EndpointEventPrivate
+	if _, err := url.ParseRequestURI(fullURL); err != nil {
+		panic("url is not valid")
+	}
+
+	return &Client{
+		endpoint:   fullURL,
+		HTTPClient: defaultClient,
+	}
+}
+
+// Notify publishes the event of the group, which is delivered to the
+// webhook endpoints registered by the team.
+func (c *Client) Notify(groupName, event string, data interface{}) error {
+	req, err := c.NewRequest(groupName, event, data)
+	if err != nil {
+		return err
+	}
+
+	return c.Do(req)
+}
+
+// NewRequest creates a new http.Request
+func (c *Client) NewRequest(groupName, event string, data interface{}) (*http.Request, error) {
+	if groupName == "" {
+		return nil, errors.New("groupName must be set")
+	}
+	if event == "" {
+		return nil, errors.New("event must be set")
+	}
+
+	ev := &PrivateEvent{
+		GroupName: groupName,
+		Event:     event,
+	}
+
+	if data != nil {
+		p, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		ev.Data = p
+	}
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequest("POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Content-Type", "application/json")
+	return r, nil
+}
+
+// Do send the http.Request
+func (c *Client) Do(req *http.Request) error {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		return errors.New("bad response")
+	}
+
+	return nil
+}
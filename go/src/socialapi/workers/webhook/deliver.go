@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"socialapi/models"
+)
+
+const (
+	// HeaderEvent holds the event name
+	HeaderEvent = "X-Koding-Event"
+
+	// HeaderDelivery holds the event id, which is same for all attempts
+	HeaderDelivery = "X-Koding-Delivery"
+
+	// HeaderTimestamp holds the unix time the request was signed at
+	HeaderTimestamp = "X-Koding-Timestamp"
+
+	// HeaderSignature holds the HMAC-SHA256 signature of the request
+	HeaderSignature = "X-Koding-Signature"
+)
+
+// DeliveryTimeout is the maximum duration of a delivery request
+const DeliveryTimeout = 10 * time.Second
+
+// DefaultClient is used for delivering the events. It only connects to the
+// public addresses, the addresses are checked after the name resolution, so
+// the hosts resolving to the internal addresses are rejected too. Proxies are
+// not used, since the checked address would be the address of the proxy.
+var DefaultClient = &http.Client{
+	Timeout: DeliveryTimeout,
+	Transport: &http.Transport{
+		Dial:                dial,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	// redirects are not followed, the endpoints must be
+	// registered with their final urls
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return errRedirect
+	},
+}
+
+var (
+	errRedirect          = errors.New("redirects are not followed")
+	errAddressNotAllowed = errors.New("webhook address is not allowed")
+)
+
+// blockedNetworks are the loopback, private, link-local and other reserved
+// networks, which are not reachable by the deliveries
+var blockedNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks[i] = network
+	}
+
+	return networks
+}
+
+// IsAllowedIP reports whether the deliveries can be sent to the ip
+func IsAllowedIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dialer is used for connecting to the checked addresses
+var dialer = &net.Dialer{
+	Timeout: 5 * time.Second,
+}
+
+// dial resolves the host and connects to the first of its addresses, the
+// connection is rejected if any of the addresses is blocked. The resolved
+// address is dialed directly, so the host can not resolve to another
+// address between the check and the connection.
+func dial(network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ips) == 0 {
+		return nil, errAddressNotAllowed
+	}
+
+	for _, ip := range ips {
+		if !IsAllowedIP(ip) {
+			return nil, errAddressNotAllowed
+		}
+	}
+
+	return dialer.Dial(network, net.JoinHostPort(ips[0].String(), port))
+}
+
+// Sign gives the signature of the payload, as sent in the X-Koding-Signature
+// header. The signed content is the timestamp and the request body joined
+// with a dot, receivers should reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature is valid for the payload
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Deliver sends the event to the endpoint and records the attempt in the
+// delivery log. The returned error is only about recording the attempt,
+// failed requests are reported with the IsDelivered field.
+func Deliver(client *http.Client, endpoint *models.WebhookEndpoint, ev *Event, attempt int) (*models.WebhookDelivery, error) {
+	d := models.NewWebhookDelivery()
+	d.EndpointId = endpoint.Id
+	d.EventId = ev.Id
+	d.Event = ev.Name
+	d.Attempt = attempt
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Payload = string(body)
+
+	start := time.Now()
+	d.StatusCode, err = send(client, endpoint, ev, body)
+	d.Duration = int64(time.Since(start) / time.Millisecond)
+
+	switch {
+	case err != nil:
+		d.Error = err.Error()
+	case d.StatusCode < 200 || d.StatusCode > 299:
+		d.Error = http.StatusText(d.StatusCode)
+	default:
+		d.IsDelivered = true
+	}
+
+	return d, d.Create()
+}
+
+func send(client *http.Client, endpoint *models.WebhookEndpoint, ev *Event, body []byte) (int, error) {
+	req, err := http.NewRequest("POST", endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Koding-Webhook")
+	req.Header.Set(HeaderEvent, ev.Name)
+	req.Header.Set(HeaderDelivery, ev.Id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain the body, so the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/koding/bongo"
+)
+
+// Event is a team event, which is delivered to the webhook endpoints of
+// the team. It is also the payload of the webhook requests.
+type Event struct {
+	// Id is unique for the event, it is same for all delivery attempts
+	Id string `json:"id"`
+
+	// Name of the event, e.g. stack.built
+	Name string `json:"event"`
+
+	// GroupName holds the team's name
+	GroupName string `json:"groupName"`
+
+	// CreatedAt holds the event time
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Data holds the event specific details
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// NewEvent creates a new event of the group with the given data
+func NewEvent(groupName, name string, data interface{}) (*Event, error) {
+	if groupName == "" {
+		return nil, errors.New("groupName should be set")
+	}
+
+	if !IsValidEvent(name) && name != EventPing {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+
+	ev := &Event{
+		Id:        newEventId(),
+		Name:      name,
+		GroupName: groupName,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if data != nil {
+		p, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		ev.Data = p
+	}
+
+	return ev, nil
+}
+
+// GetId returns the id of the event, it is here just to satisfy Bongo.Modellable
+// interface
+func (e Event) GetId() int64 {
+	return 0
+}
+
+// BongoName returns the unique name for the bongo operations
+func (e Event) BongoName() string {
+	return "webhook.event"
+}
+
+// Publish sends the event of the group to the webhook worker
+func Publish(groupName, name string, data interface{}) error {
+	ev, err := NewEvent(groupName, name, data)
+	if err != nil {
+		return err
+	}
+
+	return bongo.B.PublishEvent(EventName, ev)
+}
+
+// Retry is a failed delivery, which is scheduled to be sent again
+type Retry struct {
+	// EndpointId is the id of the receiving endpoint
+	EndpointId int64 `json:"endpointId,string"`
+
+	// Attempt is the number of the next attempt
+	Attempt int `json:"attempt"`
+
+	// Event is the delivered event
+	Event *Event `json:"event"`
+}
+
+// GetId returns the id of the retry, it is here just to satisfy Bongo.Modellable
+// interface
+func (r Retry) GetId() int64 {
+	return 0
+}
+
+// BongoName returns the unique name for the bongo operations
+func (r Retry) BongoName() string {
+	return "webhook.retry"
+}
+
+func newEventId() string {
+	p := make([]byte, 16)
+	rand.Read(p)
+
+	return hex.EncodeToString(p)
+}
+
+// EndpointRequest is used for registering and updating endpoints
+type EndpointRequest struct {
+	// URL receives the events
+	URL string `json:"url"`
+
+	// Events filters the delivered events, all of them are
+	// delivered when it is empty
+	Events []string `json:"events"`
+
+	// IsActive pauses or resumes the deliveries when set
+	IsActive *bool `json:"isActive,omitempty"`
+
+	// RotateSecret generates a new signing secret
+	RotateSecret bool `json:"rotateSecret,omitempty"`
+}
+
+// Validate checks the event filters of the request
+func (r *EndpointRequest) Validate() error {
+	for _, e := range r.Events {
+		if !IsValidEvent(e) {
+			return fmt.Errorf("unknown event %q", e)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+// Package webhook provides the logical part of the webhook worker, which
+// delivers team events to the endpoints registered by the team admins
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"socialapi/models"
+	"socialapi/workers/webhook/client"
+
+	"github.com/koding/bongo"
+	"github.com/koding/logging"
+	"github.com/koding/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+const (
+	// EndpointWebhooks creates and lists the endpoints of the team
+	EndpointWebhooks = "/webhook"
+
+	// EndpointWebhook updates and deletes an endpoint
+	EndpointWebhook = "/webhook/{id}"
+
+	// EndpointWebhookDeliveries lists the delivery log of an endpoint
+	EndpointWebhookDeliveries = "/webhook/{id}/deliveries"
+
+	// EndpointWebhookTest sends a ping event to an endpoint
+	EndpointWebhookTest = "/webhook/{id}/test"
+
+	// EndpointWebhookEventPrivate publishes events of the internal services
+	EndpointWebhookEventPrivate = client.EndpointEventPrivate
+)
+
+const (
+	// EventName holds the name of the published team events
+	EventName = "webhook_event"
+
+	// RetryEventName holds the name of the scheduled retries
+	RetryEventName = "webhook_retry"
+)
+
+// Team events that can be subscribed to, they are defined in the client
+// package, so the internal services can publish them without depending on
+// the worker
+const (
+	EventStackBuilt     = client.EventStackBuilt
+	EventMachineStarted = client.EventMachineStarted
+	EventMachineStopped = client.EventMachineStopped
+	EventMemberJoined   = client.EventMemberJoined
+	EventInvoiceFailed  = client.EventInvoiceFailed
+
+	// EventPing is only sent with the test endpoint
+	EventPing = client.EventPing
+)
+
+// Events lists the team events that can be subscribed to
+var Events = client.Events
+
+// IsValidEvent reports whether name is a known team event
+func IsValidEvent(name string) bool {
+	return client.IsValidEvent(name)
+}
+
+// Backoff holds the delays between delivery attempts, an event is
+// attempted at most len(Backoff)+1 times
+var Backoff = []time.Duration{
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+}
+
+// Controller holds the basic context data for handlers
+type Controller struct {
+	log    logging.Logger
+	client *http.Client
+
+	// mq is used for scheduling the retries, when it is
+	// nil failed deliveries are not retried
+	mq *amqp.Connection
+
+	// queue is the name of the worker queue
+	queue string
+}
+
+// New creates a controller. Retries are scheduled by publishing the failed
+// deliveries to the retry queues, one queue per backoff delay. Messages
+// are dead-lettered back to the worker queue when their TTL expires.
+func New(log logging.Logger, rmq *rabbitmq.RabbitMQ, appName string) (*Controller, error) {
+	c := &Controller{
+		log:    log,
+		client: DefaultClient,
+		// same as the queue of the broker consumer
+		queue: appName + ":WorkerQueue",
+	}
+
+	if rmq == nil {
+		return c, nil
+	}
+
+	c.mq = rmq.Conn()
+
+	ch, err := c.mq.Channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	for i, delay := range Backoff {
+		args := amqp.Table{
+			"x-message-ttl":             int64(delay / time.Millisecond),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": c.queue,
+		}
+
+		if _, err := ch.QueueDeclare(c.retryQueue(i+1), true, false, false, false, args); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// DefaultErrHandler handles the errors for webhook worker
+func (c *Controller) DefaultErrHandler(delivery amqp.Delivery, err error) bool {
+	c.log.Error("an error occurred putting message back to queue: %s", err)
+	delivery.Nack(false, true)
+	return false
+}
+
+// Handle delivers the event to the endpoints of the team
+func (c *Controller) Handle(ev *Event) error {
+	if ev.GroupName == "" || ev.Name == "" {
+		c.log.Error("invalid event %+v", ev)
+		return nil
+	}
+
+	endpoints, err := (&models.WebhookEndpoint{}).FetchActiveByGroupName(ev.GroupName, ev.Name)
+	if err != nil {
+		return err
+	}
+
+	for i := range endpoints {
+		c.deliver(&endpoints[i], ev, 1)
+	}
+
+	return nil
+}
+
+// HandleRetry delivers the event to the endpoint once again
+func (c *Controller) HandleRetry(r *Retry) error {
+	if r.Event == nil {
+		c.log.Error("invalid retry %+v", r)
+		return nil
+	}
+
+	endpoint := &models.WebhookEndpoint{}
+	err := endpoint.ById(r.EndpointId)
+	if err == bongo.RecordNotFound {
+		return nil // endpoint was deleted in the meantime
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !endpoint.Accepts(r.Event.Name) {
+		return nil
+	}
+
+	c.deliver(endpoint, r.Event, r.Attempt)
+
+	return nil
+}
+
+// HandleParticipant publishes member.joined events for the new
+// participants of the group channels
+func (c *Controller) HandleParticipant(cp *models.ChannelParticipant) error {
+	if cp.StatusConstant != models.ChannelParticipant_STATUS_ACTIVE {
+		return nil
+	}
+
+	channel, err := models.Cache.Channel.ById(cp.ChannelId)
+	if err != nil {
+		c.log.Error("Channel: %d is not found", cp.ChannelId)
+		return nil
+	}
+
+	if channel.TypeConstant != models.Channel_TYPE_GROUP {
+		return nil
+	}
+
+	acc, err := models.Cache.Account.ById(cp.AccountId)
+	if err != nil {
+		c.log.Error("Account: %d is not found", cp.AccountId)
+		return nil
+	}
+
+	ev, err := NewEvent(channel.GroupName, EventMemberJoined, map[string]string{
+		"accountId": strconv.FormatInt(acc.Id, 10),
+		"nick":      acc.Nick,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Handle(ev)
+}
+
+func (c *Controller) deliver(endpoint *models.WebhookEndpoint, ev *Event, attempt int) {
+	d, err := Deliver(c.client, endpoint, ev, attempt)
+	if err != nil {
+		c.log.Error("could not record delivery of %s to endpoint %d: %s", ev.Id, endpoint.Id, err)
+	}
+
+	if d == nil || d.IsDelivered {
+		return
+	}
+
+	c.log.Debug("delivery of %s to endpoint %d failed (attempt %d): %s", ev.Id, endpoint.Id, attempt, d.Error)
+
+	if err := c.retry(endpoint.Id, ev, attempt+1); err != nil {
+		c.log.Error("could not schedule retry of %s to endpoint %d: %s", ev.Id, endpoint.Id, err)
+	}
+}
+
+// retry schedules the given attempt of the delivery
+func (c *Controller) retry(endpointId int64, ev *Event, attempt int) error {
+	if c.mq == nil || attempt > len(Backoff)+1 {
+		return nil // give up
+	}
+
+	p, err := json.Marshal(&Retry{
+		EndpointId: endpointId,
+		Attempt:    attempt,
+		Event:      ev,
+	})
+	if err != nil {
+		return err
+	}
+
+	ch, err := c.mq.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	return ch.Publish(
+		"",                      // default exchange
+		c.retryQueue(attempt-1), // routing key, the retry queue of the delay
+		false,                   // mandatory
+		false,                   // immediate
+		amqp.Publishing{
+			// same as the message type of bongo events
+			Type:         Retry{}.BongoName() + "_" + RetryEventName,
+			Body:         p,
+			DeliveryMode: amqp.Persistent,
+		},
+	)
+}
+
+// retryQueue gives the name of the queue for the nth delay
+func (c *Controller) retryQueue(n int) string {
+	return fmt.Sprintf("%s:Retry%d", c.queue, n)
+}
@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"socialapi/models"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1","event":"ping"}`)
+
+	sig := Sign("secret", 1500000000, body)
+	if len(sig) != len("sha256=")+64 || sig[:7] != "sha256=" {
+		t.Fatalf("unexpected signature format: %q", sig)
+	}
+
+	if !Verify("secret", 1500000000, body, sig) {
+		t.Fatal("expected signature to be valid")
+	}
+
+	cases := map[string]struct {
+		secret    string
+		timestamp int64
+		body      []byte
+	}{
+		"other secret":    {"other", 1500000000, body},
+		"other timestamp": {"secret", 1500000001, body},
+		"other body":      {"secret", 1500000000, []byte(`{"id":"2","event":"ping"}`)},
+	}
+
+	for name, c := range cases {
+		if Verify(c.secret, c.timestamp, c.body, sig) {
+			t.Errorf("%s: expected signature to be invalid", name)
+		}
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	if _, err := NewEvent("", EventStackBuilt, nil); err == nil {
+		t.Error("expected error for missing group name")
+	}
+
+	if _, err := NewEvent("koding", "stack.deleted", nil); err == nil {
+		t.Error("expected error for unknown event")
+	}
+
+	ev, err := NewEvent("koding", EventMemberJoined, map[string]string{"nick": "rafal"})
+	if err != nil {
+		t.Fatalf("NewEvent()=%s", err)
+	}
+
+	if ev.Id == "" || ev.CreatedAt.IsZero() {
+		t.Errorf("expected id and creation time to be set: %+v", ev)
+	}
+
+	if string(ev.Data) != `{"nick":"rafal"}` {
+		t.Errorf("got %s, want {\"nick\":\"rafal\"}", ev.Data)
+	}
+}
+
+func TestEndpointAccepts(t *testing.T) {
+	cases := []struct {
+		endpoint *models.WebhookEndpoint
+		event    string
+		want     bool
+	}{
+		{&models.WebhookEndpoint{IsActive: true}, EventStackBuilt, true},
+		{&models.WebhookEndpoint{IsActive: false}, EventStackBuilt, false},
+		{&models.WebhookEndpoint{IsActive: true, Events: []string{EventMemberJoined}}, EventMemberJoined, true},
+		{&models.WebhookEndpoint{IsActive: true, Events: []string{EventMemberJoined}}, EventInvoiceFailed, false},
+	}
+
+	for i, c := range cases {
+		if got := c.endpoint.Accepts(c.event); got != c.want {
+			t.Errorf("%d: Accepts(%q)=%t, want %t", i, c.event, got, c.want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	endpoint := &models.WebhookEndpoint{
+		URL:    ts.URL,
+		Secret: "secret",
+	}
+
+	ev, err := NewEvent("koding", EventMachineStopped, map[string]string{"machineId": "1"})
+	if err != nil {
+		t.Fatalf("NewEvent()=%s", err)
+	}
+
+	p, err := json.Marshal(ev)
+	if err != nil {
+		t.Fatalf("Marshal()=%s", err)
+	}
+
+	code, err := send(http.DefaultClient, endpoint, ev, p)
+	if err != nil {
+		t.Fatalf("send()=%s", err)
+	}
+
+	if code != http.StatusAccepted {
+		t.Errorf("got %d, want %d", code, http.StatusAccepted)
+	}
+
+	if got := header.Get(HeaderEvent); got != EventMachineStopped {
+		t.Errorf("got event %q, want %q", got, EventMachineStopped)
+	}
+
+	if got := header.Get(HeaderDelivery); got != ev.Id {
+		t.Errorf("got delivery %q, want %q", got, ev.Id)
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp: %s", err)
+	}
+
+	if !Verify("secret", timestamp, body, header.Get(HeaderSignature)) {
+		t.Error("expected request to be signed")
+	}
+}
+
+func TestIsAllowedIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":          true,
+		"52.1.2.3":         true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.20.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.100.1.1":      false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"::ffff:127.0.0.1": false,
+		"fe80::1":          false,
+		"fd00:ec2::254":    false,
+	}
+
+	for addr, want := range cases {
+		if got := IsAllowedIP(net.ParseIP(addr)); got != want {
+			t.Errorf("%s: got %t, want %t", addr, got, want)
+		}
+	}
+}
+
+func TestDefaultClientRejectsLoopback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be delivered")
+	}))
+	defer ts.Close()
+
+	endpoint := &models.WebhookEndpoint{
+		URL:    ts.URL,
+		Secret: "secret",
+	}
+
+	ev, err := NewEvent("koding", EventMachineStopped, map[string]string{"machineId": "1"})
+	if err != nil {
+		t.Fatalf("NewEvent()=%s", err)
+	}
+
+	if _, err := send(DefaultClient, endpoint, ev, []byte("{}")); err == nil {
+		t.Fatal("expected loopback delivery to fail")
+	}
+}
+
+func TestDialRejectsInternalNames(t *testing.T) {
+	for _, addr := range []string{"localhost:80", "127.0.0.1:80", "[::1]:80"} {
+		if _, err := dial("tcp", addr); err != errAddressNotAllowed {
+			t.Errorf("%s: got %v, want %v", addr, err, errAddressNotAllowed)
+		}
+	}
+}